@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/reconciler/broker"
+	"knative.dev/eventing-kafka/pkg/broker/reconciler/trigger"
+	"knative.dev/eventing-kafka/pkg/broker/util"
+	resetoffset "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller"
+	"knative.dev/eventing-kafka/pkg/common/configmaploader"
+)
+
+// Initialize The Kafka Broker Status Conditions
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// Eventing-Kafka Broker & Trigger Controller Entry Point
+func main() {
+
+	// Shutdown / Cleanup Hook For Controllers
+	defer resetoffset.Shutdown()
+
+	// Setup The Context
+	ctx := signals.NewContext()
+	ctx = context.WithValue(ctx, configmaploader.Key{}, configmap.Load)
+
+	// Create A Trigger RefMapper Factory With The Broker Topic/Group Naming (Verifying The Triggers' Brokers)
+	triggerRefMapperFactory := util.NewRefMapperFactory()
+
+	// Create A control-protocol ControlPlaneConnectionPool
+	connectionPool := ctrlreconciler.NewInsecureControlPlaneConnectionPool()
+	defer connectionPool.Close(ctx)
+
+	// Create A Trigger ControllerConstructor Receiving The Triggers' Status From The Dispatcher Via The ConnectionPool
+	triggerControllerConstructor := trigger.NewControllerFactory(connectionPool)
+
+	// Create A ResetOffset ControllerConstructor Factory With Trigger Ref Mapping
+	resetOffsetControllerConstructor := resetoffset.NewControllerFactory(triggerRefMapperFactory, connectionPool)
+
+	// Create The SharedMain Instance With The Various Controllers
+	sharedmain.MainWithContext(ctx, constants.ControllerComponent, broker.NewController, triggerControllerConstructor, resetOffsetControllerConstructor)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrlnetwork "knative.dev/control-protocol/pkg/network"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
+	"knative.dev/eventing/pkg/kncloudevents"
+	injectionclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	kncontroller "knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	eventingmetrics "knative.dev/pkg/metrics"
+	"knative.dev/pkg/signals"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/dispatcher"
+	distributedcommonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/env"
+	dispatcherhealth "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/health"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+)
+
+// Initialize The Kafka Broker Status Conditions (Used To Determine Broker Readiness)
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// The Main Function (Go Command)
+func main() {
+
+	ctx := signals.NewContext()
+
+	// Create The K8S Configuration (In-Cluster By Default / Cmd Line Flags For Out-Of-Cluster Usage)
+	k8sConfig := injection.ParseAndGetRESTConfigOrDie()
+
+	// Put The Kubernetes Config & Client Into The Context Where The Injection Framework Expects Them
+	ctx = injection.WithConfig(ctx, k8sConfig)
+	k8sClient := kubernetes.NewForConfigOrDie(k8sConfig)
+	ctx = context.WithValue(ctx, injectionclient.Key{}, k8sClient)
+
+	// Initialize A Knative Injection Lite Context (K8S Client & Logger)
+	ctx = commonk8s.LoggingContext(ctx, constants.DispatcherComponent, k8sClient)
+
+	// Get The Logger From The Context & Defer Flushing Any Buffered Log Entries On Exit
+	logger := logging.FromContext(ctx).Desugar()
+	defer flush(logger)
+
+	// Load Environment Variables (The Broker Dispatcher Is Always A Shared Dispatcher)
+	environment, err := env.GetEnvironment(logger)
+	if err != nil {
+		logger.Fatal("Failed To Load Environment Variables - Terminating!", zap.Error(err))
+	}
+
+	configMap, err := configmap.Load(commonconstants.SettingsConfigMapMountPath)
+	if err != nil {
+		logger.Fatal("error loading configuration", zap.Error(err))
+	}
+
+	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap
+	ekConfig, err := sarama.LoadSettings(ctx, constants.DispatcherComponent, configMap, sarama.LoadAuthConfig)
+	if err != nil {
+		logger.Fatal("Failed To Load Configuration Settings", zap.Error(err))
+	}
+
+	// Enable Sarama Logging If Specified In ConfigMap
+	sarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
+
+	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
+	err = distributedcommonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName, environment.SystemNamespace)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Tracing - Terminating", zap.Error(err))
+	}
+
+	// Initialize Observability (Watches config-observability ConfigMap And Starts Profiling Server)
+	err = distributedcommonconfig.InitializeObservability(ctx, logger.Sugar(), environment.MetricsDomain, environment.MetricsPort, environment.SystemNamespace)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Observability - Terminating", zap.Error(err))
+	}
+
+	// Start The Liveness And Readiness Servers
+	healthServer := dispatcherhealth.NewDispatcherHealthServer(strconv.Itoa(environment.HealthPort))
+	err = healthServer.Start(logger)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Health Server - Terminating", zap.Error(err))
+	}
+
+	// Change The CloudEvent Connection Args
+	kncloudevents.ConfigureConnectionArgs(&kncloudevents.ConnectionArgs{
+		MaxIdleConns:        ekConfig.CloudEvents.MaxIdleConns,
+		MaxIdleConnsPerHost: ekConfig.CloudEvents.MaxIdleConnsPerHost,
+	})
+
+	logger.Info("Initializing Control-Protocol Server")
+	controlProtocolServer, err := controlprotocol.NewServerHandler(ctx, controlprotocol.ServerPort)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Control-Protocol Server - Terminating", zap.Error(err))
+	}
+	defer controlProtocolServer.Shutdown(5 * time.Second)
+
+	// Start The Control-Protocol Server Used To Report The Triggers' Status To The Controller
+	statusControlServer, err := ctrlnetwork.StartInsecureControlServer(ctx, ctrlnetwork.WithPort(distributedcontrol.ServerPort))
+	if err != nil {
+		logger.Fatal("Failed To Initialize Status Control-Protocol Server - Terminating", zap.Error(err))
+	}
+
+	// Create The Dispatcher (Requeue-ing Triggers Whose ConsumerGroups Are Paused / Resumed Via The Control-Protocol)
+	var triggerController *kncontroller.Impl
+	brokerDispatcher := dispatcher.NewDispatcher(logger, strings.Split(ekConfig.Kafka.Brokers, ","), ekConfig.Sarama.Config, controlProtocolServer, func(ref types.NamespacedName) {
+		if triggerController != nil {
+			triggerController.EnqueueKey(ref)
+		}
+	})
+
+	brokerDispatcher.ReportTriggersStatus(statusControlServer)
+
+	// Create The Trigger & Broker Informers For ALL Namespaces
+	eventingClient := eventingclientset.NewForConfigOrDie(k8sConfig)
+	eventingInformerFactory := eventinginformers.NewSharedInformerFactory(eventingClient, environment.ResyncPeriod)
+	triggerInformer := eventingInformerFactory.Eventing().V1().Triggers()
+	brokerInformer := eventingInformerFactory.Eventing().V1().Brokers()
+
+	// Construct The Trigger Controller
+	triggerController = dispatcher.NewController(ctx, logger, brokerDispatcher, triggerInformer, brokerInformer)
+
+	// Watch The Secret For Changes (Reconfiguring All ConsumerGroups & Requeue-ing All Triggers)
+	err = distributedcommonconfig.InitializeSecretWatcher(ctx, environment.KafkaSecretNamespace, environment.KafkaSecretName, environment.ResyncPeriod, func(ctx context.Context, secret *corev1.Secret) {
+		if secret == nil {
+			logger.Warn("Nil Secret passed to secretObserver; ignoring")
+			return
+		}
+		brokerDispatcher.SecretChanged(ctx, secret)
+		triggerController.GlobalResync(triggerInformer.Informer())
+	})
+	if err != nil {
+		logger.Fatal("Failed To Start Secret Watcher", zap.Error(err))
+	}
+
+	// Start The Informers
+	logger.Info("Starting Informers")
+	if err := kncontroller.StartInformers(ctx.Done(), triggerInformer.Informer(), brokerInformer.Informer()); err != nil {
+		logger.Error("Failed to start informers", zap.Error(err))
+		return
+	}
+
+	// Set The Liveness And Readiness Flags
+	logger.Info("Registering dispatcher as alive and ready")
+	healthServer.SetAlive(true)
+	healthServer.SetDispatcherReady(true)
+
+	// Start The Controller (Blocking WaitGroup.Wait Call)
+	logger.Info("Starting Kafka Broker Trigger Controller")
+	kncontroller.StartAll(ctx, triggerController)
+
+	// Reset The Liveness and Readiness Flags In Preparation For Shutdown
+	healthServer.Shutdown()
+
+	// Shutdown The Dispatcher (Close ConsumerGroups)
+	brokerDispatcher.Shutdown()
+
+	// Stop The Liveness And Readiness Servers
+	healthServer.Stop(logger)
+}
+
+func flush(logger *zap.Logger) {
+	_ = logger.Sync()
+	eventingmetrics.FlushExporter()
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
+	"knative.dev/eventing/pkg/kncloudevents"
+	injectionclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	eventingmetrics "knative.dev/pkg/metrics"
+	"knative.dev/pkg/signals"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/ingress"
+	distributedcommonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/env"
+	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+)
+
+// Initialize The Kafka Broker Status Conditions (Used To Determine Broker Readiness)
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// The Main Function (Go Command)
+func main() {
+
+	ctx := signals.NewContext()
+
+	// Create The K8S Configuration (In-Cluster By Default / Cmd Line Flags For Out-Of-Cluster Usage)
+	k8sConfig := injection.ParseAndGetRESTConfigOrDie()
+
+	// Put The Kubernetes Config & Client Into The Context Where The Injection Framework Expects Them
+	ctx = injection.WithConfig(ctx, k8sConfig)
+	k8sClient := kubernetes.NewForConfigOrDie(k8sConfig)
+	ctx = context.WithValue(ctx, injectionclient.Key{}, k8sClient)
+
+	// Initialize A Knative Injection Lite Context (K8S Client & Logger)
+	ctx = commonk8s.LoggingContext(ctx, constants.IngressComponent, k8sClient)
+
+	// Get The Logger From The Context & Defer Flushing Any Buffered Log Entries On Exit
+	logger := logging.FromContext(ctx).Desugar()
+	defer flush(logger)
+
+	// Load Environment Variables
+	environment, err := env.GetEnvironment(logger)
+	if err != nil {
+		logger.Fatal("Invalid / Missing Environment Variables - Terminating", zap.Error(err))
+	}
+
+	configMap, err := configmap.Load(commonconstants.SettingsConfigMapMountPath)
+	if err != nil {
+		logger.Fatal("error loading configuration", zap.Error(err))
+	}
+
+	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap
+	ekConfig, err := sarama.LoadSettings(ctx, constants.IngressComponent, configMap, sarama.LoadAuthConfig)
+	if err != nil {
+		logger.Fatal("Failed To Load Configuration Settings", zap.Error(err))
+	}
+
+	// Enable Sarama Logging If Specified In ConfigMap
+	sarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
+
+	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
+	err = distributedcommonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName, environment.SystemNamespace)
+	if err != nil {
+		logger.Fatal("Could Not Initialize Tracing - Terminating", zap.Error(err))
+	}
+
+	// Initialize Observability (Watches config-observability ConfigMap And Starts Profiling Server)
+	err = distributedcommonconfig.InitializeObservability(ctx, logger.Sugar(), environment.MetricsDomain, environment.MetricsPort, environment.SystemNamespace)
+	if err != nil {
+		logger.Fatal("Could Not Initialize Observability - Terminating", zap.Error(err))
+	}
+
+	// Start The Liveness And Readiness Servers
+	healthServer := channelhealth.NewChannelHealthServer(strconv.Itoa(environment.HealthPort))
+	err = healthServer.Start(logger)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Health Server - Terminating", zap.Error(err))
+	}
+
+	// Start The Metrics Reporter And Defer Shutdown
+	statsReporter := metrics.NewStatsReporter(logger)
+	defer statsReporter.Shutdown()
+
+	// Create A Broker Informer For ALL Namespaces & Wait For It
+	eventingClient := eventingclientset.NewForConfigOrDie(k8sConfig)
+	sharedInformerFactory := eventinginformers.NewSharedInformerFactory(eventingClient, environment.ResyncPeriod)
+	brokerInformer := sharedInformerFactory.Eventing().V1().Brokers()
+	brokerInformer.Informer() // Register The Informer Before Starting The Factory
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+	healthServer.SetChannelReady(true)
+	logger.Info("Successfully Initialized Broker Lister")
+
+	// Set The Liveness Flag Before Creating The Producer (Which May Take A While To Connect)
+	healthServer.SetAlive(true)
+
+	// Create The Kafka Producer Shared By All Brokers
+	kafkaProducer, err := producer.NewProducer(logger, ekConfig.Sarama.Config, strings.Split(ekConfig.Kafka.Brokers, ","), statsReporter, healthServer, nil, nil, ekConfig.Channel.Receiver.Producer)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Kafka Producer", zap.Error(err))
+	}
+	brokerIngress := ingress.NewIngress(logger, brokerInformer.Lister(), kafkaProducer)
+	defer brokerIngress.Close()
+
+	// Watch The Secret For Changes (Recreating The Producer With The New Auth Settings)
+	err = distributedcommonconfig.InitializeSecretWatcher(ctx, environment.KafkaSecretNamespace, environment.KafkaSecretName, environment.ResyncPeriod, func(ctx context.Context, secret *corev1.Secret) {
+		if secret == nil {
+			logger.Warn("Nil Secret passed to secretObserver; ignoring")
+			return
+		}
+		brokerIngress.SecretChanged(ctx, secret)
+	})
+	if err != nil {
+		logger.Fatal("Failed To Start Secret Watcher", zap.Error(err))
+	}
+
+	reporter := eventingchannel.NewStatsReporter(environment.ContainerName, kmeta.ChildName(environment.PodName, uuid.New().String()))
+
+	// Create A New Knative Eventing MessageReceiver (Parses The Broker Service From The Host Header)
+	messageReceiver, err := eventingchannel.NewMessageReceiver(brokerIngress.HandleMessage, logger, reporter, eventingchannel.ResolveMessageChannelFromHostHeader(eventingchannel.ParseChannel))
+	if err != nil {
+		logger.Fatal("Failed To Create MessageReceiver", zap.Error(err))
+	}
+
+	// Start The Message Receiver Behind The Payload Size Limit Handler (Blocking)
+	err = kncloudevents.NewHTTPMessageReceiver(constants.IngressHttpPort).StartListen(ctx, payload.NewSizeLimitHandler(messageReceiver))
+	if err != nil {
+		logger.Error("Failed To Start MessageReceiver", zap.Error(err))
+	}
+
+	// Reset The Liveness and Readiness Flags In Preparation For Shutdown & Stop The Servers
+	healthServer.Shutdown()
+	healthServer.Stop(logger)
+}
+
+// Deferred Logger / Metrics Flush
+func flush(logger *zap.Logger) {
+	_ = logger.Sync()
+	eventingmetrics.FlushExporter()
+}
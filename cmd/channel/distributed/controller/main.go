@@ -36,7 +36,6 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkachannel"
 	controllerutil "knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	resetoffset "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller"
-	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
 )
 
@@ -63,14 +62,8 @@ func main() {
 	ctx = context.WithValue(ctx, env.Key{}, environment)
 	ctx = context.WithValue(ctx, configmaploader.Key{}, configmap.Load)
 
-	// Create A Subscription RefMapper Factory With Custom Topic/Group Naming
-	subscriptionRefMapperFactory := refmappers.NewSubscriptionRefMapperFactory(
-		controllerutil.TopicNameMapper,
-		controllerutil.GroupIdMapper,
-		controllerutil.ConnectionPoolKeyMapper,
-		controllerutil.DataPlaneNamespaceMapper,
-		controllerutil.DataPlaneLabelsMapper,
-	)
+	// Create A Subscription RefMapper Factory With Custom Topic/Group Naming (Resolving Existing spec.topic Topics)
+	subscriptionRefMapperFactory := controllerutil.NewRefMapperFactory()
 
 	// Create A control-protocol ControlPlaneConnectionPool
 	connectionPool := ctrlreconciler.NewInsecureControlPlaneConnectionPool()
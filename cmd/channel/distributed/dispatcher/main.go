@@ -28,6 +28,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	ctrlnetwork "knative.dev/control-protocol/pkg/network"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
 	"knative.dev/eventing/pkg/kncloudevents"
 	injectionclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
@@ -39,6 +42,7 @@ import (
 
 	distributedmessaging "knative.dev/eventing-kafka/pkg/channel/distributed/apis/messaging"
 	distributedcommonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
@@ -50,6 +54,9 @@ import (
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
@@ -148,6 +155,28 @@ func main() {
 	}
 	defer controlProtocolServer.Shutdown(5 * time.Second)
 
+	// Start The Control-Protocol Server Used To Report Subscriber Status To The Controller
+	statusControlServer, err := ctrlnetwork.StartInsecureControlServer(ctx, ctrlnetwork.WithPort(distributedcontrol.ServerPort))
+	if err != nil {
+		logger.Fatal("Failed To Initialize Status Control-Protocol Server - Terminating", zap.Error(err))
+	}
+
+	// Create The Claim-Check Store (If Configured) From Which Offloaded Payloads Are Rehydrated
+	claimCheckStore, err := claimcheck.NewStore(ekConfig.Channel.ClaimCheck)
+	if err != nil {
+		logger.Fatal("Failed To Create Claim-Check Store - Terminating", zap.Error(err))
+	}
+
+	// Create The Subscription Informer (Subscription Filter Annotations Are Applied To The KafkaChannel's Subscribers)
+	eventingClient := eventingclientset.NewForConfigOrDie(k8sConfig)
+	eventingInformerFactory := eventinginformers.NewSharedInformerFactory(eventingClient, environment.ResyncPeriod)
+	subscriptionInformer := eventingInformerFactory.Messaging().V1().Subscriptions()
+
+	// Create KafkaChannel Informer (The Encryption Secrets Of The KafkaChannels Are Resolved From Their Specs)
+	kafkaClient := kafkaclientset.NewForConfigOrDie(k8sConfig)
+	kafkaInformerFactory := externalversions.NewSharedInformerFactory(kafkaClient, environment.ResyncPeriod)
+	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
+
 	// Create The Dispatcher With Specified Configuration
 	dispatcherConfig := dispatch.DispatcherConfig{
 		Logger:          logger,
@@ -158,29 +187,48 @@ func main() {
 		StatsReporter:   statsReporter,
 		MetricsRegistry: ekConfig.Sarama.Config.MetricRegistry,
 		SaramaConfig:    ekConfig.Sarama.Config,
+		StatusService:   statusControlServer,
+		ClaimCheckStore: claimCheckStore,
+		KeyProvider:     encryption.NewSecretKeyProvider(k8sClient),
+
+		SubscriptionLister: subscriptionInformer.Lister(),
+		KafkaChannelLister: kafkaChannelInformer.Lister(),
 	}
 	dispatcher, managerEvents := dispatch.NewDispatcher(dispatcherConfig, controlProtocolServer, func(ref types.NamespacedName) {})
 
-	// Create KafkaChannel Informer
-	kafkaClient := kafkaclientset.NewForConfigOrDie(k8sConfig)
-	kafkaInformerFactory := externalversions.NewSharedInformerFactory(kafkaClient, environment.ResyncPeriod)
-	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
+	// Start The Debug API Server (If Enabled) For Browsing & Replaying The KafkaChannels' Events
+	if ekConfig.Channel.Dispatcher.DebugApi.Enabled {
+		debugServer := debugapi.NewServer(debugapi.ServerConfig{
+			Logger:          logger,
+			HttpPort:        debugapi.HttpPort(ekConfig.Channel.Dispatcher.DebugApi.Port),
+			Dispatcher:      dispatcher,
+			Authorizer:      debugapi.NewKubernetesAuthorizer(k8sClient),
+			ClaimCheckStore: claimCheckStore,
+			KeyProvider:     dispatcherConfig.KeyProvider,
+		})
+		err = debugServer.Start()
+		if err != nil {
+			logger.Fatal("Failed To Initialize Debug API Server - Terminating", zap.Error(err))
+		}
+		defer debugServer.Stop()
+	}
 
 	// Construct The KafkaChannel Controller
 	kcController := controller.NewController(
 		ctx,
 		logger,
 		environment.ChannelKey,
+		environment.PodName,
 		dispatcher,
 		kafkaChannelInformer,
+		subscriptionInformer,
 		k8sClient,
-		kafkaClient,
 		ctx.Done(),
 		managerEvents,
 	)
 
 	// Watch The Secret For Changes
-	secretObserver := NewSecretObserver(kcController, environment.ChannelKey, dispatcher)
+	secretObserver := NewSecretObserver(kcController, kafkaChannelInformer.Informer(), environment.ChannelKey, dispatcher)
 	err = distributedcommonconfig.InitializeSecretWatcher(ctx, environment.KafkaSecretNamespace, environment.KafkaSecretName, environment.ResyncPeriod, secretObserver)
 	if err != nil {
 		logger.Fatal("Failed To Start Secret Watcher", zap.Error(err))
@@ -188,7 +236,7 @@ func main() {
 
 	// Start The Informers
 	logger.Info("Starting Informers")
-	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer()); err != nil {
+	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer(), subscriptionInformer.Informer()); err != nil {
 		logger.Error("Failed to start informers", zap.Error(err))
 		return
 	}
@@ -218,7 +266,7 @@ func flush(logger *zap.Logger) {
 }
 
 // NewSecretObserver is a factory for creating the callback function that handles changes to the Kafka Secret.
-func NewSecretObserver(kcController *kncontroller.Impl, channelKey string, dispatcher dispatch.Dispatcher) func(ctx context.Context, secret *corev1.Secret) {
+func NewSecretObserver(kcController *kncontroller.Impl, kafkaChannelInformer cache.SharedInformer, channelKey string, dispatcher dispatch.Dispatcher) func(ctx context.Context, secret *corev1.Secret) {
 	return func(ctx context.Context, secret *corev1.Secret) {
 
 		// Get The Logger From The Context
@@ -239,6 +287,13 @@ func NewSecretObserver(kcController *kncontroller.Impl, channelKey string, dispa
 		// Signal The Dispatcher To Recreate Kafka Config And Reconnect All Current ConsumerGroups
 		dispatcher.SecretChanged(ctx, secret)
 
+		// Requeue All KafkaChannels Of A Shared Dispatcher (Empty ChannelKey) To Fix Any Not-Ready Subscriptions
+		if len(channelKey) == 0 {
+			logger.Debug("Requeue-ing All KafkaChannels due to Kafka Secret change")
+			kcController.GlobalResync(kafkaChannelInformer)
+			return
+		}
+
 		// Requeue The KafkaChannel To Fix Any Not-Ready Subscriptions
 		channelNamespace, channelName, err := cache.SplitMetaNamespaceKey(channelKey)
 		if err != nil {
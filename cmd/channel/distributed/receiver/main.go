@@ -46,14 +46,22 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/env"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
 
 // Variables
-var kafkaProducer *producer.Producer
+var (
+	kafkaProducer              *producer.Producer
+	defaultClaimCheckThreshold int64
+)
 
 // Initialize The KafkaChannel Status Conditions
 func init() {
@@ -153,8 +161,18 @@ func main() {
 	logger.Info("Registering receiver as alive")
 	healthServer.SetAlive(true)
 
+	// Create The Claim-Check Store (If Configured) To Which KafkaChannels May Offload Large Payloads
+	claimCheckStore, err := claimcheck.NewStore(ekConfig.Channel.ClaimCheck)
+	if err != nil {
+		logger.Fatal("Failed To Create Claim-Check Store", zap.Error(err))
+	}
+	defaultClaimCheckThreshold = ekConfig.Channel.ClaimCheck.ThresholdBytes
+
+	// Create The Provider Of The Keys With Which KafkaChannels May Encrypt Event Data
+	keyProvider := encryption.NewSecretKeyProvider(k8sClient)
+
 	// Initialize The Kafka Producer In Order To Start Processing Status Events
-	kafkaProducer, err = producer.NewProducer(logger, ekConfig.Sarama.Config, strings.Split(ekConfig.Kafka.Brokers, ","), statsReporter, healthServer)
+	kafkaProducer, err = producer.NewProducer(logger, ekConfig.Sarama.Config, strings.Split(ekConfig.Kafka.Brokers, ","), statsReporter, healthServer, claimCheckStore, keyProvider, ekConfig.Channel.Receiver.Producer)
 	if err != nil {
 		logger.Fatal("Failed To Initialize Kafka Producer", zap.Error(err))
 	}
@@ -168,8 +186,8 @@ func main() {
 		logger.Fatal("Failed To Create MessageReceiver", zap.Error(err))
 	}
 
-	// Start The Message Receiver (Blocking)
-	err = messageReceiver.Start(ctx)
+	// Start The Message Receiver Behind The Payload Size Limit Handler (Blocking)
+	err = payload.StartReceiver(ctx, messageReceiver)
 	if err != nil {
 		logger.Error("Failed To Start MessageReceiver", zap.Error(err))
 	}
@@ -204,14 +222,14 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 	channelReference.Name = kafkautil.TrimKafkaChannelServiceNameSuffix(channelReference.Name)
 
 	// Validate The KafkaChannel Prior To Producing Kafka Message
-	err := channel.ValidateKafkaChannel(channelReference)
+	kafkaChannel, err := channel.ValidateKafkaChannel(channelReference)
 	if err != nil {
 		logger.Warn("Unable To Validate ChannelReference", zap.Any("ChannelReference", channelReference), zap.Error(err))
 		return err
 	}
 
-	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic)
-	err = kafkaProducer.ProduceKafkaMessage(ctx, channelReference, message, httpHeader, transformers...)
+	// Produce The CloudEvent Binding Message (Send To The KafkaChannel's Kafka Topic)
+	err = kafkaProducer.ProduceKafkaMessage(ctx, channelReference, util.TopicName(kafkaChannel), produce.NewOptions(kafkaChannel, defaultClaimCheckThreshold), message, httpHeader, transformers...)
 	if err != nil {
 		logger.Error("Failed To Produce Kafka Message", zap.Error(err))
 		return err
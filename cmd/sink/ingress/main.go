@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+	injectionclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	eventingmetrics "knative.dev/pkg/metrics"
+	"knative.dev/pkg/signals"
+
+	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
+	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	kafkainformers "knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/sink/constants"
+	"knative.dev/eventing-kafka/pkg/sink/ingress"
+)
+
+// The Main Function (Go Command)
+func main() {
+
+	ctx := signals.NewContext()
+
+	// Create The K8S Configuration (In-Cluster By Default / Cmd Line Flags For Out-Of-Cluster Usage)
+	k8sConfig := injection.ParseAndGetRESTConfigOrDie()
+
+	// Put The Kubernetes Config & Client Into The Context Where The Injection Framework Expects Them
+	ctx = injection.WithConfig(ctx, k8sConfig)
+	k8sClient := kubernetes.NewForConfigOrDie(k8sConfig)
+	ctx = context.WithValue(ctx, injectionclient.Key{}, k8sClient)
+
+	// Initialize A Knative Injection Lite Context (K8S Client & Logger)
+	ctx = commonk8s.LoggingContext(ctx, constants.IngressComponent, k8sClient)
+
+	// Get The Logger From The Context & Defer Flushing Any Buffered Log Entries On Exit
+	logger := logging.FromContext(ctx).Desugar()
+	defer flush(logger)
+
+	// Start The Liveness And Readiness Servers
+	healthServer := channelhealth.NewChannelHealthServer(strconv.Itoa(constants.IngressHealthPort))
+	err := healthServer.Start(logger)
+	if err != nil {
+		logger.Fatal("Failed To Initialize Health Server - Terminating", zap.Error(err))
+	}
+	healthServer.SetAlive(true)
+
+	// Start The Metrics Reporter And Defer Shutdown
+	statsReporter := metrics.NewStatsReporter(logger)
+	defer statsReporter.Shutdown()
+
+	// Create A KafkaSink Informer For ALL Namespaces
+	kafkaClient := kafkaclientset.NewForConfigOrDie(k8sConfig)
+	sharedInformerFactory := kafkainformers.NewSharedInformerFactory(kafkaClient, controller.GetResyncPeriod(ctx))
+	kafkaSinkInformer := sharedInformerFactory.Kafka().V1alpha1().KafkaSinks()
+
+	// Create The Ingress, Which Closes The Producers Of Deleted KafkaSinks
+	sinkIngress := ingress.NewIngress(ctx, logger, k8sClient, kafkaSinkInformer.Lister(), statsReporter)
+	defer sinkIngress.Close()
+	kafkaSinkInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: sinkIngress.KafkaSinkDeleted,
+	})
+
+	// Start The Informer & Wait For It
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+	logger.Info("Successfully Initialized KafkaSink Lister")
+
+	// The Producers Are Created Per KafkaSink, So Readiness Only Depends On The Lister
+	healthServer.SetProducerReady(true)
+	healthServer.SetChannelReady(true)
+
+	podName := os.Getenv("POD_NAME")
+	reporter := eventingchannel.NewStatsReporter(constants.IngressComponent, kmeta.ChildName(podName, uuid.New().String()))
+
+	// Create A New Knative Eventing MessageReceiver (Parses The KafkaSink Service From The Host Header)
+	messageReceiver, err := eventingchannel.NewMessageReceiver(sinkIngress.HandleMessage, logger, reporter, eventingchannel.ResolveMessageChannelFromHostHeader(eventingchannel.ParseChannel))
+	if err != nil {
+		logger.Fatal("Failed To Create MessageReceiver", zap.Error(err))
+	}
+
+	// Start The Message Receiver Behind The Payload Size Limit Handler (Blocking)
+	err = kncloudevents.NewHTTPMessageReceiver(constants.IngressHttpPort).StartListen(ctx, payload.NewSizeLimitHandler(messageReceiver))
+	if err != nil {
+		logger.Error("Failed To Start MessageReceiver", zap.Error(err))
+	}
+
+	// Reset The Liveness and Readiness Flags In Preparation For Shutdown & Stop The Servers
+	healthServer.Shutdown()
+	healthServer.Stop(logger)
+}
+
+// Deferred Logger / Metrics Flush
+func flush(logger *zap.Logger) {
+	_ = logger.Sync()
+	eventingmetrics.FlushExporter()
+}
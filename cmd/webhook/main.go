@@ -55,6 +55,6 @@ func main() {
 		channelwebhook.NewDefaultingAdmissionController,
 		channelwebhook.NewValidationAdmissionController,
 		channelwebhook.NewConversionController,
-		// TODO(mattmoor): Support config validation in eventing-kafka.
+		channelwebhook.NewConfigValidationController,
 	)
 }
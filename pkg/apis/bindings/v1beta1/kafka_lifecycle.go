@@ -108,6 +108,7 @@ func (kfb *KafkaBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 					SecretKeyRef: kfb.Spec.Net.SASL.Type.SecretKeyRef,
 				},
 			})
+			spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, kfb.oauthEnvVars()...)
 		}
 		if kfb.Spec.Net.TLS.Enable {
 			spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, corev1.EnvVar{
@@ -158,6 +159,7 @@ func (kfb *KafkaBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 					SecretKeyRef: kfb.Spec.Net.SASL.Type.SecretKeyRef,
 				},
 			})
+			spec.Containers[i].Env = append(spec.Containers[i].Env, kfb.oauthEnvVars()...)
 		}
 		if kfb.Spec.Net.TLS.Enable {
 			spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
@@ -195,6 +197,7 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 			switch ev.Name {
 			case "KAFKA_NET_TLS_ENABLE", "KAFKA_NET_TLS_CERT", "KAFKA_NET_TLS_KEY", "KAFKA_NET_TLS_CA_CERT",
 				"KAFKA_NET_SASL_ENABLE", "KAFKA_NET_SASL_USER", "KAFKA_NET_SASL_PASSWORD", "KAFKA_NET_SASL_TYPE",
+				"KAFKA_NET_SASL_CLIENT_ID", "KAFKA_NET_SASL_CLIENT_SECRET", "KAFKA_NET_SASL_TOKEN_URL", "KAFKA_NET_SASL_SCOPES",
 				"KAFKA_BOOTSTRAP_SERVERS":
 
 				continue
@@ -214,6 +217,7 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 			switch ev.Name {
 			case "KAFKA_NET_TLS_ENABLE", "KAFKA_NET_TLS_CERT", "KAFKA_NET_TLS_KEY", "KAFKA_NET_TLS_CA_CERT",
 				"KAFKA_NET_SASL_ENABLE", "KAFKA_NET_SASL_USER", "KAFKA_NET_SASL_PASSWORD", "KAFKA_NET_SASL_TYPE",
+				"KAFKA_NET_SASL_CLIENT_ID", "KAFKA_NET_SASL_CLIENT_SECRET", "KAFKA_NET_SASL_TOKEN_URL", "KAFKA_NET_SASL_SCOPES",
 				"KAFKA_BOOTSTRAP_SERVERS":
 				continue
 			default:
@@ -223,3 +227,25 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 		spec.Containers[i].Env = env
 	}
 }
+
+// oauthEnvVars returns the environment variables of the OAUTHBEARER settings which reference a secret
+func (kfb *KafkaBinding) oauthEnvVars() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, oauthEnv := range []struct {
+		name  string
+		value SecretValueFromSource
+	}{
+		{name: "KAFKA_NET_SASL_CLIENT_ID", value: kfb.Spec.Net.SASL.ClientId},
+		{name: "KAFKA_NET_SASL_CLIENT_SECRET", value: kfb.Spec.Net.SASL.ClientSecret},
+		{name: "KAFKA_NET_SASL_TOKEN_URL", value: kfb.Spec.Net.SASL.TokenUrl},
+		{name: "KAFKA_NET_SASL_SCOPES", value: kfb.Spec.Net.SASL.Scopes},
+	} {
+		if oauthEnv.value.SecretKeyRef != nil {
+			env = append(env, corev1.EnvVar{
+				Name:      oauthEnv.name,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: oauthEnv.value.SecretKeyRef},
+			})
+		}
+	}
+	return env
+}
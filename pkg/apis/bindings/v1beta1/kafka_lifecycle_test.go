@@ -561,6 +561,66 @@ func TestKafkaBindingDoSASL(t *testing.T) {
 	}
 }
 
+func TestKafkaBindingDoOAuth(t *testing.T) {
+	secretRef := func(key string) SecretValueFromSource {
+		return SecretValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "oauth-secret"},
+			Key:                  key,
+		}}
+	}
+	vsb := &KafkaBinding{
+		Spec: KafkaBindingSpec{
+			KafkaAuthSpec: KafkaAuthSpec{
+				BootstrapServers: []string{"kafka:9092"},
+				Net: KafkaNetSpec{
+					SASL: KafkaSASLSpec{
+						Enable:       true,
+						Type:         secretRef("saslType"),
+						ClientId:     secretRef("clientId"),
+						ClientSecret: secretRef("clientSecret"),
+						TokenUrl:     secretRef("tokenUrl"),
+					},
+				},
+			},
+		},
+	}
+	pod := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+					Containers:     []corev1.Container{{Name: "blah", Image: "busybox"}},
+				},
+			},
+		},
+	}
+
+	// Verify The Referenced OAuth Settings Are Added (Omitting The Unspecified Scopes)
+	vsb.Do(context.Background(), pod)
+	for _, container := range append(pod.Spec.Template.Spec.InitContainers, pod.Spec.Template.Spec.Containers...) {
+		env := make(map[string]*corev1.EnvVarSource)
+		for _, envVar := range container.Env {
+			env[envVar.Name] = envVar.ValueFrom
+		}
+		for name, key := range map[string]string{"KAFKA_NET_SASL_CLIENT_ID": "clientId", "KAFKA_NET_SASL_CLIENT_SECRET": "clientSecret", "KAFKA_NET_SASL_TOKEN_URL": "tokenUrl"} {
+			if valueFrom, ok := env[name]; !ok || valueFrom.SecretKeyRef.Key != key {
+				t.Errorf("%s: missing or incorrect %s env var: %v", container.Name, name, valueFrom)
+			}
+		}
+		if _, ok := env["KAFKA_NET_SASL_SCOPES"]; ok {
+			t.Errorf("%s: unexpected KAFKA_NET_SASL_SCOPES env var", container.Name)
+		}
+	}
+
+	// Verify The OAuth Settings Are Removed
+	vsb.Undo(context.Background(), pod)
+	for _, container := range append(pod.Spec.Template.Spec.InitContainers, pod.Spec.Template.Spec.Containers...) {
+		if len(container.Env) != 0 {
+			t.Errorf("%s: env vars remain after Undo: %v", container.Name, container.Env)
+		}
+	}
+}
+
 func TestKafkaBindingDoTLS(t *testing.T) {
 	url := apis.URL{
 		Scheme: "http",
@@ -57,9 +57,25 @@ type KafkaSASLSpec struct {
 	// +optional
 	Password SecretValueFromSource `json:"password,omitempty"`
 
-	// Type of saslType, defaults to plain (vs SCRAM-SHA-512 or SCRAM-SHA-256)
+	// Type of saslType, defaults to plain (vs SCRAM-SHA-512, SCRAM-SHA-256 or OAUTHBEARER)
 	// +optional
 	Type SecretValueFromSource `json:"type,omitempty"`
+
+	// ClientId is the Kubernetes secret containing the OAuth client id (OAUTHBEARER only).
+	// +optional
+	ClientId SecretValueFromSource `json:"clientId,omitempty"`
+
+	// ClientSecret is the Kubernetes secret containing the OAuth client secret (OAUTHBEARER only).
+	// +optional
+	ClientSecret SecretValueFromSource `json:"clientSecret,omitempty"`
+
+	// TokenUrl is the Kubernetes secret containing the URL of the OAuth token endpoint (OAUTHBEARER only).
+	// +optional
+	TokenUrl SecretValueFromSource `json:"tokenUrl,omitempty"`
+
+	// Scopes is the Kubernetes secret containing the comma or space separated OAuth scopes (OAUTHBEARER only).
+	// +optional
+	Scopes SecretValueFromSource `json:"scopes,omitempty"`
 }
 
 type KafkaTLSSpec struct {
@@ -168,6 +168,10 @@ func (in *KafkaSASLSpec) DeepCopyInto(out *KafkaSASLSpec) {
 	in.User.DeepCopyInto(&out.User)
 	in.Password.DeepCopyInto(&out.Password)
 	in.Type.DeepCopyInto(&out.Type)
+	in.ClientId.DeepCopyInto(&out.ClientId)
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	in.TokenUrl.DeepCopyInto(&out.TokenUrl)
+	in.Scopes.DeepCopyInto(&out.Scopes)
 	return
 }
 
@@ -28,4 +28,10 @@ var (
 		Group:    GroupName,
 		Resource: "resetoffsets",
 	}
+
+	// KafkaSinksResource represents a KafkaSink
+	KafkaSinksResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "kafkasinks",
+	}
 )
@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaSink_SetDefaults(t *testing.T) {
+	kafkaSink := &KafkaSink{}
+	kafkaSink.SetDefaults(context.TODO())
+	assert.NotNil(t, kafkaSink.Spec.ContentMode)
+	assert.Equal(t, ModeBinary, *kafkaSink.Spec.ContentMode)
+
+	structured := ModeStructured
+	kafkaSink = &KafkaSink{Spec: KafkaSinkSpec{ContentMode: &structured}}
+	kafkaSink.SetDefaults(context.TODO())
+	assert.Equal(t, ModeStructured, *kafkaSink.Spec.ContentMode)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var sinkCondSet = apis.NewLivingConditionSet(
+	KafkaSinkConditionConfigReady,
+	KafkaSinkConditionTopicReady,
+	KafkaSinkConditionAddressable)
+
+var sinkCondSetLock = sync.RWMutex{}
+
+const (
+	// KafkaSinkConditionReady has status True when all sub-conditions below have been set to True.
+	KafkaSinkConditionReady = apis.ConditionReady
+
+	// KafkaSinkConditionConfigReady has status True when the Secrets referenced by the KafkaSink
+	// have been resolved and a valid Sarama config could be built from them.
+	KafkaSinkConditionConfigReady apis.ConditionType = "ConfigReady"
+
+	// KafkaSinkConditionTopicReady has status True when the Kafka Topic of the KafkaSink has been
+	// verified to exist in the Kafka cluster.
+	KafkaSinkConditionTopicReady apis.ConditionType = "TopicReady"
+
+	// KafkaSinkConditionAddressable has status True when the KafkaSink's Service, through which
+	// the shared ingress receives its events, has been reconciled and its address is set.
+	KafkaSinkConditionAddressable apis.ConditionType = "Addressable"
+)
+
+// RegisterAlternateKafkaSinkConditionSet register a different apis.ConditionSet.
+func RegisterAlternateKafkaSinkConditionSet(conditionSet apis.ConditionSet) {
+	sinkCondSetLock.Lock()
+	defer sinkCondSetLock.Unlock()
+	sinkCondSet = conditionSet
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*KafkaSink) GetConditionSet() apis.ConditionSet {
+	sinkCondSetLock.RLock()
+	defer sinkCondSetLock.RUnlock()
+	return sinkCondSet
+}
+
+// GetConditionSet retrieves the condition set for this resource.
+func (*KafkaSinkStatus) GetConditionSet() apis.ConditionSet {
+	sinkCondSetLock.RLock()
+	defer sinkCondSetLock.RUnlock()
+	return sinkCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (kss *KafkaSinkStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return kss.GetConditionSet().Manage(kss).GetCondition(t)
+}
+
+// IsReady returns true if the KafkaSinkConditionReady status is true.
+func (kss *KafkaSinkStatus) IsReady() bool {
+	return kss.GetConditionSet().Manage(kss).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (kss *KafkaSinkStatus) InitializeConditions() {
+	kss.GetConditionSet().Manage(kss).InitializeConditions()
+}
+
+// MarkConfigTrue sets the KafkaSinkConditionConfigReady condition to True.
+func (kss *KafkaSinkStatus) MarkConfigTrue() {
+	kss.GetConditionSet().Manage(kss).MarkTrue(KafkaSinkConditionConfigReady)
+}
+
+// MarkConfigFailed sets the KafkaSinkConditionConfigReady condition to False with the specified reason and message.
+func (kss *KafkaSinkStatus) MarkConfigFailed(reason, messageFormat string, messageA ...interface{}) {
+	kss.GetConditionSet().Manage(kss).MarkFalse(KafkaSinkConditionConfigReady, reason, messageFormat, messageA...)
+}
+
+// MarkTopicTrue sets the KafkaSinkConditionTopicReady condition to True.
+func (kss *KafkaSinkStatus) MarkTopicTrue() {
+	kss.GetConditionSet().Manage(kss).MarkTrue(KafkaSinkConditionTopicReady)
+}
+
+// MarkTopicFailed sets the KafkaSinkConditionTopicReady condition to False with the specified reason and message.
+func (kss *KafkaSinkStatus) MarkTopicFailed(reason, messageFormat string, messageA ...interface{}) {
+	kss.GetConditionSet().Manage(kss).MarkFalse(KafkaSinkConditionTopicReady, reason, messageFormat, messageA...)
+}
+
+// SetAddress sets the address (as part of Addressable contract) and marks the correct condition.
+func (kss *KafkaSinkStatus) SetAddress(url *apis.URL) {
+	if kss.Address == nil {
+		kss.Address = &duckv1.Addressable{}
+	}
+	if url != nil {
+		kss.Address.URL = url
+		kss.GetConditionSet().Manage(kss).MarkTrue(KafkaSinkConditionAddressable)
+	} else {
+		kss.Address.URL = nil
+		kss.GetConditionSet().Manage(kss).MarkFalse(KafkaSinkConditionAddressable, "EmptyURL", "URL is nil")
+	}
+}
+
+// MarkAddressableFailed sets the KafkaSinkConditionAddressable condition to False with the specified reason and message.
+func (kss *KafkaSinkStatus) MarkAddressableFailed(reason, messageFormat string, messageA ...interface{}) {
+	kss.GetConditionSet().Manage(kss).MarkFalse(KafkaSinkConditionAddressable, reason, messageFormat, messageA...)
+}
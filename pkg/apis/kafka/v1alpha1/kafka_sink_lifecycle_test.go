@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestKafkaSink_GetConditionSet(t *testing.T) {
+	ks := &KafkaSink{}
+	if got, want := ks.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestKafkaSinkStatus_InitializeConditions(t *testing.T) {
+	status := &KafkaSinkStatus{}
+	status.InitializeConditions()
+	for _, conditionType := range []apis.ConditionType{KafkaSinkConditionReady, KafkaSinkConditionConfigReady, KafkaSinkConditionTopicReady, KafkaSinkConditionAddressable} {
+		condition := status.GetCondition(conditionType)
+		assert.NotNil(t, condition)
+		assert.Equal(t, corev1.ConditionUnknown, condition.Status)
+	}
+	assert.False(t, status.IsReady())
+}
+
+func TestKafkaSinkStatus_Ready(t *testing.T) {
+	url := &apis.URL{Scheme: "http", Host: "sink-kn-sink.namespace.svc.cluster.local"}
+
+	status := &KafkaSinkStatus{}
+	status.InitializeConditions()
+	status.MarkConfigTrue()
+	status.MarkTopicTrue()
+	assert.False(t, status.IsReady())
+	status.SetAddress(url)
+	assert.True(t, status.IsReady())
+	assert.Equal(t, url, status.Address.URL)
+
+	// Any Failed Condition Makes The KafkaSink Not Ready
+	status.MarkTopicFailed("TopicNotFound", "Topic %s Not Found", "topic")
+	assert.False(t, status.IsReady())
+	assert.Equal(t, "Topic topic Not Found", status.GetCondition(KafkaSinkConditionTopicReady).Message)
+	status.MarkTopicTrue()
+	status.MarkConfigFailed("InvalidConfig", "Invalid Config")
+	assert.False(t, status.IsReady())
+	status.MarkConfigTrue()
+	status.MarkAddressableFailed("ServiceFailed", "Service Failed")
+	assert.False(t, status.IsReady())
+
+	// Clearing The Address Marks The KafkaSink Not Addressable
+	status.SetAddress(url)
+	assert.True(t, status.IsReady())
+	status.SetAddress(nil)
+	assert.False(t, status.IsReady())
+	assert.Nil(t, status.Address.URL)
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(KafkaSinkConditionAddressable).Status)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+)
+
+const (
+	// ModeBinary produces events in the CloudEvents Kafka binary content mode (attributes as Kafka headers).
+	ModeBinary = "binary"
+
+	// ModeStructured produces events in the CloudEvents Kafka structured content mode (JSON event as Kafka value).
+	ModeStructured = "structured"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaSink is an Addressable resource which produces the CloudEvents it receives over HTTP to an
+// existing Kafka Topic.
+type KafkaSink struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the KafkaSink.
+	Spec KafkaSinkSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the KafkaSink.
+	// This data may be out of date.
+	// +optional
+	Status KafkaSinkStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that this resource can be validated and defaulted.
+	_ apis.Validatable = (*KafkaSink)(nil)
+	_ apis.Defaultable = (*KafkaSink)(nil)
+
+	_ runtime.Object = (*KafkaSink)(nil)
+
+	// Check that we can create OwnerReferences to an this resource.
+	_ kmeta.OwnerRefable = (*KafkaSink)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*KafkaSink)(nil)
+)
+
+// KafkaSinkSpec defines the specification for a KafkaSink.
+type KafkaSinkSpec struct {
+
+	// KafkaAuthSpec provides the BootstrapServers of the Kafka cluster and the Secret references of its
+	// SASL and TLS settings (the same as for a KafkaSource).
+	bindingsv1beta1.KafkaAuthSpec `json:",inline"`
+
+	// Topic is the name of the existing Kafka Topic to which the received events are produced.
+	Topic string `json:"topic"`
+
+	// ContentMode is the CloudEvents Kafka content mode ("binary" or "structured") in which the
+	// received events are produced, defaulting to "binary".
+	// +optional
+	ContentMode *string `json:"contentMode,omitempty"`
+}
+
+// KafkaSinkStatus represents the current state of a KafkaSink.
+type KafkaSinkStatus struct {
+
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	// * Annotations - optional status information to be conveyed to users.
+	duckv1.Status `json:",inline"`
+
+	// KafkaSink is Addressable. It exposes the endpoint as an URI to which events are sent.
+	// It generally has the form http://<name>-kn-sink.<namespace>.svc.cluster.local
+	duckv1.AddressStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaSinkList is a collection of KafkaSinks.
+type KafkaSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaSink `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for KafkaSink
+func (ks *KafkaSink) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("KafkaSink")
+}
+
+// GetStatus retrieves the duck status for this resource. Implements the KRShaped interface.
+func (ks *KafkaSink) GetStatus() *duckv1.Status {
+	return &ks.Status.Status
+}
+
+// GetContentMode returns the ContentMode of the KafkaSink, or the default "binary" mode if unspecified.
+func (kss *KafkaSinkSpec) GetContentMode() string {
+	if kss.ContentMode == nil || *kss.ContentMode == "" {
+		return ModeBinary
+	}
+	return *kss.ContentMode
+}
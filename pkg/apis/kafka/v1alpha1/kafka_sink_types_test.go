@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestKafkaSink_GetGroupVersionKind(t *testing.T) {
+	kafkaSink := KafkaSink{}
+	gvk := kafkaSink.GetGroupVersionKind()
+	if gvk.Kind != "KafkaSink" {
+		t.Errorf("Should be 'KafkaSink'.")
+	}
+}
+
+func TestKafkaSink_GetStatus(t *testing.T) {
+	status := &duckv1.Status{}
+	kafkaSink := KafkaSink{
+		Status: KafkaSinkStatus{
+			Status: *status,
+		},
+	}
+	if !cmp.Equal(kafkaSink.GetStatus(), status) {
+		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", kafkaSink.GetStatus(), status)
+	}
+}
+
+func TestKafkaSinkSpec_GetContentMode(t *testing.T) {
+	empty := ""
+	structured := ModeStructured
+	assert.Equal(t, ModeBinary, (&KafkaSinkSpec{}).GetContentMode())
+	assert.Equal(t, ModeBinary, (&KafkaSinkSpec{ContentMode: &empty}).GetContentMode())
+	assert.Equal(t, ModeStructured, (&KafkaSinkSpec{ContentMode: &structured}).GetContentMode())
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate verifies the KafkaSink and returns errors for any invalid fields.
+func (ks *KafkaSink) Validate(ctx context.Context) *apis.FieldError {
+	errs := ks.Spec.Validate(ctx).ViaField("spec")
+
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*KafkaSink)
+		errs = errs.Also(ks.CheckImmutableFields(ctx, original))
+	}
+
+	return errs
+}
+
+// Validate verifies the KafkaSinkSpec and returns errors for an invalid fields.
+func (kss *KafkaSinkSpec) Validate(_ context.Context) *apis.FieldError {
+
+	var errs *apis.FieldError
+
+	// Validate The BootstrapServers (At Least One Non-Empty Server)
+	if len(kss.BootstrapServers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("bootstrapServers"))
+	}
+	for i, bootstrapServer := range kss.BootstrapServers {
+		if bootstrapServer == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(bootstrapServer, "bootstrapServers", i))
+		}
+	}
+
+	// Validate The Topic (Existence Is Verified By The Controller)
+	if kss.Topic == "" {
+		errs = errs.Also(apis.ErrMissingField("topic"))
+	}
+
+	// Validate The ContentMode ("binary" Or "structured")
+	if kss.ContentMode != nil && *kss.ContentMode != ModeBinary && *kss.ContentMode != ModeStructured {
+		errs = errs.Also(apis.ErrInvalidValue(*kss.ContentMode, "contentMode"))
+	}
+
+	return errs
+}
+
+// CheckImmutableFields verifies the immutable spec fields have not been changed from the original.
+func (ks *KafkaSink) CheckImmutableFields(_ context.Context, original *KafkaSink) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	// The Topic Is Immutable As Consumers Of The Original Topic Would Silently Stop Receiving Events
+	if ks.Spec.Topic != original.Spec.Topic {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec.topic"},
+			Details: "-" + original.Spec.Topic + " +" + ks.Spec.Topic,
+		}
+	}
+
+	return nil
+}
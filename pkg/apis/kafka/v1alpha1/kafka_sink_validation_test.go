@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+)
+
+func TestKafkaSink_Validate(t *testing.T) {
+
+	binary := ModeBinary
+	invalidMode := "invalid"
+	authSpec := bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"kafka:9092"}}
+
+	tests := []struct {
+		name     string
+		sink     *KafkaSink
+		original *KafkaSink
+		want     *apis.FieldError
+	}{
+		{
+			name: "valid",
+			sink: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic", ContentMode: &binary}},
+		},
+		{
+			name: "valid without content mode",
+			sink: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic"}},
+		},
+		{
+			name: "missing bootstrap servers and topic",
+			sink: &KafkaSink{Spec: KafkaSinkSpec{}},
+			want: apis.ErrMissingField("spec.bootstrapServers", "spec.topic"),
+		},
+		{
+			name: "empty bootstrap server",
+			sink: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{""}}, Topic: "topic"}},
+			want: apis.ErrInvalidArrayValue("", "spec.bootstrapServers", 0),
+		},
+		{
+			name: "invalid content mode",
+			sink: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic", ContentMode: &invalidMode}},
+			want: apis.ErrInvalidValue(invalidMode, "spec.contentMode"),
+		},
+		{
+			name:     "valid update",
+			sink:     &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic", ContentMode: &binary}},
+			original: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic"}},
+		},
+		{
+			name:     "immutable topic",
+			sink:     &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "new-topic"}},
+			original: &KafkaSink{Spec: KafkaSinkSpec{KafkaAuthSpec: authSpec, Topic: "topic"}},
+			want: &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec.topic"},
+				Details: "-topic +new-topic",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+			if test.original != nil {
+				ctx = apis.WithinUpdate(ctx, test.original)
+			}
+			got := test.sink.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
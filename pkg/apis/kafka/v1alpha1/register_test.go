@@ -46,4 +46,8 @@ func TestAddKnownTypes(t *testing.T) {
 	assert.NotNil(t, roType)
 	roListType := types["ResetOffsetList"]
 	assert.NotNil(t, roListType)
+	ksType := types["KafkaSink"]
+	assert.NotNil(t, ksType)
+	ksListType := types["KafkaSinkList"]
+	assert.NotNil(t, ksListType)
 }
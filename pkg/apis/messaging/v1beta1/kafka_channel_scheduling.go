@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+func (kc *KafkaChannel) GetKey() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: kc.Namespace,
+		Name:      kc.Name,
+	}
+}
+
+// GetVReplicas returns a single virtual replica for the KafkaChannel, which is served entirely by one
+// dispatcher replica, or zero once the KafkaChannel is being deleted so that it will be de-scheduled.
+func (kc *KafkaChannel) GetVReplicas() int32 {
+	if kc.DeletionTimestamp != nil {
+		return 0
+	}
+	return 1
+}
+
+func (kc *KafkaChannel) GetPlacements() []v1alpha1.Placement {
+	if kc.Status.Placeable.Placements == nil {
+		return nil
+	}
+	return kc.Status.Placeable.Placements
+}
+
+func (kc *KafkaChannel) GetResourceVersion() string {
+	return kc.ObjectMeta.ResourceVersion
+}
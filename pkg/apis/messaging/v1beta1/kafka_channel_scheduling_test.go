@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+func TestScheduling(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	testCases := map[string]struct {
+		channel     KafkaChannel
+		key         types.NamespacedName
+		vreplicas   int32
+		placements  []v1alpha1.Placement
+		rsrcversion string
+	}{
+		"all empty": {
+			channel:     KafkaChannel{},
+			key:         types.NamespacedName{},
+			vreplicas:   int32(1),
+			placements:  nil,
+			rsrcversion: "",
+		},
+		"all full": {
+			channel: KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "achannel",
+					Namespace:       "anamespace",
+					ResourceVersion: "12345",
+				},
+				Status: KafkaChannelStatus{
+					Placeable: v1alpha1.Placeable{Placements: []v1alpha1.Placement{
+						{PodName: "apod", VReplicas: 1},
+					}},
+				},
+			},
+			key: types.NamespacedName{
+				Namespace: "anamespace",
+				Name:      "achannel",
+			},
+			vreplicas: int32(1),
+			placements: []v1alpha1.Placement{
+				{PodName: "apod", VReplicas: 1},
+			},
+			rsrcversion: "12345",
+		},
+		"deleted": {
+			channel: KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "achannel",
+					Namespace:         "anamespace",
+					DeletionTimestamp: &deletionTimestamp,
+				},
+			},
+			key: types.NamespacedName{
+				Namespace: "anamespace",
+				Name:      "achannel",
+			},
+			vreplicas:  int32(0),
+			placements: nil,
+		},
+	}
+
+	for n, tc := range testCases {
+		tc := tc
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			if !reflect.DeepEqual(tc.channel.GetKey(), tc.key) {
+				t.Errorf("unexpected key (want %v, got %v)", tc.key, tc.channel.GetKey())
+			}
+			if tc.channel.GetVReplicas() != tc.vreplicas {
+				t.Errorf("unexpected vreplicas (want %d, got %d)", tc.vreplicas, tc.channel.GetVReplicas())
+			}
+			if tc.channel.GetResourceVersion() != tc.rsrcversion {
+				t.Errorf("unexpected resource version (want %v, got %v)", tc.rsrcversion, tc.channel.GetResourceVersion())
+			}
+			if !reflect.DeepEqual(tc.channel.GetPlacements(), tc.placements) {
+				t.Errorf("unexpected placements (want %v, got %v)", tc.placements, tc.channel.GetPlacements())
+			}
+		})
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"time"
 
 	"github.com/rickb777/date/period"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -77,10 +79,87 @@ type KafkaChannelSpec struct {
 	//  - https://en.wikipedia.org/wiki/ISO_8601
 	RetentionDuration string `json:"retentionDuration"`
 
+	// Topic is the name of an existing Kafka topic to use instead of one created for the KafkaChannel. The topic
+	// must already exist with the specified NumPartitions and ReplicationFactor, and RetentionDuration is ignored.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// DeletionPolicy determines whether the Kafka topic is deleted along with the KafkaChannel. By default, an
+	// existing Topic is retained and a topic created for the KafkaChannel is deleted.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Dispatcher optionally overrides the global dispatcher settings of the config-kafka ConfigMap for
+	// this KafkaChannel's dedicated dispatcher (distributed KafkaChannel only).
+	// +optional
+	Dispatcher *KafkaChannelDispatcherSpec `json:"dispatcher,omitempty"`
+
+	// ClaimCheck opts this KafkaChannel into claim-check mode, in which event payloads larger than the
+	// threshold are offloaded to the blob store configured in the config-kafka ConfigMap and only a
+	// reference to them is written to the Kafka topic.
+	// +optional
+	ClaimCheck *KafkaChannelClaimCheckSpec `json:"claimCheck,omitempty"`
+
+	// Encryption enables the encryption (at rest) of the data of events written to the Kafka topic
+	// using the keys of the referenced Secret.  The CloudEvent attributes remain readable.
+	// +optional
+	Encryption *KafkaChannelEncryptionSpec `json:"encryption,omitempty"`
+
+	// DurabilityClass selects the producer settings (acks, idempotence, compression and linger) with which
+	// events are written to the Kafka topic. By default, the sarama settings of the config-kafka ConfigMap
+	// are used unaltered.
+	// +optional
+	DurabilityClass DurabilityClass `json:"durabilityClass,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
 
+// DeletionPolicy defines what happens to a KafkaChannel's Kafka topic when the KafkaChannel is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the Kafka topic along with the KafkaChannel.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyRetain leaves the Kafka topic in place when the KafkaChannel is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// DurabilityClass defines the trade-off between latency and durability with which events are produced.
+type DurabilityClass string
+
+const (
+	// DurabilityClassDefault produces events with the sarama settings of the config-kafka ConfigMap.
+	DurabilityClassDefault DurabilityClass = "default"
+
+	// DurabilityClassFast produces events with leader-only acknowledgement, lz4 compression and no linger.
+	DurabilityClassFast DurabilityClass = "fast"
+
+	// DurabilityClassDurable produces events idempotently with acknowledgement by all in-sync replicas, and
+	// requires the topic's "min.insync.replicas" to be at least 2.
+	DurabilityClassDurable DurabilityClass = "durable"
+)
+
+// KafkaChannelDispatcherSpec defines the per-KafkaChannel overrides of the dispatcher Deployment.
+type KafkaChannelDispatcherSpec struct {
+	// Replicas is the number of dispatcher replicas.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the dispatcher container's requests and limits, each of which is merged over the global value.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the dispatcher pods to nodes with the specified labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the dispatcher pods to be scheduled onto nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
 // ParseRetentionDuration returns the parsed Offset Time if valid (RFC3339 format) or an error for invalid content.
 // Note - If the optional RetentionDuration field is not present, or is invalid, a Duration of "-1" will be returned.
 func (kcs *KafkaChannelSpec) ParseRetentionDuration() (time.Duration, error) {
@@ -92,10 +171,54 @@ func (kcs *KafkaChannelSpec) ParseRetentionDuration() (time.Duration, error) {
 	return retentionDuration, nil
 }
 
+// EffectiveDeletionPolicy returns the DeletionPolicy, defaulting to Retain for an existing Topic and Delete otherwise.
+func (kcs *KafkaChannelSpec) EffectiveDeletionPolicy() DeletionPolicy {
+	if len(kcs.DeletionPolicy) > 0 {
+		return kcs.DeletionPolicy
+	}
+	if len(kcs.Topic) > 0 {
+		return DeletionPolicyRetain
+	}
+	return DeletionPolicyDelete
+}
+
+// EffectiveDurabilityClass returns the DurabilityClass, defaulting to DurabilityClassDefault.
+func (kcs *KafkaChannelSpec) EffectiveDurabilityClass() DurabilityClass {
+	if len(kcs.DurabilityClass) > 0 {
+		return kcs.DurabilityClass
+	}
+	return DurabilityClassDefault
+}
+
+// KafkaChannelClaimCheckSpec defines the claim-check settings of a KafkaChannel.
+type KafkaChannelClaimCheckSpec struct {
+	// ThresholdBytes is the payload size above which events are offloaded to the blob store. By default,
+	// the thresholdBytes of the config-kafka ConfigMap is used.
+	// +optional
+	ThresholdBytes int64 `json:"thresholdBytes,omitempty"`
+}
+
+// KafkaChannelEncryptionSpec defines the encryption settings of a KafkaChannel.
+type KafkaChannelEncryptionSpec struct {
+	// SecretName is the name of a Secret, in the namespace of the KafkaChannel, holding the AES keys
+	// (16, 24 or 32 bytes) used to encrypt the event data, each under its own key-id.  The optional
+	// "activeKeyId" entry names the key used for encryption, which is required when there are several
+	// keys.  The other keys are retained for decrypting events written before a key rotation.
+	SecretName string `json:"secretName"`
+}
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type ChannelableStatus.
 	eventingduck.ChannelableStatus `json:",inline"`
+
+	// Implement Placeable (Used By The Distributed KafkaChannel's Shared Dispatcher).
+	// +optional
+	v1alpha1.Placeable `json:",inline"`
+
+	// DurabilityClass is the DurabilityClass in effect for producing events to the Kafka topic.
+	// +optional
+	DurabilityClass DurabilityClass `json:"durabilityClass,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
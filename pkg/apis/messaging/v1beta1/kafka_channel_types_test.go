@@ -100,3 +100,26 @@ func TestKafkaChannelSpecParseRetentionDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaChannelSpecEffectiveDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		spec KafkaChannelSpec
+		want DeletionPolicy
+	}{
+		{name: "default", spec: KafkaChannelSpec{}, want: DeletionPolicyDelete},
+		{name: "default existing topic", spec: KafkaChannelSpec{Topic: "existing-topic"}, want: DeletionPolicyRetain},
+		{name: "explicit retain", spec: KafkaChannelSpec{DeletionPolicy: DeletionPolicyRetain}, want: DeletionPolicyRetain},
+		{name: "explicit delete existing topic", spec: KafkaChannelSpec{Topic: "existing-topic", DeletionPolicy: DeletionPolicyDelete}, want: DeletionPolicyDelete},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.spec.EffectiveDeletionPolicy())
+		})
+	}
+}
+
+func TestKafkaChannelSpecEffectiveDurabilityClass(t *testing.T) {
+	assert.Equal(t, DurabilityClassDefault, (&KafkaChannelSpec{}).EffectiveDurabilityClass())
+	assert.Equal(t, DurabilityClassFast, (&KafkaChannelSpec{DurabilityClass: DurabilityClassFast}).EffectiveDurabilityClass())
+}
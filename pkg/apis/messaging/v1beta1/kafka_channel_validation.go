@@ -19,10 +19,14 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
@@ -69,6 +73,43 @@ func (kcs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(fe)
 	}
 
+	if len(kcs.Topic) > 0 && !isValidTopicName(kcs.Topic) {
+		fe := apis.ErrInvalidValue(kcs.Topic, "topic")
+		errs = errs.Also(fe)
+	}
+
+	switch kcs.DeletionPolicy {
+	case "", DeletionPolicyDelete, DeletionPolicyRetain:
+	default:
+		fe := apis.ErrInvalidValue(kcs.DeletionPolicy, "deletionPolicy")
+		errs = errs.Also(fe)
+	}
+
+	switch kcs.DurabilityClass {
+	case "", DurabilityClassDefault, DurabilityClassFast, DurabilityClassDurable:
+	default:
+		fe := apis.ErrInvalidValue(kcs.DurabilityClass, "durabilityClass")
+		errs = errs.Also(fe)
+	}
+
+	if kcs.Dispatcher != nil {
+		errs = errs.Also(kcs.Dispatcher.Validate(ctx).ViaField("dispatcher"))
+	}
+
+	if kcs.ClaimCheck != nil && kcs.ClaimCheck.ThresholdBytes < 0 {
+		fe := apis.ErrInvalidValue(kcs.ClaimCheck.ThresholdBytes, "thresholdBytes").ViaField("claimCheck")
+		errs = errs.Also(fe)
+	}
+
+	if kcs.Encryption != nil {
+		if kcs.Encryption.SecretName == "" {
+			errs = errs.Also(apis.ErrMissingField("secretName").ViaField("encryption"))
+		} else if msgs := validation.IsDNS1123Subdomain(kcs.Encryption.SecretName); len(msgs) > 0 {
+			fe := apis.ErrInvalidValue(kcs.Encryption.SecretName, "secretName", strings.Join(msgs, ", ")).ViaField("encryption")
+			errs = errs.Also(fe)
+		}
+	}
+
 	for i, subscriber := range kcs.SubscribableSpec.Subscribers {
 		if subscriber.ReplyURI == nil && subscriber.SubscriberURI == nil {
 			fe := apis.ErrMissingField("replyURI", "subscriberURI")
@@ -79,12 +120,101 @@ func (kcs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+func (kcds *KafkaChannelDispatcherSpec) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if kcds.Replicas != nil && *kcds.Replicas < 0 {
+		fe := apis.ErrInvalidValue(*kcds.Replicas, "replicas")
+		errs = errs.Also(fe)
+	}
+
+	for name, quantity := range kcds.Resources.Limits {
+		if quantity.Sign() < 0 {
+			fe := apis.ErrInvalidValue(quantity.String(), apis.CurrentField).ViaFieldKey("limits", string(name)).ViaField("resources")
+			errs = errs.Also(fe)
+		}
+	}
+	for name, quantity := range kcds.Resources.Requests {
+		if quantity.Sign() < 0 {
+			fe := apis.ErrInvalidValue(quantity.String(), apis.CurrentField).ViaFieldKey("requests", string(name)).ViaField("resources")
+			errs = errs.Also(fe)
+		} else if limit, ok := kcds.Resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			fe := apis.ErrInvalidValue(quantity.String(), apis.CurrentField).ViaFieldKey("requests", string(name)).ViaField("resources")
+			fe.Details = fmt.Sprintf("must be less than or equal to the %s limit of %s", name, limit.String())
+			errs = errs.Also(fe)
+		}
+	}
+
+	for key, value := range kcds.NodeSelector {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			fe := apis.ErrInvalidKeyName(key, "nodeSelector", msgs...)
+			errs = errs.Also(fe)
+		}
+		if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+			fe := apis.ErrInvalidValue(value, apis.CurrentField, msgs...).ViaFieldKey("nodeSelector", key)
+			errs = errs.Also(fe)
+		}
+	}
+
+	for i, toleration := range kcds.Tolerations {
+		errs = errs.Also(validateToleration(toleration).ViaFieldIndex("tolerations", i))
+	}
+
+	return errs
+}
+
+func validateToleration(toleration corev1.Toleration) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if len(toleration.Key) > 0 {
+		if msgs := validation.IsQualifiedName(toleration.Key); len(msgs) > 0 {
+			fe := apis.ErrInvalidValue(toleration.Key, "key", msgs...)
+			errs = errs.Also(fe)
+		}
+	} else if toleration.Operator != corev1.TolerationOpExists {
+		fe := apis.ErrInvalidValue(toleration.Operator, "operator")
+		fe.Details = "expected 'Exists' when the key is empty"
+		errs = errs.Also(fe)
+	}
+
+	switch toleration.Operator {
+	case "", corev1.TolerationOpEqual:
+	case corev1.TolerationOpExists:
+		if len(toleration.Value) > 0 {
+			fe := apis.ErrInvalidValue(toleration.Value, "value")
+			fe.Details = "expected no value when the operator is 'Exists'"
+			errs = errs.Also(fe)
+		}
+	default:
+		fe := apis.ErrInvalidValue(toleration.Operator, "operator")
+		fe.Details = "expected either 'Equal' or 'Exists'"
+		errs = errs.Also(fe)
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		fe := apis.ErrInvalidValue(toleration.Effect, "effect")
+		fe.Details = "expected one of 'NoSchedule', 'PreferNoSchedule' or 'NoExecute'"
+		errs = errs.Also(fe)
+	}
+
+	return errs
+}
+
+// Kafka topic names are limited to 249 alphanumeric, '.', '_' and '-' characters, and may not be "." or "..".
+var topicNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+func isValidTopicName(topic string) bool {
+	return topic != "." && topic != ".." && topicNameRegexp.MatchString(topic)
+}
+
 func (kc *KafkaChannel) CheckImmutableFields(_ context.Context, original *KafkaChannel) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 
-	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec")}
+	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec", "Dispatcher", "DeletionPolicy", "ClaimCheck", "Encryption", "DurabilityClass")}
 
 	// In the specific case of the original RetentionDuration being an empty string, allow it
 	// as an exception to the immutability requirement.
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
@@ -33,6 +35,9 @@ import (
 
 func TestKafkaChannelValidation(t *testing.T) {
 
+	replicas := int32(4)
+	negativeReplicas := int32(-1)
+
 	testCases := map[string]struct {
 		cr   resourcesemantics.GenericCRD
 		want *apis.FieldError
@@ -175,6 +180,194 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return errs
 			}(),
 		},
+		"valid existing topic": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Topic:             "governed.topic-name_1",
+					DeletionPolicy:    DeletionPolicyRetain,
+				},
+			},
+		},
+		"invalid topic and deletionPolicy": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Topic:             "invalid/topic",
+					DeletionPolicy:    "Orphan",
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				errs = errs.Also(apis.ErrInvalidValue("invalid/topic", "spec.topic"))
+				errs = errs.Also(apis.ErrInvalidValue("Orphan", "spec.deletionPolicy"))
+				return errs
+			}(),
+		},
+		"valid claimCheck": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					ClaimCheck:        &KafkaChannelClaimCheckSpec{ThresholdBytes: 1048576},
+				},
+			},
+		},
+		"invalid claimCheck thresholdBytes": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					ClaimCheck:        &KafkaChannelClaimCheckSpec{ThresholdBytes: -1},
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.claimCheck.thresholdBytes"),
+		},
+		"valid encryption": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Encryption:        &KafkaChannelEncryptionSpec{SecretName: "channel-keys"},
+				},
+			},
+		},
+		"missing encryption secretName": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Encryption:        &KafkaChannelEncryptionSpec{},
+				},
+			},
+			want: apis.ErrMissingField("spec.encryption.secretName"),
+		},
+		"valid durabilityClass": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					DurabilityClass:   DurabilityClassDurable,
+				},
+			},
+		},
+		"invalid durabilityClass": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					DurabilityClass:   "reckless",
+				},
+			},
+			want: apis.ErrInvalidValue("reckless", "spec.durabilityClass"),
+		},
+		"valid dispatcher": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Dispatcher: &KafkaChannelDispatcherSpec{
+						Replicas: &replicas,
+						Resources: corev1.ResourceRequirements{
+							Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi"), corev1.ResourceCPU: resource.MustParse("500m")},
+						},
+						NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+						Tolerations: []corev1.Toleration{
+							{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "kafka", Effect: corev1.TaintEffectNoSchedule},
+							{Operator: corev1.TolerationOpExists},
+						},
+					},
+				},
+			},
+		},
+		"invalid dispatcher replicas and resources": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Dispatcher: &KafkaChannelDispatcherSpec{
+						Replicas: &negativeReplicas,
+						Resources: corev1.ResourceRequirements{
+							Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")},
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrInvalidValue(-1, "spec.dispatcher.replicas")
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue("-1", "spec.dispatcher.resources.limits[cpu]")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		"dispatcher request exceeds limit": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Dispatcher: &KafkaChannelDispatcherSpec{
+						Resources: corev1.ResourceRequirements{
+							Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("2Gi", "spec.dispatcher.resources.requests[memory]")
+				fe.Details = "must be less than or equal to the memory limit of 1Gi"
+				return fe
+			}(),
+		},
+		"invalid dispatcher nodeSelector and tolerations": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Dispatcher: &KafkaChannelDispatcherSpec{
+						NodeSelector: map[string]string{"not a valid key": "linux"},
+						Tolerations: []corev1.Toleration{
+							{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "kafka"},
+							{Operator: corev1.TolerationOpEqual, Effect: "NotAnEffect"},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrInvalidKeyName("not a valid key", "spec.dispatcher.nodeSelector",
+					"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')")
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue("kafka", "spec.dispatcher.tolerations[0].value")
+				fe.Details = "expected no value when the operator is 'Exists'"
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue(corev1.TolerationOpEqual, "spec.dispatcher.tolerations[1].operator")
+				fe.Details = "expected 'Exists' when the key is empty"
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue("NotAnEffect", "spec.dispatcher.tolerations[1].effect")
+				fe.Details = "expected one of 'NoSchedule', 'PreferNoSchedule' or 'NoExecute'"
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
 		"invalid scope annotation": {
 			cr: &KafkaChannel{
 				ObjectMeta: metav1.ObjectMeta{
@@ -261,6 +454,64 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				},
 			},
 		},
+		"updating mutable dispatcher": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Dispatcher:        &KafkaChannelDispatcherSpec{Replicas: &retry},
+				},
+			},
+		},
+		"updating mutable deletionPolicy": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					DeletionPolicy:    DeletionPolicyRetain,
+				},
+			},
+		},
+		"updating immutable topic": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					Topic:             "existing-topic",
+				},
+			},
+			want: func() *apis.FieldError {
+				return &apis.FieldError{
+					Message: "Immutable fields changed (-old +new)",
+					Paths:   []string{"spec"},
+					Details: "{v1beta1.KafkaChannelSpec}.Topic:\n\t-: \"\"\n\t+: \"existing-topic\"\n",
+				}
+			}(),
+		},
 		"updating immutable numPartitions": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -53,6 +54,74 @@ func (in *KafkaChannel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelClaimCheckSpec) DeepCopyInto(out *KafkaChannelClaimCheckSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelClaimCheckSpec.
+func (in *KafkaChannelClaimCheckSpec) DeepCopy() *KafkaChannelClaimCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelClaimCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelDispatcherSpec) DeepCopyInto(out *KafkaChannelDispatcherSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelDispatcherSpec.
+func (in *KafkaChannelDispatcherSpec) DeepCopy() *KafkaChannelDispatcherSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelDispatcherSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaChannelEncryptionSpec) DeepCopyInto(out *KafkaChannelEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaChannelEncryptionSpec.
+func (in *KafkaChannelEncryptionSpec) DeepCopy() *KafkaChannelEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaChannelEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelList) DeepCopyInto(out *KafkaChannelList) {
 	*out = *in
@@ -89,6 +158,21 @@ func (in *KafkaChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelSpec) DeepCopyInto(out *KafkaChannelSpec) {
 	*out = *in
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(KafkaChannelDispatcherSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimCheck != nil {
+		in, out := &in.ClaimCheck, &out.ClaimCheck
+		*out = new(KafkaChannelClaimCheckSpec)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(KafkaChannelEncryptionSpec)
+		**out = **in
+	}
 	in.ChannelableSpec.DeepCopyInto(&out.ChannelableSpec)
 	return
 }
@@ -107,6 +191,7 @@ func (in *KafkaChannelSpec) DeepCopy() *KafkaChannelSpec {
 func (in *KafkaChannelStatus) DeepCopyInto(out *KafkaChannelStatus) {
 	*out = *in
 	in.ChannelableStatus.DeepCopyInto(&out.ChannelableStatus)
+	in.Placeable.DeepCopyInto(&out.Placeable)
 	return
 }
 
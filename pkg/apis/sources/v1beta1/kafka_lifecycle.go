@@ -95,6 +95,12 @@ func (s *KafkaSourceStatus) MarkSink(uri *apis.URL) {
 	}
 }
 
+// MarkMirror sets the condition that the source has a mirror Kafka Topic configured in place of a sink.
+func (s *KafkaSourceStatus) MarkMirror(topic string) {
+	s.SinkURI = nil
+	KafkaSourceCondSet.Manage(s).MarkTrueWithReason(KafkaConditionSinkProvided, "Mirror", "Records are mirrored to the Kafka Topic %s", topic)
+}
+
 // MarkNoSink sets the condition that the source does not have a sink configured.
 func (s *KafkaSourceStatus) MarkNoSink(reason, messageFormat string, messageA ...interface{}) {
 	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionSinkProvided, reason, messageFormat, messageA...)
@@ -215,6 +215,24 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 			Type:   KafkaConditionReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "mark mirror, deployed, connection established, offset committed",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkMirror("mirror-topic")
+			s.MarkDeployed(availableDeployment)
+			s.MarkConnectionEstablished()
+			s.MarkInitialOffsetCommitted()
+			return s
+		}(),
+		condQuery: KafkaConditionSinkProvided,
+		want: &apis.Condition{
+			Type:    KafkaConditionSinkProvided,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Mirror",
+			Message: "Records are mirrored to the Kafka Topic mirror-topic",
+		},
 	}}
 
 	for _, test := range tests {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -77,6 +78,18 @@ type KafkaSourceSpec struct {
 	// +optional
 	InitialOffset Offset `json:"initialOffset,omitempty"`
 
+	// Mirror is the Kafka Topic to which the consumed records are produced as-is (preserving
+	// their keys, headers and timestamps) instead of being sent to the sink as CloudEvents.
+	// The sink is optional when a mirror is specified.
+	// +optional
+	Mirror *KafkaMirrorSpec `json:"mirror,omitempty"`
+
+	// Filter selects the events sent to the sink by their CloudEvent attributes (exact, prefix and
+	// suffix matches or CESQL expressions, which may be combined with all, any and not). Records
+	// whose events do not pass the filter are consumed without being sent.
+	// +optional
+	Filter *eventingv1.SubscriptionsAPIFilter `json:"filter,omitempty"`
+
 	// inherits duck/v1 SourceSpec, which currently provides:
 	// * Sink - a reference to an object that will resolve to a domain name or
 	//   a URI directly to use as the sink.
@@ -85,6 +98,20 @@ type KafkaSourceSpec struct {
 	duckv1.SourceSpec `json:",inline"`
 }
 
+// KafkaMirrorSpec defines the target Kafka cluster & Topic of a KafkaSource mirroring its records.
+type KafkaMirrorSpec struct {
+	bindingsv1beta1.KafkaAuthSpec `json:",inline"`
+
+	// Topic to produce the mirrored records to
+	// +required
+	Topic string `json:"topic"`
+
+	// PreservePartition produces each record to the same partition of the target Topic as the one
+	// it was consumed from, rather than partitioning by key.
+	// +optional
+	PreservePartition bool `json:"preservePartition,omitempty"`
+}
+
 type Offset string
 
 const (
@@ -19,8 +19,12 @@ package v1beta1
 import (
 	"context"
 
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
+
+	commonfilter "knative.dev/eventing-kafka/pkg/common/filter"
 )
 
 // Validate ensures KafkaSource is properly configured.
@@ -36,8 +40,10 @@ func (ks *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
 func (kss *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 
-	// Validate source spec
-	errs = errs.Also(kss.SourceSpec.Validate(ctx))
+	// Validate source spec (the sink is optional when mirroring to another Kafka Topic)
+	if kss.Mirror == nil || kss.Sink != (duckv1.Destination{}) {
+		errs = errs.Also(kss.SourceSpec.Validate(ctx))
+	}
 
 	// Check for mandatory fields
 	if len(kss.Topics) <= 0 {
@@ -52,9 +58,39 @@ func (kss *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(apis.ErrInvalidValue(kss.InitialOffset, "initialOffset"))
 	}
 
+	if kss.Mirror != nil {
+		errs = errs.Also(kss.Mirror.Validate(ctx).ViaField("mirror"))
+	}
+	if kss.Filter != nil {
+		if kss.Mirror != nil {
+			errs = errs.Also(apis.ErrGeneric("filter is not supported when mirroring records", "filter"))
+		}
+		errs = errs.Also(validateFilter(ctx, kss.Filter).ViaField("filter"))
+	}
+
+	return errs
+}
+
+func (kms *KafkaMirrorSpec) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if len(kms.BootstrapServers) <= 0 {
+		errs = errs.Also(apis.ErrMissingField("bootstrapServers"))
+	}
+	if kms.Topic == "" {
+		errs = errs.Also(apis.ErrMissingField("topic"))
+	}
 	return errs
 }
 
+// validateFilter materializes the filter first, as the CESQL parser panics on some syntax errors which the
+// materialization recovers from, before validating its dialects and attribute names
+func validateFilter(ctx context.Context, filter *eventingv1.SubscriptionsAPIFilter) *apis.FieldError {
+	if _, err := commonfilter.NewFilter(filter); err != nil {
+		return apis.ErrGeneric(err.Error())
+	}
+	return eventingv1.ValidateSubscriptionAPIFilter(ctx, filter)
+}
+
 func (ks *KafkaSource) CheckImmutableFields(ctx context.Context, original *KafkaSource) *apis.FieldError {
 	if original == nil {
 		return nil
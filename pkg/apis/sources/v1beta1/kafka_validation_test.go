@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -96,6 +97,88 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 			orig:    &fullSpec,
 			allowed: true,
 		},
+		"mirror without sink": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				Mirror: &KafkaMirrorSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"mirror-servers"}},
+					Topic:         "mirror-topic",
+				},
+			},
+			allowed: true,
+		},
+		"mirror without topic": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				Mirror: &KafkaMirrorSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"mirror-servers"}},
+				},
+			},
+			allowed: false,
+		},
+		"valid filter": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				SourceSpec:    fullSpec.SourceSpec,
+				Filter: &eventingv1.SubscriptionsAPIFilter{Any: []eventingv1.SubscriptionsAPIFilter{
+					{Prefix: map[string]string{"type": "dev.knative"}},
+					{CESQL: "kafkaheadertenant = 'acme'"},
+				}},
+			},
+			allowed: true,
+		},
+		"invalid cesql filter": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				SourceSpec:    fullSpec.SourceSpec,
+				Filter:        &eventingv1.SubscriptionsAPIFilter{CESQL: "kafkaheadertenant = 'acme"},
+			},
+			allowed: false,
+		},
+		"filter with multiple dialects": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				SourceSpec:    fullSpec.SourceSpec,
+				Filter: &eventingv1.SubscriptionsAPIFilter{
+					Exact:  map[string]string{"type": "dev.knative.kafka.event"},
+					Prefix: map[string]string{"source": "/apis"},
+				},
+			},
+			allowed: false,
+		},
+		"filter with mirror": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				Mirror: &KafkaMirrorSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"mirror-servers"}},
+					Topic:         "mirror-topic",
+				},
+				Filter: &eventingv1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "dev.knative.kafka.event"}},
+			},
+			allowed: false,
+		},
+		"mirror without bootstrapServers": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				InitialOffset: OffsetLatest,
+				SourceSpec:    fullSpec.SourceSpec,
+				Mirror:        &KafkaMirrorSpec{Topic: "mirror-topic"},
+			},
+			allowed: false,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
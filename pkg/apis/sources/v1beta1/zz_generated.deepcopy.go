@@ -23,8 +23,26 @@ package v1beta1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaMirrorSpec) DeepCopyInto(out *KafkaMirrorSpec) {
+	*out = *in
+	in.KafkaAuthSpec.DeepCopyInto(&out.KafkaAuthSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaMirrorSpec.
+func (in *KafkaMirrorSpec) DeepCopy() *KafkaMirrorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaMirrorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSource) DeepCopyInto(out *KafkaSource) {
 	*out = *in
@@ -100,6 +118,16 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(KafkaMirrorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(v1.SubscriptionsAPIFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	return
 }
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventing
+
+import (
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+)
+
+/*
+ * The Kafka Broker has no Ingress / Filter Deployments or Trigger Channel
+ * of its own, but a Kafka Topic per Broker which is served by the shared
+ * Ingress and Dispatcher.  Therefore, its Status Conditions are defined
+ * here instead of using the eventing Broker's default ConditionSet.
+ */
+
+const (
+
+	// BrokerConditionTopicReady has status True when the Kafka Topic of the Broker has been created.
+	BrokerConditionTopicReady apis.ConditionType = "TopicReady"
+)
+
+// RegisterKafkaBrokerConditionSet initializes the ConditionSet to those pertaining to the Kafka Broker.
+func RegisterKafkaBrokerConditionSet() {
+	eventingv1.RegisterAlternateBrokerConditionSet(
+		apis.NewLivingConditionSet(
+			BrokerConditionTopicReady,
+			eventingv1.BrokerConditionAddressable,
+			eventingv1.BrokerConditionDeadLetterSinkResolved,
+		),
+	)
+}
+
+func MarkTopicTrue(bs *eventingv1.BrokerStatus) {
+	bs.GetConditionSet().Manage(bs).MarkTrue(BrokerConditionTopicReady)
+}
+
+func MarkTopicFailed(bs *eventingv1.BrokerStatus, reason, messageFormat string, messageA ...interface{}) {
+	bs.GetConditionSet().Manage(bs).MarkFalse(BrokerConditionTopicReady, reason, messageFormat, messageA...)
+}
+
+func MarkAddressableFailed(bs *eventingv1.BrokerStatus, reason, messageFormat string, messageA ...interface{}) {
+	bs.GetConditionSet().Manage(bs).MarkFalse(eventingv1.BrokerConditionAddressable, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Test The Kafka Broker ConditionSet & Its Transitions To Ready
+func TestKafkaBrokerConditionSet(t *testing.T) {
+	RegisterKafkaBrokerConditionSet()
+
+	broker := &eventingv1.Broker{}
+	broker.Status.InitializeConditions()
+	for _, conditionType := range []apis.ConditionType{BrokerConditionTopicReady, eventingv1.BrokerConditionAddressable, eventingv1.BrokerConditionDeadLetterSinkResolved} {
+		assert.Equal(t, corev1.ConditionUnknown, broker.Status.GetCondition(conditionType).Status, conditionType)
+	}
+
+	// The Conditions Of The Default ConditionSet Are Not Part Of The Kafka Broker's
+	assert.Nil(t, broker.Status.GetCondition(eventingv1.BrokerConditionIngress))
+	assert.Nil(t, broker.Status.GetCondition(eventingv1.BrokerConditionTriggerChannel))
+	assert.Nil(t, broker.Status.GetCondition(eventingv1.BrokerConditionFilter))
+
+	MarkTopicFailed(&broker.Status, "TopicFailed", "test")
+	assert.Equal(t, corev1.ConditionFalse, broker.Status.GetCondition(BrokerConditionTopicReady).Status)
+	assert.False(t, broker.IsReady())
+
+	MarkTopicTrue(&broker.Status)
+	MarkAddressableFailed(&broker.Status, "ServiceFailed", "test")
+	assert.Equal(t, corev1.ConditionFalse, broker.Status.GetCondition(eventingv1.BrokerConditionAddressable).Status)
+	assert.False(t, broker.IsReady())
+
+	broker.Status.SetAddress(apis.HTTP("test-broker-kn-broker.test-namespace.svc.cluster.local"))
+	broker.Status.MarkDeadLetterSinkNotConfigured()
+	assert.True(t, broker.IsReady())
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// BrokerClass Is The Value Of The "eventing.knative.dev/broker.class" Annotation Of Brokers Backed By Kafka
+	BrokerClass = "Kafka"
+
+	// ControllerComponent Is The Component Name Of The Broker & Trigger Controller (Used For Logging)
+	ControllerComponent = "kafka-broker-controller"
+
+	// IngressComponent Is The Component Name Of The Shared Ingress (Used For Logging & Sarama Config)
+	IngressComponent = "kafka-broker-ingress"
+
+	// DispatcherComponent Is The Component Name Of The Shared Dispatcher (Used For Logging & Sarama Config)
+	DispatcherComponent = "kafka-broker-dispatcher"
+
+	// IngressServiceName Is The Name Of The Shared Ingress Service (In The System Namespace) Receiving Events For All Brokers
+	IngressServiceName = "kafka-broker-ingress"
+
+	// DispatcherName Is The Name (And "app" Label Value) Of The Shared Dispatcher Deployment In The System Namespace
+	DispatcherName = "kafka-broker-dispatcher"
+
+	// AppLabel Is The Label Identifying The Pods Of The Dispatcher Deployment
+	AppLabel = "app"
+
+	// BrokerServiceNameSuffix Is The Name Suffix Of The Per-Broker ExternalName Services (Parsed From The Host Header By The Ingress)
+	BrokerServiceNameSuffix = "kn-broker"
+
+	// BrokerLabel Identifies The Services Created For Brokers
+	BrokerLabel = "kafka.eventing.knative.dev/broker"
+
+	// TopicPrefix Is The Prefix Of The Kafka Topics Created For Brokers
+	TopicPrefix = "knative-broker"
+
+	// IngressHttpPort Is The Port On Which The Ingress Receives Events
+	IngressHttpPort = 8080
+
+	// HealthPort Is The Port On Which The Ingress & Dispatcher Serve Liveness And Readiness Requests
+	HealthPort = 8082
+
+	// TriggersStatusResendInterval Is How Often The Dispatcher Resends Its Triggers' Status To The Controller
+	TriggersStatusResendInterval = 30 * time.Second
+
+	// Trigger Subscription Condition Reasons & Messages (Derived From The Status Reported By The Dispatcher)
+	TriggerNotStartedReason            = "ConsumerGroupNotStarted"
+	TriggerNotStartedMessage           = "no status reported by dispatcher"
+	TriggerConsumerGroupFailedReason   = "ConsumerGroupFailed"
+	TriggerConsumerGroupStoppedReason  = "ConsumerGroupStopped"
+	TriggerConsumerGroupStoppedMessage = "consumer group is stopped"
+)
+
+// AppendBrokerServiceNameSuffix appends the Broker Service name suffix to the specified string.
+func AppendBrokerServiceNameSuffix(brokerName string) string {
+	return fmt.Sprintf("%s-%s", brokerName, BrokerServiceNameSuffix)
+}
+
+// TrimBrokerServiceNameSuffix removes the Broker Service name suffix from the specified string.
+func TrimBrokerServiceNameSuffix(serviceName string) string {
+	return strings.TrimSuffix(serviceName, "-"+BrokerServiceNameSuffix)
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The Appending And Trimming Of The Broker Service Name Suffix
+func TestBrokerServiceNameSuffix(t *testing.T) {
+	serviceName := AppendBrokerServiceNameSuffix("my-broker")
+	assert.Equal(t, "my-broker-kn-broker", serviceName)
+	assert.Equal(t, "my-broker", TrimBrokerServiceNameSuffix(serviceName))
+	assert.Equal(t, "my-broker", TrimBrokerServiceNameSuffix("my-broker"))
+}
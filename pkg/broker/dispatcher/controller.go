@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	informers "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/eventing-kafka/pkg/broker/util"
+)
+
+// ReconcilerName is the name of the reconciler.
+const ReconcilerName = "KafkaBrokerTriggers"
+
+// Reconciler reconciles the Triggers of Kafka Brokers by starting (or closing) their ConsumerGroups in the Dispatcher.
+type Reconciler struct {
+	logger        *zap.Logger
+	dispatcher    TriggerDispatcher
+	triggerLister eventinglisters.TriggerLister
+	brokerLister  eventinglisters.BrokerLister
+}
+
+// TriggerDispatcher is the interface of the Dispatcher used by the Reconciler (facilitating testing).
+type TriggerDispatcher interface {
+	UpdateTrigger(ctx context.Context, trigger *eventingv1.Trigger, broker *eventingv1.Broker) error
+	RemoveTrigger(key types.NamespacedName) error
+}
+
+var _ controller.Reconciler = &Reconciler{}
+var _ TriggerDispatcher = &Dispatcher{}
+
+// NewController initializes the controller of the Triggers dispatched by the specified Dispatcher.
+func NewController(ctx context.Context, logger *zap.Logger, dispatcher TriggerDispatcher, triggerInformer informers.TriggerInformer, brokerInformer informers.BrokerInformer) *controller.Impl {
+
+	reconciler := &Reconciler{
+		logger:        logger,
+		dispatcher:    dispatcher,
+		triggerLister: triggerInformer.Lister(),
+		brokerLister:  brokerInformer.Lister(),
+	}
+	impl := controller.NewContext(ctx, reconciler, controller.ControllerOptions{
+		WorkQueueName: ReconcilerName,
+		Logger:        logger.Sugar(),
+	})
+
+	// Watch All Triggers (Including Those Of Deleted Or Other Brokers, Whose ConsumerGroups Must Be Closed)
+	triggerInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Reconcile The Triggers Of A Broker Whenever It Changes (e.g. Its Address Or Delivery)
+	brokerInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if broker, ok := obj.(*eventingv1.Broker); ok {
+			triggers, err := util.TriggersOfBroker(reconciler.triggerLister, broker)
+			if err != nil {
+				logger.Warn("Failed To List Triggers Of Broker", zap.String("Broker", broker.Name), zap.Error(err))
+				return
+			}
+			for _, trigger := range triggers {
+				impl.Enqueue(trigger)
+			}
+		}
+	}))
+
+	return impl
+}
+
+// Reconcile implements the controller.Reconciler interface.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		r.logger.Error("Invalid Resource Key", zap.String("Key", key), zap.Error(err))
+		return nil
+	}
+	triggerKey := types.NamespacedName{Namespace: namespace, Name: name}
+	logger := r.logger.With(zap.String("Trigger", key))
+
+	// Close The ConsumerGroup Of A Deleted Trigger
+	trigger, err := r.triggerLister.Triggers(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		logger.Info("Trigger No Longer Exists")
+		return r.dispatcher.RemoveTrigger(triggerKey)
+	} else if err != nil {
+		return err
+	}
+
+	// Close The ConsumerGroup Of A Trigger Whose Broker Is Missing Or Not A Kafka Broker
+	broker, err := r.brokerLister.Brokers(namespace).Get(trigger.Spec.Broker)
+	if apierrors.IsNotFound(err) || (err == nil && !util.IsKafkaBroker(broker)) {
+		return r.dispatcher.RemoveTrigger(triggerKey)
+	} else if err != nil {
+		return err
+	}
+
+	// Triggers Are Only Dispatched Once Their Subscriber Has Been Resolved By The Trigger Controller
+	if trigger.Status.SubscriberURI.IsEmpty() {
+		logger.Debug("Trigger Has No Resolved Subscriber")
+		return r.dispatcher.RemoveTrigger(triggerKey)
+	}
+
+	if err := r.dispatcher.UpdateTrigger(ctx, trigger, broker); err != nil {
+		return fmt.Errorf("failed to update the ConsumerGroup of trigger %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Dispatcher Reconciler's Reconcile() Functionality
+func TestReconcile(t *testing.T) {
+
+	otherBroker := newBroker()
+	otherBroker.Name = "other-broker"
+	otherBroker.Annotations[eventing.BrokerClassKey] = eventing.MTChannelBrokerClassValue
+
+	unresolvedTrigger := newTrigger()
+	unresolvedTrigger.Name = "unresolved-trigger"
+	unresolvedTrigger.Status.SubscriberURI = nil
+
+	otherTrigger := newTrigger()
+	otherTrigger.Name = "other-trigger"
+	otherTrigger.Spec.Broker = otherBroker.Name
+
+	missingBrokerTrigger := newTrigger()
+	missingBrokerTrigger.Name = "missing-broker-trigger"
+	missingBrokerTrigger.Spec.Broker = "missing-broker"
+
+	tests := []struct {
+		name          string
+		key           string
+		expectUpdated bool
+		expectRemoved bool
+	}{
+		{name: "Dispatched Trigger", key: "test-namespace/test-trigger", expectUpdated: true},
+		{name: "Deleted Trigger", key: "test-namespace/deleted-trigger", expectRemoved: true},
+		{name: "Unresolved Trigger", key: "test-namespace/unresolved-trigger", expectRemoved: true},
+		{name: "Trigger Of Other Broker Class", key: "test-namespace/other-trigger", expectRemoved: true},
+		{name: "Trigger Of Missing Broker", key: "test-namespace/missing-broker-trigger", expectRemoved: true},
+		{name: "Invalid Key", key: "a/b/c"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			triggerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, trigger := range []*eventingv1.Trigger{newTrigger(), unresolvedTrigger, otherTrigger, missingBrokerTrigger} {
+				assert.Nil(t, triggerIndexer.Add(trigger))
+			}
+			brokerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.Nil(t, brokerIndexer.Add(newBroker()))
+			assert.Nil(t, brokerIndexer.Add(otherBroker))
+
+			dispatcher := &mockTriggerDispatcher{}
+			reconciler := &Reconciler{
+				logger:        logtesting.TestLogger(t).Desugar(),
+				dispatcher:    dispatcher,
+				triggerLister: eventinglisters.NewTriggerLister(triggerIndexer),
+				brokerLister:  eventinglisters.NewBrokerLister(brokerIndexer),
+			}
+
+			assert.Nil(t, reconciler.Reconcile(context.TODO(), test.key))
+			assert.Equal(t, test.expectUpdated, len(dispatcher.updated) > 0)
+			assert.Equal(t, test.expectRemoved, len(dispatcher.removed) > 0)
+		})
+	}
+}
+
+// Mock TriggerDispatcher Recording The Updated & Removed Triggers
+type mockTriggerDispatcher struct {
+	updated []*eventingv1.Trigger
+	removed []types.NamespacedName
+}
+
+func (m *mockTriggerDispatcher) UpdateTrigger(_ context.Context, trigger *eventingv1.Trigger, _ *eventingv1.Broker) error {
+	m.updated = append(m.updated, trigger)
+	return nil
+}
+
+func (m *mockTriggerDispatcher) RemoveTrigger(key types.NamespacedName) error {
+	m.removed = append(m.removed, key)
+	return nil
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter/attributes"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/util"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	channeldispatcher "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
+	"knative.dev/eventing-kafka/pkg/common/client"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/latency"
+)
+
+// NewConsumerGroupManagerFn Is A Wrapper For Stubbing The ConsumerGroupManager Creation In Unit Tests
+var NewConsumerGroupManagerFn = commonconsumer.NewConsumerGroupManager
+
+// Dispatcher consumes the events of the Triggers of all Kafka Brokers from the Brokers' Kafka Topics, with one
+// ConsumerGroup per Trigger, and dispatches those passing the Trigger's filter to its Subscriber.
+type Dispatcher struct {
+	logger       *zap.Logger
+	brokers      []string
+	saramaConfig *sarama.Config
+	consumerMgr  commonconsumer.KafkaConsumerGroupManager
+	lagCollector *commonconsumer.LagCollector
+
+	triggersLock sync.Mutex
+	triggers     map[types.NamespacedName]*triggerConsumer
+
+	statusNotifyChan   chan struct{}
+	statusStopChan     chan struct{}
+	statusStopOnce     sync.Once
+	statusResendPeriod time.Duration // Zero Disables The Periodic Resending Of The TriggersStatus
+}
+
+// The triggerConsumer struct holds the ConsumerGroup ID & Handler of a Trigger along with the fingerprint of the
+// Trigger (and Broker) it was started for
+type triggerConsumer struct {
+	uid         types.UID
+	groupId     string
+	fingerprint string
+	handler     *channeldispatcher.Handler
+}
+
+// NewDispatcher returns a new Dispatcher whose ConsumerGroups are managed (and made available to the ResetOffset
+// control-protocol commands) by a KafkaConsumerGroupManager.  The enqueue function is called with the Trigger
+// of a ConsumerGroup which has been restarted by such a command.
+func NewDispatcher(logger *zap.Logger, brokers []string, saramaConfig *sarama.Config, controlServer controlprotocol.ServerHandler, enqueue func(ref types.NamespacedName)) *Dispatcher {
+	consumerMgr := NewConsumerGroupManagerFn(logger, controlServer, brokers, saramaConfig, &commonconsumer.NoopConsumerGroupOffsetsChecker{}, enqueue)
+
+	// Collect The Lag Of The Triggers' ConsumerGroups
+	lagCollector := commonconsumer.NewLagCollector(logger, commonconsumer.TriggerResourceGroup, brokers, saramaConfig)
+	consumerMgr.SetLagCollector(lagCollector)
+	lagCollector.Start(commonconsumer.DefaultLagInterval)
+
+	return &Dispatcher{
+		logger:       logger,
+		brokers:      brokers,
+		saramaConfig: saramaConfig,
+		consumerMgr:  consumerMgr,
+		lagCollector: lagCollector,
+		triggers:     make(map[types.NamespacedName]*triggerConsumer),
+
+		statusNotifyChan:   make(chan struct{}, 1),
+		statusStopChan:     make(chan struct{}),
+		statusResendPeriod: constants.TriggersStatusResendInterval,
+	}
+}
+
+// UpdateTrigger starts the ConsumerGroup of the specified Trigger of the specified Broker, restarting it if the
+// Trigger's Subscriber, Filter or Delivery (or the Broker's address or Delivery) have changed since it was started.
+func (d *Dispatcher) UpdateTrigger(ctx context.Context, trigger *eventingv1.Trigger, broker *eventingv1.Broker) error {
+
+	key := types.NamespacedName{Namespace: trigger.Namespace, Name: trigger.Name}
+	groupId := util.GroupId(trigger)
+	fingerprint := triggerFingerprint(trigger, broker)
+	logger := d.logger.With(zap.String("Trigger", key.String()), zap.String("GroupId", groupId))
+
+	d.triggersLock.Lock()
+	defer d.triggersLock.Unlock()
+
+	// Nothing To Do If The ConsumerGroup Was Started For The Current Trigger & Broker
+	existing, ok := d.triggers[key]
+	if ok && existing.fingerprint == fingerprint && d.consumerMgr.IsManaged(existing.groupId) {
+		return nil
+	}
+
+	// Close Any ConsumerGroup Started For A Previous Version Of The Trigger (Or A Previous Trigger Of The Same Name)
+	if ok {
+		if err := d.closeConsumerGroup(key, existing); err != nil {
+			return err
+		}
+	}
+
+	if trigger.Status.SubscriberURI.IsEmpty() {
+		return fmt.Errorf("trigger %s has no resolved subscriber", key.String())
+	}
+
+	// Create The Handler Dispatching The Events Passing The Trigger's Filter To Its Subscriber (Replies Are Sent
+	// Back To The Broker)
+	handler := channeldispatcher.NewHandler(logger, groupId, newSubscriberSpec(trigger, broker), d.notifyTriggersStatus)
+	if trigger.Spec.Filter != nil && len(trigger.Spec.Filter.Attributes) > 0 {
+		handler.Filter = attributes.NewAttributesFilter(trigger.Spec.Filter.Attributes)
+	}
+	handler.LatencyArgs = &latency.ReportArgs{
+		Namespace:     trigger.Namespace,
+		Name:          trigger.Name,
+		ResourceGroup: commonconsumer.TriggerResourceGroup,
+		Subscriber:    trigger.UID,
+	}
+
+	// Start The Trigger's ConsumerGroup On The Broker's Topic
+	err := d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{util.BrokerTopicName(broker)}, handler, key)
+	if err != nil {
+		logger.Error("Failed To Start ConsumerGroup", zap.Error(err))
+		return err
+	}
+	d.triggers[key] = &triggerConsumer{uid: trigger.UID, groupId: groupId, fingerprint: fingerprint, handler: handler}
+	d.notifyTriggersStatus()
+
+	// Asynchronously Process ConsumerGroup's Error Channel
+	go func() {
+		for groupErr := range d.consumerMgr.Errors(groupId) { // Closing ConsumerGroup Will Break Out Of This
+			logger.Error("ConsumerGroup Error", zap.Error(groupErr))
+		}
+	}()
+
+	logger.Info("Successfully Started ConsumerGroup")
+	return nil
+}
+
+// RemoveTrigger closes the ConsumerGroup of the specified (deleted, or no longer dispatchable) Trigger.
+func (d *Dispatcher) RemoveTrigger(key types.NamespacedName) error {
+	d.triggersLock.Lock()
+	defer d.triggersLock.Unlock()
+
+	if existing, ok := d.triggers[key]; ok {
+		return d.closeConsumerGroup(key, existing)
+	}
+	return nil
+}
+
+// Close The ConsumerGroup Of The Specified Trigger (Expects The Triggers Lock To Be Held)
+func (d *Dispatcher) closeConsumerGroup(key types.NamespacedName, consumer *triggerConsumer) error {
+	logger := d.logger.With(zap.String("Trigger", key.String()), zap.String("GroupId", consumer.groupId))
+	if d.consumerMgr.IsManaged(consumer.groupId) {
+		if err := d.consumerMgr.CloseConsumerGroup(consumer.groupId); err != nil {
+			// Don't Delete From The Triggers Map To Force A Retry Of The Close Next Time Around
+			logger.Error("Failed To Close ConsumerGroup", zap.Error(err))
+			return err
+		}
+	}
+	logger.Info("Successfully Closed ConsumerGroup")
+	delete(d.triggers, key)
+	d.notifyTriggersStatus()
+	return nil
+}
+
+// SecretChanged is called by the secretObserver handler function in main() so that the ConsumerGroups may be
+// recreated with the auth settings of the changed Kafka Secret if necessary.
+func (d *Dispatcher) SecretChanged(ctx context.Context, secret *corev1.Secret) {
+
+	kafkaAuthCfg := commonconfig.GetAuthConfigFromSecret(secret)
+	if kafkaAuthCfg == nil {
+		d.logger.Warn("No auth config found in secret; ignoring update")
+		return
+	}
+
+	d.triggersLock.Lock()
+	defer d.triggersLock.Unlock()
+
+	// Don't Restart The ConsumerGroups If All Auth Settings Identical
+	if kafkaAuthCfg.SASL.HasSameSettings(d.saramaConfig) {
+		d.logger.Info("No relevant changes in Secret; ignoring update")
+		return
+	}
+
+	// Build New Config Using Existing Config And New Auth Settings
+	if !kafkaAuthCfg.SASL.HasCredentials() {
+		// The config builder expects the entire config object to be nil if not using auth
+		kafkaAuthCfg = nil
+		// Any existing SASL config must be cleared explicitly or the "WithExisting" builder will keep the old values
+		d.saramaConfig.Net.SASL.Enable = false
+		d.saramaConfig.Net.SASL.User = ""
+		d.saramaConfig.Net.SASL.Password = ""
+		d.saramaConfig.Net.SASL.TokenProvider = nil
+	}
+	newConfig, err := client.NewConfigBuilder().WithExisting(d.saramaConfig).WithAuth(kafkaAuthCfg).Build(ctx)
+	if err != nil {
+		d.logger.Error("Unable to merge new auth into sarama settings", zap.Error(err))
+		return
+	}
+	d.saramaConfig = newConfig
+
+	// Close And Recreate All Managed ConsumerGroups With The New Config
+	reconfigureErr := d.consumerMgr.Reconfigure(d.brokers, d.saramaConfig)
+	if reconfigureErr != nil {
+
+		// Forget The Triggers Of Failed ConsumerGroups To Allow Recreation Next Reconcile Loop
+		d.logger.Error("Failed To Reconfigure Consumer Group Manager Using Updated Secret", zap.Error(reconfigureErr))
+		failedGroupIds := make(map[string]bool, len(reconfigureErr.GroupIds))
+		for _, groupId := range reconfigureErr.GroupIds {
+			failedGroupIds[groupId] = true
+		}
+		for key, consumer := range d.triggers {
+			if failedGroupIds[consumer.groupId] {
+				delete(d.triggers, key)
+			}
+		}
+		d.notifyTriggersStatus()
+	}
+}
+
+// Shutdown closes the ConsumerGroups of all Triggers and stops collecting their lag.
+func (d *Dispatcher) Shutdown() {
+	d.triggersLock.Lock()
+	defer d.triggersLock.Unlock()
+
+	for key, consumer := range d.triggers {
+		_ = d.closeConsumerGroup(key, consumer)
+	}
+	d.consumerMgr.ClearNotifications()
+	d.lagCollector.Stop()
+	d.statusStopOnce.Do(func() { close(d.statusStopChan) })
+}
+
+// ReportTriggersStatus is an async process sending the TriggersStatus (the partitions claimed by the ConsumerGroup
+// of each started Trigger, keyed by Trigger UID) to the controller via the specified control-protocol Service
+// whenever it changes, as well as periodically so that failed sends are retried and a restarted controller catches
+// up.  The controller only marks a Trigger's subscription Ready once its ConsumerGroup has been reported started.
+func (d *Dispatcher) ReportTriggersStatus(statusService ctrl.Service) {
+	go func() {
+
+		// Periodically Resend The Status (Unless Disabled)
+		var resendChan <-chan time.Time
+		if d.statusResendPeriod > 0 {
+			resendTicker := time.NewTicker(d.statusResendPeriod)
+			defer resendTicker.Stop()
+			resendChan = resendTicker.C
+		}
+
+		for {
+			select {
+			case <-d.statusStopChan:
+				d.logger.Info("Stopped Triggers' Status Reporting")
+				return
+			case <-d.statusNotifyChan:
+				d.sendTriggersStatus(statusService)
+			case <-resendChan:
+				d.sendTriggersStatus(statusService)
+			}
+		}
+	}()
+}
+
+// Signal The Status Reporting Process That The TriggersStatus Has Changed (Never Blocks)
+func (d *Dispatcher) notifyTriggersStatus() {
+	select {
+	case d.statusNotifyChan <- struct{}{}:
+	default:
+	}
+}
+
+// Send The Current TriggersStatus To The Controller & Wait For Its Acknowledgement
+func (d *Dispatcher) sendTriggersStatus(statusService ctrl.Service) {
+	status := d.triggersStatus()
+	d.logger.Debug("Sending Triggers' Status", zap.Stringer("Status", status))
+	if err := statusService.SendAndWaitForAck(distributedcontrol.NotifySubscribersStatusOpCode, status); err != nil {
+		d.logger.Warn("Failed To Send Triggers' Status", zap.Error(err))
+	}
+}
+
+// Get A Snapshot Of The Status Of The ConsumerGroups Of All Started Triggers
+func (d *Dispatcher) triggersStatus() distributedcontrol.SubscribersStatus {
+	d.triggersLock.Lock()
+	defer d.triggersLock.Unlock()
+
+	status := make(distributedcontrol.SubscribersStatus, len(d.triggers))
+	for _, consumer := range d.triggers {
+		triggerStatus := distributedcontrol.SubscriberStatus{Stopped: d.consumerMgr.IsStopped(consumer.groupId)}
+		if consumer.handler != nil {
+			triggerStatus.Partitions = consumer.handler.Claims()
+		}
+		status[consumer.uid] = triggerStatus
+	}
+	return status
+}
+
+// Create The SubscriberSpec Of The Specified Trigger, With The Trigger's Resolved Dead Letter Sink And The Delivery Of
+// The Trigger (Or Of Its Broker)
+func newSubscriberSpec(trigger *eventingv1.Trigger, broker *eventingv1.Broker) *eventingduck.SubscriberSpec {
+	subscriberSpec := &eventingduck.SubscriberSpec{
+		UID:           trigger.UID,
+		Generation:    trigger.Generation,
+		SubscriberURI: trigger.Status.SubscriberURI,
+	}
+	subscriberSpec.ReplyURI = brokerURL(broker)
+
+	var delivery *eventingduck.DeliverySpec
+	if trigger.Spec.Delivery != nil {
+		delivery = trigger.Spec.Delivery.DeepCopy()
+	} else if broker.Spec.Delivery != nil {
+		delivery = broker.Spec.Delivery.DeepCopy()
+	}
+	if delivery != nil {
+		delivery.DeadLetterSink = nil
+		if trigger.Status.DeadLetterSinkURI != nil {
+			delivery.DeadLetterSink = &duckv1.Destination{URI: trigger.Status.DeadLetterSinkURI}
+		}
+		subscriberSpec.Delivery = delivery
+	}
+	return subscriberSpec
+}
+
+// Create A Fingerprint Of The Trigger & Broker Settings Used By The Trigger's ConsumerGroup
+func triggerFingerprint(trigger *eventingv1.Trigger, broker *eventingv1.Broker) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s",
+		trigger.UID,
+		strconv.FormatInt(trigger.Generation, 10),
+		trigger.Status.SubscriberURI,
+		trigger.Status.DeadLetterSinkURI,
+		brokerURL(broker),
+		strconv.FormatInt(broker.Generation, 10))
+}
+
+// Get The Address URL Of The Specified Broker (If It Has One)
+func brokerURL(broker *eventingv1.Broker) *apis.URL {
+	if broker.Status.Address == nil {
+		return nil
+	}
+	return broker.Status.Address.URL
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	channeldispatcher "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	consumertesting "knative.dev/eventing-kafka/pkg/common/consumer/testing"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+const (
+	testNamespace   = "test-namespace"
+	testBrokerName  = "test-broker"
+	testTriggerName = "test-trigger"
+	testGroupId     = "kafka.test-trigger-uid"
+	testTopic       = "knative-broker-test-namespace-test-broker"
+)
+
+var testTriggerKey = types.NamespacedName{Namespace: testNamespace, Name: testTriggerName}
+
+// Test The Dispatcher's Trigger ConsumerGroup Lifecycle
+func TestUpdateAndRemoveTrigger(t *testing.T) {
+
+	dispatcher, mockManager := newTestDispatcher(t)
+	errorChannel := make(chan error)
+	defer close(errorChannel)
+	mockManager.On("Errors", testGroupId).Return((<-chan error)(errorChannel))
+	mockManager.On("IsManaged", testGroupId).Return(true)
+	mockManager.On("CloseConsumerGroup", testGroupId).Return(nil)
+
+	// The ConsumerGroup Of A New Trigger Is Started With A Handler Of Its Subscriber & Filter
+	var startedHandlers []*channeldispatcher.Handler
+	mockManager.On("StartConsumerGroup", mock.Anything, testGroupId, []string{testTopic}, mock.Anything, testTriggerKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			startedHandlers = append(startedHandlers, args.Get(3).(*channeldispatcher.Handler))
+		}).
+		Return(nil)
+	trigger := newTrigger()
+	broker := newBroker()
+	assert.Nil(t, dispatcher.UpdateTrigger(context.TODO(), trigger, broker))
+	assert.Len(t, startedHandlers, 1)
+	assert.Equal(t, trigger.Status.SubscriberURI, startedHandlers[0].Subscriber.SubscriberURI)
+	assert.Equal(t, broker.Status.Address.URL, startedHandlers[0].Subscriber.ReplyURI)
+	assert.Equal(t, trigger.Status.DeadLetterSinkURI, startedHandlers[0].Subscriber.Delivery.DeadLetterSink.URI)
+	assert.NotNil(t, startedHandlers[0].Filter)
+
+	// An Unchanged Trigger Keeps Its ConsumerGroup
+	assert.Nil(t, dispatcher.UpdateTrigger(context.TODO(), newTrigger(), newBroker()))
+	assert.Len(t, startedHandlers, 1)
+	mockManager.AssertNotCalled(t, "CloseConsumerGroup", testGroupId)
+
+	// A Changed Trigger Restarts Its ConsumerGroup
+	trigger.Generation++
+	trigger.Status.SubscriberURI = apis.HTTP("new-subscriber.example.com")
+	assert.Nil(t, dispatcher.UpdateTrigger(context.TODO(), trigger, broker))
+	assert.Len(t, startedHandlers, 2)
+	assert.Equal(t, trigger.Status.SubscriberURI, startedHandlers[1].Subscriber.SubscriberURI)
+	mockManager.AssertNumberOfCalls(t, "CloseConsumerGroup", 1)
+
+	// A Removed Trigger Closes Its ConsumerGroup (Once)
+	assert.Nil(t, dispatcher.RemoveTrigger(testTriggerKey))
+	assert.Nil(t, dispatcher.RemoveTrigger(testTriggerKey))
+	mockManager.AssertNumberOfCalls(t, "CloseConsumerGroup", 2)
+	assert.Len(t, dispatcher.triggers, 0)
+}
+
+// Test The Dispatcher's Handling Of ConsumerGroup Failures
+func TestUpdateTriggerFailures(t *testing.T) {
+
+	dispatcher, mockManager := newTestDispatcher(t)
+
+	// A Trigger Without A Resolved Subscriber Is Rejected
+	trigger := newTrigger()
+	trigger.Status.SubscriberURI = nil
+	assert.NotNil(t, dispatcher.UpdateTrigger(context.TODO(), trigger, newBroker()))
+
+	// A ConsumerGroup Which Fails To Start Is Not Tracked
+	mockManager.On("StartConsumerGroup", mock.Anything, testGroupId, []string{testTopic}, mock.Anything, testTriggerKey, mock.Anything).Return(errors.New("test start error"))
+	assert.NotNil(t, dispatcher.UpdateTrigger(context.TODO(), newTrigger(), newBroker()))
+	assert.Len(t, dispatcher.triggers, 0)
+}
+
+// Test The Dispatcher's Reporting Of The Triggers' Status To The Controller
+func TestReportTriggersStatus(t *testing.T) {
+
+	dispatcher, mockManager := newTestDispatcher(t)
+	dispatcher.statusResendPeriod = 0
+	errorChannel := make(chan error)
+	defer close(errorChannel)
+	mockManager.On("Errors", testGroupId).Return((<-chan error)(errorChannel))
+	mockManager.On("IsManaged", testGroupId).Return(true)
+	mockManager.On("IsStopped", testGroupId).Return(false)
+	mockManager.On("CloseConsumerGroup", testGroupId).Return(nil)
+	mockManager.On("ClearNotifications").Return()
+	mockManager.On("StartConsumerGroup", mock.Anything, testGroupId, []string{testTopic}, mock.Anything, testTriggerKey, mock.Anything).Return(nil)
+
+	// Capture The Status Sent To The Controller
+	sentStatus := make(chan distributedcontrol.SubscribersStatus, 10)
+	mockService := &controltesting.MockService{}
+	mockService.On("SendAndWaitForAck", distributedcontrol.NotifySubscribersStatusOpCode, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sentStatus <- args.Get(1).(distributedcontrol.SubscribersStatus)
+		}).
+		Return(nil)
+	dispatcher.ReportTriggersStatus(mockService)
+
+	// A Started Trigger Is Reported By UID
+	trigger := newTrigger()
+	assert.Nil(t, dispatcher.UpdateTrigger(context.TODO(), trigger, newBroker()))
+	status := receiveTriggersStatus(t, sentStatus)
+	assert.Contains(t, status, trigger.UID)
+	assert.False(t, status[trigger.UID].Stopped)
+
+	// A Removed Trigger Is No Longer Reported
+	assert.Nil(t, dispatcher.RemoveTrigger(testTriggerKey))
+	assert.Empty(t, receiveTriggersStatus(t, sentStatus))
+
+	// No Status Is Sent After Shutdown
+	dispatcher.Shutdown()
+	dispatcher.notifyTriggersStatus()
+	select {
+	case <-sentStatus:
+		t.Fatal("Unexpected Triggers' Status Sent After Shutdown")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// Test The Dispatcher's Reconfiguration On Kafka Secret Changes
+func TestSecretChanged(t *testing.T) {
+
+	dispatcher, mockManager := newTestDispatcher(t)
+	dispatcher.triggers[testTriggerKey] = &triggerConsumer{groupId: testGroupId, fingerprint: "fingerprint"}
+
+	// A Secret Without Auth Settings Is Ignored
+	dispatcher.SecretChanged(context.TODO(), &corev1.Secret{})
+	mockManager.AssertNotCalled(t, "Reconfigure", mock.Anything, mock.Anything)
+
+	// Changed SASL Credentials Reconfigure The ConsumerGroups, Forgetting Those Which Failed To Restart
+	mockManager.On("Reconfigure", []string{"kafka:9092"}, mock.Anything).Return(&commonconsumer.ReconfigureError{GroupIds: []string{testGroupId}})
+	dispatcher.SecretChanged(context.TODO(), &corev1.Secret{
+		Data: map[string][]byte{"username": []byte("new-user"), "password": []byte("new-password"), "saslType": []byte("PLAIN")},
+	})
+	mockManager.AssertNumberOfCalls(t, "Reconfigure", 1)
+	assert.Equal(t, "new-user", dispatcher.saramaConfig.Net.SASL.User)
+	assert.Len(t, dispatcher.triggers, 0)
+}
+
+// Test The Delivery Of The SubscriberSpec Of A Trigger
+func TestNewSubscriberSpec(t *testing.T) {
+	retry := int32(3)
+	broker := newBroker()
+	broker.Spec.Delivery = &eventingduck.DeliverySpec{Retry: &retry, DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("broker-dls.example.com")}}
+
+	// The Broker's Delivery Is Used (With The Trigger's Resolved Dead Letter Sink) If The Trigger Has None
+	trigger := newTrigger()
+	trigger.Spec.Delivery = nil
+	subscriberSpec := newSubscriberSpec(trigger, broker)
+	assert.Equal(t, &retry, subscriberSpec.Delivery.Retry)
+	assert.Equal(t, trigger.Status.DeadLetterSinkURI, subscriberSpec.Delivery.DeadLetterSink.URI)
+
+	// No Delivery Is Used If Neither Has One
+	broker.Spec.Delivery = nil
+	assert.Nil(t, newSubscriberSpec(trigger, broker).Delivery)
+}
+
+// Wait For The Next TriggersStatus Sent By The Dispatcher
+func receiveTriggersStatus(t *testing.T, sentStatus <-chan distributedcontrol.SubscribersStatus) distributedcontrol.SubscribersStatus {
+	select {
+	case status := <-sentStatus:
+		return status
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed Out Waiting For Triggers' Status")
+		return nil
+	}
+}
+
+// Create A New Dispatcher With A Mock ConsumerGroupManager For Testing
+func newTestDispatcher(t *testing.T) (*Dispatcher, *consumertesting.MockConsumerGroupManager) {
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	newConsumerGroupManagerFnRestore := NewConsumerGroupManagerFn
+	t.Cleanup(func() { NewConsumerGroupManagerFn = newConsumerGroupManagerFnRestore })
+	NewConsumerGroupManagerFn = func(_ *zap.Logger, _ controlprotocol.ServerHandler, brokers []string, _ *sarama.Config, _ commonconsumer.ConsumerGroupOffsetsChecker, _ func(types.NamespacedName)) commonconsumer.KafkaConsumerGroupManager {
+		assert.Equal(t, []string{"kafka:9092"}, brokers)
+		return mockManager
+	}
+	return NewDispatcher(logtesting.TestLogger(t).Desugar(), []string{"kafka:9092"}, sarama.NewConfig(), nil, func(types.NamespacedName) {}), mockManager
+}
+
+// Create A New Ready Kafka Broker For Testing
+func newBroker() *eventingv1.Broker {
+	broker := &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        testBrokerName,
+			Annotations: map[string]string{eventing.BrokerClassKey: constants.BrokerClass},
+		},
+	}
+	broker.Status.SetAddress(apis.HTTP("test-broker-kn-broker.test-namespace.svc.cluster.local"))
+	return broker
+}
+
+// Create A New Trigger With A Resolved Subscriber & Dead Letter Sink For Testing
+func newTrigger() *eventingv1.Trigger {
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testTriggerName, UID: "test-trigger-uid", Generation: 1},
+		Spec: eventingv1.TriggerSpec{
+			Broker:   testBrokerName,
+			Filter:   &eventingv1.TriggerFilter{Attributes: eventingv1.TriggerFilterAttributes{"type": "test-type"}},
+			Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("trigger-dls.example.com")}},
+		},
+	}
+	trigger.Status.SubscriberURI = apis.HTTP("subscriber.example.com")
+	trigger.Status.DeadLetterSinkURI = apis.HTTP("trigger-dls.example.com")
+	return trigger
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
+)
+
+// Ingress produces the events received for Kafka Brokers (via their ExternalName Services) to the Kafka Topics of the
+// Brokers, with a single Producer shared by all Brokers (as their Topics are in the Kafka cluster of the config-kafka).
+type Ingress struct {
+	logger       *zap.Logger
+	brokerLister eventinglisters.BrokerLister
+
+	producerMutex sync.RWMutex
+	producer      *producer.Producer
+}
+
+// NewIngress returns a new Ingress which looks up the Brokers with the specified lister and produces their events
+// with the specified Producer.
+func NewIngress(logger *zap.Logger, brokerLister eventinglisters.BrokerLister, kafkaProducer *producer.Producer) *Ingress {
+	return &Ingress{
+		logger:       logger,
+		brokerLister: brokerLister,
+		producer:     kafkaProducer,
+	}
+}
+
+// HandleMessage is the Knative MessageReceiver function which produces the specified message to the Kafka Topic of the
+// Broker whose Service is referenced (by its Host header).
+func (i *Ingress) HandleMessage(ctx context.Context, reference eventingchannel.ChannelReference, message binding.Message, transformers []binding.Transformer, httpHeader http.Header) error {
+
+	// Look Up The Broker Of The Referenced Service (Unknown Or Non-Kafka Brokers Are Answered With A 404)
+	brokerName := constants.TrimBrokerServiceNameSuffix(reference.Name)
+	broker, err := i.brokerLister.Brokers(reference.Namespace).Get(brokerName)
+	if apierrors.IsNotFound(err) || (err == nil && !util.IsKafkaBroker(broker)) {
+		return &eventingchannel.UnknownChannelError{Channel: reference}
+	} else if err != nil {
+		return err
+	}
+	if !broker.IsReady() {
+		return fmt.Errorf("broker %s/%s is not ready", broker.Namespace, broker.Name)
+	}
+
+	i.producerMutex.RLock()
+	defer i.producerMutex.RUnlock()
+
+	reference.Name = broker.Name
+	return i.producer.ProduceKafkaMessage(ctx, reference, util.BrokerTopicName(broker), produce.Options{}, message, httpHeader, transformers...)
+}
+
+// SecretChanged replaces the shared Producer with one using the auth settings of the changed Kafka Secret (if they
+// are different from those of the current Producer).
+func (i *Ingress) SecretChanged(ctx context.Context, secret *corev1.Secret) {
+	i.producerMutex.Lock()
+	defer i.producerMutex.Unlock()
+
+	if newProducer := i.producer.SecretChanged(ctx, secret); newProducer != nil {
+		i.logger.Info("Kafka Secret Changed - Replaced Producer")
+		i.producer = newProducer
+	}
+}
+
+// Close closes the shared Producer.
+func (i *Ingress) Close() {
+	i.producerMutex.Lock()
+	defer i.producerMutex.Unlock()
+	i.producer.Close()
+}
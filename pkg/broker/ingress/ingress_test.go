@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	producertesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/testing"
+	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	payloadtesting "knative.dev/eventing-kafka/pkg/common/kafka/payload/testing"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+)
+
+const (
+	brokerNamespace = "broker-namespace"
+	brokerTopic     = "knative-broker-broker-namespace-kafka-broker"
+)
+
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// Test The Ingress HandleMessage() Functionality
+func TestHandleMessage(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		brokerName    string
+		expectErr     bool
+		expectUnknown bool
+	}{
+		{
+			name:       "Kafka Broker",
+			brokerName: "kafka-broker",
+		},
+		{
+			name:          "Unknown Broker",
+			brokerName:    "unknown-broker",
+			expectErr:     true,
+			expectUnknown: true,
+		},
+		{
+			name:          "Broker Of Other Class",
+			brokerName:    "other-broker",
+			expectErr:     true,
+			expectUnknown: true,
+		},
+		{
+			name:       "Broker Not Ready",
+			brokerName: "not-ready-broker",
+			expectErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Stub The Kafka SyncProducer & The Payload Limiter's ClusterAdmin
+			mockSyncProducer := producertesting.NewMockSyncProducer()
+			producertesting.StubNewSyncProducerFn(producertesting.NonValidatingNewSyncProducerFn(mockSyncProducer))
+			defer producertesting.RestoreNewSyncProducerFn()
+			payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, brokerTopic, 1000000, nil, nil))
+
+			// Create The Ingress With The Test Brokers
+			ingress := newTestIngress(t,
+				newBroker("kafka-broker", constants.BrokerClass, true),
+				newBroker("other-broker", eventing.MTChannelBrokerClassValue, true),
+				newBroker("not-ready-broker", constants.BrokerClass, false))
+			defer ingress.Close()
+
+			// Perform The Test
+			reference := eventingchannel.ChannelReference{Namespace: brokerNamespace, Name: constants.AppendBrokerServiceNameSuffix(test.brokerName)}
+			err := ingress.HandleMessage(context.TODO(), reference, receivertesting.CreateBindingMessage(cloudevents.VersionV1), nil, nil)
+
+			// Verify The Results
+			assert.Equal(t, test.expectErr, err != nil, err)
+			var unknownChannelError *eventingchannel.UnknownChannelError
+			assert.Equal(t, test.expectUnknown, errors.As(err, &unknownChannelError))
+			if !test.expectErr {
+				producerMessage := mockSyncProducer.GetMessage()
+				assert.Equal(t, brokerTopic, producerMessage.Topic)
+				receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, "ce_specversion", cloudevents.VersionV1)
+			}
+		})
+	}
+}
+
+// Test That The Ingress Replaces The Shared Producer When The Kafka Secret's Auth Settings Change
+func TestSecretChanged(t *testing.T) {
+
+	// Stub The Kafka SyncProducer (A New Mock Per Producer) & The Payload Limiter's ClusterAdmin
+	var mockSyncProducers []*producertesting.MockSyncProducer
+	producertesting.StubNewSyncProducerFn(func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		mockSyncProducer := producertesting.NewMockSyncProducer()
+		mockSyncProducers = append(mockSyncProducers, mockSyncProducer)
+		return mockSyncProducer, nil
+	})
+	defer producertesting.RestoreNewSyncProducerFn()
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, brokerTopic, 1000000, nil, nil))
+
+	ingress := newTestIngress(t)
+	defer ingress.Close()
+	originalProducer := ingress.producer
+
+	// A Secret Without Auth Settings Is Ignored
+	ingress.SecretChanged(context.TODO(), &corev1.Secret{})
+	assert.Same(t, originalProducer, ingress.producer)
+
+	// Changed SASL Credentials Replace The Producer
+	ingress.SecretChanged(context.TODO(), &corev1.Secret{
+		Data: map[string][]byte{"username": []byte("new-user"), "password": []byte("new-password"), "saslType": []byte("PLAIN")},
+	})
+	assert.NotSame(t, originalProducer, ingress.producer)
+	assert.Len(t, mockSyncProducers, 2)
+	assert.True(t, mockSyncProducers[0].Closed())
+	assert.False(t, mockSyncProducers[1].Closed())
+}
+
+// Create A New Ingress With A Lister Of The Specified Brokers
+func newTestIngress(t *testing.T, brokers ...*eventingv1.Broker) *Ingress {
+	logger := logtesting.TestLogger(t).Desugar()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, broker := range brokers {
+		assert.Nil(t, indexer.Add(broker))
+	}
+	kafkaProducer, err := producer.NewProducer(logger, sarama.NewConfig(), []string{"kafka:9092"}, metrics.NewStatsReporter(logger), channelhealth.NewChannelHealthServer("0"), nil, nil, commonconfig.EKReceiverProducerConfig{})
+	assert.Nil(t, err)
+	return NewIngress(logger, eventinglisters.NewBrokerLister(indexer), kafkaProducer)
+}
+
+// Create A New Broker With The Specified Class & Readiness
+func newBroker(name string, class string, ready bool) *eventingv1.Broker {
+	broker := &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   brokerNamespace,
+			Name:        name,
+			Annotations: map[string]string{eventing.BrokerClassKey: class},
+		},
+	}
+	broker.Status.InitializeConditions()
+	if ready {
+		brokereventing.MarkTopicTrue(&broker.Status)
+		broker.Status.SetAddress(apis.HTTP(constants.AppendBrokerServiceNameSuffix(name) + ".broker-namespace.svc.cluster.local"))
+		broker.Status.MarkDeadLetterSinkNotConfigured()
+	}
+	return broker
+}
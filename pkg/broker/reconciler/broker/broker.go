@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/rickb777/date/period"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/network"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/externalname"
+	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+)
+
+// Reconciler reconciles Kafka Brokers by creating their Kafka Topic, and by exposing them through a Service (an
+// ExternalName reference to the shared ingress) whose address is set in the Broker status.
+type Reconciler struct {
+	kubeClientSet   kubernetes.Interface
+	serviceLister   corev1listers.ServiceLister
+	uriResolver     *resolver.URIResolver
+	systemNamespace string
+	config          *commonconfig.EventingKafkaConfig
+	configLock      sync.RWMutex
+}
+
+// Check that our Reconciler implements Interface & Finalizer
+var _ brokerreconciler.Interface = (*Reconciler)(nil)
+var _ brokerreconciler.Finalizer = (*Reconciler)(nil)
+
+// ReconcileKind implements the Broker Reconciler Interface.
+func (r *Reconciler) ReconcileKind(ctx context.Context, broker *eventingv1.Broker) pkgreconciler.Event {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	broker.Status.InitializeConditions()
+
+	// Create The Broker's Kafka Topic (If It Does Not Already Exist)
+	topicName := util.BrokerTopicName(broker)
+	err := r.reconcileTopic(ctx, topicName)
+	if err != nil {
+		logger.Error("Unable To Reconcile Kafka Topic", zap.String("Topic", topicName), zap.Error(err))
+		brokereventing.MarkTopicFailed(&broker.Status, "TopicFailed", "Failed To Reconcile Kafka Topic %s: %v", topicName, err)
+		return err
+	}
+	brokereventing.MarkTopicTrue(&broker.Status)
+
+	// Reconcile The Broker's Service And Expose Its Address
+	service, err := r.reconcileService(ctx, broker)
+	if err != nil {
+		logger.Error("Unable To Reconcile Broker Service", zap.Error(err))
+		brokereventing.MarkAddressableFailed(&broker.Status, "ServiceFailed", "Failed To Reconcile Broker Service: %v", err)
+		return err
+	}
+	broker.Status.SetAddress(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(service.Name, service.Namespace),
+	})
+
+	// Resolve The Broker's Dead Letter Sink (Used By Triggers Without One Of Their Own)
+	if broker.Spec.Delivery != nil && broker.Spec.Delivery.DeadLetterSink != nil {
+		deadLetterSinkURI, err := r.uriResolver.URIFromDestinationV1(ctx, *broker.Spec.Delivery.DeadLetterSink, broker)
+		if err != nil {
+			logger.Error("Unable To Resolve Dead Letter Sink", zap.Error(err))
+			broker.Status.MarkDeadLetterSinkResolvedFailed("Unable To Get The DeadLetterSink's URI", "%v", err)
+			return err
+		}
+		broker.Status.MarkDeadLetterSinkResolvedSucceeded(deadLetterSinkURI)
+	} else {
+		broker.Status.MarkDeadLetterSinkNotConfigured()
+	}
+
+	return nil
+}
+
+// FinalizeKind implements the Broker Finalizer Interface by deleting the Broker's Kafka Topic.
+func (r *Reconciler) FinalizeKind(ctx context.Context, broker *eventingv1.Broker) pkgreconciler.Event {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	topicName := util.BrokerTopicName(broker)
+	err := r.deleteTopic(ctx, topicName)
+	if err != nil {
+		logger.Error("Failed To Finalize Kafka Topic", zap.String("Topic", topicName), zap.Error(err))
+		return err
+	}
+	logger.Info("Successfully Finalized Kafka Topic", zap.String("Topic", topicName))
+	return nil
+}
+
+// Update The Reconciler's Eventing-Kafka Configuration From The Specified Settings ConfigMap
+func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.ConfigMap) error {
+	if configMap == nil || configMap.Data == nil {
+		return fmt.Errorf("nil configMap data passed to updateKafkaConfig")
+	}
+	ekConfig, err := kafkasarama.LoadSettings(ctx, constants.ControllerComponent, configMap.Data, kafkasarama.LoadAuthConfig)
+	if err != nil {
+		return err
+	} else if ekConfig == nil {
+		return fmt.Errorf("eventing-kafka config is nil")
+	}
+	kafkasarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
+	r.setConfig(ekConfig)
+	return nil
+}
+
+// Safely Set The Reconciler's Eventing-Kafka Configuration
+func (r *Reconciler) setConfig(ekConfig *commonconfig.EventingKafkaConfig) {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	r.config = ekConfig
+}
+
+// Create A Kafka AdminClient From The Reconciler's Current Eventing-Kafka Configuration
+func (r *Reconciler) newAdminClient(ctx context.Context) (types.AdminClientInterface, *commonconfig.EventingKafkaConfig, error) {
+	r.configLock.RLock()
+	ekConfig := r.config
+	r.configLock.RUnlock()
+	if ekConfig == nil || ekConfig.Sarama.Config == nil {
+		return nil, nil, fmt.Errorf("eventing-kafka config is not loaded")
+	}
+	adminClient, err := admin.CreateAdminClient(ctx, strings.Split(ekConfig.Kafka.Brokers, ","), ekConfig.Sarama.Config, types.Kafka)
+	return adminClient, ekConfig, err
+}
+
+// Create The Specified Kafka Topic With The Broker Settings Of The Configuration (Handles Case Where Already Exists)
+func (r *Reconciler) reconcileTopic(ctx context.Context, topicName string) error {
+
+	adminClient, ekConfig, err := r.newAdminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = adminClient.Close() }()
+
+	// Attempt To Create The Topic & Process TopicError Results (Including Success ;)
+	topicErr := adminClient.CreateTopic(ctx, topicName, newTopicDetail(ctx, ekConfig.Broker))
+	if topicErr != nil {
+		switch topicErr.Err {
+		case sarama.ErrNoError, sarama.ErrTopicAlreadyExists:
+			return nil
+		default:
+			return topicErr
+		}
+	}
+	return nil
+}
+
+// Delete The Specified Kafka Topic (Handles Case Where Not Found)
+func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
+
+	adminClient, _, err := r.newAdminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = adminClient.Close() }()
+
+	// Attempt To Delete The Topic & Process TopicError Results
+	topicErr := adminClient.DeleteTopic(ctx, topicName)
+	if topicErr != nil {
+		switch topicErr.Err {
+		case sarama.ErrNoError, sarama.ErrUnknownTopicOrPartition, sarama.ErrInvalidTopic, sarama.ErrInvalidPartitions:
+			return nil
+		default:
+			return topicErr
+		}
+	}
+	return nil
+}
+
+// Create The Sarama TopicDetail From The Broker Settings, Defaulting Unset Values
+func newTopicDetail(ctx context.Context, config commonconfig.EKBrokerConfig) *sarama.TopicDetail {
+
+	numPartitions := config.NumPartitions
+	if numPartitions <= 0 {
+		numPartitions = commonconstants.DefaultNumPartitions
+	}
+
+	replicationFactor := config.ReplicationFactor
+	if replicationFactor <= 0 {
+		replicationFactor = commonconstants.DefaultReplicationFactor
+	}
+
+	retentionDuration := commonconstants.DefaultRetentionDuration
+	if config.RetentionDuration != "" {
+		retentionPeriod, err := period.Parse(config.RetentionDuration)
+		if err != nil {
+			// Should never happen with the config-kafka webhook validation in place.
+			logging.FromContext(ctx).Desugar().Error("Failed To Parse RetentionDuration Using Default Value Instead", zap.String("RetentionDuration", config.RetentionDuration), zap.Error(err))
+		} else {
+			retentionDuration, _ = retentionPeriod.Duration() // Ignore precision flag and accept ISO8601 estimation
+		}
+	}
+	retentionMillisString := strconv.FormatInt(retentionDuration.Milliseconds(), 10)
+
+	return &sarama.TopicDetail{
+		NumPartitions:     numPartitions,
+		ReplicationFactor: replicationFactor,
+		ConfigEntries: map[string]*string{
+			commonconstants.KafkaTopicConfigRetentionMs: &retentionMillisString,
+		},
+	}
+}
+
+// Reconcile The Broker's ExternalName Service Referencing The Shared Ingress Service
+func (r *Reconciler) reconcileService(ctx context.Context, broker *eventingv1.Broker) (*corev1.Service, error) {
+	return externalname.ReconcileService(ctx, r.kubeClientSet, r.serviceLister, broker, r.newService(broker))
+}
+
+// Create The ExternalName Service Model For The Specified Broker
+func (r *Reconciler) newService(broker *eventingv1.Broker) *corev1.Service {
+	return externalname.NewService(broker,
+		constants.AppendBrokerServiceNameSuffix(broker.Name), // Must Match Broker For HOST Parsing In Ingress!
+		map[string]string{constants.BrokerLabel: broker.Name},
+		constants.IngressServiceName,
+		r.systemNamespace)
+}
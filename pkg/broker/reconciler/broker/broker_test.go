@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	admintesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/testing"
+	admintypes "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+const (
+	brokerNamespace = "broker-namespace"
+	brokerName      = "broker-name"
+	brokerTopic     = "knative-broker-broker-namespace-broker-name"
+	systemNamespace = "knative-eventing"
+)
+
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// Test The Broker ReconcileKind() Functionality
+func TestReconcileKind(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		services       []*corev1.Service
+		createErr      *sarama.TopicError
+		adminClientErr error
+		deadLetterSink *duckv1.Destination
+		expectErr      bool
+		expectReady    bool
+		expectedReason string
+	}{
+		{
+			name:        "Ready",
+			expectReady: true,
+		},
+		{
+			name:        "Ready With Existing Service",
+			services:    []*corev1.Service{newReconciler(nil).newService(newBroker())},
+			expectReady: true,
+		},
+		{
+			name:        "Ready With Existing Topic",
+			createErr:   &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists},
+			expectReady: true,
+		},
+		{
+			name:           "Ready With Dead Letter Sink",
+			deadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.example.com")},
+			expectReady:    true,
+		},
+		{
+			name:           "Create Topic Failed",
+			createErr:      &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor},
+			expectErr:      true,
+			expectedReason: "TopicFailed",
+		},
+		{
+			name:           "Create Admin Client Failed",
+			adminClientErr: errors.New("test admin client error"),
+			expectErr:      true,
+			expectedReason: "TopicFailed",
+		},
+		{
+			name: "Service Not Owned",
+			services: []*corev1.Service{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: brokerNamespace, Name: constants.AppendBrokerServiceNameSuffix(brokerName)},
+			}},
+			expectErr:      true,
+			expectedReason: "ServiceFailed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+			// Stub The Kafka AdminClient
+			defer admintesting.RestoreNewAdminClientFn()
+			if test.adminClientErr != nil {
+				admintesting.StubNewAdminClientFn(admintesting.ErrorNewAdminClientFn(test.adminClientErr))
+			} else {
+				admintesting.StubNewAdminClientFn(func(_ context.Context, brokers []string, _ *sarama.Config, adminClientType admintypes.AdminClientType) (admintypes.AdminClientInterface, error) {
+					assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, brokers)
+					assert.Equal(t, admintypes.Kafka, adminClientType)
+					return &mockAdminClient{
+						createTopicFn: func(topic string, detail *sarama.TopicDetail) *sarama.TopicError {
+							assert.Equal(t, brokerTopic, topic)
+							assert.Equal(t, int32(4), detail.NumPartitions)
+							assert.Equal(t, int16(commonconstants.DefaultReplicationFactor), detail.ReplicationFactor)
+							assert.Equal(t, "86400000", *detail.ConfigEntries[commonconstants.KafkaTopicConfigRetentionMs])
+							return test.createErr
+						},
+					}, nil
+				})
+			}
+
+			// Create The Reconciler With Any Existing Services
+			kubeClient := fake.NewSimpleClientset()
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, service := range test.services {
+				assert.Nil(t, indexer.Add(service))
+				_, err := kubeClient.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
+				assert.Nil(t, err)
+			}
+			reconciler := newReconciler(kubeClient)
+			reconciler.serviceLister = corev1listers.NewServiceLister(indexer)
+			ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+			reconciler.uriResolver = resolver.NewURIResolverFromTracker(addressable.WithDuck(ctx), tracker.New(func(types.NamespacedName) {}, time.Minute))
+
+			// Perform The Test
+			broker := newBroker()
+			if test.deadLetterSink != nil {
+				broker.Spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: test.deadLetterSink}
+			}
+			err := reconciler.ReconcileKind(ctx, broker)
+
+			// Verify The Results
+			assert.Equal(t, test.expectErr, err != nil, err)
+			assert.Equal(t, test.expectReady, broker.IsReady())
+			if test.expectReady {
+				assert.Equal(t, "http://broker-name-kn-broker.broker-namespace.svc.cluster.local", broker.Status.Address.URL.String())
+				service, err := kubeClient.CoreV1().Services(brokerNamespace).Get(ctx, constants.AppendBrokerServiceNameSuffix(brokerName), metav1.GetOptions{})
+				assert.Nil(t, err)
+				assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+				assert.Equal(t, "kafka-broker-ingress.knative-eventing.svc.cluster.local", service.Spec.ExternalName)
+				assert.True(t, metav1.IsControlledBy(service, broker))
+				if test.deadLetterSink != nil {
+					assert.Equal(t, test.deadLetterSink.URI, broker.Status.DeadLetterSinkURI)
+				}
+			} else {
+				readyCondition := broker.Status.GetCondition(apis.ConditionReady)
+				assert.Equal(t, test.expectedReason, readyCondition.Reason)
+			}
+		})
+	}
+}
+
+// Test The Broker FinalizeKind() Functionality
+func TestFinalizeKind(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		deleteErr      *sarama.TopicError
+		adminClientErr error
+		expectErr      bool
+	}{
+		{name: "Deleted"},
+		{name: "Deleted (ErrNoError)", deleteErr: &sarama.TopicError{Err: sarama.ErrNoError}},
+		{name: "Topic Not Found", deleteErr: &sarama.TopicError{Err: sarama.ErrUnknownTopicOrPartition}},
+		{name: "Delete Failed", deleteErr: &sarama.TopicError{Err: sarama.ErrBrokerNotAvailable}, expectErr: true},
+		{name: "Create Admin Client Failed", adminClientErr: errors.New("test admin client error"), expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+			// Stub The Kafka AdminClient
+			defer admintesting.RestoreNewAdminClientFn()
+			if test.adminClientErr != nil {
+				admintesting.StubNewAdminClientFn(admintesting.ErrorNewAdminClientFn(test.adminClientErr))
+			} else {
+				admintesting.StubNewAdminClientFn(admintesting.NonValidatingNewAdminClientFn(&mockAdminClient{
+					deleteTopicFn: func(topic string) *sarama.TopicError {
+						assert.Equal(t, brokerTopic, topic)
+						return test.deleteErr
+					},
+				}))
+			}
+
+			// Perform The Test
+			err := newReconciler(fake.NewSimpleClientset()).FinalizeKind(ctx, newBroker())
+			assert.Equal(t, test.expectErr, err != nil, err)
+		})
+	}
+}
+
+// Test The Defaulting Of The Broker Topic Settings
+func TestNewTopicDetail(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	detail := newTopicDetail(ctx, commonconfig.EKBrokerConfig{RetentionDuration: "invalid"})
+	assert.Equal(t, int32(commonconstants.DefaultNumPartitions), detail.NumPartitions)
+	assert.Equal(t, int16(commonconstants.DefaultReplicationFactor), detail.ReplicationFactor)
+	assert.Equal(t, "604800000", *detail.ConfigEntries[commonconstants.KafkaTopicConfigRetentionMs])
+}
+
+// Create A New Reconciler For Testing
+func newReconciler(kubeClient *fake.Clientset) *Reconciler {
+	return &Reconciler{
+		kubeClientSet:   kubeClient,
+		systemNamespace: systemNamespace,
+		config: &commonconfig.EventingKafkaConfig{
+			Kafka:  commonconfig.EKKafkaConfig{Brokers: "kafka-1:9092,kafka-2:9092"},
+			Sarama: commonconfig.EKSaramaConfig{Config: sarama.NewConfig()},
+			Broker: commonconfig.EKBrokerConfig{NumPartitions: 4, RetentionDuration: "PT24H"},
+		},
+	}
+}
+
+// mockAdminClient is a Kafka AdminClient whose topic creation & deletion is delegated to the specified functions
+type mockAdminClient struct {
+	admintesting.MockAdminClient
+	createTopicFn func(topic string, detail *sarama.TopicDetail) *sarama.TopicError
+	deleteTopicFn func(topic string) *sarama.TopicError
+}
+
+func (c *mockAdminClient) CreateTopic(_ context.Context, topic string, detail *sarama.TopicDetail) *sarama.TopicError {
+	return c.createTopicFn(topic, detail)
+}
+
+func (c *mockAdminClient) DeleteTopic(_ context.Context, topic string) *sarama.TopicError {
+	return c.deleteTopicFn(topic)
+}
+
+// Create A New Kafka Broker For Testing
+func newBroker() *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   brokerNamespace,
+			Name:        brokerName,
+			UID:         "broker-uid",
+			Annotations: map[string]string{"eventing.knative.dev/broker.class": constants.BrokerClass},
+		},
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/configmaploader"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+// NewController initializes the Kafka Broker controller.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+
+	logger := logging.FromContext(ctx).Desugar()
+
+	brokerInformer := brokerinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+
+	r := &Reconciler{
+		kubeClientSet:   kubeclient.Get(ctx),
+		serviceLister:   serviceInformer.Lister(),
+		systemNamespace: system.Namespace(),
+	}
+
+	// Load The Initial Eventing-Kafka Settings From The Mounted ConfigMap
+	configmapLoader, err := configmaploader.FromContext(ctx)
+	if err != nil {
+		logger.Fatal("Failed To Get ConfigmapLoader From Context - Terminating!", zap.Error(err))
+	}
+	configMap, err := configmapLoader(commonconstants.SettingsConfigMapMountPath)
+	if err != nil {
+		logger.Fatal("error loading configuration", zap.Error(err))
+	}
+	err = r.updateKafkaConfig(ctx, &corev1.ConfigMap{Data: configMap})
+	if err != nil {
+		logger.Fatal("Failed To Load Eventing-Kafka Settings", zap.Error(err))
+	}
+
+	// Only Brokers Of The Kafka BrokerClass Are Reconciled
+	impl := brokerreconciler.NewImpl(ctx, r, constants.BrokerClass)
+	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+
+	brokerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, constants.BrokerClass, false),
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	// Reconcile The Broker Of A Service Which Was Changed Or Deleted
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&eventingv1.Broker{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Watch The Settings ConfigMap For Changes (e.g. The Kafka Brokers Or The Broker Topic Settings)
+	handleKafkaConfigMapChange := func(ctx context.Context, configMap *corev1.ConfigMap) {
+		logger.Info("Configmap is updated or, it is being read for the first time")
+		if err := r.updateKafkaConfig(ctx, configMap); err != nil {
+			logger.Error("Update from configmap failed; skipping GlobalResync", zap.Error(err))
+			return
+		}
+		impl.GlobalResync(brokerInformer.Informer())
+	}
+	err = commonconfig.InitializeKafkaConfigMapWatcher(ctx, cmw, logger.Sugar(), handleKafkaConfigMapChange, system.Namespace())
+	if err != nil {
+		logger.Fatal("Failed To Initialize ConfigMap Watcher", zap.Error(err))
+	}
+
+	return impl
+}
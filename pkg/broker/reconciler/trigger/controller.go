@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	triggerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
+	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
+	triggerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/trigger"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	"knative.dev/eventing-kafka/pkg/broker/util"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+)
+
+// NewControllerFactory returns the ControllerConstructor of the controller of the Triggers of Kafka Brokers, which
+// receives the status of the Triggers' ConsumerGroups from the dispatcher over the specified ConnectionPool.
+func NewControllerFactory(connectionPool ctrlreconciler.ControlPlaneConnectionPool) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		return newController(ctx, cmw, connectionPool)
+	}
+}
+
+// Initialize The Controller Of The Triggers Of Kafka Brokers
+func newController(ctx context.Context, _ configmap.Watcher, connectionPool ctrlreconciler.ControlPlaneConnectionPool) *controller.Impl {
+
+	logger := logging.FromContext(ctx).Desugar()
+
+	triggerInformer := triggerinformer.Get(ctx)
+	brokerInformer := brokerinformer.Get(ctx)
+	podInformer := pod.Get(ctx)
+	triggerLister := triggerInformer.Lister()
+
+	r := &Reconciler{
+		brokerLister:    brokerInformer.Lister(),
+		podLister:       podInformer.Lister(),
+		connectionPool:  connectionPool,
+		systemNamespace: system.Namespace(),
+	}
+
+	// Only The Triggers Of Kafka Brokers Are Reconciled
+	triggerFilter := util.FilterKafkaBrokerTriggers(r.brokerLister)
+	impl := triggerreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{PromoteFilterFunc: triggerFilter}
+	})
+	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+
+	// Create The Store For The Triggers' Status Notifications Sent By The Dispatcher (Which Pertain To All Triggers)
+	r.statusStore = ctrlreconciler.NewNotificationStore(func(types.NamespacedName) {
+		impl.FilteredGlobalResync(triggerFilter, triggerInformer.Informer())
+	}, distributedcontrol.SubscribersStatusParser)
+
+	triggerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: triggerFilter,
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	// Reconcile The Triggers Of A Kafka Broker Whenever It Changes (e.g. Becomes Ready)
+	brokerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, constants.BrokerClass, false),
+		Handler: controller.HandleAll(func(obj interface{}) {
+			if broker, ok := obj.(*eventingv1.Broker); ok {
+				triggers, err := util.TriggersOfBroker(triggerLister, broker)
+				if err != nil {
+					logger.Warn("Failed To List Triggers Of Broker", zap.String("Broker", broker.Name), zap.Error(err))
+					return
+				}
+				for _, trigger := range triggers {
+					impl.Enqueue(trigger)
+				}
+			}
+		}),
+	})
+
+	return impl
+}
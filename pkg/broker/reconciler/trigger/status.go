@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+)
+
+//
+// Reconcile The Subscription Status Of A Trigger
+//
+// Each replica of the shared dispatcher reports the status of the ConsumerGroups of the Triggers it
+// has started (keyed by Trigger UID) via the control-protocol.  A Trigger's subscription is only
+// considered Ready once its ConsumerGroup has been reported started, and not stopped or failed.
+//
+
+// Reconcile The Control-Protocol Connections To The Dispatcher & Propagate The Trigger's Subscription Status
+func (r *Reconciler) reconcileSubscriptionStatus(ctx context.Context, trigger *eventingv1.Trigger) error {
+
+	// Get The Trigger-Specific Logger Provided Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Always Propagate The Latest Known Notifications (Even On Failure)
+	storeKey := dispatcherNamespacedName(r.systemNamespace)
+	defer func() {
+		notifications, _ := r.statusStore.GetPodsNotifications(storeKey)
+		trigger.Status.PropagateSubscriptionCondition(newSubscriptionCondition(trigger, notifications))
+	}()
+
+	// Get The IPs Of The Dispatcher Pods
+	dispatcherLabels := labels.Set{constants.AppLabel: constants.DispatcherName}
+	podIpGetter := ctrlreconciler.PodIpGetter{Lister: r.podLister}
+	podIPs, err := podIpGetter.GetAllPodsIp(r.systemNamespace, dispatcherLabels.AsSelector())
+	if err != nil {
+		logger.Error("Failed To Get Dispatcher Pods", zap.Error(err))
+		return err
+	}
+	for index, podIP := range podIPs {
+		podIPs[index] = fmt.Sprintf("%s:%d", podIP, distributedcontrol.ServerPort)
+	}
+
+	// Reconcile The Control-Protocol Connections To The Dispatcher Pods (Shared By All Triggers)
+	_, err = r.connectionPool.ReconcileConnections(
+		ctx,
+		constants.DispatcherName,
+		podIPs,
+		func(newHost string, service ctrl.Service) {
+			service.MessageHandler(ctrlservice.MessageRouter{
+				distributedcontrol.NotifySubscribersStatusOpCode: r.statusStore.MessageHandler(
+					storeKey,
+					newHost,
+					ctrlreconciler.PassNewValue,
+				),
+			})
+		},
+		func(oldHost string) {
+			r.statusStore.CleanPodNotification(storeKey, oldHost)
+		},
+	)
+	if err != nil {
+		logger.Error("Failed To Reconcile Dispatcher Connections", zap.Error(err))
+		return err
+	}
+
+	logger.Debug("Successfully Reconciled Dispatcher Connections", zap.Strings("PodIPs", podIPs))
+	return nil
+}
+
+// Get The NamespacedName Used To Track The Dispatcher's Triggers' Status Notifications
+func dispatcherNamespacedName(systemNamespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: systemNamespace, Name: constants.DispatcherName}
+}
+
+// Create The Subscription Condition Of The Trigger From The Status Notifications Of All Dispatcher Pods
+func newSubscriptionCondition(trigger *eventingv1.Trigger, notifications map[string]interface{}) *apis.Condition {
+
+	// Combine The Status Of The Trigger's ConsumerGroup From All Dispatcher Pods
+	reported := false
+	stopped := false
+	errMessage := ""
+	for _, notification := range notifications {
+		triggersStatus, ok := notification.(distributedcontrol.SubscribersStatus)
+		if !ok {
+			continue
+		}
+		if status, ok := triggersStatus[trigger.UID]; ok {
+			reported = true
+			stopped = stopped || status.Stopped
+			if status.Error != "" {
+				errMessage = status.Error
+			}
+		}
+	}
+
+	switch {
+	case !reported:
+		return &apis.Condition{Status: corev1.ConditionUnknown, Reason: constants.TriggerNotStartedReason, Message: constants.TriggerNotStartedMessage}
+	case errMessage != "":
+		return &apis.Condition{Status: corev1.ConditionFalse, Reason: constants.TriggerConsumerGroupFailedReason, Message: errMessage}
+	case stopped:
+		return &apis.Condition{Status: corev1.ConditionFalse, Reason: constants.TriggerConsumerGroupStoppedReason, Message: constants.TriggerConsumerGroupStoppedMessage}
+	default:
+		return &apis.Condition{Status: corev1.ConditionTrue}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+)
+
+// Test The newSubscriptionCondition() Functionality
+func TestNewSubscriptionCondition(t *testing.T) {
+
+	uid := types.UID("test-trigger-uid")
+
+	tests := []struct {
+		name          string
+		notifications map[string]interface{}
+		wantStatus    corev1.ConditionStatus
+		wantReason    string
+		wantMessage   string
+	}{
+		{
+			name:          "No Notifications",
+			notifications: nil,
+			wantStatus:    corev1.ConditionUnknown,
+			wantReason:    constants.TriggerNotStartedReason,
+			wantMessage:   constants.TriggerNotStartedMessage,
+		},
+		{
+			name: "Trigger Not Reported",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{"other-uid": {Partitions: []int32{0}}},
+			},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  constants.TriggerNotStartedReason,
+			wantMessage: constants.TriggerNotStartedMessage,
+		},
+		{
+			name: "Started Without Claimed Partitions",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {}},
+			},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "Started Across Pods",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 2}}},
+				"pod-2": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{1, 3}}},
+			},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "Stopped Group",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 1}}},
+				"pod-2": distributedcontrol.SubscribersStatus{uid: {Stopped: true}},
+			},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  constants.TriggerConsumerGroupStoppedReason,
+			wantMessage: constants.TriggerConsumerGroupStoppedMessage,
+		},
+		{
+			name: "Error Reported",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Error: "test error"}},
+			},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  constants.TriggerConsumerGroupFailedReason,
+			wantMessage: "test error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition := newSubscriptionCondition(newTrigger(false), test.notifications)
+			assert.Equal(t, test.wantStatus, condition.Status)
+			assert.Equal(t, test.wantReason, condition.Reason)
+			assert.Equal(t, test.wantMessage, condition.Message)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	triggerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/trigger"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing-kafka/pkg/broker/util"
+)
+
+// Reconciler reconciles the Triggers of Kafka Brokers by resolving their Subscriber and Dead Letter Sink.  The events
+// of a Trigger are consumed from its Broker's Kafka Topic by the shared dispatcher, in a ConsumerGroup of its own,
+// whose status the dispatcher reports back via the control-protocol.
+type Reconciler struct {
+	brokerLister    eventinglisters.BrokerLister
+	podLister       corelisters.PodLister
+	uriResolver     *resolver.URIResolver
+	connectionPool  ctrlreconciler.ControlPlaneConnectionPool
+	statusStore     *ctrlreconciler.NotificationStore
+	systemNamespace string
+}
+
+// Check that our Reconciler implements Interface
+var _ triggerreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements the Trigger Reconciler Interface.
+func (r *Reconciler) ReconcileKind(ctx context.Context, trigger *eventingv1.Trigger) pkgreconciler.Event {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	broker, err := r.brokerLister.Brokers(trigger.Namespace).Get(trigger.Spec.Broker)
+	if apierrors.IsNotFound(err) {
+		// Ok To Return Nil Here - Once The Broker Becomes Available, Or The Trigger Changes, It Is Requeued
+		logger.Warn("Trigger References Unknown Broker", zap.String("Broker", trigger.Spec.Broker))
+		trigger.Status.MarkBrokerFailed("BrokerDoesNotExist", "Broker %q does not exist", trigger.Spec.Broker)
+		return nil
+	} else if err != nil {
+		trigger.Status.MarkBrokerFailed("FailedToGetBroker", "Failed to get broker %q : %s", trigger.Spec.Broker, err)
+		return err
+	}
+
+	// Ignore The Triggers Of Brokers Of Other Classes
+	if !util.IsKafkaBroker(broker) {
+		logger.Debug("Ignoring Trigger Of Non-Kafka Broker", zap.String("Broker", trigger.Spec.Broker))
+		return nil
+	}
+	trigger.Status.PropagateBrokerCondition(broker.Status.GetTopLevelCondition())
+
+	// If The Broker Is Not Ready We're Done, But Once It Becomes Ready The Trigger Is Requeued
+	if !broker.IsReady() {
+		logger.Info("Broker Is Not Ready", zap.String("Broker", trigger.Spec.Broker))
+		return nil
+	}
+
+	// Resolve The Subscriber (Defaulting The Namespace Of Its Reference To The Trigger's)
+	if trigger.Spec.Subscriber.Ref != nil && trigger.Spec.Subscriber.Ref.Namespace == "" {
+		trigger.Spec.Subscriber.Ref.Namespace = trigger.Namespace
+	}
+	subscriberURI, err := r.uriResolver.URIFromDestinationV1(ctx, trigger.Spec.Subscriber, broker)
+	if err != nil {
+		logger.Error("Unable To Resolve Subscriber", zap.Error(err))
+		trigger.Status.MarkSubscriberResolvedFailed("Unable to get the Subscriber's URI", "%v", err)
+		trigger.Status.SubscriberURI = nil
+		return err
+	}
+	trigger.Status.SubscriberURI = subscriberURI
+	trigger.Status.MarkSubscriberResolvedSucceeded()
+
+	if err := r.resolveDeadLetterSink(ctx, broker, trigger); err != nil {
+		return err
+	}
+
+	// The Trigger Is Subscribed Once The Shared Dispatcher Reports Its ConsumerGroup Started (There Is No Dependency)
+	trigger.Status.MarkDependencySucceeded()
+	if err := r.reconcileSubscriptionStatus(ctx, trigger); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resolve The Trigger's Dead Letter Sink, Falling Back To That Of Its Broker
+func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, broker *eventingv1.Broker, trigger *eventingv1.Trigger) error {
+	if trigger.Spec.Delivery != nil && trigger.Spec.Delivery.DeadLetterSink != nil {
+		deadLetterSinkURI, err := r.uriResolver.URIFromDestinationV1(ctx, *trigger.Spec.Delivery.DeadLetterSink, trigger)
+		if err != nil {
+			logging.FromContext(ctx).Desugar().Error("Unable To Resolve Dead Letter Sink", zap.Error(err))
+			trigger.Status.DeadLetterSinkURI = nil
+			trigger.Status.MarkDeadLetterSinkResolvedFailed("Unable to get the dead letter sink's URI", "%v", err)
+			return err
+		}
+		trigger.Status.DeadLetterSinkURI = deadLetterSinkURI
+		trigger.Status.MarkDeadLetterSinkResolvedSucceeded()
+	} else if broker.Spec.Delivery != nil && broker.Spec.Delivery.DeadLetterSink != nil {
+		if broker.Status.DeadLetterSinkURI == nil {
+			trigger.Status.DeadLetterSinkURI = nil
+			trigger.Status.MarkDeadLetterSinkResolvedFailed(fmt.Sprintf("Broker %s didn't set status.deadLetterSinkURI", broker.Name), "")
+			return fmt.Errorf("broker %s didn't set status.deadLetterSinkURI", broker.Name)
+		}
+		trigger.Status.DeadLetterSinkURI = broker.Status.DeadLetterSinkURI
+		trigger.Status.MarkDeadLetterSinkResolvedSucceeded()
+	} else {
+		trigger.Status.DeadLetterSinkURI = nil
+		trigger.Status.MarkDeadLetterSinkNotConfigured()
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	brokereventing "knative.dev/eventing-kafka/pkg/broker/apis/eventing"
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+const (
+	testNamespace       = "test-namespace"
+	testSystemNamespace = "knative-eventing"
+	testBrokerName      = "test-broker"
+)
+
+var (
+	subscriberURI        = apis.HTTP("subscriber.example.com")
+	triggerDeadLetterURI = apis.HTTP("trigger-dls.example.com")
+	brokerDeadLetterURI  = apis.HTTP("broker-dls.example.com")
+)
+
+func init() {
+	brokereventing.RegisterKafkaBrokerConditionSet()
+}
+
+// Test The Trigger ReconcileKind() Functionality
+func TestReconcileKind(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		broker              *eventingv1.Broker
+		triggerDeadLetter   bool
+		expectErr           bool
+		expectReady         bool
+		expectedReason      string
+		expectedDeadLetter  *apis.URL
+		expectUnreconciled  bool
+		expectBrokerPending bool
+		notStarted          bool
+		connectionsErr      error
+	}{
+		{
+			name:        "Ready",
+			broker:      newBroker(constants.BrokerClass, true, false),
+			expectReady: true,
+		},
+		{
+			name:               "Ready With Trigger Dead Letter Sink",
+			broker:             newBroker(constants.BrokerClass, true, true),
+			triggerDeadLetter:  true,
+			expectReady:        true,
+			expectedDeadLetter: triggerDeadLetterURI,
+		},
+		{
+			name:               "Ready With Broker Dead Letter Sink",
+			broker:             newBroker(constants.BrokerClass, true, true),
+			expectReady:        true,
+			expectedDeadLetter: brokerDeadLetterURI,
+		},
+		{
+			name:           "ConsumerGroup Not Yet Started",
+			broker:         newBroker(constants.BrokerClass, true, false),
+			notStarted:     true,
+			expectedReason: constants.TriggerNotStartedReason,
+		},
+		{
+			name:           "Dispatcher Connections Failure",
+			broker:         newBroker(constants.BrokerClass, true, false),
+			connectionsErr: fmt.Errorf("test connections error"),
+			expectErr:      true,
+			expectReady:    true,
+		},
+		{
+			name:           "Broker Does Not Exist",
+			expectedReason: "BrokerDoesNotExist",
+		},
+		{
+			name:               "Broker Of Other Class",
+			broker:             newBroker(eventing.MTChannelBrokerClassValue, true, false),
+			expectUnreconciled: true,
+		},
+		{
+			name:                "Broker Not Ready",
+			broker:              newBroker(constants.BrokerClass, false, false),
+			expectBrokerPending: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+			// Create The Reconciler With Any Existing Broker
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if test.broker != nil {
+				assert.Nil(t, indexer.Add(test.broker))
+			}
+			ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+			mockConnectionPool := &controlprotocoltesting.MockConnectionPool{}
+			mockConnectionPool.On("ReconcileConnections", mock.Anything, constants.DispatcherName, mock.Anything, mock.Anything, mock.Anything).Return(map[string]ctrl.Service{}, test.connectionsErr)
+			reconciler := &Reconciler{
+				brokerLister:    eventinglisters.NewBrokerLister(indexer),
+				podLister:       corelisters.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+				uriResolver:     resolver.NewURIResolverFromTracker(addressable.WithDuck(ctx), tracker.New(func(types.NamespacedName) {}, time.Minute)),
+				connectionPool:  mockConnectionPool,
+				statusStore:     ctrlreconciler.NewNotificationStore(func(types.NamespacedName) {}, distributedcontrol.SubscribersStatusParser),
+				systemNamespace: testSystemNamespace,
+			}
+
+			// Simulate The Dispatcher Reporting The Trigger's ConsumerGroup Started (Unless Otherwise Specified)
+			trigger := newTrigger(test.triggerDeadLetter)
+			if !test.notStarted {
+				reportTriggersStatus(t, ctx, reconciler, distributedcontrol.SubscribersStatus{trigger.UID: {Partitions: []int32{0}}})
+			}
+
+			// Perform The Test
+			err := reconciler.ReconcileKind(ctx, trigger)
+
+			// Verify The Results
+			assert.Equal(t, test.expectErr, err != nil, err)
+			assert.Equal(t, test.expectReady, trigger.Status.IsReady())
+			switch {
+			case test.expectReady:
+				assert.Equal(t, subscriberURI, trigger.Status.SubscriberURI)
+				assert.Equal(t, test.expectedDeadLetter, trigger.Status.DeadLetterSinkURI)
+			case test.expectUnreconciled:
+				assert.Nil(t, trigger.Status.GetTopLevelCondition())
+			case test.expectBrokerPending:
+				assert.Nil(t, trigger.Status.SubscriberURI)
+				assert.Equal(t, corev1.ConditionFalse, trigger.Status.GetCondition(eventingv1.TriggerConditionBroker).Status)
+			case test.notStarted:
+				assert.Equal(t, corev1.ConditionUnknown, trigger.Status.GetCondition(eventingv1.TriggerConditionSubscribed).Status)
+				assert.Equal(t, test.expectedReason, trigger.Status.GetCondition(eventingv1.TriggerConditionSubscribed).Reason)
+			default:
+				assert.Equal(t, test.expectedReason, trigger.Status.GetCondition(eventingv1.TriggerConditionBroker).Reason)
+			}
+		})
+	}
+}
+
+// Store The Specified TriggersStatus As Though It Were Sent By A Dispatcher Pod
+func reportTriggersStatus(t *testing.T, ctx context.Context, reconciler *Reconciler, status distributedcontrol.SubscribersStatus) {
+	payload, err := status.MarshalBinary()
+	assert.Nil(t, err)
+	message := ctrl.NewMessage(uuid.New(), uint8(distributedcontrol.NotifySubscribersStatusOpCode), payload)
+	handler := reconciler.statusStore.MessageHandler(dispatcherNamespacedName(testSystemNamespace), "10.0.0.1:8086", ctrlreconciler.PassNewValue)
+	handler.HandleServiceMessage(ctx, ctrl.NewServiceMessage(&message, func(error) {}))
+}
+
+// Create A New Broker (Optionally Ready And With A Dead Letter Sink) For Testing
+func newBroker(class string, ready bool, deadLetterSink bool) *eventingv1.Broker {
+	broker := &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        testBrokerName,
+			Annotations: map[string]string{eventing.BrokerClassKey: class},
+		},
+	}
+	broker.Status.InitializeConditions()
+	if ready {
+		brokereventing.MarkTopicTrue(&broker.Status)
+	} else {
+		brokereventing.MarkTopicFailed(&broker.Status, "TopicFailed", "test topic failure")
+	}
+	broker.Status.SetAddress(apis.HTTP("test-broker-kn-broker.test-namespace.svc.cluster.local"))
+	if deadLetterSink {
+		broker.Spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: brokerDeadLetterURI}}
+		broker.Status.MarkDeadLetterSinkResolvedSucceeded(brokerDeadLetterURI)
+	} else {
+		broker.Status.MarkDeadLetterSinkNotConfigured()
+	}
+	return broker
+}
+
+// Create A New Trigger (Optionally With A Dead Letter Sink) For Testing
+func newTrigger(deadLetterSink bool) *eventingv1.Trigger {
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-trigger", UID: "test-trigger-uid"},
+		Spec: eventingv1.TriggerSpec{
+			Broker:     testBrokerName,
+			Subscriber: duckv1.Destination{URI: subscriberURI},
+		},
+	}
+	if deadLetterSink {
+		trigger.Spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: triggerDeadLetterURI}}
+	}
+	return trigger
+}
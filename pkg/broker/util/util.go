@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/broker/constants"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// IsKafkaBroker returns whether the specified Broker is of the Kafka BrokerClass.
+func IsKafkaBroker(broker *eventingv1.Broker) bool {
+	return broker != nil && broker.GetAnnotations()[eventing.BrokerClassKey] == constants.BrokerClass
+}
+
+// FilterKafkaBrokerTriggers returns an informer FilterFunc accepting the Triggers of existing Kafka Brokers.
+func FilterKafkaBrokerTriggers(brokerLister eventinglisters.BrokerLister) func(interface{}) bool {
+	return func(obj interface{}) bool {
+		trigger, ok := obj.(*eventingv1.Trigger)
+		if !ok {
+			return false
+		}
+		broker, err := brokerLister.Brokers(trigger.Namespace).Get(trigger.Spec.Broker)
+		return err == nil && IsKafkaBroker(broker)
+	}
+}
+
+// TriggersOfBroker returns the Triggers referencing the specified Broker.
+func TriggersOfBroker(triggerLister eventinglisters.TriggerLister, broker *eventingv1.Broker) ([]*eventingv1.Trigger, error) {
+	triggers, err := triggerLister.Triggers(broker.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	brokerTriggers := make([]*eventingv1.Trigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		if trigger.Spec.Broker == broker.Name {
+			brokerTriggers = append(brokerTriggers, trigger)
+		}
+	}
+	return brokerTriggers, nil
+}
+
+// TopicName returns the name of the Kafka Topic of the Broker with the specified namespace and name.
+func TopicName(namespace string, name string) string {
+	return fmt.Sprintf("%s-%s-%s", constants.TopicPrefix, namespace, name)
+}
+
+// BrokerTopicName returns the name of the Kafka Topic of the specified Broker.
+func BrokerTopicName(broker *eventingv1.Broker) string {
+	return TopicName(broker.Namespace, broker.Name)
+}
+
+// GroupId returns the Kafka ConsumerGroup ID of the specified Trigger.
+func GroupId(trigger *eventingv1.Trigger) string {
+	return commonkafkautil.GroupId(string(trigger.UID))
+}
+
+// TopicNameMapper returns the name of the Kafka Topic of the Broker of the specified Trigger.
+func TopicNameMapper(trigger *eventingv1.Trigger) (string, error) {
+	if trigger == nil {
+		return "", fmt.Errorf("unable to format topic name for nil Trigger")
+	}
+	return TopicName(trigger.Namespace, trigger.Spec.Broker), nil
+}
+
+// NewTopicNameMapper returns a TopicNameMapper which verifies that the Broker of the specified Trigger is a Kafka
+// Broker before returning its Kafka Topic name.  The Context must have injected informers (BrokerInformer).
+func NewTopicNameMapper(ctx context.Context) refmappers.TriggerTopicNameMapper {
+	brokerLister := brokerinformer.Get(ctx).Lister()
+	return func(trigger *eventingv1.Trigger) (string, error) {
+		if trigger == nil {
+			return "", fmt.Errorf("unable to format topic name for nil Trigger")
+		}
+		broker, err := brokerLister.Brokers(trigger.Namespace).Get(trigger.Spec.Broker)
+		if err != nil {
+			return "", fmt.Errorf("unable to get Broker %s/%s of Trigger: %v", trigger.Namespace, trigger.Spec.Broker, err)
+		}
+		if !IsKafkaBroker(broker) {
+			return "", fmt.Errorf("broker %s/%s of Trigger is not a %s Broker", broker.Namespace, broker.Name, constants.BrokerClass)
+		}
+		return BrokerTopicName(broker), nil
+	}
+}
+
+// GroupIdMapper returns the Kafka ConsumerGroup ID of the specified Trigger.
+func GroupIdMapper(trigger *eventingv1.Trigger) (string, error) {
+	if trigger == nil {
+		return "", fmt.Errorf("unable to format group id for nil Trigger")
+	}
+	return GroupId(trigger), nil
+}
+
+// ConnectionPoolKeyMapper returns the control-protocol ControlPlaneConnectionPool Key of the specified Trigger,
+// which is the name of the Dispatcher as the single shared Dispatcher serves the Triggers of all Brokers.
+func ConnectionPoolKeyMapper(_ *eventingv1.Trigger) (string, error) {
+	return constants.DispatcherName, nil
+}
+
+// DataPlaneNamespaceMapper returns the Kubernetes Namespace of the shared Dispatcher.
+func DataPlaneNamespaceMapper(_ *eventingv1.Trigger) (string, error) {
+	return system.Namespace(), nil
+}
+
+// DataPlaneLabelsMapper returns a map of Kubernetes Labels identifying the shared Dispatcher's pods.
+func DataPlaneLabelsMapper(_ *eventingv1.Trigger) (map[string]string, error) {
+	return map[string]string{constants.AppLabel: constants.DispatcherName}, nil
+}
+
+// NewRefMapperFactory returns a Trigger RefMapper Factory using the Kafka Broker Topic / Group naming, which
+// resolves Topic names via the Broker informer in order to verify the Triggers' Brokers are Kafka Brokers.
+func NewRefMapperFactory() *refmappers.TriggerRefMapperFactory {
+	factory := refmappers.NewTriggerRefMapperFactory(
+		TopicNameMapper,
+		GroupIdMapper,
+		ConnectionPoolKeyMapper,
+		DataPlaneNamespaceMapper,
+		DataPlaneLabelsMapper,
+	)
+	factory.TopicNameMapperFactory = NewTopicNameMapper
+	return factory
+}
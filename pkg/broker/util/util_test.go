@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	fakebrokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/injection"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+// Test Data
+const (
+	testNamespace   = "test-namespace"
+	testBrokerName  = "test-broker"
+	testTriggerName = "test-trigger"
+	testTriggerUID  = "test-trigger-uid"
+)
+
+// Test The IsKafkaBroker Functionality
+func TestIsKafkaBroker(t *testing.T) {
+	assert.True(t, IsKafkaBroker(newTestBroker(testBrokerName, "Kafka")))
+	assert.False(t, IsKafkaBroker(newTestBroker(testBrokerName, eventing.MTChannelBrokerClassValue)))
+	assert.False(t, IsKafkaBroker(newTestBroker(testBrokerName, "")))
+	assert.False(t, IsKafkaBroker(nil))
+}
+
+// Test The Topic Name & ConsumerGroup ID Functionality
+func TestNaming(t *testing.T) {
+	assert.Equal(t, "knative-broker-test-namespace-test-broker", BrokerTopicName(newTestBroker(testBrokerName, "Kafka")))
+	assert.Equal(t, "kafka."+testTriggerUID, GroupId(newTestTrigger()))
+}
+
+// Test The Trigger Mappers Used By The ResetOffset Controller
+func TestMappers(t *testing.T) {
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, "knative-eventing"))
+	defer func() { assert.Nil(t, os.Unsetenv(system.NamespaceEnvKey)) }()
+	trigger := newTestTrigger()
+
+	topicName, err := TopicNameMapper(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, "knative-broker-test-namespace-test-broker", topicName)
+	_, err = TopicNameMapper(nil)
+	assert.NotNil(t, err)
+
+	groupId, err := GroupIdMapper(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka."+testTriggerUID, groupId)
+	_, err = GroupIdMapper(nil)
+	assert.NotNil(t, err)
+
+	connectionPoolKey, err := ConnectionPoolKeyMapper(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka-broker-dispatcher", connectionPoolKey)
+
+	dataPlaneNamespace, err := DataPlaneNamespaceMapper(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, "knative-eventing", dataPlaneNamespace)
+
+	dataPlaneLabels, err := DataPlaneLabelsMapper(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"app": "kafka-broker-dispatcher"}, dataPlaneLabels)
+}
+
+// Test The NewTopicNameMapper Functionality
+func TestNewTopicNameMapper(t *testing.T) {
+
+	// Create A Context With A Fake Broker Informer Containing A Kafka Broker And A Broker Of Another Class
+	ctx, _ := injection.Fake.SetupInformers(logtesting.TestContextWithLogger(t), &rest.Config{})
+	assert.Nil(t, fakebrokerinformer.Get(ctx).Informer().GetIndexer().Add(newTestBroker(testBrokerName, "Kafka")))
+	assert.Nil(t, fakebrokerinformer.Get(ctx).Informer().GetIndexer().Add(newTestBroker("other-broker", eventing.MTChannelBrokerClassValue)))
+
+	topicNameMapper := NewTopicNameMapper(ctx)
+
+	tests := []struct {
+		name     string
+		broker   string
+		nil      bool
+		expected string
+		err      bool
+	}{
+		{name: "kafka broker", broker: testBrokerName, expected: "knative-broker-test-namespace-test-broker"},
+		{name: "other broker class", broker: "other-broker", err: true},
+		{name: "unknown broker", broker: "unknown-broker", err: true},
+		{name: "nil trigger", nil: true, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trigger := newTestTrigger()
+			trigger.Spec.Broker = test.broker
+			if test.nil {
+				trigger = nil
+			}
+			actual, err := topicNameMapper(trigger)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.err, err != nil)
+		})
+	}
+}
+
+// Test The Trigger Filtering & Listing Functionality
+func TestTriggersOfKafkaBrokers(t *testing.T) {
+
+	brokerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, brokerIndexer.Add(newTestBroker(testBrokerName, "Kafka")))
+	assert.Nil(t, brokerIndexer.Add(newTestBroker("other-broker", eventing.MTChannelBrokerClassValue)))
+	filter := FilterKafkaBrokerTriggers(eventinglisters.NewBrokerLister(brokerIndexer))
+
+	kafkaTrigger := newTestTrigger()
+	otherTrigger := newTestTrigger()
+	otherTrigger.Name = "other-trigger"
+	otherTrigger.Spec.Broker = "other-broker"
+	unknownTrigger := newTestTrigger()
+	unknownTrigger.Name = "unknown-trigger"
+	unknownTrigger.Spec.Broker = "unknown-broker"
+
+	assert.True(t, filter(kafkaTrigger))
+	assert.False(t, filter(otherTrigger))
+	assert.False(t, filter(unknownTrigger))
+	assert.False(t, filter(newTestBroker(testBrokerName, "Kafka")))
+
+	triggerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, trigger := range []*eventingv1.Trigger{kafkaTrigger, otherTrigger, unknownTrigger} {
+		assert.Nil(t, triggerIndexer.Add(trigger))
+	}
+	triggers, err := TriggersOfBroker(eventinglisters.NewTriggerLister(triggerIndexer), newTestBroker(testBrokerName, "Kafka"))
+	assert.Nil(t, err)
+	assert.Equal(t, []*eventingv1.Trigger{kafkaTrigger}, triggers)
+}
+
+func newTestBroker(name string, class string) *eventingv1.Broker {
+	broker := &eventingv1.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name}}
+	if class != "" {
+		broker.Annotations = map[string]string{eventing.BrokerClassKey: class}
+	}
+	return broker
+}
+
+func newTestTrigger() *eventingv1.Trigger {
+	return &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testTriggerName, UID: testTriggerUID},
+		Spec:       eventingv1.TriggerSpec{Broker: testBrokerName},
+	}
+}
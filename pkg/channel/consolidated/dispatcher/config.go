@@ -16,11 +16,18 @@ limitations under the License.
 
 package dispatcher
 
+import "knative.dev/eventing-kafka/pkg/common/kafka/produce"
+
 type ChannelConfig struct {
 	Namespace     string
 	Name          string
 	HostName      string
+	Topic         string // Optional existing Topic, otherwise the Topic is derived from Namespace & Name
 	Subscriptions []Subscription
+
+	// ProduceOptions are the claim-check, encryption and durability settings with which events are
+	// produced to the topic.
+	ProduceOptions produce.Options
 }
 
 func (cc ChannelConfig) SubscriptionsUIDs() []string {
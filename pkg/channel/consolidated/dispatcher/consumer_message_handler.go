@@ -24,11 +24,17 @@ import (
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/eventfilter"
 	"knative.dev/eventing/pkg/kncloudevents"
 
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
@@ -40,6 +46,11 @@ type consumerMessageHandler struct {
 	consumerGroup     string
 	reporter          eventingchannels.StatsReporter
 	channelNs         string
+	claimCheckStore   claimcheck.Store
+	keyProvider       encryption.KeyProvider
+	channelName       string
+	filter            eventfilter.Filter
+	encryptionSecret  func() string // resolves the encryption secret of the channel's current config
 }
 
 var _ consumer.KafkaConsumerHandler = (*consumerMessageHandler)(nil)
@@ -61,11 +72,46 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 			)
 		}
 	}()
+
+	// Measure the latency from the ingress of the event to its consumption, and track its delivery to the subscriber
+	latencyArgs := latency.ReportArgs{
+		Namespace:     c.channelNs,
+		Name:          c.channelName,
+		ResourceGroup: consumer.KafkaChannelResourceGroup,
+		Subscriber:    c.sub.UID,
+	}
+	latency.ReportIngressLatency(ctx, latencyArgs, consumerMessage)
+	delivery := latency.NewDelivery(c.sub.Subscriber)
+
+	if err := claimcheck.Rehydrate(ctx, c.claimCheckStore, eventingchannels.ChannelReference{Namespace: c.channelNs, Name: c.channelName}, consumerMessage); err != nil {
+		return false, err
+	}
+	if err := encryption.Decrypt(ctx, c.keyProvider, c.channelNs, c.encryptionSecret(), consumerMessage); err != nil {
+		return false, err
+	}
+
 	message := protocolkafka.NewMessageFromConsumerMessage(consumerMessage)
 	if message.ReadEncoding() == binding.EncodingUnknown {
 		return false, errors.New("received a message with unknown encoding")
 	}
 
+	// Skip (and commit) the events which don't pass the filter of the subscription, if any
+	if c.filter != nil {
+		event, err := binding.ToEvent(ctx, message)
+		if err != nil {
+			return false, err
+		}
+		result := c.filter.Filter(ctx, *event)
+		filter.ReportFilterEventCount(ctx, types.NamespacedName{Namespace: c.channelNs, Name: c.channelName}, c.sub.UID, result)
+		if result == eventfilter.FailFilter {
+			c.logger.Debug("Event filtered out by the subscription",
+				zap.String("id", event.ID()),
+				zap.String("subscription", c.sub.String()),
+			)
+			return true, nil
+		}
+	}
+
 	c.logger.Debug("Going to dispatch the message",
 		zap.String("topic", consumerMessage.Topic),
 		zap.String("subscription", c.sub.String()),
@@ -73,10 +119,11 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 
 	// Convert ConsumerMessage.Headers Into HTTP Header Struct For Dispatching (Passing-Through of "Additional Headers")
 	// Using Sarama RecordHeaders instead of CloudEvent Message.Headers to support multi-value HTTP Headers without
-	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message.
-	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers))
+	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message, and the
+	// internal ingress time header.
+	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(latency.FilterIngressTimeHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers)))
 
-	ctx, span := tracing.StartTraceFromMessage(c.logger, ctx, message, "kafkachannel-"+consumerMessage.Topic)
+	ctx, span := tracing.StartConsumerSpan(c.logger, ctx, consumerMessage, c.consumerGroup, "kafkachannel-"+consumerMessage.Topic)
 	defer span.End()
 
 	te := kncloudevents.TypeExtractorTransformer("")
@@ -88,9 +135,10 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 		c.sub.Subscriber,
 		c.sub.Reply,
 		c.sub.DeadLetter,
-		c.sub.RetryConfig,
+		delivery.RetryConfig(c.sub.RetryConfig),
 		&te,
 	)
+	latency.ReportDelivery(ctx, latencyArgs, delivery)
 
 	args := eventingchannels.ReportArgs{
 		Ns:        c.channelNs,
@@ -26,10 +26,10 @@ import (
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/google/uuid"
-	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing-kafka/pkg/common/config"
@@ -39,16 +39,26 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/durability"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
 type TopicFunc func(separator, namespace, name string) string
 
 type KafkaDispatcherArgs struct {
-	Brokers   []string
-	Config    *config.EventingKafkaConfig
-	TopicFunc TopicFunc
+	Brokers    []string
+	Config     *config.EventingKafkaConfig
+	TopicFunc  TopicFunc
+	KubeClient kubernetes.Interface // Optional client for reading the encryption secrets of channels
 }
 
 type KafkaDispatcher struct {
@@ -60,6 +70,14 @@ type KafkaDispatcher struct {
 	// map[string]eventingchannels.ChannelReference
 	hostToChannelMap  sync.Map
 	kafkaSyncProducer sarama.SyncProducer
+	producerPool      *durability.Pool
+	sizeLimiter       *payload.Limiter
+	// map[types.NamespacedName]string of channels using an existing (spec.topic) topic
+	channelTopics sync.Map
+	// map[types.NamespacedName]produce.Options of the claim-check, encryption & durability settings of channels
+	produceOptions  sync.Map
+	claimCheckStore claimcheck.Store
+	keyProvider     encryption.KeyProvider
 
 	// Dispatcher data structures
 	// consumerUpdateLock must be used to update all the below maps
@@ -69,10 +87,20 @@ type KafkaDispatcher struct {
 	subscriptions        map[types.UID]Subscription
 	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
 
+	// Kafka configuration with which the debug API reads the channels' topics
+	brokers      []string
+	saramaConfig *sarama.Config
+
+	// Optional collector of the lag of the subscriptions' consumer groups
+	lagCollector *consumer.LagCollector
+
 	topicFunc TopicFunc
 	logger    *zap.SugaredLogger
 }
 
+// Verify The KafkaDispatcher Implements The Debug API Dispatcher Interface
+var _ debugapi.Dispatcher = &KafkaDispatcher{}
+
 // NewDispatcher creates a new dispatcher struct. enqueue argument is a function that is used to
 // requeue a KafkaChannel instance via the reconciler which is used when creating the consumer.
 func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(ref types.NamespacedName)) (*KafkaDispatcher, error) {
@@ -82,6 +110,16 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(
 		return nil, fmt.Errorf("unable to create kafka producer against Kafka bootstrap servers %v : %v", args.Brokers, err)
 	}
 
+	claimCheckStore, err := claimcheck.NewStore(args.Config.Channel.ClaimCheck)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create claim-check store: %v", err)
+	}
+
+	var keyProvider encryption.KeyProvider
+	if args.KubeClient != nil {
+		keyProvider = encryption.NewSecretKeyProvider(args.KubeClient)
+	}
+
 	dispatcher := &KafkaDispatcher{
 		dispatcher:           eventingchannels.NewMessageDispatcher(logging.FromContext(ctx).Desugar()),
 		kafkaConsumerFactory: consumer.NewConsumerGroupFactory(args.Brokers, args.Config.Sarama.Config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue),
@@ -89,6 +127,13 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(
 		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		kafkaSyncProducer:    producer,
+		producerPool:         durability.NewPool(logging.FromContext(ctx).Desugar(), args.Brokers, args.Config.Sarama.Config, producer, sarama.NewSyncProducer),
+		sizeLimiter:          payload.NewLimiter(logging.FromContext(ctx).Desugar(), args.Brokers, args.Config.Sarama.Config),
+		claimCheckStore:      claimCheckStore,
+		keyProvider:          keyProvider,
+		brokers:              args.Brokers,
+		saramaConfig:         args.Config.Sarama.Config,
+		lagCollector:         consumer.NewLagCollector(logging.FromContext(ctx).Desugar(), consumer.KafkaChannelResourceGroup, args.Brokers, args.Config.Sarama.Config),
 		logger:               logging.FromContext(ctx),
 		topicFunc:            args.TopicFunc,
 	}
@@ -106,19 +151,60 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(
 	receiverFunc, err := eventingchannels.NewMessageReceiver(
 		func(ctx context.Context, channel eventingchannels.ChannelReference, message binding.Message, transformers []binding.Transformer, httpHeader nethttp.Header) error {
 			kafkaProducerMessage := sarama.ProducerMessage{
-				Topic: dispatcher.topicFunc(utils.KafkaChannelSeparator, channel.Namespace, channel.Name),
+				Topic: dispatcher.topicName(channel.Namespace, channel.Name),
 			}
 
 			dispatcher.logger.Debugw("Received a new message from MessageReceiver, dispatching to Kafka", zap.Any("channel", channel))
+
+			// Encrypted messages are written in binary mode so that their attributes remain readable headers
+			options := dispatcher.channelProduceOptions(channel.Namespace, channel.Name)
+			if options.EncryptionSecret != "" {
+				binaryMessage, err := encryption.ToBinaryMessage(ctx, message, transformers...)
+				if err != nil {
+					return err
+				}
+				message, transformers = binaryMessage, nil
+			}
+
 			err := protocolkafka.WriteProducerMessage(ctx, message, &kafkaProducerMessage, transformers...)
 			if err != nil {
 				return err
 			}
 
-			kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, tracing.SerializeTrace(trace.FromContext(ctx).SpanContext())...)
+			// Start a producer span whose context is propagated to the dispatchers by the message's headers
+			ctx, span := tracing.StartProducerSpan(ctx, &kafkaProducerMessage)
+			defer span.End()
 			kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, tracing.ConvertHttpHeaderToRecordHeaders(httpHeader)...)
 
-			partition, offset, err := dispatcher.kafkaSyncProducer.SendMessage(&kafkaProducerMessage)
+			// Stamp the ingress time from which the end-to-end latency of the event is measured when dispatching it
+			latency.StampIngressTime(&kafkaProducerMessage)
+
+			// Encrypt the data of channels which opted in to encryption before any offloading
+			if err := encryption.Encrypt(ctx, dispatcher.keyProvider, channel.Namespace, options.EncryptionSecret, &kafkaProducerMessage); err != nil {
+				dispatcher.logger.Warnw("message not encrypted", zap.Error(err))
+				return err
+			}
+
+			// Offload large payloads of channels which opted in to claim-check before checking the size
+			if err := claimcheck.Offload(ctx, dispatcher.claimCheckStore, channel, options.ClaimCheckThreshold, &kafkaProducerMessage); err != nil {
+				dispatcher.logger.Warnw("message payload not offloaded", zap.Error(err))
+				return err
+			}
+
+			// Reject messages which the channel's topic would not accept (answered with a 413 by the size limit handler)
+			if err := dispatcher.sizeLimiter.Check(ctx, channel, &kafkaProducerMessage); err != nil {
+				return err
+			}
+
+			// Produce with the producer of the channel's durability class
+			producer, err := dispatcher.producerPool.Producer(kafkaProducerMessage.Topic, options.DurabilityClass)
+			if err != nil {
+				dispatcher.logger.Warnw("message not sent", zap.Error(err))
+				return err
+			}
+
+			partition, offset, err := producer.SendMessage(&kafkaProducerMessage)
+			tracing.RecordProducerResult(span, &kafkaProducerMessage, partition, offset, err)
 
 			if err == nil {
 				dispatcher.logger.Debugw("message sent", zap.Int32("partition", partition), zap.Int64("offset", offset))
@@ -145,7 +231,32 @@ func (d *KafkaDispatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("message receiver is not set")
 	}
 
-	return d.receiver.Start(ctx)
+	// Collect The Lag Of The Subscriptions' Consumer Groups While Receiving
+	d.lagCollector.Start(consumer.DefaultLagInterval)
+	defer d.lagCollector.Stop()
+
+	return payload.StartReceiver(ctx, d.receiver)
+}
+
+// StartDebugApi starts the debug API server on the specified port (the default port if zero), with which the
+// events of the dispatcher's channels are browsed and replayed, until the context is done.
+func (d *KafkaDispatcher) StartDebugApi(ctx context.Context, port int, authorizer debugapi.Authorizer) error {
+	debugServer := debugapi.NewServer(debugapi.ServerConfig{
+		Logger:          d.logger.Desugar(),
+		HttpPort:        debugapi.HttpPort(port),
+		Dispatcher:      d,
+		Authorizer:      authorizer,
+		ClaimCheckStore: d.claimCheckStore,
+		KeyProvider:     d.keyProvider,
+	})
+	if err := debugServer.Start(); err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		debugServer.Stop()
+	}()
+	return nil
 }
 
 // UpdateError is the error returned from the ReconcileConsumers method, with the details of which
@@ -167,6 +278,9 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 		Name:      config.Name,
 	}
 
+	d.storeChannelTopic(channelNamespacedName, config.Topic)
+	d.storeProduceOptions(channelNamespacedName, config.ProduceOptions)
+
 	// Aux data structures to reconcile
 	toAddSubs := make(map[types.UID]Subscription)
 	toRemoveSubs := sets.NewString()
@@ -197,6 +311,12 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 	for _, subSpec := range config.Subscriptions {
 		if thisChannelToAddSubs.Has(string(subSpec.UID)) {
 			toAddSubs[subSpec.UID] = subSpec
+		} else if existingSub, ok := d.subscriptions[subSpec.UID]; ok && existingSub.Filter != subSpec.Filter {
+			// The filter of an existing sub changed, re-subscribe it (resuming from its committed offsets)
+			if err := d.unsubscribe(channelNamespacedName, existingSub); err != nil {
+				d.logger.Warnw("Error while unsubscribing", zap.Error(err))
+			}
+			toAddSubs[subSpec.UID] = subSpec
 		}
 	}
 
@@ -226,6 +346,9 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 
 // RegisterChannelHost adds a new channel to the host-channel mapping.
 func (d *KafkaDispatcher) RegisterChannelHost(channelConfig *ChannelConfig) error {
+	channelRef := types.NamespacedName{Namespace: channelConfig.Namespace, Name: channelConfig.Name}
+	d.storeChannelTopic(channelRef, channelConfig.Topic)
+	d.storeProduceOptions(channelRef, channelConfig.ProduceOptions)
 	old, ok := d.hostToChannelMap.LoadOrStore(channelConfig.HostName, eventingchannels.ChannelReference{
 		Name:      channelConfig.Name,
 		Namespace: channelConfig.Namespace,
@@ -255,6 +378,9 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 
 	// Remove from the hostToChannel map the mapping with this channel
 	d.hostToChannelMap.Delete(hostname)
+	d.channelTopics.Delete(channelRef)
+	d.produceOptions.Delete(channelRef)
+	d.sizeLimiter.Forget(eventingchannels.ChannelReference{Namespace: namespace, Name: name})
 
 	// Remove all subs
 	d.consumerUpdateLock.Lock()
@@ -274,13 +400,97 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 	return nil
 }
 
+// storeChannelTopic records the existing topic of a channel, if any, for use by topicName.
+func (d *KafkaDispatcher) storeChannelTopic(channelRef types.NamespacedName, topic string) {
+	if topic == "" {
+		d.channelTopics.Delete(channelRef)
+	} else {
+		d.channelTopics.Store(channelRef, topic)
+	}
+}
+
+// storeProduceOptions records the produce options of a channel for use by channelProduceOptions.
+func (d *KafkaDispatcher) storeProduceOptions(channelRef types.NamespacedName, options produce.Options) {
+	d.produceOptions.Store(channelRef, options)
+}
+
+// channelProduceOptions returns the produce options of the specified channel (the zero options if it is unknown).
+func (d *KafkaDispatcher) channelProduceOptions(namespace, name string) produce.Options {
+	if options, ok := d.produceOptions.Load(types.NamespacedName{Namespace: namespace, Name: name}); ok {
+		return options.(produce.Options)
+	}
+	return produce.Options{}
+}
+
+// topicName returns the Kafka topic of the specified channel, preferring an existing topic
+// over the one derived by the topicFunc.
+func (d *KafkaDispatcher) topicName(namespace, name string) string {
+	if topic, ok := d.channelTopics.Load(types.NamespacedName{Namespace: namespace, Name: name}); ok {
+		return topic.(string)
+	}
+	return d.topicFunc(utils.KafkaChannelSeparator, namespace, name)
+}
+
+// consumerGroupId returns the ID of the consumer group of a channel's subscription.
+func consumerGroupId(channelRef types.NamespacedName, uid types.UID) string {
+	return fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(uid))
+}
+
+// DebugChannel implements the debugapi.Dispatcher interface by describing a channel served by the dispatcher.
+func (d *KafkaDispatcher) DebugChannel(ref types.NamespacedName) (*debugapi.Channel, bool) {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	kafkaSubscription, ok := d.channelSubscriptions[ref]
+	if !ok && !d.hasChannelHost(ref) {
+		return nil, false
+	}
+
+	debugChannel := &debugapi.Channel{Namespace: ref.Namespace, Name: ref.Name, Topic: d.topicName(ref.Namespace, ref.Name), EncryptionSecret: d.channelProduceOptions(ref.Namespace, ref.Name).EncryptionSecret}
+	if kafkaSubscription != nil {
+		for _, uid := range kafkaSubscription.subs.List() {
+			sub, ok := d.subscriptions[types.UID(uid)]
+			if !ok {
+				continue
+			}
+			subFilter, err := filter.ParseFilter(sub.Filter)
+			if err != nil {
+				continue // Not subscribed, as subscribe rejects invalid filters
+			}
+			debugChannel.Subscribers = append(debugChannel.Subscribers, debugapi.Subscriber{
+				UID:     sub.UID,
+				URI:     sub.Subscriber,
+				GroupId: consumerGroupId(ref, sub.UID),
+				Filter:  subFilter,
+			})
+		}
+	}
+	return debugChannel, true
+}
+
+// DebugKafkaConfig implements the debugapi.Dispatcher interface by returning the dispatcher's Kafka configuration.
+func (d *KafkaDispatcher) DebugKafkaConfig() ([]string, *sarama.Config) {
+	return d.brokers, d.saramaConfig
+}
+
+// hasChannelHost returns whether the specified channel has been registered by RegisterChannelHost.
+func (d *KafkaDispatcher) hasChannelHost(channelRef types.NamespacedName) bool {
+	found := false
+	d.hostToChannelMap.Range(func(_, value interface{}) bool {
+		ref := value.(eventingchannels.ChannelReference)
+		found = ref.Namespace == channelRef.Namespace && ref.Name == channelRef.Name
+		return !found
+	})
+	return found
+}
+
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // subscribe must be called under updateLock.
 func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.NamespacedName, sub Subscription) error {
 	d.logger.Infow("Subscribing to Kafka Channel", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
 
-	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
-	groupID := fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(sub.UID))
+	topicName := d.topicName(channelRef.Namespace, channelRef.Name)
+	groupID := consumerGroupId(channelRef, sub.UID)
 
 	// Get or create the channel kafka subscription
 	kafkaSubscription, ok := d.channelSubscriptions[channelRef]
@@ -289,6 +499,12 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		d.channelSubscriptions[channelRef] = kafkaSubscription
 	}
 
+	subFilter, err := filter.ParseFilter(sub.Filter)
+	if err != nil {
+		d.logger.Infow("Invalid subscription filter", zap.Any("subscription", sub.UID), zap.Error(err))
+		return fmt.Errorf("invalid filter annotation %s: %w", constants.SubscriptionFilterAnnotationKey, err)
+	}
+
 	handler := &consumerMessageHandler{
 		d.logger,
 		sub,
@@ -297,6 +513,11 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		groupID,
 		d.reporter,
 		channelRef.Namespace,
+		d.claimCheckStore,
+		d.keyProvider,
+		channelRef.Name,
+		subFilter,
+		func() string { return d.channelProduceOptions(channelRef.Namespace, channelRef.Name).EncryptionSecret },
 	}
 	d.logger.Debugw("Starting consumer group", zap.Any("channelRef", channelRef),
 		zap.Any("subscription", sub.UID), zap.String("topic", topicName), zap.String("consumer group", groupID))
@@ -316,6 +537,8 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		}
 	}()
 
+	d.lagCollector.Register(groupID, consumer.LagTarget{Ref: channelRef, Subscriber: sub.UID, Topics: []string{topicName}})
+
 	// Update the data structures that holds the reconciliation data
 	kafkaSubscription.subs.Insert(string(sub.UID))
 	d.subscriptions[sub.UID] = sub
@@ -344,7 +567,8 @@ func (d *KafkaDispatcher) unsubscribe(channelRef types.NamespacedName, sub Subsc
 		delete(d.channelSubscriptions, channelRef)
 	}
 
-	// Delete the consumer group
+	// Delete the consumer group (and its lag)
+	d.lagCollector.Unregister(consumerGroupId(channelRef, sub.UID))
 	if consumerGroup, ok := d.subsConsumerGroups[sub.UID]; ok {
 		delete(d.subsConsumerGroups, sub.UID)
 		d.logger.Debugw("Closing cached consumerGroup group", zap.Any("consumer group", consumerGroup))
@@ -40,8 +40,11 @@ import (
 	klogtesting "knative.dev/pkg/logging/testing"
 	_ "knative.dev/pkg/system/testing"
 
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
 )
 
 // ----- Mocks
@@ -411,6 +414,166 @@ func TestDispatcher_MultipleChannelsInParallel(t *testing.T) {
 	require.Empty(t, d.subsConsumerGroups)
 }
 
+func TestKafkaDispatcher_ExistingTopic(t *testing.T) {
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		Topic:     "existing-topic",
+	}
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	require.Equal(t, "knative-messaging-kafka.default.test-channel", d.topicName(channelConfig.Namespace, channelConfig.Name))
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	require.Equal(t, "existing-topic", d.topicName(channelConfig.Namespace, channelConfig.Name))
+	require.NoError(t, d.CleanupChannel(channelConfig.Name, channelConfig.Namespace, channelConfig.HostName))
+	require.Equal(t, "knative-messaging-kafka.default.test-channel", d.topicName(channelConfig.Namespace, channelConfig.Name))
+}
+
+func TestKafkaDispatcher_ProduceOptions(t *testing.T) {
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		ProduceOptions: produce.Options{
+			ClaimCheckThreshold: 1024,
+			EncryptionSecret:    "test-channel-keys",
+			DurabilityClass:     v1beta1.DurabilityClassDurable,
+		},
+	}
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	require.Equal(t, produce.Options{}, d.channelProduceOptions(channelConfig.Namespace, channelConfig.Name))
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	require.Equal(t, channelConfig.ProduceOptions, d.channelProduceOptions(channelConfig.Namespace, channelConfig.Name))
+	channelConfig.ProduceOptions = produce.Options{}
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, produce.Options{}, d.channelProduceOptions(channelConfig.Namespace, channelConfig.Name))
+	channelConfig.ProduceOptions = produce.Options{ClaimCheckThreshold: 2048, EncryptionSecret: "rotated-channel-keys", DurabilityClass: v1beta1.DurabilityClassFast}
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, channelConfig.ProduceOptions, d.channelProduceOptions(channelConfig.Namespace, channelConfig.Name))
+	require.NoError(t, d.CleanupChannel(channelConfig.Name, channelConfig.Namespace, channelConfig.HostName))
+	require.Equal(t, produce.Options{}, d.channelProduceOptions(channelConfig.Namespace, channelConfig.Name))
+}
+
+func TestKafkaDispatcher_SubscriptionFilter(t *testing.T) {
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		Subscriptions: []Subscription{{
+			UID:    "filtered",
+			Filter: `{"exact":{"type":"a"}}`,
+		}, {
+			UID: "unfiltered",
+		}},
+	}
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, `{"exact":{"type":"a"}}`, d.subscriptions["filtered"].Filter)
+	require.Empty(t, d.subscriptions["unfiltered"].Filter)
+
+	// A changed filter re-subscribes the sub
+	channelConfig.Subscriptions[0].Filter = `{"cesql":"type = 'b'"}`
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, `{"cesql":"type = 'b'"}`, d.subscriptions["filtered"].Filter)
+	require.Contains(t, d.subsConsumerGroups, types.UID("filtered"))
+	require.Contains(t, d.subsConsumerGroups, types.UID("unfiltered"))
+
+	// An invalid filter fails the sub
+	channelConfig.Subscriptions[0].Filter = `{"cesql":"type ="}`
+	err := d.ReconcileConsumers(context.TODO(), channelConfig)
+	require.Error(t, err)
+	require.Contains(t, err.(UpdateError), types.UID("filtered"))
+	require.NotContains(t, d.subsConsumerGroups, types.UID("filtered"))
+	require.Contains(t, d.subsConsumerGroups, types.UID("unfiltered"))
+}
+
+func TestKafkaDispatcher_DebugChannel(t *testing.T) {
+	subscriberURI, _ := url.Parse("http://test-subscriber")
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		Subscriptions: []Subscription{{
+			UID:          "filtered",
+			Subscription: fanout.Subscription{Subscriber: subscriberURI},
+			Filter:       `{"exact":{"type":"a"}}`,
+		}, {
+			UID: "unfiltered",
+		}},
+	}
+	channelRef := types.NamespacedName{Namespace: "default", Name: "test-channel"}
+	saramaConfig := sarama.NewConfig()
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		brokers:              []string{"test-broker"},
+		saramaConfig:         saramaConfig,
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	// Unknown channels are not described
+	_, ok := d.DebugChannel(channelRef)
+	require.False(t, ok)
+
+	// A registered channel is described without subscribers
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	debugChannel, ok := d.DebugChannel(channelRef)
+	require.True(t, ok)
+	require.Equal(t, &debugapi.Channel{Namespace: "default", Name: "test-channel", Topic: "knative-messaging-kafka.default.test-channel"}, debugChannel)
+
+	// A channel's subscribers are described with their consumer groups and filters
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	debugChannel, ok = d.DebugChannel(channelRef)
+	require.True(t, ok)
+	require.Len(t, debugChannel.Subscribers, 2)
+	require.Equal(t, types.UID("filtered"), debugChannel.Subscribers[0].UID)
+	require.Equal(t, subscriberURI, debugChannel.Subscribers[0].URI)
+	require.Equal(t, "kafka.default.test-channel.filtered", debugChannel.Subscribers[0].GroupId)
+	require.NotNil(t, debugChannel.Subscribers[0].Filter)
+	require.Equal(t, types.UID("unfiltered"), debugChannel.Subscribers[1].UID)
+	require.Nil(t, debugChannel.Subscribers[1].Filter)
+
+	// A cleaned up channel is no longer described
+	require.NoError(t, d.CleanupChannel("test-channel", "default", "a.b.c.d"))
+	_, ok = d.DebugChannel(channelRef)
+	require.False(t, ok)
+
+	brokers, config := d.DebugKafkaConfig()
+	require.Equal(t, []string{"test-broker"}, brokers)
+	require.Same(t, saramaConfig, config)
+}
+
 func TestKafkaDispatcher_CleanupChannel(t *testing.T) {
 	subscriber, _ := url.Parse("http://test/subscriber")
 
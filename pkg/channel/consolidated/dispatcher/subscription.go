@@ -26,6 +26,9 @@ import (
 type Subscription struct {
 	UID types.UID
 	fanout.Subscription
+
+	// Filter is the JSON serialized SubscriptionsAPIFilter of the Subscription, or "" to dispatch all events.
+	Filter string
 }
 
 func (sub Subscription) String() string {
@@ -44,5 +47,9 @@ func (sub Subscription) String() string {
 		s.WriteString("DeadLetter: " + sub.DeadLetter.String())
 		s.WriteRune('\n')
 	}
+	if sub.Filter != "" {
+		s.WriteString("Filter: " + sub.Filter)
+		s.WriteRune('\n')
+	}
 	return s.String()
 }
@@ -49,6 +49,7 @@ import (
 
 	consolidatedmessaging "knative.dev/eventing-kafka/pkg/channel/consolidated/apis/messaging"
 	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafka/topic"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller/resources"
@@ -164,6 +165,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 		logger.Errorw("Invalid kafka channel", zap.String("channel", kc.Name), zap.Error(err))
 		return err
 	}
+	kc.Status.DurabilityClass = kc.Spec.EffectiveDurabilityClass()
 	if r.kafkaConfig == nil {
 		if r.kafkaConfigError == nil {
 			r.kafkaConfigError = fmt.Errorf("the config map '%s' does not exist", constants.SettingsConfigMapName)
@@ -359,6 +361,7 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope string, disp
 		DeploymentLabels:      r.kafkaConfig.EventingKafka.Channel.Dispatcher.DeploymentLabels,
 		PodAnnotations:        r.kafkaConfig.EventingKafka.Channel.Dispatcher.PodAnnotations,
 		PodLabels:             r.kafkaConfig.EventingKafka.Channel.Dispatcher.PodLabels,
+		ClaimCheck:            r.kafkaConfig.EventingKafka.Channel.ClaimCheck,
 	}
 
 	want := resources.NewDispatcherDeploymentBuilder().WithArgs(&args).Build()
@@ -577,7 +580,18 @@ func (r *Reconciler) createClusterAdmin() (sarama.ClusterAdmin, error) {
 func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
-	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
+	topicName := utils.ChannelTopicName(channel)
+
+	// Existing Topics are only validated, never created
+	if channel.Spec.Topic != "" {
+		logger.Infow("Validating existing topic on Kafka cluster", zap.String("topic", topicName))
+		err := topic.ValidateExistingTopic(kafkaClusterAdmin, topicName, channel.Spec.NumPartitions, channel.Spec.ReplicationFactor)
+		if err != nil {
+			logger.Errorw("Error validating existing topic", zap.String("topic", topicName), zap.Error(err))
+		}
+		return err
+	}
+
 	logger.Infow("Creating topic on Kafka cluster", zap.String("topic", topicName),
 		zap.Int32("partitions", channel.Spec.NumPartitions), zap.Int16("replication", channel.Spec.ReplicationFactor))
 
@@ -614,7 +628,7 @@ func (r *Reconciler) reconcileInitialOffset(ctx context.Context, channel *v1beta
 		return nil
 	}
 
-	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
+	topicName := utils.ChannelTopicName(channel)
 	groupID := fmt.Sprintf("kafka.%s.%s.%s", channel.Namespace, channel.Name, string(sub.UID))
 	_, err := offset.InitOffsets(ctx, kafkaClient, kafkaClusterAdmin, []string{topicName}, groupID)
 	if err != nil {
@@ -627,7 +641,7 @@ func (r *Reconciler) reconcileInitialOffset(ctx context.Context, channel *v1beta
 func (r *Reconciler) deleteTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
-	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
+	topicName := utils.ChannelTopicName(channel)
 	logger.Infow("Deleting topic on Kafka Cluster", zap.String("topic", topicName))
 	err := kafkaClusterAdmin.DeleteTopic(topicName)
 	if err == sarama.ErrUnknownTopicOrPartition {
@@ -690,6 +704,11 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, kc *v1beta1.KafkaChannel)
 	}
 	defer kafkaClusterAdmin.Close()
 
+	if kc.Spec.EffectiveDeletionPolicy() == v1beta1.DeletionPolicyRetain {
+		logger.Infow("retaining Kafka channel topic per deletion policy", zap.String("channel", channel))
+		return newReconciledNormal(kc.Namespace, kc.Name) //ok to remove finalizer
+	}
+
 	logger.Debugw("got client, about to delete topic")
 	if err := r.deleteTopic(ctx, kc, kafkaClusterAdmin); err != nil {
 		logger.Errorw("error deleting Kafka channel topic", zap.String("channel", channel), zap.Error(err))
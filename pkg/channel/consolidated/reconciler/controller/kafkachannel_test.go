@@ -1108,3 +1108,73 @@ func makePatch(namespace, name, patch string) clientgotesting.PatchActionImpl {
 		Patch: []byte(patch),
 	}
 }
+
+func TestReconcileExistingTopic(t *testing.T) {
+	testCases := map[string]struct {
+		describeErr error
+		wantErr     bool
+	}{
+		"existing topic valid":   {},
+		"existing topic invalid": {describeErr: fmt.Errorf("test describe error"), wantErr: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kc := reconcilertesting.NewKafkaChannel(kcName, testNS)
+			kc.Spec.Topic = "existing-topic"
+			kc.Spec.NumPartitions = 1
+			kc.Spec.ReplicationFactor = 1
+			clusterAdmin := &commontesting.MockClusterAdmin{
+				MockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+					t.Errorf("unexpected creation of existing topic %s", topic)
+					return nil
+				},
+				MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					if len(topics) != 1 || topics[0] != "existing-topic" {
+						t.Errorf("unexpected topics described: %v", topics)
+					}
+					partitions := []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1}}}
+					return []*sarama.TopicMetadata{{Name: "existing-topic", Partitions: partitions}}, tc.describeErr
+				},
+			}
+			r := &Reconciler{}
+			err := r.reconcileTopic(logging.WithLogger(context.TODO(), zap.NewNop().Sugar()), kc, clusterAdmin)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("reconcileTopic() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFinalizeKindDeletionPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		topic          string
+		deletionPolicy v1beta1.DeletionPolicy
+		wantDelete     bool
+	}{
+		"default deletes derived topic":  {wantDelete: true},
+		"default retains existing topic": {topic: "existing-topic"},
+		"retain derived topic":           {deletionPolicy: v1beta1.DeletionPolicyRetain},
+		"delete existing topic":          {topic: "existing-topic", deletionPolicy: v1beta1.DeletionPolicyDelete, wantDelete: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kc := reconcilertesting.NewKafkaChannel(kcName, testNS)
+			kc.Spec.Topic = tc.topic
+			kc.Spec.DeletionPolicy = tc.deletionPolicy
+			deleted := false
+			r := &Reconciler{
+				kafkaConfig: &KafkaConfig{Brokers: []string{brokerName}},
+				kafkaClusterAdmin: &commontesting.MockClusterAdmin{
+					MockDeleteTopicFunc: func(topic string) error {
+						deleted = true
+						return nil
+					},
+				},
+			}
+			_ = r.FinalizeKind(logging.WithLogger(context.TODO(), zap.NewNop().Sugar()), kc)
+			if deleted != tc.wantDelete {
+				t.Errorf("FinalizeKind() deleted topic = %v, want %v", deleted, tc.wantDelete)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
 )
 
 const (
@@ -56,6 +57,7 @@ type DispatcherDeploymentArgs struct {
 	DeploymentLabels      map[string]string
 	PodAnnotations        map[string]string
 	PodLabels             map[string]string
+	ClaimCheck            commonconfig.EKClaimCheckConfig
 }
 
 // NewDispatcherDeploymentBuilder returns a builder which builds from scratch a dispatcher deployment.
@@ -100,12 +102,45 @@ func (b *DispatcherDeploymentBuilder) Build() *v1.Deployment {
 			if container.Env == nil {
 				container.Env = makeEnv(b.args)
 			}
+			applyClaimCheckContainer(container, b.args.ClaimCheck)
 		}
 	}
+	applyClaimCheckPodSpec(&b.deployment.Spec.Template.Spec, b.args.ClaimCheck)
 
 	return b.deployment
 }
 
+// applyClaimCheckContainer replaces any claim-check store credentials and volume mounts of the container with
+// those of the specified config, so that config changes are reconciled into existing deployments.
+func applyClaimCheckContainer(container *corev1.Container, config commonconfig.EKClaimCheckConfig) {
+	env := make([]corev1.EnvVar, 0, len(container.Env))
+	for _, envVar := range container.Env {
+		if envVar.Name != claimcheck.AccessKeyIdEnvVarKey && envVar.Name != claimcheck.SecretAccessKeyEnvVarKey {
+			env = append(env, envVar)
+		}
+	}
+	container.Env = append(env, claimcheck.EnvVars(config)...)
+
+	volumeMounts := make([]corev1.VolumeMount, 0, len(container.VolumeMounts))
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.Name != claimcheck.VolumeName {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+	container.VolumeMounts = append(volumeMounts, claimcheck.VolumeMounts(config)...)
+}
+
+// applyClaimCheckPodSpec replaces any claim-check store volume of the pod with that of the specified config.
+func applyClaimCheckPodSpec(podSpec *corev1.PodSpec, config commonconfig.EKClaimCheckConfig) {
+	volumes := make([]corev1.Volume, 0, len(podSpec.Volumes))
+	for _, volume := range podSpec.Volumes {
+		if volume.Name != claimcheck.VolumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+	podSpec.Volumes = append(volumes, claimcheck.Volumes(config)...)
+}
+
 func dispatcherTemplate() *v1.Deployment {
 
 	return &v1.Deployment{
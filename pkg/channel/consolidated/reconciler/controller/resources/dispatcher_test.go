@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+func TestDispatcherDeploymentClaimCheck(t *testing.T) {
+	commontesting.SetTestEnvironment(t)
+
+	s3Config := commonconfig.EKClaimCheckConfig{
+		Store: claimcheck.StoreTypeS3,
+		S3:    commonconfig.EKClaimCheckS3Config{CredentialsSecret: "s3-credentials"},
+	}
+	fsConfig := commonconfig.EKClaimCheckConfig{
+		Store:      claimcheck.StoreTypeFilesystem,
+		Filesystem: commonconfig.EKClaimCheckFilesystemConfig{Path: "/claim-check", PersistentVolumeClaim: "claim-check-pvc"},
+	}
+
+	plain := NewDispatcherDeploymentBuilder().WithArgs(&DispatcherDeploymentArgs{}).Build()
+	s3 := NewDispatcherDeploymentBuilder().WithArgs(&DispatcherDeploymentArgs{ClaimCheck: s3Config}).Build()
+	fs := NewDispatcherDeploymentBuilder().WithArgs(&DispatcherDeploymentArgs{ClaimCheck: fsConfig}).Build()
+
+	if got, want := len(s3.Spec.Template.Spec.Containers[0].Env), len(plain.Spec.Template.Spec.Containers[0].Env)+2; got != want {
+		t.Errorf("s3 claim-check env vars: got %d, want %d", got, want)
+	}
+	if got, want := len(fs.Spec.Template.Spec.Volumes), len(plain.Spec.Template.Spec.Volumes)+1; got != want {
+		t.Errorf("filesystem claim-check volumes: got %d, want %d", got, want)
+	}
+	if got, want := len(fs.Spec.Template.Spec.Containers[0].VolumeMounts), len(plain.Spec.Template.Spec.Containers[0].VolumeMounts)+1; got != want {
+		t.Errorf("filesystem claim-check volume mounts: got %d, want %d", got, want)
+	}
+
+	// Rebuilding an existing deployment must be idempotent and must remove a claim-check store no longer configured
+	rebuilt := NewDispatcherDeploymentBuilderFromDeployment(fs.DeepCopy()).WithArgs(&DispatcherDeploymentArgs{ClaimCheck: fsConfig}).Build()
+	if !equality.Semantic.DeepEqual(fs.Spec, rebuilt.Spec) {
+		t.Errorf("rebuilding with the same claim-check config changed the deployment spec")
+	}
+	removed := NewDispatcherDeploymentBuilderFromDeployment(fs.DeepCopy()).WithArgs(&DispatcherDeploymentArgs{}).Build()
+	if !equality.Semantic.DeepEqual(plain.Spec, removed.Spec) {
+		t.Errorf("removing the claim-check config did not restore the plain deployment spec")
+	}
+}
@@ -25,14 +25,16 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 	"knative.dev/eventing/pkg/kncloudevents"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	configmapinformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
-	"knative.dev/pkg/tracing"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/dispatcher"
@@ -45,7 +47,11 @@ import (
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
 	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
 const dispatcherClientId = "kafka-ch-dispatcher"
@@ -63,7 +69,11 @@ type Reconciler struct {
 	kafkaClientSet       kafkaclientset.Interface
 	kafkachannelLister   listers.KafkaChannelLister
 	kafkachannelInformer cache.SharedIndexInformer
+	subscriptionLister   messaginglisters.SubscriptionLister
 	impl                 *controller.Impl
+
+	// Default claim-check threshold for KafkaChannels opting in without their own threshold
+	claimCheckThreshold int64
 }
 
 var _ kafkachannelreconciler.Interface = (*Reconciler)(nil)
@@ -73,7 +83,7 @@ var _ kafkachannelreconciler.Interface = (*Reconciler)(nil)
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	logger := logging.FromContext(ctx)
 
-	tracer, err := tracing.SetupPublishingWithDynamicConfig(logger, cmw.(*configmapinformer.InformedWatcher), "kafka-ch-dispatcher", "config-tracing")
+	tracer, err := tracing.SetupPublishingWithDynamicConfig(logger, cmw.(*configmapinformer.InformedWatcher), "kafka-ch-dispatcher")
 	if err != nil {
 		logger.Fatalw("unable to setup tracing", zap.Error(err))
 	}
@@ -101,16 +111,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	})
 
 	kafkaChannelInformer := kafkachannel.Get(ctx)
+	subscriptionInformer := subscription.Get(ctx)
 	args := &dispatcher.KafkaDispatcherArgs{
-		Brokers:   kafkaConfig.Brokers,
-		Config:    kafkaConfig.EventingKafka,
-		TopicFunc: utils.TopicName,
+		Brokers:    kafkaConfig.Brokers,
+		Config:     kafkaConfig.EventingKafka,
+		TopicFunc:  utils.TopicName,
+		KubeClient: kubeclient.Get(ctx),
 	}
 
 	r := &Reconciler{
 		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
 		kafkachannelLister:   kafkaChannelInformer.Lister(),
 		kafkachannelInformer: kafkaChannelInformer.Informer(),
+		subscriptionLister:   subscriptionInformer.Lister(),
+		claimCheckThreshold:  kafkaConfig.EventingKafka.Channel.ClaimCheck.ThresholdBytes,
 	}
 	r.impl = kafkachannelreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{SkipStatusUpdates: true}
@@ -143,6 +157,24 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 			},
 		})
 
+	// Watch for subscriptions, whose filter annotations are applied to the subscribers of their kafka channels.
+	subscriptionInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		channelRef, ok := filter.SubscriptionChannel(obj)
+		if !ok {
+			return
+		}
+		if _, err := r.kafkachannelLister.KafkaChannels(channelRef.Namespace).Get(channelRef.Name); err == nil {
+			r.impl.EnqueueKey(channelRef)
+		}
+	}))
+
+	// Start the debug API (if enabled) for browsing and replaying the events of the kafka channels.
+	if debugApi := kafkaConfig.EventingKafka.Channel.Dispatcher.DebugApi; debugApi.Enabled {
+		if err := kafkaDispatcher.StartDebugApi(ctx, debugApi.Port, debugapi.NewKubernetesAuthorizer(kubeclient.Get(ctx))); err != nil {
+			logger.Fatalw("Unable to start the debug API", zap.Error(err))
+		}
+	}
+
 	logger.Info("Starting dispatcher.")
 	go func() {
 		if err := kafkaDispatcher.Start(ctx); err != nil {
@@ -177,7 +209,11 @@ func (r *Reconciler) syncChannel(ctx context.Context, kc *v1beta1.KafkaChannel)
 		return nil
 	}
 
-	config := r.newConfigFromKafkaChannel(kc)
+	config, err := r.newConfigFromKafkaChannel(kc)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Error getting the subscription filters of the channel", zap.Error(err))
+		return err
+	}
 
 	// Update receiver side
 	if err := r.kafkaDispatcher.RegisterChannelHost(config); err != nil {
@@ -186,7 +222,7 @@ func (r *Reconciler) syncChannel(ctx context.Context, kc *v1beta1.KafkaChannel)
 	}
 
 	// Update dispatcher side
-	err := r.kafkaDispatcher.ReconcileConsumers(ctx, config)
+	err = r.kafkaDispatcher.ReconcileConsumers(ctx, config)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Some kafka subscriptions failed to subscribe", zap.Error(err))
 		return fmt.Errorf("some kafka subscriptions failed to subscribe: %v", err)
@@ -199,13 +235,20 @@ func (r *Reconciler) CleanupChannel(kc *v1beta1.KafkaChannel) pkgreconciler.Even
 }
 
 // newConfigFromKafkaChannel creates a new Config from the list of kafka channels.
-func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
+func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) (*dispatcher.ChannelConfig, error) {
 	channelConfig := dispatcher.ChannelConfig{
 		Namespace: c.Namespace,
 		Name:      c.Name,
 		HostName:  c.Status.Address.URL.Host,
+		Topic:     c.Spec.Topic,
+
+		ProduceOptions: produce.NewOptions(c, r.claimCheckThreshold),
 	}
 	if c.Spec.SubscribableSpec.Subscribers != nil {
+		filters, err := filter.SubscriptionFilters(r.subscriptionLister, c.Namespace)
+		if err != nil {
+			return nil, err
+		}
 		newSubs := make([]dispatcher.Subscription, 0, len(c.Spec.SubscribableSpec.Subscribers))
 		for _, source := range c.Spec.SubscribableSpec.Subscribers {
 			innerSub, _ := fanout.SubscriberSpecToFanoutConfig(source)
@@ -213,10 +256,11 @@ func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatc
 			newSubs = append(newSubs, dispatcher.Subscription{
 				Subscription: *innerSub,
 				UID:          source.UID,
+				Filter:       filters[source.UID],
 			})
 		}
 		channelConfig.Subscriptions = newSubs
 	}
 
-	return &channelConfig
+	return &channelConfig, nil
 }
@@ -58,6 +58,7 @@ func NewKafkaChannel(name, namespace string, ncopt ...KafkaChannelOption) *v1bet
 
 func WithInitKafkaChannelConditions(nc *v1beta1.KafkaChannel) {
 	nc.Status.InitializeConditions()
+	nc.Status.DurabilityClass = nc.Spec.EffectiveDurabilityClass()
 }
 
 func WithKafkaChannelDeleted(nc *v1beta1.KafkaChannel) {
@@ -27,6 +27,7 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/system"
 
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
@@ -115,6 +116,15 @@ func TopicName(separator, namespace, name string) string {
 	return strings.Join(topic, separator)
 }
 
+// ChannelTopicName returns the Kafka Topic used by the specified KafkaChannel, which is either the
+// existing Topic referenced in its spec or the one derived from its namespace and name.
+func ChannelTopicName(channel *v1beta1.KafkaChannel) string {
+	if channel.Spec.Topic != "" {
+		return channel.Spec.Topic
+	}
+	return TopicName(KafkaChannelSeparator, channel.Namespace, channel.Name)
+}
+
 func FindContainer(d *appsv1.Deployment, containerName string) *corev1.Container {
 	for i := range d.Spec.Template.Spec.Containers {
 		if d.Spec.Template.Spec.Containers[i].Name == containerName {
@@ -97,6 +97,10 @@ func MarkDispatcherDeploymentFailed(kcs *messaging.KafkaChannelStatus, reason, m
 	kcs.GetConditionSet().Manage(kcs).MarkFalse(KafkaChannelConditionDispatcherDeploymentReady, reason, messageFormat, messageA...)
 }
 
+func MarkDispatcherDeploymentTrue(kcs *messaging.KafkaChannelStatus) {
+	kcs.GetConditionSet().Manage(kcs).MarkTrue(KafkaChannelConditionDispatcherDeploymentReady)
+}
+
 func MarkDispatcherDeploymentUnknown(kcs *messaging.KafkaChannelStatus, reason, messageFormat string, messageA ...interface{}) {
 	kcs.GetConditionSet().Manage(kcs).MarkUnknown(KafkaChannelConditionDispatcherDeploymentReady, reason, messageFormat, messageA...)
 }
@@ -105,7 +109,7 @@ func PropagateDispatcherDeploymentStatus(kcs *messaging.KafkaChannelStatus, ds *
 	for _, cond := range ds.Conditions {
 		if cond.Type == appsv1.DeploymentAvailable {
 			if cond.Status == corev1.ConditionTrue {
-				kcs.GetConditionSet().Manage(kcs).MarkTrue(KafkaChannelConditionDispatcherDeploymentReady)
+				MarkDispatcherDeploymentTrue(kcs)
 			} else if cond.Status == corev1.ConditionFalse {
 				MarkDispatcherDeploymentFailed(kcs, "DispatcherDeploymentFalse", "The status of Dispatcher Deployment is False: %s : %s", cond.Reason, cond.Message)
 			} else if cond.Status == corev1.ConditionUnknown {
@@ -210,17 +210,18 @@ func TestInitializeConditions(t *testing.T) {
 func TestChannelIsReady(t *testing.T) {
 	RegisterDistributedKafkaChannelConditionSet()
 	tests := []struct {
-		name                         string
-		setAddress                   bool
-		markChannelServiceReady      bool
-		markConfigurationReady       bool
-		markDispatcherServiceReady   bool
-		markDispatcherServiceUnknown bool
-		dispatcherStatus             *appsv1.DeploymentStatus
-		markReceiverServiceReady     bool
-		markReceiverDeploymentReady  bool
-		markTopicReady               bool
-		wantReady                    bool
+		name                          string
+		setAddress                    bool
+		markChannelServiceReady       bool
+		markConfigurationReady        bool
+		markDispatcherServiceReady    bool
+		markDispatcherServiceUnknown  bool
+		markDispatcherDeploymentReady bool
+		dispatcherStatus              *appsv1.DeploymentStatus
+		markReceiverServiceReady      bool
+		markReceiverDeploymentReady   bool
+		markTopicReady                bool
+		wantReady                     bool
 	}{{
 		name:                        "all happy",
 		setAddress:                  true,
@@ -232,6 +233,17 @@ func TestChannelIsReady(t *testing.T) {
 		markReceiverServiceReady:    true,
 		markTopicReady:              true,
 		wantReady:                   true,
+	}, {
+		name:                          "shared dispatcher scheduled",
+		setAddress:                    true,
+		markChannelServiceReady:       true,
+		markConfigurationReady:        true,
+		markDispatcherDeploymentReady: true,
+		markDispatcherServiceReady:    true,
+		markReceiverDeploymentReady:   true,
+		markReceiverServiceReady:      true,
+		markTopicReady:                true,
+		wantReady:                     true,
 	}, {
 		name:                        "address not ready",
 		setAddress:                  false,
@@ -361,7 +373,9 @@ func TestChannelIsReady(t *testing.T) {
 			} else {
 				cs.MarkConfigFailed("NotReadyConfiguration", "testing")
 			}
-			if test.dispatcherStatus != nil {
+			if test.markDispatcherDeploymentReady {
+				MarkDispatcherDeploymentTrue(cs)
+			} else if test.dispatcherStatus != nil {
 				PropagateDispatcherDeploymentStatus(cs, test.dispatcherStatus)
 			} else {
 				MarkDispatcherDeploymentFailed(cs, "NotReadyDispatcherDeployment", "testing")
@@ -24,8 +24,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	injectionclient "knative.dev/pkg/client/injection/kube/client"
 	configmap "knative.dev/pkg/configmap/informer"
-	"knative.dev/pkg/tracing"
-	tracingconfig "knative.dev/pkg/tracing/config"
+
+	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
 // Initialize The Specified Context With A Tracer (ConfigMap Watcher)
@@ -38,10 +38,10 @@ func InitializeTracing(logger *zap.SugaredLogger, ctx context.Context, service s
 		return err
 	}
 
-	// Create A Watcher On The Tracing ConfigMap & Dynamically Update Tracing Configuration
+	// Create A Watcher On The Tracing ConfigMap & Dynamically Update Tracing Configuration (Including The OTLP Backend)
 	cmw := configmap.NewInformedWatcher(k8sClient, namespace)
 
-	tracer, err := tracing.SetupPublishingWithDynamicConfig(logger, cmw, service, tracingconfig.ConfigName)
+	tracer, err := tracing.SetupPublishingWithDynamicConfig(logger, cmw, service)
 	if err != nil {
 		logger.Error("Error setting up dynamic trace publishing", zap.Error(err))
 		return err
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+)
+
+const (
+	// ServerPort is the port on which the Dispatcher's status control-protocol server listens.  This is
+	// separate from the common controlprotocol.ServerPort, which is used by the ResetOffset controller to
+	// send commands to the Dispatcher, so that the two control-plane connections do not interfere.
+	ServerPort = 8086
+
+	// NotifySubscribersStatusOpCode is sent by the Dispatcher with a full snapshot of its SubscribersStatus.
+	NotifySubscribersStatusOpCode ctrl.OpCode = 1
+)
+
+// SubscriberStatus is the status of a single Subscriber's ConsumerGroup as seen by one Dispatcher replica.
+type SubscriberStatus struct {
+	Partitions []int32 `json:"partitions,omitempty"`
+	Stopped    bool    `json:"stopped,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// SubscribersStatus is the status of all Subscribers, keyed by Subscriber UID, as seen by one Dispatcher replica.
+type SubscribersStatus map[types.UID]SubscriberStatus
+
+// SubscribersStatusParser implements the control-protocol PayloadParser for the SubscribersStatus
+func SubscribersStatusParser(payload []byte) (interface{}, error) {
+	var status SubscribersStatus
+	err := (&status).UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// String returns a human-readable representation of the SubscribersStatus sorted by Subscriber UID.
+func (s SubscribersStatus) String() string {
+	strs := make([]string, 0, len(s))
+	for uid, status := range s {
+		strs = append(strs, fmt.Sprintf("'%s': %v", uid, status.Partitions))
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s SubscribersStatus) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SubscribersStatus) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, s)
+}
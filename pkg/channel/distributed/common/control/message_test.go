@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test The SubscribersStatus Marshalling & Parsing Round Trip
+func TestSubscribersStatusParser(t *testing.T) {
+	status := SubscribersStatus{
+		"uid-1": {Partitions: []int32{0, 1, 2}},
+		"uid-2": {Stopped: true},
+		"uid-3": {Error: "test error"},
+	}
+
+	data, err := status.MarshalBinary()
+	require.Nil(t, err)
+
+	parsed, err := SubscribersStatusParser(data)
+	require.Nil(t, err)
+	assert.Equal(t, status, parsed)
+
+	_, err = SubscribersStatusParser([]byte("invalid"))
+	assert.NotNil(t, err)
+}
+
+// Test The SubscribersStatus String() Functionality
+func TestSubscribersStatusString(t *testing.T) {
+	status := SubscribersStatus{
+		"uid-2": {Partitions: []int32{1}},
+		"uid-1": {Partitions: []int32{0, 2}},
+	}
+	assert.Equal(t, "'uid-1': [0 2], 'uid-2': [1]", status.String())
+}
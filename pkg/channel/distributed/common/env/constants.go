@@ -36,6 +36,7 @@ const (
 	KafkaTopicEnvVarKey = "KAFKA_TOPIC"
 
 	// Dispatcher Configuration
-	ChannelKeyEnvVarKey  = "CHANNEL_KEY"
-	ServiceNameEnvVarKey = "SERVICE_NAME"
+	ChannelKeyEnvVarKey       = "CHANNEL_KEY"
+	ServiceNameEnvVarKey      = "SERVICE_NAME"
+	SharedDispatcherEnvVarKey = "SHARED_DISPATCHER"
 )
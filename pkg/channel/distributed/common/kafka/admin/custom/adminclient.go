@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -48,8 +49,10 @@ var _ types.AdminClientInterface = &CustomAdminClient{}
 
 // Custom AdminClient Definition
 type CustomAdminClient struct {
-	logger     *zap.Logger
-	httpClient *http.Client
+	logger          *zap.Logger
+	httpClient      *http.Client
+	protocolMutex   sync.Mutex
+	protocolVersion int // The Sidecar's ProtocolVersion (Zero Until Discovered)
 }
 
 // Create A New Custom Kafka AdminClient Based On The Kafka Secret In The Specified K8S Namespace
@@ -155,11 +158,176 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Describing Topics (Requires ProtocolVersion2)
+func (c *CustomAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Verify The Sidecar Supports The Operation
+	if topicError := c.requireProtocolVersion(ProtocolVersion2, "describe"); topicError != nil {
+		return nil, topicError
+	}
+
+	// Make The HTTP GET Request (TopicName In GET URL!)
+	response, topicError := c.sendRequest(logger, http.MethodGet, c.sidecarTopicsUrl(topicName), nil, topicName, "describe")
+	defer c.safeCloseHTTPResponseBody(response)
+	if topicError != nil {
+		return nil, topicError
+	}
+
+	// Map Error Responses Into A Sarama TopicError
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, c.mapHttpResponse("describe", response)
+	}
+
+	// Parse The Custom TopicDetail From The Response Body & Convert Into A Sarama TopicDetail
+	customTopicDetail := &TopicDetail{}
+	err := json.NewDecoder(response.Body).Decode(customTopicDetail)
+	if err != nil {
+		logger.Error("Failed To Unmarshal Describe Topic Response Body", zap.Error(err))
+		return nil, util.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("failed to unmarshal response body for description of topic '%s'", topicName))
+	}
+	return customTopicDetail.ToSaramaTopicDetail(), util.NewTopicError(sarama.ErrNoError, "custom sidecar topic 'describe' operation succeeded")
+}
+
+// Custom REST Pass-Through Function For Altering Topic Config (Requires ProtocolVersion2)
+func (c *CustomAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Verify The Sidecar Supports The Operation
+	if topicError := c.requireProtocolVersion(ProtocolVersion2, "alter config"); topicError != nil {
+		return topicError
+	}
+
+	// Make The HTTP PUT Request & Map The HTTP Response Into A Sarama TopicError
+	response, topicError := c.sendRequest(logger, http.MethodPut, c.sidecarTopicsUrl(topicName)+ConfigPath, &TopicConfig{ConfigEntries: configEntries}, topicName, "alter config")
+	defer c.safeCloseHTTPResponseBody(response)
+	if topicError != nil {
+		return topicError
+	}
+	return c.mapHttpResponse("alter config", response)
+}
+
+// Custom REST Pass-Through Function For Creating Partitions (Requires ProtocolVersion2)
+func (c *CustomAdminClient) CreatePartitions(_ context.Context, topicName string, count int32) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Verify The Sidecar Supports The Operation
+	if topicError := c.requireProtocolVersion(ProtocolVersion2, "create partitions"); topicError != nil {
+		return topicError
+	}
+
+	// Make The HTTP POST Request & Map The HTTP Response Into A Sarama TopicError
+	response, topicError := c.sendRequest(logger, http.MethodPost, c.sidecarTopicsUrl(topicName)+PartitionsPath, &TopicPartitions{Count: count}, topicName, "create partitions")
+	defer c.safeCloseHTTPResponseBody(response)
+	if topicError != nil {
+		return topicError
+	}
+	return c.mapHttpResponse("create partitions", response)
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
 }
 
+// Verify The Sidecar Implements At Least The Specified ProtocolVersion (Discovering It On First Use)
+func (c *CustomAdminClient) requireProtocolVersion(version int, operation string) *sarama.TopicError {
+
+	c.protocolMutex.Lock()
+	defer c.protocolMutex.Unlock()
+
+	// Discover The Sidecar's ProtocolVersion If Not Already Known
+	if c.protocolVersion <= 0 {
+		protocolVersion, err := c.getProtocolVersion()
+		if err != nil {
+			c.logger.Error("Failed To Discover Custom Sidecar Protocol Version", zap.Error(err))
+			return util.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to discover custom sidecar protocol version: %v", err))
+		}
+		c.logger.Info("Discovered Custom Sidecar Protocol Version", zap.Int("ProtocolVersion", protocolVersion))
+		c.protocolVersion = protocolVersion
+	}
+
+	// Verify The Sidecar's ProtocolVersion Supports The Operation
+	if c.protocolVersion < version {
+		return util.NewTopicError(sarama.ErrUnsupportedVersion, fmt.Sprintf("custom sidecar topic '%s' operation requires protocol version %d but sidecar implements version %d", operation, version, c.protocolVersion))
+	}
+	return nil
+}
+
+// Get The ProtocolVersion Implemented By The Sidecar (Sidecars Without The Protocol Endpoint Implement ProtocolVersion1)
+func (c *CustomAdminClient) getProtocolVersion() (int, error) {
+
+	// Make The HTTP GET Request
+	response, err := c.httpClient.Get("http://" + SidecarHost + ":" + SidecarPort + ProtocolPath)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		return 0, err
+	}
+
+	// Older Sidecars Do Not Implement The Protocol Endpoint, Whereas Other Failures Are Returned (Uncached) So That
+	// A Temporarily Unavailable Sidecar Isn't Permanently Mistaken For An Older One
+	if response.StatusCode == http.StatusNotFound {
+		return ProtocolVersion1, nil
+	} else if response.StatusCode < 200 || response.StatusCode > 299 {
+		return 0, fmt.Errorf("unexpected protocol response status code %d", response.StatusCode)
+	}
+
+	// Parse The ProtocolInfo From The Response Body
+	protocolInfo := &ProtocolInfo{}
+	err = json.NewDecoder(response.Body).Decode(protocolInfo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unmarshal protocol response body: %w", err)
+	} else if protocolInfo.Version < ProtocolVersion1 {
+		return ProtocolVersion1, nil
+	}
+	return protocolInfo.Version, nil
+}
+
+// Send An HTTP Request With The Optional JSON Body To The Sidecar
+func (c *CustomAdminClient) sendRequest(logger *zap.Logger, method string, url string, body interface{}, topicName string, operation string) (*http.Response, *sarama.TopicError) {
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		logger.Warn("Received Empty/Nil Topic Configuration")
+		return nil, util.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Create The Request Body
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			logger.Error("Failed To Marshall Request Body", zap.String("Operation", operation), zap.Error(err))
+			return nil, util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("failed to marshal request body for '%s' of topic '%s'", operation, topicName))
+		}
+		requestBody = bytes.NewBuffer(bodyBytes)
+	}
+
+	// Create The HTTP Request
+	request, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		logger.Error("Failed To Create New HTTP Request", zap.String("Method", method), zap.String("URL", url), zap.Error(err))
+		return nil, util.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for '%s' of topic '%s'", operation, topicName))
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		logger.Error("HTTP Request To Sidecar Failed", zap.String("Method", method), zap.String("Operation", operation), zap.Error(err))
+		return response, util.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to make http request for '%s' of topic '%s'", operation, topicName))
+	}
+	return response, nil
+}
+
 // Safely Close The Specified HTTP Response Body
 func (c *CustomAdminClient) safeCloseHTTPResponseBody(response *http.Response) {
 	if response != nil && response.Body != nil {
@@ -205,7 +373,7 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 		switch {
 		case statusCode >= 200 && statusCode <= 299:
 			return util.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 404 && operation == "delete": // 404 Not Found Indicates Topic Does Not Exist In Delete Operation
+		case statusCode == 404 && operation != "create": // 404 Not Found Indicates Topic Does Not Exist In Delete (& Other Topic) Operations
 			return util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
 		case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
 			return util.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
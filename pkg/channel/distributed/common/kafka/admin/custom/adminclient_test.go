@@ -133,6 +133,124 @@ func TestDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions:     4,
+		ReplicationFactor: 3,
+		ConfigEntries:     map[string]*string{"retention.ms": &retentionMillis},
+	}
+	responseBody, err := json.Marshal(NewTopicDetail(topicDetail.NumPartitions, topicDetail.ReplicationFactor, nil, topicDetail.ConfigEntries))
+	assert.Nil(t, err)
+
+	// Create & Start The Test Sidecar HTTP Server (ProtocolVersion2 Success Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.protocolVersion = ProtocolVersion2
+	mockSidecarServer.responseBody = responseBody
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Create A New Custom AdminClient To Test
+	adminClient, err := NewAdminClient(logging.WithLogger(context.TODO(), logtesting.TestLogger(t)))
+	assert.Nil(t, err)
+
+	// Perform The Test Twice (Protocol Version Is Only Discovered Once)
+	for i := 0; i < 2; i++ {
+		resultTopicDetail, resultTopicError := adminClient.DescribeTopic(context.TODO(), topicName)
+		assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+		assert.Equal(t, topicDetail, resultTopicDetail)
+	}
+
+	// Verify The Results
+	assert.Equal(t, 1, mockSidecarServer.protocolRequests)
+	assert.Equal(t, 2, len(mockSidecarServer.requests))
+	for request, body := range mockSidecarServer.requests {
+		verifySidecarRequest(t, request, body, topicName, nil)
+	}
+}
+
+// Test The AlterTopicConfig() & CreatePartitions() Functionality
+func TestAlterTopicConfigAndCreatePartitions(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	configEntries := map[string]*string{"retention.ms": &retentionMillis}
+
+	// Create & Start The Test Sidecar HTTP Server (ProtocolVersion2 Success Response) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.protocolVersion = ProtocolVersion2
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Create A New Custom AdminClient To Test
+	adminClient, err := NewAdminClient(logging.WithLogger(context.TODO(), logtesting.TestLogger(t)))
+	assert.Nil(t, err)
+
+	// Perform The Tests
+	assert.Equal(t, sarama.ErrNoError, adminClient.AlterTopicConfig(context.TODO(), topicName, configEntries).Err)
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(context.TODO(), topicName, 8).Err)
+
+	// Verify The Results
+	assert.Equal(t, 2, len(mockSidecarServer.requests))
+	for request, body := range mockSidecarServer.requests {
+		verifySidecarRequest(t, request, body, topicName, &sarama.TopicDetail{NumPartitions: 8, ConfigEntries: configEntries})
+	}
+}
+
+// Test The DescribeTopic(), AlterTopicConfig() & CreatePartitions() Functionality Against An Older Sidecar
+func TestProtocolVersion1Sidecar(t *testing.T) {
+
+	// Create & Start The Test Sidecar HTTP Server (Without Protocol Endpoint) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Create A New Custom AdminClient To Test
+	adminClient, err := NewAdminClient(logging.WithLogger(context.TODO(), logtesting.TestLogger(t)))
+	assert.Nil(t, err)
+
+	// Perform The Tests
+	topicDetail, topicError := adminClient.DescribeTopic(context.TODO(), "TestTopicName")
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, topicError.Err)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, adminClient.AlterTopicConfig(context.TODO(), "TestTopicName", nil).Err)
+	assert.Equal(t, sarama.ErrUnsupportedVersion, adminClient.CreatePartitions(context.TODO(), "TestTopicName", 8).Err)
+
+	// Verify The Results (No Topic Requests Were Sent)
+	assert.Equal(t, 1, mockSidecarServer.protocolRequests)
+	assert.Empty(t, mockSidecarServer.requests)
+}
+
+// Test A Failed Protocol Discovery Is Not Mistaken For An Older Sidecar, And Is Retried
+func TestProtocolVersionDiscoveryError(t *testing.T) {
+
+	// Create & Start The Test Sidecar HTTP Server (Failing Protocol Endpoint) & Defer Close
+	mockSidecarServer := NewMockSidecarServer(t, http.StatusOK)
+	mockSidecarServer.protocolVersion = ProtocolVersion2
+	mockSidecarServer.protocolStatus = http.StatusServiceUnavailable
+	mockSidecarServer.Start()
+	defer mockSidecarServer.Close()
+
+	// Create A New Custom AdminClient To Test
+	adminClient, err := NewAdminClient(logging.WithLogger(context.TODO(), logtesting.TestLogger(t)))
+	assert.Nil(t, err)
+
+	// Perform The Test While The Protocol Endpoint Is Failing
+	assert.Equal(t, sarama.ErrNetworkException, adminClient.CreatePartitions(context.TODO(), "TestTopicName", 8).Err)
+	assert.Empty(t, mockSidecarServer.requests)
+
+	// Perform The Test Again Once The Protocol Endpoint Recovers
+	mockSidecarServer.protocolStatus = 0
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(context.TODO(), "TestTopicName", 8).Err)
+	assert.Equal(t, 2, mockSidecarServer.protocolRequests)
+	assert.Len(t, mockSidecarServer.requests, 1)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -224,6 +342,24 @@ func TestMapHttpResponse(t *testing.T) {
 			response:  &http.Response{StatusCode: 409, Body: io.NopCloser(bytes.NewReader(bodyBytes))},
 			expected:  &sarama.TopicError{Err: sarama.ErrInvalidRequest},
 		},
+		{
+			name:      "Describe 404",
+			operation: "describe",
+			response:  &http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrUnknownTopicOrPartition},
+		},
+		{
+			name:      "Alter Config 404",
+			operation: "alter config",
+			response:  &http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrUnknownTopicOrPartition},
+		},
+		{
+			name:      "Create Partitions 400",
+			operation: "create partitions",
+			response:  &http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrInvalidRequest},
+		},
 		{
 			name:      "Create 500",
 			operation: "create",
@@ -269,10 +405,14 @@ func TestMapHttpResponse(t *testing.T) {
 
 // MockSidecarServer Struct
 type MockSidecarServer struct {
-	t          *testing.T
-	statusCode int
-	server     *httptest.Server
-	requests   map[*http.Request][]byte // Map Of Request Pointers To BodyBytes For Tracking Requests For Subsequent Validation
+	t                *testing.T
+	statusCode       int
+	responseBody     []byte
+	protocolVersion  int // Zero Emulates An Older Sidecar Without The Protocol Endpoint
+	protocolStatus   int // Non-Zero Emulates A Failing Protocol Endpoint
+	protocolRequests int
+	server           *httptest.Server
+	requests         map[*http.Request][]byte // Map Of Request Pointers To BodyBytes For Tracking Requests For Subsequent Validation
 }
 
 // MockSidecarServer Constructor
@@ -315,6 +455,21 @@ func (s *MockSidecarServer) Close() {
 // The HTTP Handler Interface Implementation
 func (s *MockSidecarServer) ServeHTTP(responseWriter http.ResponseWriter, request *http.Request) {
 
+	// Respond To Protocol Discovery Requests Separately
+	if request.Method == http.MethodGet && request.URL.Path == ProtocolPath {
+		s.protocolRequests++
+		if s.protocolStatus != 0 {
+			responseWriter.WriteHeader(s.protocolStatus)
+			return
+		}
+		if s.protocolVersion <= 0 {
+			responseWriter.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Nil(s.t, json.NewEncoder(responseWriter).Encode(&ProtocolInfo{Version: s.protocolVersion}))
+		return
+	}
+
 	// Read The Request Body Before Responding (It Will Otherwise Be Closed!)
 	bodyBytes, err := io.ReadAll(request.Body)
 	assert.Nil(s.t, err)
@@ -322,8 +477,12 @@ func (s *MockSidecarServer) ServeHTTP(responseWriter http.ResponseWriter, reques
 	// Track The Received HTTP Request & Body For Future Validation
 	s.requests[request] = bodyBytes
 
-	// Return The Desired StatusCode
+	// Return The Desired StatusCode & Body
 	responseWriter.WriteHeader(s.statusCode)
+	if len(s.responseBody) > 0 {
+		_, err = responseWriter.Write(s.responseBody)
+		assert.Nil(s.t, err)
+	}
 }
 
 // Utility Function For Verifying The Inbound HTTP Request (What Is Sent To The Sidecar)
@@ -335,7 +494,24 @@ func verifySidecarRequest(t *testing.T, request *http.Request, body []byte, topi
 	// Verify Method Specific Request Data
 	switch request.Method {
 
+	case http.MethodGet:
+		assert.Equal(t, TopicsPath+"/"+topicName, request.URL.Path)
+		assert.Empty(t, body)
+
+	case http.MethodPut:
+		assert.Equal(t, TopicsPath+"/"+topicName+ConfigPath, request.URL.Path)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		topicConfig := &TopicConfig{}
+		assert.Nil(t, json.Unmarshal(body, topicConfig))
+		assert.Equal(t, saramaTopicDetail.ConfigEntries, topicConfig.ConfigEntries)
+
 	case http.MethodPost:
+		if request.URL.Path == TopicsPath+"/"+topicName+PartitionsPath {
+			topicPartitions := &TopicPartitions{}
+			assert.Nil(t, json.Unmarshal(body, topicPartitions))
+			assert.Equal(t, saramaTopicDetail.NumPartitions, topicPartitions.Count)
+			return
+		}
 		assert.Equal(t, TopicsPath, request.URL.Path)
 		assert.Equal(t, topicName, request.Header.Get(TopicNameHeader))
 		customTopicDetail := &TopicDetail{}
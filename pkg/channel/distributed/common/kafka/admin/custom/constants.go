@@ -29,4 +29,18 @@ const (
 	TopicsPath      = "/topics"        // The HTTP request path for Kafka Topic creation / deletion to be implemented by the sidecar.
 	TopicNameHeader = "Slug"           // The HTTP Header key used to identify the TopicName in the POST request.
 	SidecarTimeout  = 30 * time.Second // How long to wait for the sidecar's server to respond.
+
+	ProtocolPath   = "/protocol"   // The HTTP request path (GET) for discovering the ProtocolVersion implemented by the sidecar.
+	ConfigPath     = "/config"     // The HTTP request path (PUT) suffix of "/topics/<topic-name>" for altering Topic config.
+	PartitionsPath = "/partitions" // The HTTP request path (POST) suffix of "/topics/<topic-name>" for creating partitions.
+)
+
+// Custom REST Sidecar Protocol Versions
+//
+// Sidecars which do not implement the ProtocolPath endpoint are assumed to
+// implement ProtocolVersion1 (create & delete only).
+const (
+	ProtocolVersion1 = 1 // Create & Delete Topics
+	ProtocolVersion2 = 2 // Describe Topics, Alter Topic Config & Create Partitions
+	ProtocolVersion  = ProtocolVersion2
 )
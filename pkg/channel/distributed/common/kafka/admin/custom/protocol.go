@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package custom
+
+//
+//  These Golang Types can be used by third party implementers of the
+//  eventing-kafka "custom" AdminClient Type when building the REST endpoints
+//  of ProtocolVersion2 (and later) sidecars.
+//
+
+// Custom ProtocolInfo Struct (Response Body Of The GET ProtocolPath Request)
+type ProtocolInfo struct {
+	Version int `json:"version"`
+}
+
+// Custom TopicConfig Struct (Request Body Of The PUT Topic Config Request)
+//
+// Only the specified ConfigEntries are to be set, all other Topic config is
+// expected to remain unchanged.
+type TopicConfig struct {
+	ConfigEntries map[string]*string `json:"configEntries"`
+}
+
+// Custom TopicPartitions Struct (Request Body Of The POST Topic Partitions Request)
+//
+// The Count is the new total number of partitions of the Topic.
+type TopicPartitions struct {
+	Count int32 `json:"count"`
+}
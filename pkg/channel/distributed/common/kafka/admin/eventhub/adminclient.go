@@ -159,11 +159,123 @@ func (c *EventHubAdminClient) DeleteTopic(ctx context.Context, topicName string)
 	return util.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
 }
 
+// Describe A Single Topic (EventHub) Via The Azure EventHub API
+//
+// EventHubs do not expose a replication factor, so the returned TopicDetail's ReplicationFactor is always zero, and
+// the only config entry is the retention.ms mapped from the EventHub's MessageRetentionInDays.
+func (c *EventHubAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+
+	// Get The EventHub (Topic)
+	hubEntity, topicError := c.getHub(ctx, topicName)
+	if topicError != nil {
+		return nil, topicError
+	}
+
+	// Map The EventHub Description To A Kafka TopicDetail
+	topicDetail := &sarama.TopicDetail{ConfigEntries: make(map[string]*string)}
+	if hubEntity.PartitionCount != nil {
+		topicDetail.NumPartitions = *hubEntity.PartitionCount
+	}
+	if hubEntity.MessageRetentionInDays != nil {
+		retentionMillis := strconv.FormatInt(int64(*hubEntity.MessageRetentionInDays)*constants.MillisPerDay, 10)
+		topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs] = &retentionMillis
+	}
+
+	// Return The TopicDetail - Success
+	return topicDetail, util.NewTopicError(sarama.ErrNoError, "successfully described topic")
+}
+
+// Alter The Config Of A Single Topic (EventHub) Via The Azure EventHub API (Only retention.ms Is Supported)
+func (c *EventHubAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+
+	// Extract The Retention Millis (The Only Kafka Config With An EventHub Equivalent)
+	var topicRetentionDays int32
+	for name, value := range configEntries {
+		if name != constants.TopicDetailConfigRetentionMs || value == nil {
+			return util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("config entry '%s' is not supported by EventHubs", name))
+		}
+		topicRetentionMillis, err := strconv.ParseInt(*value, 10, 64)
+		if err != nil {
+			c.logger.Error("Failed To Parse Retention Millis From Config Entries", zap.Error(err))
+			return util.NewTopicError(sarama.ErrInvalidConfig, "failed to parse retention millis from config entries")
+		}
+		topicRetentionDays = convertMillisToDays(topicRetentionMillis)
+	}
+	if topicRetentionDays <= 0 {
+		return util.NewTopicError(sarama.ErrNoError, "no topic config to alter")
+	}
+
+	// Get The EventHub (Topic) So That Its Partition Count Is Preserved
+	hubEntity, topicError := c.getHub(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+
+	// Update The EventHub (Topic) Via The PUT Rest Endpoint
+	opts := []eventhub.HubManagementOption{eventhub.HubWithMessageRetentionInDays(topicRetentionDays)}
+	if hubEntity.PartitionCount != nil {
+		opts = append(opts, eventhub.HubWithPartitionCount(*hubEntity.PartitionCount))
+	}
+	return c.updateHub(ctx, topicName, "altered topic config", opts...)
+}
+
+// Increase The Partition Count Of A Single Topic (EventHub) Via The Azure EventHub API
+//
+// Note - Azure only supports increasing the partition count of EventHubs in the Premium & Dedicated tiers.
+func (c *EventHubAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+
+	// Get The EventHub (Topic) So That Its Retention Is Preserved
+	hubEntity, topicError := c.getHub(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+	if hubEntity.PartitionCount != nil && count <= *hubEntity.PartitionCount {
+		return util.NewTopicError(sarama.ErrInvalidPartitions, fmt.Sprintf("topic currently has %d partitions, which is higher than or equal to the requested %d", *hubEntity.PartitionCount, count))
+	}
+
+	// Update The EventHub (Topic) Via The PUT Rest Endpoint
+	opts := []eventhub.HubManagementOption{eventhub.HubWithPartitionCount(count)}
+	if hubEntity.MessageRetentionInDays != nil {
+		opts = append(opts, eventhub.HubWithMessageRetentionInDays(*hubEntity.MessageRetentionInDays))
+	}
+	return c.updateHub(ctx, topicName, "created partitions", opts...)
+}
+
 // Kafka AdminClient Close Implementation Using Azure EventHub API
 func (c *EventHubAdminClient) Close() error {
 	return nil // Nothing to "close" in the HubManager (just a REST client) so this is just a compatibility no-op.
 }
 
+// Get A Single EventHub (Topic), Mapping A Missing EventHub To ErrUnknownTopicOrPartition
+func (c *EventHubAdminClient) getHub(ctx context.Context, topicName string) (*eventhub.HubEntity, *sarama.TopicError) {
+
+	// If The HubManager Is Not Valid Then Return Error
+	if c.hubManager == nil {
+		c.logger.Warn("Failed To Find EventHub Namespace With Valid HubManager", zap.String("Topic", topicName))
+		return nil, util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("azure namespace has invalid HubManager - unable to get EventHub '%s'", topicName))
+	}
+
+	// Get The EventHub (The Azure API Returns A Nil HubEntity For Non-Existent EventHubs)
+	hubEntity, err := c.hubManager.Get(ctx, topicName)
+	if err != nil {
+		c.logger.Error("Failed To Get EventHub", zap.String("TopicName", topicName), zap.Error(err))
+		return nil, util.NewUnknownTopicError(err.Error())
+	} else if hubEntity == nil || hubEntity.HubDescription == nil {
+		return nil, util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("EventHub '%s' not found", topicName))
+	}
+	return hubEntity, nil
+}
+
+// Update An Existing EventHub (Topic) Via The PUT Rest Endpoint
+func (c *EventHubAdminClient) updateHub(ctx context.Context, topicName string, operation string, opts ...eventhub.HubManagementOption) *sarama.TopicError {
+	_, err := c.hubManager.Put(ctx, topicName, opts...)
+	if err != nil {
+		c.logger.Error("Failed To Update EventHub", zap.String("TopicName", topicName), zap.Int("ErrorCode", getEventHubErrorCode(err)), zap.Error(err))
+		return util.NewTopicError(sarama.ErrInvalidRequest, err.Error())
+	}
+	return util.NewTopicError(sarama.ErrNoError, "successfully "+operation)
+}
+
 // Utility Function For Converting Millis To Days (Rounded Up To Larger Day Value)
 func convertMillisToDays(millis int64) int32 {
 	return int32(math.Ceil(float64(millis) / float64(constants.MillisPerDay)))
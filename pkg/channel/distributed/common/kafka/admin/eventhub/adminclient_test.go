@@ -22,8 +22,10 @@ import (
 	"strconv"
 	"testing"
 
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -306,6 +308,106 @@ func TestDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	logger := logtesting.TestLogger(t).Desugar()
+	topicName := "TestTopicName"
+	partitionCount := int32(4)
+	retentionDays := int32(2)
+	retentionMillis := strconv.FormatInt(int64(retentionDays)*constants.MillisPerDay, 10)
+	hubEntity := &eventhub.HubEntity{Name: topicName, HubDescription: &eventhub.HubDescription{PartitionCount: &partitionCount, MessageRetentionInDays: &retentionDays}}
+
+	// Verify An Existing EventHub Is Described
+	mockHubManager := NewMockHubManager(WithMockedGet(ctx, topicName, hubEntity, nil))
+	adminClient := &EventHubAdminClient{logger: logger, hubManager: mockHubManager}
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, createTopicDetail(partitionCount, retentionMillis), topicDetail)
+	mockHubManager.AssertExpectations(t)
+
+	// Verify A Missing EventHub Is Reported As An Unknown Topic
+	adminClient.hubManager = NewMockHubManager(WithMockedGet(ctx, topicName, nil, nil))
+	topicDetail, resultTopicError = adminClient.DescribeTopic(ctx, topicName)
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, resultTopicError.Err)
+
+	// Verify Get Errors Are Reported
+	adminClient.hubManager = NewMockHubManager(WithMockedGet(ctx, topicName, nil, fmt.Errorf("test-get-error")))
+	_, resultTopicError = adminClient.DescribeTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+
+	// Verify A Nil HubManager Is Reported
+	adminClient.hubManager = nil
+	_, resultTopicError = adminClient.DescribeTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrInvalidConfig, resultTopicError.Err)
+}
+
+// Test The AlterTopicConfig() Functionality
+func TestAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	logger := logtesting.TestLogger(t).Desugar()
+	topicName := "TestTopicName"
+	partitionCount := int32(4)
+	retentionDays := int32(2)
+	hubEntity := &eventhub.HubEntity{Name: topicName, HubDescription: &eventhub.HubDescription{PartitionCount: &partitionCount, MessageRetentionInDays: &retentionDays}}
+	newRetentionMillis := strconv.FormatInt(5*constants.MillisPerDay, 10)
+	unsupportedValue := "compact"
+
+	// Verify The Retention Is Updated & The Partition Count Preserved
+	mockHubManager := NewMockHubManager(WithMockedGet(ctx, topicName, hubEntity, nil))
+	mockHubManager.On("Put", ctx, topicName, mock.MatchedBy(func(opts []eventhub.HubManagementOption) bool {
+		hubDescription := applyHubManagementOptions(t, opts)
+		return *hubDescription.MessageRetentionInDays == 5 && *hubDescription.PartitionCount == partitionCount
+	})).Return(nil, nil)
+	adminClient := &EventHubAdminClient{logger: logger, hubManager: mockHubManager}
+	resultTopicError := adminClient.AlterTopicConfig(ctx, topicName, map[string]*string{constants.TopicDetailConfigRetentionMs: &newRetentionMillis})
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	mockHubManager.AssertExpectations(t)
+
+	// Verify Unsupported Config Entries Are Rejected
+	resultTopicError = adminClient.AlterTopicConfig(ctx, topicName, map[string]*string{"cleanup.policy": &unsupportedValue})
+	assert.Equal(t, sarama.ErrInvalidConfig, resultTopicError.Err)
+
+	// Verify Put Errors Are Reported
+	adminClient.hubManager = NewMockHubManager(WithMockedGet(ctx, topicName, hubEntity, nil), WithMockedPut(ctx, topicName, true, 400))
+	resultTopicError = adminClient.AlterTopicConfig(ctx, topicName, map[string]*string{constants.TopicDetailConfigRetentionMs: &newRetentionMillis})
+	assert.Equal(t, sarama.ErrInvalidRequest, resultTopicError.Err)
+}
+
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	logger := logtesting.TestLogger(t).Desugar()
+	topicName := "TestTopicName"
+	partitionCount := int32(4)
+	retentionDays := int32(2)
+	hubEntity := &eventhub.HubEntity{Name: topicName, HubDescription: &eventhub.HubDescription{PartitionCount: &partitionCount, MessageRetentionInDays: &retentionDays}}
+
+	// Verify The Partition Count Is Updated & The Retention Preserved
+	mockHubManager := NewMockHubManager(WithMockedGet(ctx, topicName, hubEntity, nil))
+	mockHubManager.On("Put", ctx, topicName, mock.MatchedBy(func(opts []eventhub.HubManagementOption) bool {
+		hubDescription := applyHubManagementOptions(t, opts)
+		return *hubDescription.PartitionCount == 8 && *hubDescription.MessageRetentionInDays == retentionDays
+	})).Return(nil, nil)
+	adminClient := &EventHubAdminClient{logger: logger, hubManager: mockHubManager}
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(ctx, topicName, 8).Err)
+	mockHubManager.AssertExpectations(t)
+
+	// Verify The Partition Count Cannot Be Decreased
+	assert.Equal(t, sarama.ErrInvalidPartitions, adminClient.CreatePartitions(ctx, topicName, 2).Err)
+
+	// Verify A Missing EventHub Is Reported As An Unknown Topic
+	adminClient.hubManager = NewMockHubManager(WithMockedGet(ctx, topicName, nil, nil))
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, adminClient.CreatePartitions(ctx, topicName, 8).Err)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -326,6 +428,15 @@ func TestClose(t *testing.T) {
 // Test Utilities
 //
 
+// Apply The HubManagementOptions Passed To The Mock HubManager's Put() To An Empty HubDescription
+func applyHubManagementOptions(t *testing.T, opts []eventhub.HubManagementOption) *eventhub.HubDescription {
+	hubDescription := &eventhub.HubDescription{}
+	for _, opt := range opts {
+		assert.Nil(t, opt(hubDescription))
+	}
+	return hubDescription
+}
+
 // Create A Sarama TopicDetail For Testing
 func createTopicDetail(numPartitions int32, retentionMsString string) *sarama.TopicDetail {
 	return &sarama.TopicDetail{
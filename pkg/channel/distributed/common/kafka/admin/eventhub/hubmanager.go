@@ -25,6 +25,7 @@ import (
 // Azure EventHub Client Doesn't Code To Interfaces Or Provide Mocks So We're Wrapping Our Usage Of The HubManager For Testing
 type HubManagerInterface interface {
 	Delete(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*eventhub.HubEntity, error)
 	List(ctx context.Context) ([]*eventhub.HubEntity, error)
 	Put(ctx context.Context, name string, opts ...eventhub.HubManagementOption) (*eventhub.HubEntity, error)
 }
@@ -53,6 +53,16 @@ func (m *MockHubManager) Delete(ctx context.Context, name string) error {
 	return args.Error(0)
 }
 
+func (m *MockHubManager) Get(ctx context.Context, name string) (*eventhub.HubEntity, error) {
+	args := m.Called(ctx, name)
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.(*eventhub.HubEntity), args.Error(1)
+	}
+}
+
 func (m *MockHubManager) List(ctx context.Context) ([]*eventhub.HubEntity, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]*eventhub.HubEntity), args.Error(1)
@@ -89,6 +99,16 @@ func WithMockedPut(ctx context.Context, topic string, returnErr bool, errCode in
 	}
 }
 
+func WithMockedGet(ctx context.Context, topic string, hubEntity *eventhub.HubEntity, err error) func(mockHubManager *MockHubManager) {
+	return func(mockHubManager *MockHubManager) {
+		if hubEntity == nil {
+			mockHubManager.On("Get", ctx, topic).Return(nil, err)
+		} else {
+			mockHubManager.On("Get", ctx, topic).Return(hubEntity, err)
+		}
+	}
+}
+
 func WithMockedDelete(ctx context.Context, topic string, returnErr bool, errCode int) func(mockHubManager *MockHubManager) {
 	return func(mockHubManager *MockHubManager) {
 		if returnErr {
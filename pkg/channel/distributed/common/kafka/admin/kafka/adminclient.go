@@ -92,6 +92,74 @@ func (k KafkaAdminClient) DeleteTopic(_ context.Context, topicName string) *sara
 	}
 }
 
+// Sarama Pass-Through Function For Describing Topics (Partitions, Replication Factor & Topic-Level Config Overrides)
+func (k KafkaAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, util.NewUnknownTopicError("unable to describe topic due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// Describe The Topic's Partitions
+	metadata, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return nil, util.PromoteErrorToTopicError(err)
+	} else if len(metadata) != 1 {
+		return nil, util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("no metadata returned for topic '%s'", topicName))
+	} else if metadata[0].Err != sarama.ErrNoError {
+		return nil, util.NewTopicError(metadata[0].Err, metadata[0].Err.Error())
+	}
+	topicDetail := &sarama.TopicDetail{NumPartitions: int32(len(metadata[0].Partitions))}
+	if len(metadata[0].Partitions) > 0 {
+		topicDetail.ReplicationFactor = int16(len(metadata[0].Partitions[0].Replicas))
+	}
+
+	// Describe The Topic's Config Overrides
+	topicDetail.ConfigEntries, err = k.describeTopicConfig(topicName)
+	if err != nil {
+		return nil, util.PromoteErrorToTopicError(err)
+	}
+
+	// Return The TopicDetail - Success
+	return topicDetail, util.NewTopicError(sarama.ErrNoError, "successfully described topic")
+}
+
+// Sarama Pass-Through Function For Altering Topic Config (Only The Specified Entries Are Changed)
+func (k KafkaAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Alter Topic Config Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return util.NewUnknownTopicError("unable to alter topic config due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// The (Non-Incremental) AlterConfigs API Replaces All Overrides, So Merge The Entries Into The Existing Ones
+	entries, err := k.describeTopicConfig(topicName)
+	if err != nil {
+		return util.PromoteErrorToTopicError(err)
+	}
+	for name, value := range configEntries {
+		entries[name] = value
+	}
+
+	err = k.clusterAdmin.AlterConfig(sarama.TopicResource, topicName, entries, false)
+	if err != nil {
+		return util.PromoteErrorToTopicError(err)
+	}
+	return util.NewTopicError(sarama.ErrNoError, "successfully altered topic config")
+}
+
+// Sarama Pass-Through Function For Increasing The (Total) Number Of Topic Partitions
+func (k KafkaAdminClient) CreatePartitions(_ context.Context, topicName string, count int32) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Create Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return util.NewUnknownTopicError("unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	err := k.clusterAdmin.CreatePartitions(topicName, count, nil, false)
+	if err != nil {
+		return util.PromoteErrorToTopicError(err)
+	}
+	return util.NewTopicError(sarama.ErrNoError, "successfully created partitions")
+}
+
 // Sarama Pass-Through Function For Closing ClusterAdmin
 func (k KafkaAdminClient) Close() error {
 	if k.clusterAdmin == nil {
@@ -101,3 +169,19 @@ func (k KafkaAdminClient) Close() error {
 		return k.clusterAdmin.Close()
 	}
 }
+
+// Get The Config Entries Set Explicitly On The Topic (Excluding Broker & Default Values)
+func (k KafkaAdminClient) describeTopicConfig(topicName string) (map[string]*string, error) {
+	entries, err := k.clusterAdmin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName})
+	if err != nil {
+		return nil, err
+	}
+	configEntries := make(map[string]*string)
+	for _, entry := range entries {
+		if entry.Source == sarama.SourceTopic || (entry.Source == sarama.SourceUnknown && !entry.Default) {
+			value := entry.Value
+			configEntries[entry.Name] = &value
+		}
+	}
+	return configEntries, nil
+}
@@ -206,6 +206,123 @@ func TestDeleteTopicInvalidAdminClient(t *testing.T) {
 	assert.Equal(t, errMsg, *resultTopicError.ErrMsg)
 }
 
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{{
+		Name: topicName,
+		Partitions: []*sarama.PartitionMetadata{
+			{ID: 0, Replicas: []int32{1, 2, 3}},
+			{ID: 1, Replicas: []int32{2, 3, 1}},
+		},
+	}}, nil)
+	mockClusterAdmin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}).Return([]sarama.ConfigEntry{
+		{Name: constants.TopicDetailConfigRetentionMs, Value: retentionMillis, Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: sarama.SourceDefault, Default: true},
+		{Name: "segment.bytes", Value: "1073741824", Source: sarama.SourceStaticBroker},
+	}, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Test
+	topicDetail, resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+
+	// Verify The Results
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, &sarama.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 3,
+		ConfigEntries:     map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis},
+	}, topicDetail)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The DescribeTopic() Functionality Of An Unknown Topic
+func TestDescribeTopicUnknown(t *testing.T) {
+
+	// Create A Mock Sarama ClusterAdmin Returning Unknown Topic Metadata
+	topicName := "TestTopicName"
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrUnknownTopicOrPartition}}, nil)
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Test & Verify The Results
+	topicDetail, resultTopicError := adminClient.DescribeTopic(context.TODO(), topicName)
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, resultTopicError.Err)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The AlterTopicConfig() Functionality Merges The Entries Into The Existing Topic Config
+func TestAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	cleanupPolicy := "compact"
+	existingRetentionMillis := "3600000"
+	existingSegmentBytes := "1048576"
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}).Return([]sarama.ConfigEntry{
+		{Name: constants.TopicDetailConfigRetentionMs, Value: existingRetentionMillis, Source: sarama.SourceTopic},
+		{Name: "segment.bytes", Value: existingSegmentBytes, Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: sarama.SourceDefault, Default: true},
+	}, nil)
+	mockClusterAdmin.On("AlterConfig", sarama.TopicResource, topicName, map[string]*string{
+		constants.TopicDetailConfigRetentionMs: &retentionMillis,
+		"segment.bytes":                        &existingSegmentBytes,
+		"cleanup.policy":                       &cleanupPolicy,
+	}).Return(nil)
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Test
+	resultTopicError := adminClient.AlterTopicConfig(context.TODO(), topicName, map[string]*string{
+		constants.TopicDetailConfigRetentionMs: &retentionMillis,
+		"cleanup.policy":                       &cleanupPolicy,
+	})
+
+	// Verify The Results
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	topicName := "TestTopicName"
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(8)).Return(nil).Once()
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(2)).Return(sarama.ErrInvalidPartitions).Once()
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Tests & Verify The Results
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(context.TODO(), topicName, 8).Err)
+	assert.Equal(t, sarama.ErrInvalidPartitions, adminClient.CreatePartitions(context.TODO(), topicName, 2).Err)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The DescribeTopic(), AlterTopicConfig() & CreatePartitions() Without ClusterAdmin Functionality
+func TestDescribeAlterInvalidAdminClient(t *testing.T) {
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	topicDetail, resultTopicError := adminClient.DescribeTopic(context.TODO(), "TestTopicName")
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+	assert.Equal(t, sarama.ErrUnknown, adminClient.AlterTopicConfig(context.TODO(), "TestTopicName", nil).Err)
+	assert.Equal(t, sarama.ErrUnknown, adminClient.CreatePartitions(context.TODO(), "TestTopicName", 2).Err)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -273,7 +390,8 @@ func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
 }
 
 func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
-	panic("implement me")
+	args := m.Called(topics)
+	return args.Get(0).([]*sarama.TopicMetadata), args.Error(1)
 }
 
 func (m *MockClusterAdmin) DeleteTopic(topic string) error {
@@ -282,7 +400,8 @@ func (m *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(topic, count)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -298,11 +417,13 @@ func (m *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int3
 }
 
 func (m *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
-	panic("implement me")
+	args := m.Called(resource)
+	return args.Get(0).([]sarama.ConfigEntry), args.Error(1)
 }
 
 func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(resourceType, name, entries)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
@@ -0,0 +1,356 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+//
+// Strimzi Kafka AdminClient Implementation (KafkaTopic Custom Resources)
+//
+// Kafka clusters managed by the Strimzi Topic Operator are expected to have
+// their Topics declared as KafkaTopic custom resources rather than being
+// created via the Kafka Admin API.  This implementation creates / deletes
+// such KafkaTopics and waits for the Topic Operator to report them as Ready.
+//
+
+// Strimzi KafkaTopic Constants
+const (
+	ClusterLabel       = "strimzi.io/cluster"
+	ReadyConditionType = "Ready"
+)
+
+// KafkaTopicGVR Is The GroupVersionResource Of The Strimzi KafkaTopic Custom Resource
+var KafkaTopicGVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkatopics"}
+
+// Polling Of The KafkaTopic Ready Condition (Variables To Facilitate Testing)
+var (
+	TopicReadyPollInterval = 500 * time.Millisecond
+	TopicReadyTimeout      = 10 * time.Second
+)
+
+// Characters Not Permitted In KafkaTopic Resource Names
+var invalidNameCharsRegexp = regexp.MustCompile(`[^a-z0-9.-]`)
+
+// Ensure The StrimziAdminClient Struct Implements The AdminClientInterface
+var _ types.AdminClientInterface = &StrimziAdminClient{}
+
+// Strimzi AdminClient Definition
+type StrimziAdminClient struct {
+	logger        *zap.Logger
+	dynamicClient dynamic.Interface
+	clusterName   string
+	namespace     string
+}
+
+// Context Key For The Strimzi Configuration
+type configKey struct{}
+
+// WithConfig Returns A Copy Of The Context Containing The Strimzi Configuration Used By NewAdminClient()
+func WithConfig(ctx context.Context, config commonconfig.EKStrimziConfig) context.Context {
+	return context.WithValue(ctx, configKey{}, config)
+}
+
+// Create A New Strimzi AdminClient Managing KafkaTopics For The Cluster In The Context's Strimzi Configuration
+func NewAdminClient(ctx context.Context) (types.AdminClientInterface, error) {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Validate The Strimzi Configuration
+	config, ok := ctx.Value(configKey{}).(commonconfig.EKStrimziConfig)
+	if !ok || len(config.ClusterName) <= 0 || len(config.Namespace) <= 0 {
+		return nil, fmt.Errorf("received context without Strimzi ClusterName and Namespace - unable to create Strimzi AdminClient")
+	}
+
+	// Create And Return A New Strimzi AdminClient
+	logger.Debug("Successfully Created New Strimzi AdminClient", zap.String("ClusterName", config.ClusterName), zap.String("Namespace", config.Namespace))
+	return &StrimziAdminClient{
+		logger:        logger,
+		dynamicClient: dynamicclient.Get(ctx),
+		clusterName:   config.ClusterName,
+		namespace:     config.Namespace,
+	}, nil
+}
+
+// Kafka AdminClient CreateTopic Implementation Using A Strimzi KafkaTopic
+func (c *StrimziAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	name := ResourceName(topicName)
+	logger := c.logger.With(zap.String("TopicName", topicName), zap.String("KafkaTopic", name))
+
+	// Create The KafkaTopic, Or Use The Existing One
+	kafkaTopic, err := c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).Create(ctx, c.newKafkaTopic(name, topicName, topicDetail), metav1.CreateOptions{})
+	alreadyExists := apierrors.IsAlreadyExists(err)
+	if alreadyExists {
+		logger.Debug("KafkaTopic Already Exists - Verifying Readiness")
+		kafkaTopic, err = c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		logger.Error("Failed To Create KafkaTopic", zap.Error(err))
+		return util.NewUnknownTopicError(fmt.Sprintf("failed to create KafkaTopic '%s/%s': %v", c.namespace, name, err))
+	}
+
+	// Wait For The Topic Operator To Report The KafkaTopic As Ready
+	ready, message := isReady(kafkaTopic)
+	if !ready && message == "" {
+		_ = wait.PollImmediate(TopicReadyPollInterval, TopicReadyTimeout, func() (bool, error) {
+			kafkaTopic, err = c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil // Keep Polling Until The Timeout
+			}
+			ready, message = isReady(kafkaTopic)
+			return ready || message != "", nil
+		})
+	}
+
+	// Map The KafkaTopic Readiness To Kafka AdminClient Results
+	if ready && alreadyExists {
+		return util.NewTopicError(sarama.ErrTopicAlreadyExists, "KafkaTopic already exists and is ready")
+	} else if ready {
+		return util.NewTopicError(sarama.ErrNoError, "successfully created KafkaTopic")
+	} else if message != "" {
+		logger.Warn("KafkaTopic Is Not Ready", zap.String("Message", message))
+		return util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("KafkaTopic '%s/%s' is not ready: %s", c.namespace, name, message))
+	} else {
+		logger.Warn("Timed Out Waiting For KafkaTopic To Become Ready")
+		return util.NewTopicError(sarama.ErrRequestTimedOut, fmt.Sprintf("KafkaTopic '%s/%s' is not yet ready", c.namespace, name))
+	}
+}
+
+// Kafka AdminClient DeleteTopic Implementation Using A Strimzi KafkaTopic (The Topic Operator Deletes The Topic)
+func (c *StrimziAdminClient) DeleteTopic(ctx context.Context, topicName string) *sarama.TopicError {
+
+	// Delete The KafkaTopic
+	name := ResourceName(topicName)
+	err := c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("KafkaTopic '%s/%s' not found", c.namespace, name))
+	} else if err != nil {
+		c.logger.Error("Failed To Delete KafkaTopic", zap.String("TopicName", topicName), zap.String("KafkaTopic", name), zap.Error(err))
+		return util.NewUnknownTopicError(err.Error())
+	}
+
+	// Return Success!
+	return util.NewTopicError(sarama.ErrNoError, "successfully deleted KafkaTopic")
+}
+
+// Kafka AdminClient DescribeTopic Implementation Using The Spec Of A Strimzi KafkaTopic
+func (c *StrimziAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+
+	// Get The KafkaTopic
+	kafkaTopic, topicError := c.getKafkaTopic(ctx, topicName)
+	if topicError != nil {
+		return nil, topicError
+	}
+
+	// Map The KafkaTopic Spec To A Sarama TopicDetail
+	partitions, _, _ := unstructured.NestedInt64(kafkaTopic.Object, "spec", "partitions")
+	replicas, _, _ := unstructured.NestedInt64(kafkaTopic.Object, "spec", "replicas")
+	topicConfig, _, _ := unstructured.NestedMap(kafkaTopic.Object, "spec", "config")
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions:     int32(partitions),
+		ReplicationFactor: int16(replicas),
+		ConfigEntries:     make(map[string]*string, len(topicConfig)),
+	}
+	for key, value := range topicConfig {
+		configValue := fmt.Sprint(value)
+		topicDetail.ConfigEntries[key] = &configValue
+	}
+
+	// Return The TopicDetail - Success
+	return topicDetail, util.NewTopicError(sarama.ErrNoError, "successfully described KafkaTopic")
+}
+
+// Kafka AdminClient AlterTopicConfig Implementation Merging The Entries Into The Config Of A Strimzi KafkaTopic
+func (c *StrimziAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	return c.updateKafkaTopic(ctx, topicName, "altered KafkaTopic config", func(kafkaTopic *unstructured.Unstructured) *sarama.TopicError {
+		topicConfig, _, _ := unstructured.NestedMap(kafkaTopic.Object, "spec", "config")
+		if topicConfig == nil {
+			topicConfig = make(map[string]interface{}, len(configEntries))
+		}
+		for key, value := range configEntries {
+			if value != nil {
+				topicConfig[key] = *value
+			} else {
+				delete(topicConfig, key)
+			}
+		}
+		_ = unstructured.SetNestedMap(kafkaTopic.Object, topicConfig, "spec", "config")
+		return nil
+	})
+}
+
+// Kafka AdminClient CreatePartitions Implementation Increasing The Partitions Of A Strimzi KafkaTopic
+func (c *StrimziAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+	return c.updateKafkaTopic(ctx, topicName, "increased KafkaTopic partitions", func(kafkaTopic *unstructured.Unstructured) *sarama.TopicError {
+		partitions, _, _ := unstructured.NestedInt64(kafkaTopic.Object, "spec", "partitions")
+		if int64(count) <= partitions {
+			return util.NewTopicError(sarama.ErrInvalidPartitions, fmt.Sprintf("KafkaTopic currently has %d partitions, which is higher than or equal to the requested %d", partitions, count))
+		}
+		_ = unstructured.SetNestedField(kafkaTopic.Object, int64(count), "spec", "partitions")
+		return nil
+	})
+}
+
+// Kafka AdminClient Close Implementation Using Strimzi KafkaTopics
+func (c *StrimziAdminClient) Close() error {
+	return nil // Nothing to "close" in the dynamic client so this is just a compatibility no-op.
+}
+
+// Get The KafkaTopic Of The Cluster Whose spec.topicName Is The Specified Kafka Topic, Mapping A Missing KafkaTopic To
+// ErrUnknownTopicOrPartition (The Topic Operator Names The KafkaTopics Of Topics It Did Not Create Itself, So Their
+// Resource Names Need Not Match ResourceName())
+func (c *StrimziAdminClient) getKafkaTopic(ctx context.Context, topicName string) (*unstructured.Unstructured, *sarama.TopicError) {
+	listOptions := metav1.ListOptions{LabelSelector: labels.Set{ClusterLabel: c.clusterName}.String()}
+	kafkaTopics, err := c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).List(ctx, listOptions)
+	if err != nil {
+		c.logger.Error("Failed To List KafkaTopics", zap.String("TopicName", topicName), zap.Error(err))
+		return nil, util.NewUnknownTopicError(err.Error())
+	}
+	for i := range kafkaTopics.Items {
+		if kafkaTopicName(&kafkaTopics.Items[i]) == topicName {
+			return &kafkaTopics.Items[i], nil
+		}
+	}
+	return nil, util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("KafkaTopic for topic '%s' not found in namespace '%s'", topicName, c.namespace))
+}
+
+// Get The Kafka Topic Name Of The KafkaTopic (Its spec.topicName, Which Defaults To Its Resource Name)
+func kafkaTopicName(kafkaTopic *unstructured.Unstructured) string {
+	if topicName, _, _ := unstructured.NestedString(kafkaTopic.Object, "spec", "topicName"); topicName != "" {
+		return topicName
+	}
+	return kafkaTopic.GetName()
+}
+
+// Update The Spec Of The KafkaTopic For The Specified Kafka Topic (The Topic Operator Applies The Changes)
+func (c *StrimziAdminClient) updateKafkaTopic(ctx context.Context, topicName string, operation string, mutate func(*unstructured.Unstructured) *sarama.TopicError) *sarama.TopicError {
+
+	// Get & Mutate The KafkaTopic
+	kafkaTopic, topicError := c.getKafkaTopic(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+	if topicError = mutate(kafkaTopic); topicError != nil {
+		return topicError
+	}
+
+	// Update The KafkaTopic
+	_, err := c.dynamicClient.Resource(KafkaTopicGVR).Namespace(c.namespace).Update(ctx, kafkaTopic, metav1.UpdateOptions{})
+	if err != nil {
+		c.logger.Error("Failed To Update KafkaTopic", zap.String("TopicName", topicName), zap.String("KafkaTopic", kafkaTopic.GetName()), zap.Error(err))
+		return util.NewUnknownTopicError(err.Error())
+	}
+	return util.NewTopicError(sarama.ErrNoError, "successfully "+operation)
+}
+
+// ResourceName Returns The KafkaTopic Resource Name For The Specified Kafka Topic Name
+//
+// Kafka Topic names may contain characters (uppercase & underscores) which are not valid in Kubernetes resource
+// names, in which case a sanitized name with a hash suffix (for uniqueness) is used.  The actual Kafka Topic name
+// is always specified in the KafkaTopic's spec.topicName.
+func ResourceName(topicName string) string {
+	if len(validation.IsDNS1123Subdomain(topicName)) == 0 {
+		return topicName
+	}
+	sanitized := strings.Trim(invalidNameCharsRegexp.ReplaceAllString(strings.ToLower(topicName), "-"), ".-")
+	topicNameHash := fnv.New32a()
+	_, _ = topicNameHash.Write([]byte(topicName))
+	return kmeta.ChildName(sanitized+"-", strconv.FormatUint(uint64(topicNameHash.Sum32()), 36))
+}
+
+// Create The Unstructured KafkaTopic For The Specified Kafka Topic
+func (c *StrimziAdminClient) newKafkaTopic(name string, topicName string, topicDetail *sarama.TopicDetail) *unstructured.Unstructured {
+	topicConfig := make(map[string]interface{}, len(topicDetail.ConfigEntries))
+	for key, value := range topicDetail.ConfigEntries {
+		if value != nil {
+			topicConfig[key] = *value
+		}
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": KafkaTopicGVR.GroupVersion().String(),
+			"kind":       "KafkaTopic",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": c.namespace,
+				"labels": map[string]interface{}{
+					ClusterLabel: c.clusterName,
+				},
+			},
+			"spec": map[string]interface{}{
+				"topicName":  topicName,
+				"partitions": int64(topicDetail.NumPartitions),
+				"replicas":   int64(topicDetail.ReplicationFactor),
+				"config":     topicConfig,
+			},
+		},
+	}
+}
+
+// Determine Whether The KafkaTopic Is Ready, Returning The Message Of A "False" Ready Condition
+func isReady(kafkaTopic *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(kafkaTopic.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["type"] != ReadyConditionType {
+			continue
+		}
+		switch conditionMap["status"] {
+		case "True":
+			return true, ""
+		case "False":
+			message, _ := conditionMap["message"].(string)
+			if message == "" {
+				message, _ = conditionMap["reason"].(string)
+			}
+			if message == "" {
+				message = "Ready condition is False"
+			}
+			return false, message
+		}
+	}
+	return false, ""
+}
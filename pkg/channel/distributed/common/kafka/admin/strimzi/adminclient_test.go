@@ -0,0 +1,268 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic/fake"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+// Test Data
+const (
+	clusterName = "my-cluster"
+	namespace   = "kafka"
+	topicName   = "knative-messaging-kafka.test-namespace.test-channel"
+)
+
+// Test The NewAdminClient() Functionality
+func TestNewAdminClient(t *testing.T) {
+
+	// Verify The Strimzi Configuration Is Required
+	ctx, _ := newTestContext(t)
+	adminClient, err := NewAdminClient(ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, adminClient)
+
+	// Verify The AdminClient Uses The Strimzi Configuration
+	ctx = WithConfig(ctx, commonconfig.EKStrimziConfig{ClusterName: clusterName, Namespace: namespace})
+	adminClient, err = NewAdminClient(ctx)
+	assert.Nil(t, err)
+	strimziAdminClient, ok := adminClient.(*StrimziAdminClient)
+	assert.True(t, ok)
+	assert.Equal(t, clusterName, strimziAdminClient.clusterName)
+	assert.Equal(t, namespace, strimziAdminClient.namespace)
+	assert.Nil(t, adminClient.Close())
+}
+
+// Test The CreateTopic() Functionality
+func TestCreateTopic(t *testing.T) {
+
+	// Shorten The Readiness Polling For The Test
+	stubTopicReadyTimeout(t, 50*time.Millisecond)
+
+	// Create A Strimzi AdminClient With A Fake Dynamic Client
+	ctx, dynamicClient := newTestContext(t)
+	adminClient, err := NewAdminClient(WithConfig(ctx, commonconfig.EKStrimziConfig{ClusterName: clusterName, Namespace: namespace}))
+	assert.Nil(t, err)
+
+	// Verify The KafkaTopic Is Created But Reported As Not Yet Ready
+	retentionMillis := "604800000"
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions:     4,
+		ReplicationFactor: 3,
+		ConfigEntries:     map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis},
+	}
+	topicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	assert.Equal(t, sarama.ErrRequestTimedOut, topicError.Err)
+
+	kafkaTopic, err := dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Get(ctx, topicName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, clusterName, kafkaTopic.GetLabels()[ClusterLabel])
+	spec, _, _ := unstructured.NestedMap(kafkaTopic.Object, "spec")
+	assert.Equal(t, map[string]interface{}{
+		"topicName":  topicName,
+		"partitions": int64(4),
+		"replicas":   int64(3),
+		"config":     map[string]interface{}{constants.TopicDetailConfigRetentionMs: retentionMillis},
+	}, spec)
+
+	// Verify A False Ready Condition Is Reported
+	setReadyCondition(t, dynamicClient, kafkaTopic, "False", "Invalid total replication factor")
+	topicError = adminClient.CreateTopic(ctx, topicName, topicDetail)
+	assert.Equal(t, sarama.ErrInvalidConfig, topicError.Err)
+	assert.Contains(t, *topicError.ErrMsg, "Invalid total replication factor")
+
+	// Verify The Ready KafkaTopic Is Reported As Existing
+	setReadyCondition(t, dynamicClient, kafkaTopic, "True", "")
+	topicError = adminClient.CreateTopic(ctx, topicName, topicDetail)
+	assert.Equal(t, sarama.ErrTopicAlreadyExists, topicError.Err)
+}
+
+// Test The CreateTopic() Functionality Waits For The KafkaTopic To Become Ready
+func TestCreateTopicWaitsForReady(t *testing.T) {
+
+	// Create A Strimzi AdminClient With A Fake Dynamic Client
+	ctx, dynamicClient := newTestContext(t)
+	adminClient, err := NewAdminClient(WithConfig(ctx, commonconfig.EKStrimziConfig{ClusterName: clusterName, Namespace: namespace}))
+	assert.Nil(t, err)
+
+	// Emulate The Topic Operator Reconciling The KafkaTopic
+	go func() {
+		for {
+			kafkaTopic, err := dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Get(ctx, topicName, metav1.GetOptions{})
+			if err == nil {
+				setReadyCondition(t, dynamicClient, kafkaTopic, "True", "")
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	topicError := adminClient.CreateTopic(ctx, topicName, &sarama.TopicDetail{NumPartitions: 1, ReplicationFactor: 1})
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+}
+
+// Test The DeleteTopic() Functionality
+func TestDeleteTopic(t *testing.T) {
+
+	// Create A Strimzi AdminClient With A Fake Dynamic Client Containing The KafkaTopic
+	ctx, dynamicClient := newTestContext(t)
+	adminClient, err := NewAdminClient(WithConfig(ctx, commonconfig.EKStrimziConfig{ClusterName: clusterName, Namespace: namespace}))
+	assert.Nil(t, err)
+	kafkaTopic := adminClient.(*StrimziAdminClient).newKafkaTopic(topicName, topicName, &sarama.TopicDetail{NumPartitions: 1, ReplicationFactor: 1})
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Create(ctx, kafkaTopic, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	// Verify The KafkaTopic Is Deleted
+	topicError := adminClient.DeleteTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Get(ctx, topicName, metav1.GetOptions{})
+	assert.NotNil(t, err)
+
+	// Verify A Missing KafkaTopic Is Reported As An Unknown Topic
+	topicError = adminClient.DeleteTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, topicError.Err)
+}
+
+// Test The DescribeTopic(), AlterTopicConfig() & CreatePartitions() Functionality
+func TestDescribeAndAlterTopic(t *testing.T) {
+
+	// Create A Strimzi AdminClient With A Fake Dynamic Client Containing The KafkaTopic
+	ctx, dynamicClient := newTestContext(t)
+	adminClient, err := NewAdminClient(WithConfig(ctx, commonconfig.EKStrimziConfig{ClusterName: clusterName, Namespace: namespace}))
+	assert.Nil(t, err)
+	retentionMillis := "604800000"
+	cleanupPolicy := "compact"
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions:     4,
+		ReplicationFactor: 3,
+		ConfigEntries:     map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis},
+	}
+	kafkaTopic := adminClient.(*StrimziAdminClient).newKafkaTopic(topicName, topicName, topicDetail)
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Create(ctx, kafkaTopic, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	// Verify The KafkaTopic Is Described
+	resultTopicDetail, topicError := adminClient.DescribeTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+	assert.Equal(t, topicDetail, resultTopicDetail)
+
+	// Verify The Config Entries Are Merged Into The KafkaTopic Config
+	topicError = adminClient.AlterTopicConfig(ctx, topicName, map[string]*string{"cleanup.policy": &cleanupPolicy})
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+
+	// Verify The Partitions Are Increased (But Not Decreased)
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(ctx, topicName, 8).Err)
+	assert.Equal(t, sarama.ErrInvalidPartitions, adminClient.CreatePartitions(ctx, topicName, 2).Err)
+
+	resultTopicDetail, topicError = adminClient.DescribeTopic(ctx, topicName)
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+	assert.Equal(t, &sarama.TopicDetail{
+		NumPartitions:     8,
+		ReplicationFactor: 3,
+		ConfigEntries:     map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis, "cleanup.policy": &cleanupPolicy},
+	}, resultTopicDetail)
+
+	// Verify A KafkaTopic Named By The Topic Operator Is Found By Its spec.topicName (Only In The Configured Cluster)
+	operatorTopicName := "Operator_Topic"
+	operatorKafkaTopic := adminClient.(*StrimziAdminClient).newKafkaTopic("operator-topic---4a1f2b3c", operatorTopicName, topicDetail)
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Create(ctx, operatorKafkaTopic, metav1.CreateOptions{})
+	assert.Nil(t, err)
+	otherClusterKafkaTopic := adminClient.(*StrimziAdminClient).newKafkaTopic("other-cluster-topic", "Other_Cluster_Topic", topicDetail)
+	otherClusterKafkaTopic.SetLabels(map[string]string{ClusterLabel: "other-cluster"})
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Create(ctx, otherClusterKafkaTopic, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	resultTopicDetail, topicError = adminClient.DescribeTopic(ctx, operatorTopicName)
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+	assert.Equal(t, topicDetail, resultTopicDetail)
+	assert.Equal(t, sarama.ErrNoError, adminClient.AlterTopicConfig(ctx, operatorTopicName, map[string]*string{"cleanup.policy": &cleanupPolicy}).Err)
+	assert.Equal(t, sarama.ErrNoError, adminClient.CreatePartitions(ctx, operatorTopicName, 8).Err)
+	operatorKafkaTopic, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Get(ctx, "operator-topic---4a1f2b3c", metav1.GetOptions{})
+	assert.Nil(t, err)
+	partitions, _, _ := unstructured.NestedInt64(operatorKafkaTopic.Object, "spec", "partitions")
+	assert.Equal(t, int64(8), partitions)
+	_, topicError = adminClient.DescribeTopic(ctx, "Other_Cluster_Topic")
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, topicError.Err)
+
+	// Verify A KafkaTopic Without spec.topicName Is Found By Its Resource Name
+	unnamedKafkaTopic := adminClient.(*StrimziAdminClient).newKafkaTopic("unnamed-topic", "", topicDetail)
+	unstructured.RemoveNestedField(unnamedKafkaTopic.Object, "spec", "topicName")
+	_, err = dynamicClient.Resource(KafkaTopicGVR).Namespace(namespace).Create(ctx, unnamedKafkaTopic, metav1.CreateOptions{})
+	assert.Nil(t, err)
+	_, topicError = adminClient.DescribeTopic(ctx, "unnamed-topic")
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+
+	// Verify A Missing KafkaTopic Is Reported As An Unknown Topic
+	_, topicError = adminClient.DescribeTopic(ctx, "missing-topic")
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, topicError.Err)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, adminClient.AlterTopicConfig(ctx, "missing-topic", nil).Err)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, adminClient.CreatePartitions(ctx, "missing-topic", 8).Err)
+}
+
+// Test The ResourceName() Functionality
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, topicName, ResourceName(topicName))
+
+	invalidName := "Knative_Messaging_Kafka.Test.Channel"
+	resourceName := ResourceName(invalidName)
+	assert.Empty(t, validation.IsDNS1123Subdomain(resourceName))
+	assert.Contains(t, resourceName, "knative-messaging-kafka.test.channel-")
+	assert.NotEqual(t, resourceName, ResourceName("knative_messaging_kafka.test.channel"))
+}
+
+// Create A Context With Test Logger & A Fake Dynamic Client (Able To List KafkaTopics)
+func newTestContext(t *testing.T) (context.Context, *fake.FakeDynamicClient) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{KafkaTopicGVR: "KafkaTopicList"})
+	return context.WithValue(ctx, dynamicclient.Key{}, dynamicClient), dynamicClient
+}
+
+// Set The Ready Condition Of The KafkaTopic (As The Strimzi Topic Operator Would)
+func setReadyCondition(t *testing.T, dynamicClient *fake.FakeDynamicClient, kafkaTopic *unstructured.Unstructured, status string, message string) {
+	kafkaTopic = kafkaTopic.DeepCopy()
+	condition := map[string]interface{}{"type": ReadyConditionType, "status": status}
+	if message != "" {
+		condition["message"] = message
+	}
+	assert.Nil(t, unstructured.SetNestedSlice(kafkaTopic.Object, []interface{}{condition}, "status", "conditions"))
+	_, err := dynamicClient.Resource(KafkaTopicGVR).Namespace(kafkaTopic.GetNamespace()).Update(context.TODO(), kafkaTopic, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+}
+
+// Stub The KafkaTopic Readiness Polling Timeout For The Duration Of The Test
+func stubTopicReadyTimeout(t *testing.T, timeout time.Duration) {
+	originalInterval, originalTimeout := TopicReadyPollInterval, TopicReadyTimeout
+	TopicReadyPollInterval, TopicReadyTimeout = 10*time.Millisecond, timeout
+	t.Cleanup(func() { TopicReadyPollInterval, TopicReadyTimeout = originalInterval, originalTimeout })
+}
@@ -44,6 +44,18 @@ func (c MockAdminClient) DeleteTopic(context.Context, string) *sarama.TopicError
 	return nil
 }
 
+func (c MockAdminClient) DescribeTopic(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError) {
+	return nil, nil
+}
+
+func (c MockAdminClient) AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) CreatePartitions(context.Context, string, int32) *sarama.TopicError {
+	return nil
+}
+
 func (c MockAdminClient) Close() error {
 	return nil
 }
@@ -29,6 +29,7 @@ const (
 	Kafka AdminClientType = iota
 	EventHub
 	Custom
+	Strimzi
 	Unknown
 )
 
@@ -36,5 +37,8 @@ const (
 type AdminClientInterface interface {
 	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
 	DeleteTopic(context.Context, string) *sarama.TopicError
+	DescribeTopic(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError)
+	AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError // Sets The Specified Entries Only
+	CreatePartitions(context.Context, string, int32) *sarama.TopicError              // Total Partition Count
 	Close() error
 }
@@ -24,6 +24,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/eventhub"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/kafka"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/strimzi"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 )
 
@@ -42,6 +43,8 @@ func ProductionNewAdminClientWrapper(ctx context.Context, brokers []string, conf
 		return eventhub.NewAdminClient(ctx, config) // Config Must Contain EventHub Namespace ConnectionString In Net.SASL.Password Field !
 	case types.Custom:
 		return custom.NewAdminClient(ctx)
+	case types.Strimzi:
+		return strimzi.NewAdminClient(ctx) // Context Must Contain The Strimzi Config (See strimzi.WithConfig()) !
 	case types.Unknown:
 		return nil, fmt.Errorf("received unknown AdminClientType") // Should Never Happen But...
 	default:
@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
 
@@ -32,6 +33,15 @@ func TopicName(namespace string, name string) string {
 	return fmt.Sprintf("%s.%s", namespace, name)
 }
 
+// ChannelTopicName returns the Kafka Topic name of the specified KafkaChannel, which is either the existing
+// Topic specified by the KafkaChannel or the Topic name derived from the KafkaChannel's namespace and name.
+func ChannelTopicName(channel *kafkav1beta1.KafkaChannel) string {
+	if len(channel.Spec.Topic) > 0 {
+		return channel.Spec.Topic
+	}
+	return TopicName(channel.Namespace, channel.Name)
+}
+
 // GroupId returns a formatted string representing the Kafka ConsumerGroup ID.
 func GroupId(uid string) string {
 	return fmt.Sprintf("%s.%s", GroupIdPrefix, uid)
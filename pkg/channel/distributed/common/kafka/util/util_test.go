@@ -21,8 +21,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
 
@@ -41,6 +43,14 @@ func TestTopicName(t *testing.T) {
 	assert.Equal(t, expectedTopicName, actualTopicName)
 }
 
+// Test The ChannelTopicName() Functionality
+func TestChannelTopicName(t *testing.T) {
+	channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Namespace: "TestNamespace", Name: "TestName"}}
+	assert.Equal(t, "TestNamespace.TestName", ChannelTopicName(channel))
+	channel.Spec.Topic = "existing-topic"
+	assert.Equal(t, "existing-topic", ChannelTopicName(channel))
+}
+
 // Test The GroupId() Functionality
 func TestGroupId(t *testing.T) {
 
@@ -38,18 +38,45 @@ func VerifyConfiguration(configuration *commonconfig.EventingKafkaConfig) error
 	// Verify & Lowercase The Kafka AdminType
 	lowercaseKafkaAdminType := strings.ToLower(configuration.Channel.AdminType)
 	switch lowercaseKafkaAdminType {
-	case constants.KafkaAdminTypeValueKafka, constants.KafkaAdminTypeValueAzure, constants.KafkaAdminTypeValueCustom:
+	case constants.KafkaAdminTypeValueKafka, constants.KafkaAdminTypeValueAzure, constants.KafkaAdminTypeValueCustom, constants.KafkaAdminTypeValueStrimzi:
 		configuration.Channel.AdminType = lowercaseKafkaAdminType
 	default:
 		return ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: " + configuration.Channel.AdminType)
 	}
 
+	// Verify The Strimzi Cluster Is Identified When Using The Strimzi AdminType
+	if configuration.Channel.AdminType == constants.KafkaAdminTypeValueStrimzi &&
+		(configuration.Channel.Strimzi.ClusterName == "" || configuration.Channel.Strimzi.Namespace == "") {
+		return ControllerConfigurationError("Distributed.Strimzi.ClusterName and Namespace must be set for the strimzi AdminType")
+	}
+
 	// Verify mandatory configuration settings
 	switch {
 	case configuration.Channel.Dispatcher.Replicas < 1:
 		return ControllerConfigurationError("Distributed.Dispatcher.Replicas must be > 0")
 	case configuration.Channel.Receiver.Replicas < 1:
 		return ControllerConfigurationError("Distributed.Receiver.Replicas must be > 0")
+	case configuration.Channel.SharedDispatcher.Capacity < 0:
+		return ControllerConfigurationError("Distributed.SharedDispatcher.Capacity must be >= 0")
+	}
+
+	// Verify the optional dispatcher autoscaling settings (zero values are defaulted by the autoscaler)
+	autoscaling := configuration.Channel.Autoscaling
+	switch {
+	case autoscaling.MinReplicas < 0, autoscaling.MaxReplicas < 0:
+		return ControllerConfigurationError("Distributed.Autoscaling.MinReplicas and MaxReplicas must be >= 0")
+	case autoscaling.MaxReplicas > 0 && autoscaling.MinReplicas > autoscaling.MaxReplicas:
+		return ControllerConfigurationError("Distributed.Autoscaling.MinReplicas must be <= MaxReplicas")
+	case autoscaling.LagPerReplica < 0:
+		return ControllerConfigurationError("Distributed.Autoscaling.LagPerReplica must be >= 0")
+	case autoscaling.IntervalSeconds < 0, autoscaling.ScaleUpCooldownSeconds < 0, autoscaling.ScaleDownCooldownSeconds < 0:
+		return ControllerConfigurationError("Distributed.Autoscaling intervals and cooldowns must be >= 0")
+	}
+
+	// Default The Shared Dispatcher Capacity If Not Specified
+	if configuration.Channel.SharedDispatcher.Capacity == 0 {
+		configuration.Channel.SharedDispatcher.Capacity = constants.DefaultSharedDispatcherCapacity
 	}
+
 	return nil // no problems found
 }
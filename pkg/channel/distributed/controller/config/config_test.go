@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 )
 
@@ -40,6 +41,8 @@ const (
 	receiverCpuRquest     = "10m"
 	receiverMemoryLimit   = "20Mi"
 	receiverCpuLimit      = "100m"
+
+	sharedDispatcherCapacity = 50
 )
 
 // Define The TestCase Struct
@@ -59,25 +62,31 @@ type TestCase struct {
 	receiverMemoryRequest   resource.Quantity
 	receiverReplicas        int
 
+	sharedDispatcherCapacity int32
+
+	autoscaling commonconfig.EKDispatcherAutoscalingConfig
+	strimzi     commonconfig.EKStrimziConfig
+
 	expectedError error
 }
 
 // Get The Base / Valid Test Case - All Config Specified / No Errors
 func getValidTestCase(name string) TestCase {
 	return TestCase{
-		name:                    name,
-		kafkaAdminType:          kafkaAdminType,
-		dispatcherCpuLimit:      resource.MustParse(dispatcherCpuLimit),
-		dispatcherCpuRequest:    resource.MustParse(dispatcherCpuRequest),
-		dispatcherMemoryLimit:   resource.MustParse(dispatcherMemoryLimit),
-		dispatcherMemoryRequest: resource.MustParse(dispatcherMemoryRequest),
-		dispatcherReplicas:      dispatcherReplicas,
-		receiverCpuLimit:        resource.MustParse(receiverCpuLimit),
-		receiverCpuRequest:      resource.MustParse(receiverCpuRquest),
-		receiverMemoryLimit:     resource.MustParse(receiverMemoryLimit),
-		receiverMemoryRequest:   resource.MustParse(receiverMemoryRequest),
-		receiverReplicas:        receiverReplicas,
-		expectedError:           nil,
+		name:                     name,
+		kafkaAdminType:           kafkaAdminType,
+		dispatcherCpuLimit:       resource.MustParse(dispatcherCpuLimit),
+		dispatcherCpuRequest:     resource.MustParse(dispatcherCpuRequest),
+		dispatcherMemoryLimit:    resource.MustParse(dispatcherMemoryLimit),
+		dispatcherMemoryRequest:  resource.MustParse(dispatcherMemoryRequest),
+		dispatcherReplicas:       dispatcherReplicas,
+		receiverCpuLimit:         resource.MustParse(receiverCpuLimit),
+		receiverCpuRequest:       resource.MustParse(receiverCpuRquest),
+		receiverMemoryLimit:      resource.MustParse(receiverMemoryLimit),
+		receiverMemoryRequest:    resource.MustParse(receiverMemoryRequest),
+		receiverReplicas:         receiverReplicas,
+		sharedDispatcherCapacity: sharedDispatcherCapacity,
+		expectedError:            nil,
 	}
 }
 
@@ -132,6 +141,50 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Distributed.Receiver.Replicas must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Invalid Config - Distributed SharedDispatcher.Capacity")
+	testCase.sharedDispatcherCapacity = -1
+	testCase.expectedError = ControllerConfigurationError("Distributed.SharedDispatcher.Capacity must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - SharedDispatcher.Capacity = Zero (default)")
+	testCase.sharedDispatcherCapacity = 0
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Autoscaling")
+	testCase.autoscaling = commonconfig.EKDispatcherAutoscalingConfig{Enabled: true, MinReplicas: 1, MaxReplicas: 4, LagPerReplica: 100}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Autoscaling.MinReplicas")
+	testCase.autoscaling = commonconfig.EKDispatcherAutoscalingConfig{Enabled: true, MinReplicas: -1}
+	testCase.expectedError = ControllerConfigurationError("Distributed.Autoscaling.MinReplicas and MaxReplicas must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Autoscaling.MinReplicas > MaxReplicas")
+	testCase.autoscaling = commonconfig.EKDispatcherAutoscalingConfig{Enabled: true, MinReplicas: 5, MaxReplicas: 4}
+	testCase.expectedError = ControllerConfigurationError("Distributed.Autoscaling.MinReplicas must be <= MaxReplicas")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Autoscaling.LagPerReplica")
+	testCase.autoscaling = commonconfig.EKDispatcherAutoscalingConfig{Enabled: true, LagPerReplica: -1}
+	testCase.expectedError = ControllerConfigurationError("Distributed.Autoscaling.LagPerReplica must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Autoscaling.ScaleDownCooldownSeconds")
+	testCase.autoscaling = commonconfig.EKDispatcherAutoscalingConfig{Enabled: true, ScaleDownCooldownSeconds: -1}
+	testCase.expectedError = ControllerConfigurationError("Distributed.Autoscaling intervals and cooldowns must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Strimzi AdminType")
+	testCase.kafkaAdminType = constants.KafkaAdminTypeValueStrimzi
+	testCase.strimzi = commonconfig.EKStrimziConfig{ClusterName: "my-cluster", Namespace: "kafka"}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Strimzi AdminType Without ClusterName")
+	testCase.kafkaAdminType = constants.KafkaAdminTypeValueStrimzi
+	testCase.strimzi = commonconfig.EKStrimziConfig{Namespace: "kafka"}
+	testCase.expectedError = ControllerConfigurationError("Distributed.Strimzi.ClusterName and Namespace must be set for the strimzi AdminType")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Kafka.Provider")
 	testCase.kafkaAdminType = "invalidadmintype"
 	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: invalidadmintype")
@@ -151,6 +204,9 @@ func TestVerifyConfiguration(t *testing.T) {
 			testConfig.Channel.Receiver.MemoryLimit = testCase.receiverMemoryLimit
 			testConfig.Channel.Receiver.MemoryRequest = testCase.receiverMemoryRequest
 			testConfig.Channel.Receiver.Replicas = testCase.receiverReplicas
+			testConfig.Channel.SharedDispatcher.Capacity = testCase.sharedDispatcherCapacity
+			testConfig.Channel.Autoscaling = testCase.autoscaling
+			testConfig.Channel.Strimzi = testCase.strimzi
 
 			// Perform The Test
 			err := VerifyConfiguration(testConfig)
@@ -169,6 +225,11 @@ func TestVerifyConfiguration(t *testing.T) {
 				assert.Equal(t, testCase.receiverMemoryLimit, testConfig.Channel.Receiver.MemoryLimit)
 				assert.Equal(t, testCase.receiverMemoryRequest, testConfig.Channel.Receiver.MemoryRequest)
 				assert.Equal(t, testCase.receiverReplicas, testConfig.Channel.Receiver.Replicas)
+				if testCase.sharedDispatcherCapacity == 0 {
+					assert.Equal(t, int32(constants.DefaultSharedDispatcherCapacity), testConfig.Channel.SharedDispatcher.Capacity)
+				} else {
+					assert.Equal(t, testCase.sharedDispatcherCapacity, testConfig.Channel.SharedDispatcher.Capacity)
+				}
 			} else {
 				assert.Equal(t, testCase.expectedError, err)
 			}
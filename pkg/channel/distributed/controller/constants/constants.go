@@ -22,9 +22,10 @@ const (
 	Component = "eventing-kafka-channel-controller"
 
 	// Kafka Admin Type Types
-	KafkaAdminTypeValueKafka  = "kafka"
-	KafkaAdminTypeValueAzure  = "azure"
-	KafkaAdminTypeValueCustom = "custom"
+	KafkaAdminTypeValueKafka   = "kafka"
+	KafkaAdminTypeValueAzure   = "azure"
+	KafkaAdminTypeValueCustom  = "custom"
+	KafkaAdminTypeValueStrimzi = "strimzi"
 
 	// The Controller's Component Name (Needs To Be DNS Safe!)
 	ControllerComponentName = "eventing-kafka-channel-controller"
@@ -37,6 +38,7 @@ const (
 	ConfigMapKind           = "ConfigMap"
 	ServiceKind             = "Service"
 	DeploymentKind          = "Deployment"
+	StatefulSetKind         = "StatefulSet"
 	KnativeSubscriptionKind = "Subscription"
 	KafkaChannelKind        = "KafkaChannel"
 
@@ -58,6 +60,24 @@ const (
 	ReconciliationFailedError = "reconciliation failed"
 	FinalizationFailedError   = "finalization failed"
 
+	// Shared Dispatcher Configuration
+	SharedDispatcherName            = "kafkachannel-dispatcher" // Name Of The Shared Dispatcher StatefulSet / Service
+	DefaultSharedDispatcherCapacity = 100                       // Default Maximum Number Of KafkaChannels Per Shared Dispatcher Replica
+	SharedDispatcherRefreshPeriod   = 10                        // Shared Dispatcher Scheduler Autoscaling Refresh Period (Seconds)
+
+	// Dispatcher Autoscaling Configuration
+	DefaultAutoscalingMinReplicas       = 1                                            // Default Minimum Dispatcher Replicas
+	DefaultAutoscalingLagPerReplica     = 1000                                         // Default Consumer Group Lag Handled By Each Dispatcher Replica
+	DefaultAutoscalingInterval          = 30                                           // Default Autoscaling Evaluation Interval (Seconds)
+	DefaultAutoscalingScaleUpCooldown   = 60                                           // Default Minimum Time Between Scaling Up (Seconds)
+	DefaultAutoscalingScaleDownCooldown = 300                                          // Default Minimum Time Between Scaling Down (Seconds)
+	LastScaleTimeAnnotationKey          = "kafka.eventing.knative.dev/last-scale-time" // Time Of The Dispatcher Deployment's Last Autoscaling Change (RFC3339)
+
+	// Subscriber Status Messages
+	SubscriberGroupStoppedMessage      = "consumer group is stopped"
+	SubscriberNoStatusMessage          = "no status reported by dispatcher"
+	SubscriberPartitionsClaimedMessage = "%d of %d partitions claimed"
+
 	// Eventing-Kafka Finalizers Prefix
 	EventingKafkaFinalizerPrefix = "eventing-kafka/"
 	KafkaChannelFinalizerSuffix  = "kafkachannels.messaging.knative.dev" // Matches default value in client/injection/reconciler/messaging/v1beta1/kafkachannel
@@ -37,17 +37,21 @@ const (
 
 	// Receiver Configuration
 	ReceiverImageEnvVarKey = "RECEIVER_IMAGE"
+
+	// Receiver & Dispatcher ServiceAccount Configuration
+	DataPlaneServiceAccountEnvVarKey = "DATA_PLANE_SERVICE_ACCOUNT"
 )
 
 // Environment Structure
 type Environment struct {
 
 	// Eventing-kafka Configuration
-	SystemNamespace string        // Required
-	ServiceAccount  string        // Required
-	MetricsPort     int           // Required
-	MetricsDomain   string        // Required
-	ResyncPeriod    time.Duration // Optional
+	SystemNamespace         string        // Required
+	ServiceAccount          string        // Required
+	DataPlaneServiceAccount string        // Optional (Defaults To ServiceAccount)
+	MetricsPort             int           // Required
+	MetricsDomain           string        // Required
+	ResyncPeriod            time.Duration // Optional
 
 	// Dispatcher Configuration
 	DispatcherImage string // Required
@@ -80,6 +84,9 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	// Get The Optional K8S ServiceAccount Of The Receivers & Dispatchers (Which Need Less Access Than The Controller)
+	environment.DataPlaneServiceAccount = env.GetOptionalConfigValue(logger, DataPlaneServiceAccountEnvVarKey, environment.ServiceAccount)
+
 	// Get The Required Metrics Domain Config Value
 	environment.MetricsDomain, err = env.GetRequiredConfigValue(logger, env.MetricsDomainEnvVarKey)
 	if err != nil {
@@ -36,6 +36,7 @@ import (
 const (
 	systemNamespace     = "test-system-namespace"
 	serviceAccount      = "TestServiceAccount"
+	dataPlaneAccount    = "TestDataPlaneServiceAccount"
 	metricsPort         = "9999"
 	metricsDomain       = "example.com/kafka-eventing"
 	resyncPeriodMinutes = "3600"
@@ -52,6 +53,7 @@ type TestCase struct {
 	name                  string
 	systemNamespace       string
 	serviceAccount        string
+	dataPlaneAccount      string
 	metricsPort           string
 	metricsDomain         string
 	resyncPeriodMinutes   string
@@ -83,6 +85,10 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(env.ServiceAccountEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Default DataPlaneServiceAccount")
+	testCase.dataPlaneAccount = ""
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - MetricsDomain")
 	testCase.metricsDomain = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(env.MetricsDomainEnvVarKey)
@@ -134,6 +140,11 @@ func TestGetEnvironment(t *testing.T) {
 				assert.NotNil(t, environment)
 				assert.Equal(t, testCase.systemNamespace, environment.SystemNamespace)
 				assert.Equal(t, testCase.serviceAccount, environment.ServiceAccount)
+				if testCase.dataPlaneAccount != "" {
+					assert.Equal(t, testCase.dataPlaneAccount, environment.DataPlaneServiceAccount)
+				} else {
+					assert.Equal(t, testCase.serviceAccount, environment.DataPlaneServiceAccount)
+				}
 				assert.Equal(t, testCase.metricsPort, strconv.Itoa(environment.MetricsPort))
 				assert.Equal(t, testCase.channelImage, environment.ReceiverImage)
 				assert.Equal(t, testCase.dispatcherImage, environment.DispatcherImage)
@@ -165,6 +176,7 @@ func setupTestEnvironment(t *testing.T, testCase TestCase) {
 	os.Clearenv()
 	assertSetenv(t, system.NamespaceEnvKey, testCase.systemNamespace)
 	assertSetenv(t, env.ServiceAccountEnvVarKey, testCase.serviceAccount)
+	assertSetenvNonempty(t, DataPlaneServiceAccountEnvVarKey, testCase.dataPlaneAccount)
 	assertSetenv(t, env.MetricsDomainEnvVarKey, testCase.metricsDomain)
 	assertSetenvNonempty(t, env.MetricsPortEnvVarKey, testCase.metricsPort)
 	assertSetenv(t, DispatcherImageEnvVarKey, testCase.dispatcherImage)
@@ -177,6 +189,7 @@ func getValidTestCase(name string) TestCase {
 	return TestCase{
 		name:                  name,
 		serviceAccount:        serviceAccount,
+		dataPlaneAccount:      dataPlaneAccount,
 		systemNamespace:       systemNamespace,
 		metricsPort:           metricsPort,
 		metricsDomain:         metricsDomain,
@@ -56,6 +56,9 @@ const (
 	DispatcherDeploymentUpdateFailed
 	DispatcherServicePatched
 	DispatcherServicePatchFailed
+	DispatcherSubscribersStatusReconciliationFailed
+	DispatcherDeploymentScaled
+	DispatcherDeploymentScaleFailed
 
 	// Kafka Secret Reconciliation
 	KafkaSecretReconciled
@@ -114,6 +117,12 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "DispatcherServicePatched"
 	case DispatcherServicePatchFailed:
 		eventTypeString = "DispatcherServicePatchFailed"
+	case DispatcherSubscribersStatusReconciliationFailed:
+		eventTypeString = "DispatcherSubscribersStatusReconciliationFailed"
+	case DispatcherDeploymentScaled:
+		eventTypeString = "DispatcherDeploymentScaled"
+	case DispatcherDeploymentScaleFailed:
+		eventTypeString = "DispatcherDeploymentScaleFailed"
 	case KafkaSecretReconciled:
 		eventTypeString = "KafkaSecretReconciled"
 	case KafkaSecretFinalized:
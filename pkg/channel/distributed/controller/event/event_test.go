@@ -45,6 +45,9 @@ func TestEventTypes(t *testing.T) {
 	performEventTypeStringTest(t, DispatcherDeploymentUpdateFailed, "DispatcherDeploymentUpdateFailed")
 	performEventTypeStringTest(t, DispatcherServicePatched, "DispatcherServicePatched")
 	performEventTypeStringTest(t, DispatcherServicePatchFailed, "DispatcherServicePatchFailed")
+	performEventTypeStringTest(t, DispatcherSubscribersStatusReconciliationFailed, "DispatcherSubscribersStatusReconciliationFailed")
+	performEventTypeStringTest(t, DispatcherDeploymentScaled, "DispatcherDeploymentScaled")
+	performEventTypeStringTest(t, DispatcherDeploymentScaleFailed, "DispatcherDeploymentScaleFailed")
 	performEventTypeStringTest(t, KafkaSecretReconciled, "KafkaSecretReconciled")
 	performEventTypeStringTest(t, KafkaSecretFinalized, "KafkaSecretFinalized")
 }
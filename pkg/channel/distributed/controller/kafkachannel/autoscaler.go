@@ -0,0 +1,254 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// consumerGroupLagFunc Returns The Greatest Lag Of The Specified ConsumerGroups On The Specified Topic
+type consumerGroupLagFunc func(ctx context.Context, topic string, groupIds []string) (int64, error)
+
+// Run The Lag-Driven Dispatcher Autoscaler At The Specified Interval Until The Context Is Done
+func (r *Reconciler) runDispatcherAutoscaler(ctx context.Context, interval time.Duration) {
+	logger := logging.FromContext(ctx).Desugar()
+	logger.Info("Starting Dispatcher Autoscaler", zap.Duration("Interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping Dispatcher Autoscaler")
+			r.closeConsumerGroupLagClient()
+			return
+		case <-ticker.C:
+			r.autoscaleDispatchers(ctx, time.Now())
+		}
+	}
+}
+
+// Autoscale The Dedicated Dispatcher Deployments Of All KafkaChannels
+func (r *Reconciler) autoscaleDispatchers(ctx context.Context, now time.Time) {
+
+	// The Shared Dispatcher Is Scaled By Its Scheduler Instead
+	if r.scheduler != nil {
+		return
+	}
+
+	logger := logging.FromContext(ctx).Desugar()
+	channels, err := r.kafkachannelLister.List(labels.Everything())
+	if err != nil {
+		logger.Error("Failed To List KafkaChannels For Autoscaling", zap.Error(err))
+		return
+	}
+
+	for _, channel := range channels {
+
+		// Skip Deleted KafkaChannels & Those With An Explicit Replicas Override
+		if !channel.DeletionTimestamp.IsZero() || (channel.Spec.Dispatcher != nil && channel.Spec.Dispatcher.Replicas != nil) {
+			continue
+		}
+
+		// Only The Leader For A KafkaChannel Scales Its Dispatcher (Other Controller Replicas Would Race It)
+		if r.isLeaderFor != nil && !r.isLeaderFor(apitypes.NamespacedName{Namespace: channel.Namespace, Name: channel.Name}) {
+			continue
+		}
+
+		channelCtx := logging.WithLogger(ctx, util.ChannelLogger(logger, channel).Sugar())
+		_ = r.autoscaleDispatcher(channelCtx, channel, now) // Errors Are Logged & Reported As Events
+	}
+}
+
+// Autoscale The Dedicated Dispatcher Deployment Of The Specified KafkaChannel Based On Its Subscribers' Lag
+func (r *Reconciler) autoscaleDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel, now time.Time) error {
+
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Get The Dispatcher Deployment (Nothing To Scale Until It Has Been Reconciled)
+	deployment, err := r.getDispatcherDeployment(channel)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error("Failed To Get Dispatcher Deployment For Autoscaling", zap.Error(err))
+		return err
+	}
+
+	// Determine The Greatest Lag Of The KafkaChannel's Subscribers (Each Is A Separate ConsumerGroup)
+	groupIds := make([]string, 0, len(channel.Spec.Subscribers))
+	for _, subscriber := range channel.Spec.Subscribers {
+		groupIds = append(groupIds, commonkafkautil.GroupId(string(subscriber.UID)))
+	}
+	var lag int64
+	if len(groupIds) > 0 {
+		lag, err = r.consumerGroupLag(ctx, util.TopicName(channel), groupIds)
+		if err != nil {
+			logger.Error("Failed To Determine ConsumerGroup Lag For Autoscaling", zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentScaleFailed.String(), "Failed To Determine ConsumerGroup Lag: %v", err)
+			return err
+		}
+	}
+
+	// Determine The Current & Desired Replicas
+	autoscaling := autoscalingConfigWithDefaults(r.config.Channel.Autoscaling)
+	currentReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		currentReplicas = *deployment.Spec.Replicas
+	}
+	desiredReplicas := desiredDispatcherReplicas(lag, autoscaling, channel.Spec.NumPartitions)
+	if desiredReplicas == currentReplicas {
+		return nil
+	}
+
+	// Respect The Cooldown Since The Last Scaling Change
+	cooldown := time.Duration(autoscaling.ScaleUpCooldownSeconds) * time.Second
+	if desiredReplicas < currentReplicas {
+		cooldown = time.Duration(autoscaling.ScaleDownCooldownSeconds) * time.Second
+	}
+	if lastScaleTime, err := time.Parse(time.RFC3339, deployment.Annotations[constants.LastScaleTimeAnnotationKey]); err == nil && now.Sub(lastScaleTime) < cooldown {
+		logger.Debug("Dispatcher Autoscaling Deferred (Cooldown)", zap.Int32("Current", currentReplicas), zap.Int32("Desired", desiredReplicas), zap.Int64("Lag", lag))
+		return nil
+	}
+
+	// Scale The Dispatcher Deployment & Track The Time Of The Change
+	deployment = deployment.DeepCopy()
+	deployment.Spec.Replicas = &desiredReplicas
+	if deployment.Annotations == nil {
+		deployment.Annotations = make(map[string]string)
+	}
+	deployment.Annotations[constants.LastScaleTimeAnnotationKey] = now.UTC().Format(time.RFC3339)
+	_, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+	if err != nil {
+		logger.Error("Failed To Scale Dispatcher Deployment", zap.Error(err))
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentScaleFailed.String(), "Failed To Scale Dispatcher Deployment From %d To %d Replicas: %v", currentReplicas, desiredReplicas, err)
+		return err
+	}
+	logger.Info("Dispatcher Deployment Scaled", zap.Int32("From", currentReplicas), zap.Int32("To", desiredReplicas), zap.Int64("Lag", lag))
+	controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeNormal, event.DispatcherDeploymentScaled.String(), "Dispatcher Deployment Scaled From %d To %d Replicas (ConsumerGroup Lag %d)", currentReplicas, desiredReplicas, lag)
+	return nil
+}
+
+// Determine The Dispatcher Replicas Required To Handle The Specified Lag, Within The Configured Bounds & NumPartitions
+func desiredDispatcherReplicas(lag int64, autoscaling commonconfig.EKDispatcherAutoscalingConfig, numPartitions int32) int32 {
+
+	// Replicas Beyond The Number Of Partitions Would Sit Idle
+	maxReplicas := autoscaling.MaxReplicas
+	if maxReplicas <= 0 || (numPartitions > 0 && maxReplicas > numPartitions) {
+		maxReplicas = numPartitions
+	}
+
+	replicas := int64(autoscaling.MinReplicas)
+	if required := (lag + autoscaling.LagPerReplica - 1) / autoscaling.LagPerReplica; required > replicas {
+		replicas = required
+	}
+	if maxReplicas > 0 && replicas > int64(maxReplicas) {
+		replicas = int64(maxReplicas)
+	}
+	return int32(replicas)
+}
+
+// Return A Copy Of The Autoscaling Config With Defaults Applied To Any Unspecified (Zero) Values
+func autoscalingConfigWithDefaults(autoscaling commonconfig.EKDispatcherAutoscalingConfig) commonconfig.EKDispatcherAutoscalingConfig {
+	if autoscaling.MinReplicas <= 0 {
+		autoscaling.MinReplicas = constants.DefaultAutoscalingMinReplicas
+	}
+	if autoscaling.LagPerReplica <= 0 {
+		autoscaling.LagPerReplica = constants.DefaultAutoscalingLagPerReplica
+	}
+	if autoscaling.IntervalSeconds <= 0 {
+		autoscaling.IntervalSeconds = constants.DefaultAutoscalingInterval
+	}
+	if autoscaling.ScaleUpCooldownSeconds <= 0 {
+		autoscaling.ScaleUpCooldownSeconds = constants.DefaultAutoscalingScaleUpCooldown
+	}
+	if autoscaling.ScaleDownCooldownSeconds <= 0 {
+		autoscaling.ScaleDownCooldownSeconds = constants.DefaultAutoscalingScaleDownCooldown
+	}
+	return autoscaling
+}
+
+// Determine The Greatest Lag Of The Specified ConsumerGroups On The Specified Topic Via The Kafka Brokers
+func (r *Reconciler) kafkaConsumerGroupLag(_ context.Context, topic string, groupIds []string) (int64, error) {
+
+	r.lagMutex.Lock()
+	defer r.lagMutex.Unlock()
+
+	// Lazily Create The Kafka Client & ClusterAdmin, Which Are Reused Across Autoscaler Ticks
+	if r.lagClusterAdmin == nil {
+		client, err := sarama.NewClient(strings.Split(r.config.Kafka.Brokers, ","), r.config.Sarama.Config)
+		if err != nil {
+			return -1, err
+		}
+		clusterAdmin, err := sarama.NewClusterAdminFromClient(client)
+		if err != nil {
+			_ = client.Close()
+			return -1, err
+		}
+		r.lagClient = client
+		r.lagClusterAdmin = clusterAdmin
+	}
+
+	var maxLag int64
+	for _, groupId := range groupIds {
+		lag, err := offset.GetConsumerGroupLag(r.lagClient, r.lagClusterAdmin, []string{topic}, groupId)
+		if err != nil {
+			// Discard The Connections So The Next Tick Starts Fresh (See The Sarama ClusterAdmin "broken-pipe" Issues)
+			r.closeConsumerGroupLagClientLocked()
+			return -1, err
+		}
+		if lag > maxLag {
+			maxLag = lag
+		}
+	}
+	return maxLag, nil
+}
+
+// Close The Autoscaler's Kafka Client & ClusterAdmin, If Any (Closing The ClusterAdmin Also Closes The Client)
+func (r *Reconciler) closeConsumerGroupLagClient() {
+	r.lagMutex.Lock()
+	defer r.lagMutex.Unlock()
+	r.closeConsumerGroupLagClientLocked()
+}
+
+// Close The Autoscaler's Kafka Client & ClusterAdmin While Already Holding The lagMutex
+func (r *Reconciler) closeConsumerGroupLagClientLocked() {
+	if r.lagClusterAdmin != nil {
+		_ = r.lagClusterAdmin.Close()
+	}
+	r.lagClient = nil
+	r.lagClusterAdmin = nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test The desiredDispatcherReplicas() Functionality
+func TestDesiredDispatcherReplicas(t *testing.T) {
+	autoscaling := commonconfig.EKDispatcherAutoscalingConfig{MinReplicas: 1, MaxReplicas: 5, LagPerReplica: 100}
+	tests := []struct {
+		name          string
+		lag           int64
+		maxReplicas   int32
+		numPartitions int32
+		want          int32
+	}{
+		{name: "No Lag", lag: 0, maxReplicas: 5, numPartitions: 10, want: 1},
+		{name: "Partial Replica", lag: 150, maxReplicas: 5, numPartitions: 10, want: 2},
+		{name: "Exceeds MaxReplicas", lag: 10000, maxReplicas: 5, numPartitions: 10, want: 5},
+		{name: "Exceeds NumPartitions", lag: 10000, maxReplicas: 5, numPartitions: 3, want: 3},
+		{name: "No MaxReplicas", lag: 10000, maxReplicas: 0, numPartitions: 8, want: 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			autoscaling.MaxReplicas = test.maxReplicas
+			assert.Equal(t, test.want, desiredDispatcherReplicas(test.lag, autoscaling, test.numPartitions))
+		})
+	}
+}
+
+// Test The autoscaleDispatcher() Functionality
+func TestAutoscaleDispatcher(t *testing.T) {
+
+	commontesting.SetTestEnvironment(t)
+	now := time.Now()
+	subscriberUid := types.UID("test-subscriber-uid")
+
+	tests := []struct {
+		name          string
+		lag           int64
+		lagErr        error
+		lastScaleTime time.Time
+		wantErr       bool
+		wantReplicas  int32
+		wantScaled    bool
+	}{
+		{
+			name:         "Scale Up",
+			lag:          2500,
+			wantReplicas: 3,
+			wantScaled:   true,
+		},
+		{
+			name:          "Scale Up Deferred By Cooldown",
+			lag:           2500,
+			lastScaleTime: now.Add(-10 * time.Second),
+			wantReplicas:  controllertesting.DispatcherReplicas,
+		},
+		{
+			name:         "No Change",
+			lag:          500,
+			wantReplicas: controllertesting.DispatcherReplicas,
+		},
+		{
+			name:         "Lag Error",
+			lagErr:       errors.New("test lag error"),
+			wantErr:      true,
+			wantReplicas: controllertesting.DispatcherReplicas,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create The Test Data
+			ctx := newDispatcherTestContext(t)
+			channel := controllertesting.NewKafkaChannel()
+			channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: subscriberUid}}
+			deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+			if !test.lastScaleTime.IsZero() {
+				deployment.Annotations = map[string]string{constants.LastScaleTimeAnnotationKey: test.lastScaleTime.UTC().Format(time.RFC3339)}
+			}
+			r := newDispatcherTestReconciler([]runtime.Object{channel, deployment}, nil)
+			r.consumerGroupLag = func(_ context.Context, topic string, groupIds []string) (int64, error) {
+				assert.Equal(t, util.TopicName(channel), topic)
+				assert.Equal(t, []string{commonkafkautil.GroupId(string(subscriberUid))}, groupIds)
+				return test.lag, test.lagErr
+			}
+
+			// Perform The Test
+			err := r.autoscaleDispatcher(ctx, channel, now)
+
+			// Verify The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			updatedDeployment := getTestDeployment(ctx, t, r, deployment)
+			assert.Equal(t, test.wantReplicas, *updatedDeployment.Spec.Replicas)
+			if test.wantScaled {
+				assert.Equal(t, now.UTC().Format(time.RFC3339), updatedDeployment.Annotations[constants.LastScaleTimeAnnotationKey])
+			}
+		})
+	}
+}
+
+// Test The autoscaleDispatchers() Functionality Skips KafkaChannels With A Replicas Override
+func TestAutoscaleDispatchersReplicasOverride(t *testing.T) {
+	commontesting.SetTestEnvironment(t)
+	ctx := newDispatcherTestContext(t)
+	replicas := int32(1)
+	channel := controllertesting.NewKafkaChannel(withDispatcherSpec(&kafkav1beta1.KafkaChannelDispatcherSpec{Replicas: &replicas}))
+	channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: "test-subscriber-uid"}}
+	deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	r := newDispatcherTestReconciler([]runtime.Object{channel, deployment}, nil)
+	r.consumerGroupLag = func(context.Context, string, []string) (int64, error) {
+		t.Error("Unexpected ConsumerGroup Lag Request For KafkaChannel With Replicas Override")
+		return 0, nil
+	}
+	r.autoscaleDispatchers(ctx, time.Now())
+	assert.Equal(t, replicas, *getTestDeployment(ctx, t, r, deployment).Spec.Replicas)
+}
+
+// Test The autoscaleDispatchers() Functionality Skips KafkaChannels This Controller Replica Is Not The Leader For
+func TestAutoscaleDispatchersNotLeader(t *testing.T) {
+	commontesting.SetTestEnvironment(t)
+	ctx := newDispatcherTestContext(t)
+	channel := controllertesting.NewKafkaChannel()
+	channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: "test-subscriber-uid"}}
+	deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	r := newDispatcherTestReconciler([]runtime.Object{channel, deployment}, nil)
+	r.isLeaderFor = func(key types.NamespacedName) bool {
+		assert.Equal(t, types.NamespacedName{Namespace: channel.Namespace, Name: channel.Name}, key)
+		return false
+	}
+	r.consumerGroupLag = func(context.Context, string, []string) (int64, error) {
+		t.Error("Unexpected ConsumerGroup Lag Request For KafkaChannel Not Led By This Replica")
+		return 0, nil
+	}
+	r.autoscaleDispatchers(ctx, time.Now())
+	assert.Equal(t, int32(controllertesting.DispatcherReplicas), *getTestDeployment(ctx, t, r, deployment).Spec.Replicas)
+}
+
+// Get The Current Version Of The Specified Deployment From The Reconciler's Fake Clientset
+func getTestDeployment(ctx context.Context, t *testing.T, r *Reconciler, deployment *appsv1.Deployment) *appsv1.Deployment {
+	updatedDeployment, err := r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	return updatedDeployment
+}
@@ -19,16 +19,25 @@ package kafkachannel
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	kafkachannelv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	kafkaclientsetinjection "knative.dev/eventing-kafka/pkg/client/injection/client"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
@@ -36,8 +45,14 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing/pkg/scheduler"
+	stsscheduler "knative.dev/eventing/pkg/scheduler/statefulset"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	"knative.dev/pkg/client/injection/kube/informers/apps/v1/statefulset"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/node"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -56,7 +71,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Get The Needed Informers
 	kafkachannelInformer := kafkachannel.Get(ctx)
 	deploymentInformer := deployment.Get(ctx)
+	statefulSetInformer := statefulset.Get(ctx)
 	serviceInformer := service.Get(ctx)
+	podInformer := pod.Get(ctx)
 
 	// Load The Environment Variables
 	environment, err := env.FromContext(ctx)
@@ -96,6 +113,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		kafkaAdminClientType = types.EventHub
 	case constants.KafkaAdminTypeValueCustom:
 		kafkaAdminClientType = types.Custom
+	case constants.KafkaAdminTypeValueStrimzi:
+		kafkaAdminClientType = types.Strimzi
 	default:
 		logger.Warn("Encountered Unexpected Kafka AdminType - Defaulting To 'kafka'", zap.String("AdminType", configuration.Channel.AdminType))
 		kafkaAdminClientType = types.Kafka
@@ -110,7 +129,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		kafkachannelLister:   kafkachannelInformer.Lister(),
 		kafkachannelInformer: kafkachannelInformer.Informer(),
 		deploymentLister:     deploymentInformer.Lister(),
+		statefulSetLister:    statefulSetInformer.Lister(),
 		serviceLister:        serviceInformer.Lister(),
+		podLister:            podInformer.Lister(),
+		connectionPool:       ctrlreconciler.NewInsecureControlPlaneConnectionPool(),
 		adminClientType:      kafkaAdminClientType,
 		adminClient:          nil,
 		adminMutex:           &sync.Mutex{},
@@ -120,6 +142,51 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Create A New KafkaChannel Controller Impl With The Reconciler
 	controllerImpl := kafkachannelreconciler.NewImpl(ctx, rec)
 
+	// Create The Shared Dispatcher's Scheduler If Enabled (Changes Require A Controller Restart)
+	if configuration.Channel.SharedDispatcher.Enabled {
+		capacity := configuration.Channel.SharedDispatcher.Capacity
+		if capacity <= 0 {
+			capacity = constants.DefaultSharedDispatcherCapacity
+		}
+		logger.Info("Shared Dispatcher Enabled", zap.Int32("Capacity", capacity))
+		rec.scheduler = stsscheduler.NewScheduler(ctx,
+			environment.SystemNamespace,
+			constants.SharedDispatcherName,
+			rec.vpodLister,
+			constants.SharedDispatcherRefreshPeriod*time.Second,
+			capacity,
+			scheduler.MAXFILLUP,
+			node.Get(ctx).Lister(),
+			func(pod *corev1.Pod, vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+				return rec.evictSharedDispatcherPod(ctx, pod, vpod, from)
+			},
+			nil,
+			nil)
+	}
+
+	// Start The Lag-Driven Dispatcher Autoscaler If Enabled (Changes Require A Controller Restart)
+	if configuration.Channel.Autoscaling.Enabled && rec.scheduler == nil {
+		autoscaling := autoscalingConfigWithDefaults(configuration.Channel.Autoscaling)
+		rec.consumerGroupLag = rec.kafkaConsumerGroupLag
+		if leaderAware, ok := controllerImpl.Reconciler.(interface {
+			IsLeaderFor(apitypes.NamespacedName) bool
+		}); ok {
+			rec.isLeaderFor = leaderAware.IsLeaderFor
+		}
+		go rec.runDispatcherAutoscaler(controller.WithEventRecorder(ctx, newEventRecorder(ctx, kubeClientset)), time.Duration(autoscaling.IntervalSeconds)*time.Second)
+	}
+
+	// Create The Store For The Subscribers' Status Notifications Sent By The Dispatchers
+	// (The Shared Dispatcher's Notifications Pertain To All KafkaChannels, So Enqueue Them All)
+	sharedDispatcherKey := sharedDispatcherNamespacedName(environment.SystemNamespace)
+	rec.subscribersStatusStore = ctrlreconciler.NewNotificationStore(func(key apitypes.NamespacedName) {
+		if key == sharedDispatcherKey {
+			controllerImpl.GlobalResync(kafkachannelInformer.Informer())
+		} else {
+			controllerImpl.EnqueueKey(key)
+		}
+	}, distributedcontrol.SubscribersStatusParser)
+
 	// Call GlobalResync on kafkachannels.
 	grCh := func(interface{}) {
 		logger.Info("Changes detected, doing global resync")
@@ -162,11 +229,36 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		FilterFunc: FilterKafkaChannelOwnerByReferenceOrLabel(),
 		Handler:    controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
 	})
+	statefulSetInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterWithNameAndNamespace(environment.SystemNamespace, constants.SharedDispatcherName),
+		Handler:    controller.HandleAll(grCh),
+	})
 
 	// Return The KafkaChannel Controller Impl
 	return controllerImpl
 }
 
+// Get The EventRecorder From The Context, Or Create One (As The Generated Reconciler Does) If Absent
+func newEventRecorder(ctx context.Context, kubeClientset kubernetes.Interface) record.EventRecorder {
+	recorder := controller.GetEventRecorder(ctx)
+	if recorder == nil {
+		logger := logging.FromContext(ctx)
+		eventBroadcaster := record.NewBroadcaster()
+		watches := []watch.Interface{
+			eventBroadcaster.StartLogging(logger.Named("event-broadcaster").Infof),
+			eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")}),
+		}
+		recorder = eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: constants.ControllerComponentName})
+		go func() {
+			<-ctx.Done()
+			for _, w := range watches {
+				w.Stop()
+			}
+		}()
+	}
+	return recorder
+}
+
 // FilterKafkaChannelOwnerByReferenceOrLabel - Custom Filter For Common K8S Components "Owned" By KafkaChannels
 //
 // This function is similar to, and based on, the various knative.dev/pkg/controller/FilterXYZ
@@ -200,4 +292,5 @@ func FilterKafkaChannelOwnerByReferenceOrLabel() func(obj interface{}) bool {
 // Shutdown - Graceful Shutdown Hook
 func Shutdown() {
 	rec.ClearKafkaAdminClient(context.Background())
+	rec.connectionPool.Close(context.Background())
 }
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
@@ -37,10 +38,14 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
 	fakeConfigmapLoader "knative.dev/eventing-kafka/pkg/common/configmaploader/fake"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 	"knative.dev/pkg/client/injection/kube/client/fake"
-	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake" // Knative Fake Informer Injection
-	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"    // Knative Fake Informer Injection
+	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake"  // Knative Fake Informer Injection
+	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/statefulset/fake" // Knative Fake Informer Injection
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/node/fake"        // Knative Fake Informer Injection
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"         // Knative Fake Informer Injection
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"     // Knative Fake Informer Injection
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/logging"
@@ -205,17 +210,20 @@ func TestFilterKafkaChannelOwnerByReferenceOrLabel(t *testing.T) {
 // Test The Shutdown() Functionality
 func TestShutdown(t *testing.T) {
 
-	// Create A Mock AdminClient To Test Closing
+	// Create A Mock AdminClient & ConnectionPool To Test Closing
 	mockAdminClient := &controllertesting.MockAdminClient{}
+	mockConnectionPool := &controlprotocoltesting.MockConnectionPool{}
+	mockConnectionPool.On("Close", mock.Anything).Return()
 
 	// Set The Package Level The Reconciler To Test Against
-	rec = &Reconciler{adminClient: mockAdminClient}
+	rec = &Reconciler{adminClient: mockAdminClient, connectionPool: mockConnectionPool}
 
 	// Perform The Test
 	Shutdown()
 
 	// Verify The Results
 	assert.True(t, mockAdminClient.CloseCalled())
+	mockConnectionPool.AssertExpectations(t)
 }
 
 // Utility Function For Populating Required Environment Variables For Testing
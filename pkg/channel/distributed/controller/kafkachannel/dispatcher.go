@@ -43,6 +43,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
 )
 
 //
@@ -58,6 +59,14 @@ import (
 // Reconcile The Dispatcher For The Specified KafkaChannel - Ensure Desired State!
 func (r *Reconciler) reconcileDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
 
+	// Use The Shared Dispatcher Instead Of A Dedicated One If Enabled
+	if r.scheduler != nil {
+		return r.reconcileSharedDispatcher(ctx, channel)
+	}
+
+	// Clear Any Placements Left Over From When The Shared Dispatcher Was Enabled
+	channel.Status.Placements = nil
+
 	// Get The Channel-Specific Logger Provided Via The Context
 	logger := logging.FromContext(ctx).Desugar()
 
@@ -353,6 +362,16 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, logger *
 		// (This includes the configmap hash annotation, so configmap changes will trigger updates)
 		updatedDeployment, needsUpdate := util.CheckDeploymentChanged(logger, existingDeployment, newDeployment)
 
+		// Apply Any Per-KafkaChannel Replicas Override (The Global Replicas Are Otherwise Only Used On Creation)
+		if channel.Spec.Dispatcher != nil && channel.Spec.Dispatcher.Replicas != nil &&
+			(updatedDeployment.Spec.Replicas == nil || *updatedDeployment.Spec.Replicas != *channel.Spec.Dispatcher.Replicas) {
+			if !needsUpdate {
+				updatedDeployment = updatedDeployment.DeepCopy()
+			}
+			updatedDeployment.Spec.Replicas = newDeployment.Spec.Replicas
+			needsUpdate = true
+		}
+
 		// Update the deployment in Kubernetes if necessary
 		if needsUpdate {
 			updatedDeployment, err = r.kubeClientset.AppsV1().Deployments(newDeployment.Namespace).Update(ctx, updatedDeployment, metav1.UpdateOptions{})
@@ -434,8 +453,11 @@ func (r *Reconciler) newDispatcherDeployment(logger *zap.Logger, channel *kafkav
 	// Get The Dispatcher Deployment Name For The Channel
 	deploymentName := util.DispatcherDnsSafeName(channel)
 
-	// Replicas Int Value For De-Referencing
+	// Replicas Int Value For De-Referencing (Per-KafkaChannel Override Takes Precedence)
 	replicas := int32(r.config.Channel.Dispatcher.Replicas)
+	if channel.Spec.Dispatcher != nil && channel.Spec.Dispatcher.Replicas != nil {
+		replicas = *channel.Spec.Dispatcher.Replicas
+	}
 
 	// Create The Dispatcher Container Environment Variables
 	envVars, err := r.dispatcherDeploymentEnvVars(channel)
@@ -444,35 +466,6 @@ func (r *Reconciler) newDispatcherDeployment(logger *zap.Logger, channel *kafkav
 		return nil, err
 	}
 
-	// There is a difference between setting an entry in the limits or requests map to the zero-value
-	// of a Quantity and not actually having that entry in the map at all.
-	// If we want "no limit" or "no request" then the entry must not be present in the map.
-	// Note: Since a "Quantity" type has no nil value, we use the Zero value to represent unlimited.
-	resourceLimits := make(map[corev1.ResourceName]resource.Quantity)
-	if !r.config.Channel.Dispatcher.MemoryLimit.IsZero() {
-		resourceLimits[corev1.ResourceMemory] = r.config.Channel.Dispatcher.MemoryLimit
-	}
-	if !r.config.Channel.Dispatcher.CpuLimit.IsZero() {
-		resourceLimits[corev1.ResourceCPU] = r.config.Channel.Dispatcher.CpuLimit
-	}
-	resourceRequests := make(map[corev1.ResourceName]resource.Quantity)
-	if !r.config.Channel.Dispatcher.MemoryRequest.IsZero() {
-		resourceRequests[corev1.ResourceMemory] = r.config.Channel.Dispatcher.MemoryRequest
-	}
-	if !r.config.Channel.Dispatcher.CpuRequest.IsZero() {
-		resourceRequests[corev1.ResourceCPU] = r.config.Channel.Dispatcher.CpuRequest
-	}
-
-	// If either the limits or requests are an entirely-empty map, this will be translated to a nil
-	// value in the resource itself, so pre-emptively set that here.  This prevents the CheckDeploymentChanged
-	// function from returning a difference and restarting the dispatcher unnecessarily.
-	if len(resourceLimits) == 0 {
-		resourceLimits = nil
-	}
-	if len(resourceRequests) == 0 {
-		resourceRequests = nil
-	}
-
 	// Create The Dispatcher Deployment
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -511,63 +504,7 @@ func (r *Reconciler) newDispatcherDeployment(logger *zap.Logger, channel *kafkav
 						commonconstants.ConfigMapHashAnnotationKey: r.kafkaConfigMapHash,
 					},
 				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: r.environment.ServiceAccount,
-					Containers: []corev1.Container{
-						{
-							Name: deploymentName,
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Port: intstr.FromInt(constants.HealthPort),
-										Path: health.LivenessPath,
-									},
-								},
-								InitialDelaySeconds: constants.DispatcherLivenessDelay,
-								PeriodSeconds:       constants.DispatcherLivenessPeriod,
-								TimeoutSeconds:      constants.DispatcherLivenessTimeout,
-								SuccessThreshold:    constants.DispatcherLivenessSuccessThreshold,
-								FailureThreshold:    constants.DispatcherLivenessFailureThreshold,
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Port: intstr.FromInt(constants.HealthPort),
-										Path: health.ReadinessPath,
-									},
-								},
-								InitialDelaySeconds: constants.DispatcherReadinessDelay,
-								PeriodSeconds:       constants.DispatcherReadinessPeriod,
-								TimeoutSeconds:      constants.DispatcherReadinessTimeout,
-								SuccessThreshold:    constants.DispatcherReadinessSuccessThreshold,
-								FailureThreshold:    constants.DispatcherReadinessFailureThreshold,
-							},
-							Image:           r.environment.DispatcherImage,
-							Env:             envVars,
-							ImagePullPolicy: corev1.PullIfNotPresent,
-							Resources: corev1.ResourceRequirements{
-								Limits:   resourceLimits,
-								Requests: resourceRequests,
-							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      commonconstants.SettingsConfigMapName,
-									MountPath: commonconstants.SettingsConfigMapMountPath,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: commonconstants.SettingsConfigMapName,
-							VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: commonconstants.SettingsConfigMapName,
-								},
-							}},
-						},
-					},
-				},
+				Spec: r.newDispatcherPodSpec(deploymentName, envVars),
 			},
 		},
 	}
@@ -578,17 +515,187 @@ func (r *Reconciler) newDispatcherDeployment(logger *zap.Logger, channel *kafkav
 	deployment.Spec.Template.ObjectMeta.Annotations = commonconfig.JoinStringMaps(deployment.Spec.Template.ObjectMeta.Annotations, r.config.Channel.Dispatcher.PodAnnotations)
 	deployment.Spec.Template.ObjectMeta.Labels = commonconfig.JoinStringMaps(deployment.Spec.Template.ObjectMeta.Labels, r.config.Channel.Dispatcher.PodLabels)
 
+	// Merge Any Per-KafkaChannel Overrides Over The Global Dispatcher Config
+	if channel.Spec.Dispatcher != nil {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Containers[0].Resources = mergeResourceRequirements(podSpec.Containers[0].Resources, channel.Spec.Dispatcher.Resources)
+		podSpec.NodeSelector = channel.Spec.Dispatcher.NodeSelector
+		podSpec.Tolerations = channel.Spec.Dispatcher.Tolerations
+	}
+
 	// Return The Dispatcher Deployment
 	return deployment, nil
 }
 
+// Create The Dispatcher Pod Spec (Shared By The Dedicated Deployment & Shared StatefulSet)
+func (r *Reconciler) newDispatcherPodSpec(containerName string, envVars []corev1.EnvVar) corev1.PodSpec {
+	return corev1.PodSpec{
+		ServiceAccountName: r.environment.DataPlaneServiceAccount,
+		Containers: []corev1.Container{
+			{
+				Name: containerName,
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Port: intstr.FromInt(constants.HealthPort),
+							Path: health.LivenessPath,
+						},
+					},
+					InitialDelaySeconds: constants.DispatcherLivenessDelay,
+					PeriodSeconds:       constants.DispatcherLivenessPeriod,
+					TimeoutSeconds:      constants.DispatcherLivenessTimeout,
+					SuccessThreshold:    constants.DispatcherLivenessSuccessThreshold,
+					FailureThreshold:    constants.DispatcherLivenessFailureThreshold,
+				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Port: intstr.FromInt(constants.HealthPort),
+							Path: health.ReadinessPath,
+						},
+					},
+					InitialDelaySeconds: constants.DispatcherReadinessDelay,
+					PeriodSeconds:       constants.DispatcherReadinessPeriod,
+					TimeoutSeconds:      constants.DispatcherReadinessTimeout,
+					SuccessThreshold:    constants.DispatcherReadinessSuccessThreshold,
+					FailureThreshold:    constants.DispatcherReadinessFailureThreshold,
+				},
+				Image:           r.environment.DispatcherImage,
+				Env:             envVars,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Resources:       r.dispatcherResourceRequirements(),
+				VolumeMounts: append([]corev1.VolumeMount{
+					{
+						Name:      commonconstants.SettingsConfigMapName,
+						MountPath: commonconstants.SettingsConfigMapMountPath,
+					},
+				}, claimcheck.VolumeMounts(r.config.Channel.ClaimCheck)...),
+			},
+		},
+		Volumes: append([]corev1.Volume{
+			{
+				Name: commonconstants.SettingsConfigMapName,
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: commonconstants.SettingsConfigMapName,
+					},
+				}},
+			},
+		}, claimcheck.Volumes(r.config.Channel.ClaimCheck)...),
+	}
+}
+
+// Create The Dispatcher Container's Resource Requirements
+func (r *Reconciler) dispatcherResourceRequirements() corev1.ResourceRequirements {
+
+	// There is a difference between setting an entry in the limits or requests map to the zero-value
+	// of a Quantity and not actually having that entry in the map at all.
+	// If we want "no limit" or "no request" then the entry must not be present in the map.
+	// Note: Since a "Quantity" type has no nil value, we use the Zero value to represent unlimited.
+	resourceLimits := make(map[corev1.ResourceName]resource.Quantity)
+	if !r.config.Channel.Dispatcher.MemoryLimit.IsZero() {
+		resourceLimits[corev1.ResourceMemory] = r.config.Channel.Dispatcher.MemoryLimit
+	}
+	if !r.config.Channel.Dispatcher.CpuLimit.IsZero() {
+		resourceLimits[corev1.ResourceCPU] = r.config.Channel.Dispatcher.CpuLimit
+	}
+	resourceRequests := make(map[corev1.ResourceName]resource.Quantity)
+	if !r.config.Channel.Dispatcher.MemoryRequest.IsZero() {
+		resourceRequests[corev1.ResourceMemory] = r.config.Channel.Dispatcher.MemoryRequest
+	}
+	if !r.config.Channel.Dispatcher.CpuRequest.IsZero() {
+		resourceRequests[corev1.ResourceCPU] = r.config.Channel.Dispatcher.CpuRequest
+	}
+
+	// If either the limits or requests are an entirely-empty map, this will be translated to a nil
+	// value in the resource itself, so pre-emptively set that here.  This prevents the CheckDeploymentChanged
+	// function from returning a difference and restarting the dispatcher unnecessarily.
+	if len(resourceLimits) == 0 {
+		resourceLimits = nil
+	}
+	if len(resourceRequests) == 0 {
+		resourceRequests = nil
+	}
+
+	return corev1.ResourceRequirements{
+		Limits:   resourceLimits,
+		Requests: resourceRequests,
+	}
+}
+
+// Merge The Override ResourceRequirements Over The Specified Defaults (Individually By Resource Name).  An Inherited
+// Request Above An Overridden Limit Is Capped At That Limit, And An Inherited Limit Below An Overridden Request Is
+// Raised To That Request, So That The Merged Requests Never Exceed The Merged Limits (Which The API Server Rejects).
+func mergeResourceRequirements(defaults corev1.ResourceRequirements, overrides corev1.ResourceRequirements) corev1.ResourceRequirements {
+	limits := mergeResourceList(defaults.Limits, overrides.Limits)
+	requests := mergeResourceList(defaults.Requests, overrides.Requests)
+	for name, limit := range overrides.Limits {
+		if _, overridden := overrides.Requests[name]; !overridden {
+			if request, ok := requests[name]; ok && request.Cmp(limit) > 0 {
+				requests[name] = limit
+			}
+		}
+	}
+	for name, request := range overrides.Requests {
+		if _, overridden := overrides.Limits[name]; !overridden {
+			if limit, ok := limits[name]; ok && request.Cmp(limit) > 0 {
+				limits[name] = request
+			}
+		}
+	}
+	return corev1.ResourceRequirements{
+		Limits:   limits,
+		Requests: requests,
+	}
+}
+
+// Merge The Override ResourceList Over A Copy Of The Specified Defaults (Nil If Empty - See dispatcherResourceRequirements)
+func mergeResourceList(defaults corev1.ResourceList, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(overrides) == 0 {
+		return defaults.DeepCopy()
+	}
+	merged := make(corev1.ResourceList, len(defaults)+len(overrides))
+	for name, quantity := range defaults {
+		merged[name] = quantity
+	}
+	for name, quantity := range overrides {
+		merged[name] = quantity
+	}
+	return merged
+}
+
 // Create The Dispatcher Container's Env Vars
 func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChannel) ([]corev1.EnvVar, error) {
 
 	// Get The TopicName For Specified Channel
 	topicName := util.TopicName(channel)
 
-	// Create The Dispatcher Deployment EnvVars
+	// Validate The Kafka Secret Name - Cannot Proceed Without One
+	if len(r.config.Kafka.AuthSecretName) <= 0 {
+		return nil, fmt.Errorf("invalid authSecretName for topic '%s'", topicName)
+	}
+
+	// Create The Dispatcher Deployment EnvVars With The Channel-Specific Values
+	return r.dispatcherEnvVars([]corev1.EnvVar{
+		{
+			Name:  commonenv.ChannelKeyEnvVarKey,
+			Value: util.ChannelKey(channel),
+		},
+		{
+			Name:  commonenv.ServiceNameEnvVarKey,
+			Value: util.DispatcherDnsSafeName(channel),
+		},
+		{
+			Name:  commonenv.KafkaTopicEnvVarKey,
+			Value: topicName,
+		},
+	}), nil
+}
+
+// Create The Dispatcher Container's Env Vars Common To All Dispatchers, Including The Specified Additional Env Vars
+func (r *Reconciler) dispatcherEnvVars(additionalEnvVars []corev1.EnvVar) []corev1.EnvVar {
+
+	// Create The Dispatcher EnvVars
 	envVars := []corev1.EnvVar{
 		{
 			Name:  system.NamespaceEnvKey,
@@ -622,48 +729,32 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 			Name:  commonenv.HealthPortEnvVarKey,
 			Value: strconv.Itoa(constants.HealthPort),
 		},
-		{
-			Name:  commonenv.ChannelKeyEnvVarKey,
-			Value: util.ChannelKey(channel),
-		},
-		{
-			Name:  commonenv.ServiceNameEnvVarKey,
-			Value: util.DispatcherDnsSafeName(channel),
-		},
-		{
-			Name:  commonenv.KafkaTopicEnvVarKey,
-			Value: topicName,
-		},
-		{
-			Name:  commonenv.ResyncPeriodMinutesEnvVarKey,
-			Value: strconv.Itoa(int(r.environment.ResyncPeriod / time.Minute)),
-		},
 	}
 
-	// If The Kafka Secret Name Is Specified Then Append Relevant Env Vars
-	if len(r.config.Kafka.AuthSecretName) <= 0 {
-
-		// Received Invalid Kafka Secret Name - Cannot Proceed
-		return nil, fmt.Errorf("invalid authSecretName for topic '%s'", topicName)
-
-	} else {
+	// Append The Additional (Dedicated / Shared Dispatcher Specific) EnvVars
+	envVars = append(envVars, additionalEnvVars...)
 
-		// Append The Secret Name As Env Var
-		envVars = append(envVars, corev1.EnvVar{
+	// Append The Resync Period & Kafka Secret EnvVars
+	envVars = append(envVars,
+		corev1.EnvVar{
+			Name:  commonenv.ResyncPeriodMinutesEnvVarKey,
+			Value: strconv.Itoa(int(r.environment.ResyncPeriod / time.Minute)),
+		},
+		corev1.EnvVar{
 			Name:  commonenv.KafkaSecretNameEnvVarKey,
 			Value: r.config.Kafka.AuthSecretName,
-		})
-
-		// Append The Secret Namespace As Env Var
-		envVars = append(envVars, corev1.EnvVar{
+		},
+		corev1.EnvVar{
 			Name:  commonenv.KafkaSecretNamespaceEnvVarKey,
 			Value: r.config.Kafka.AuthSecretNamespace,
-		})
+		},
+	)
 
-	}
+	// Append The Claim-Check Store Credentials (If Any)
+	envVars = append(envVars, claimcheck.EnvVars(r.config.Channel.ClaimCheck)...)
 
-	// Return The Dispatcher Deployment EnvVars Array
-	return envVars, nil
+	// Return The Dispatcher EnvVars Array
+	return envVars
 }
 
 // Utility Function To Get The Finalizer Name For K8S Resources (Service, Deployment, etc.)
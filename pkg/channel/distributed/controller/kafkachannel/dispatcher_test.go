@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test The newDispatcherDeployment() Functionality With Per-KafkaChannel Overrides
+func TestNewDispatcherDeploymentOverrides(t *testing.T) {
+
+	// Test Data
+	replicas := int32(4)
+	nodeSelector := map[string]string{"kubernetes.io/os": "linux"}
+	tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "kafka", Effect: corev1.TaintEffectNoSchedule}}
+	channel := controllertesting.NewKafkaChannel(withDispatcherSpec(&kafkav1beta1.KafkaChannelDispatcherSpec{
+		Replicas: &replicas,
+		Resources: corev1.ResourceRequirements{
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		NodeSelector: nodeSelector,
+		Tolerations:  tolerations,
+	}))
+	r := newDispatcherTestReconciler(nil, nil)
+
+	// Perform The Test
+	deployment, err := r.newDispatcherDeployment(logtesting.TestLogger(t).Desugar(), channel)
+
+	// Verify The Overrides Were Merged Over The Global Dispatcher Config
+	assert.Nil(t, err)
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.Equal(t, nodeSelector, deployment.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, tolerations, deployment.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(controllertesting.DispatcherCpuLimit),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(controllertesting.DispatcherCpuRequest),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}, deployment.Spec.Template.Spec.Containers[0].Resources)
+}
+
+// Test The newDispatcherDeployment() Functionality With Resource Overrides Conflicting With The Global Dispatcher Config
+func TestNewDispatcherDeploymentConflictingResourceOverrides(t *testing.T) {
+
+	// Test Data - A Memory Limit Below The Global Request & A CPU Request Above The Global Limit
+	channel := controllertesting.NewKafkaChannel(withDispatcherSpec(&kafkav1beta1.KafkaChannelDispatcherSpec{
+		Resources: corev1.ResourceRequirements{
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("10Mi")},
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		},
+	}))
+	r := newDispatcherTestReconciler(nil, nil)
+
+	// Perform The Test
+	deployment, err := r.newDispatcherDeployment(logtesting.TestLogger(t).Desugar(), channel)
+
+	// Verify The Inherited Request Was Capped At The Override Limit & The Inherited Limit Raised To The Override Request
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("10Mi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("10Mi"),
+		},
+	}, deployment.Spec.Template.Spec.Containers[0].Resources)
+
+	// Verify The Global Dispatcher Config Was Not Modified
+	deployment, err = r.newDispatcherDeployment(logtesting.TestLogger(t).Desugar(), controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	assert.Equal(t, resource.MustParse(controllertesting.DispatcherMemoryRequest), deployment.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse(controllertesting.DispatcherCpuLimit), deployment.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU])
+}
+
+// Test The Dispatcher & Receiver Deployments Include The Claim-Check Store Credentials And Volume
+func TestNewDeploymentsClaimCheck(t *testing.T) {
+
+	// Setup Test Environment Namespaces
+	commontesting.SetTestEnvironment(t)
+
+	// Test Data
+	r := newDispatcherTestReconciler(nil, nil)
+	r.config.Channel.ClaimCheck = commonconfig.EKClaimCheckConfig{
+		Store:      claimcheck.StoreTypeFilesystem,
+		Filesystem: commonconfig.EKClaimCheckFilesystemConfig{Path: "/claim-check", PersistentVolumeClaim: "claim-check-pvc"},
+		S3:         commonconfig.EKClaimCheckS3Config{CredentialsSecret: "ignored-for-filesystem"},
+	}
+	expectedVolume := claimcheck.Volumes(r.config.Channel.ClaimCheck)[0]
+	expectedVolumeMount := corev1.VolumeMount{Name: claimcheck.VolumeName, MountPath: "/claim-check"}
+
+	// Perform The Test
+	dispatcherDeployment, err := r.newDispatcherDeployment(logtesting.TestLogger(t).Desugar(), controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	receiverDeployment := r.newReceiverDeployment(controllertesting.NewKafkaSecret())
+
+	// Verify The Claim-Check Volume Is Mounted In Both Deployments
+	for _, podSpec := range []corev1.PodSpec{dispatcherDeployment.Spec.Template.Spec, receiverDeployment.Spec.Template.Spec} {
+		assert.Contains(t, podSpec.Volumes, expectedVolume)
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, expectedVolumeMount)
+	}
+
+	// Verify The S3 Credentials Are Only Provided For The S3 Store
+	r.config.Channel.ClaimCheck.Store = claimcheck.StoreTypeS3
+	envVars, err := r.dispatcherDeploymentEnvVars(controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	assert.Subset(t, envVars, claimcheck.EnvVars(r.config.Channel.ClaimCheck))
+	assert.Subset(t, r.receiverDeploymentEnvVars(controllertesting.NewKafkaSecret()), claimcheck.EnvVars(r.config.Channel.ClaimCheck))
+	assert.Len(t, claimcheck.EnvVars(r.config.Channel.ClaimCheck), 2)
+}
+
+// Test The reconcileDispatcherDeployment() Functionality With A Per-KafkaChannel Replicas Override
+func TestReconcileDispatcherDeploymentReplicasOverride(t *testing.T) {
+
+	// Test Data
+	replicas := int32(4)
+	channel := controllertesting.NewKafkaChannel(withDispatcherSpec(&kafkav1beta1.KafkaChannelDispatcherSpec{Replicas: &replicas}))
+	r := newDispatcherTestReconciler([]runtime.Object{controllertesting.NewKafkaChannelDispatcherDeployment()}, nil)
+	ctx := newDispatcherTestContext(t)
+
+	// Perform The Test
+	err := r.reconcileDispatcherDeployment(ctx, logtesting.TestLogger(t).Desugar(), channel)
+
+	// Verify The Existing Deployment Was Scaled To The Override
+	assert.Nil(t, err)
+	deployment, err := r.kubeClientset.AppsV1().Deployments(commontesting.SystemNamespace).Get(ctx, util.DispatcherDnsSafeName(channel), metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+}
+
+// Utility Function For Setting A KafkaChannel's Dispatcher Overrides
+func withDispatcherSpec(dispatcherSpec *kafkav1beta1.KafkaChannelDispatcherSpec) controllertesting.KafkaChannelOption {
+	return func(kafkachannel *kafkav1beta1.KafkaChannel) {
+		kafkachannel.Spec.Dispatcher = dispatcherSpec
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
 )
 
 // reconcileReceiver Reconciles The Receiver (Kafka Producer) For The Specified KafkaChannel
@@ -410,7 +411,7 @@ func (r *Reconciler) newReceiverDeployment(secret *corev1.Secret) *appsv1.Deploy
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: r.environment.ServiceAccount,
+					ServiceAccountName: r.environment.DataPlaneServiceAccount,
 					Containers: []corev1.Container{
 						{
 							Name: deploymentName,
@@ -453,15 +454,15 @@ func (r *Reconciler) newReceiverDeployment(secret *corev1.Secret) *appsv1.Deploy
 								Requests: resourceRequests,
 								Limits:   resourceLimits,
 							},
-							VolumeMounts: []corev1.VolumeMount{
+							VolumeMounts: append([]corev1.VolumeMount{
 								{
 									Name:      commonconstants.SettingsConfigMapName,
 									MountPath: commonconstants.SettingsConfigMapMountPath,
 								},
-							},
+							}, claimcheck.VolumeMounts(r.config.Channel.ClaimCheck)...),
 						},
 					},
-					Volumes: []corev1.Volume{
+					Volumes: append([]corev1.Volume{
 						{
 							Name: commonconstants.SettingsConfigMapName,
 							VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
@@ -470,7 +471,7 @@ func (r *Reconciler) newReceiverDeployment(secret *corev1.Secret) *appsv1.Deploy
 								},
 							}},
 						},
-					},
+					}, claimcheck.Volumes(r.config.Channel.ClaimCheck)...),
 				},
 			},
 		},
@@ -545,6 +546,9 @@ func (r *Reconciler) receiverDeploymentEnvVars(secret *corev1.Secret) []corev1.E
 		Value: secret.Namespace,
 	})
 
+	// Append The Claim-Check Store Credentials (If Any)
+	envVars = append(envVars, claimcheck.EnvVars(r.config.Channel.ClaimCheck)...)
+
 	// Return The Receiver Deployment EnvVars Array
 	return envVars
 }
@@ -22,18 +22,23 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/eventing/pkg/scheduler"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/strimzi"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
@@ -48,18 +53,28 @@ import (
 
 // Reconciler Implements controller.Reconciler for KafkaChannel Resources
 type Reconciler struct {
-	kubeClientset        kubernetes.Interface
-	kafkaClientSet       kafkaclientset.Interface
-	adminClientType      types.AdminClientType
-	adminClient          types.AdminClientInterface
-	environment          *env.Environment
-	config               *commonconfig.EventingKafkaConfig
-	kafkachannelLister   kafkalisters.KafkaChannelLister
-	kafkachannelInformer cache.SharedIndexInformer
-	deploymentLister     appsv1listers.DeploymentLister
-	serviceLister        corev1listers.ServiceLister
-	adminMutex           *sync.Mutex
-	kafkaConfigMapHash   string
+	kubeClientset          kubernetes.Interface
+	kafkaClientSet         kafkaclientset.Interface
+	adminClientType        types.AdminClientType
+	adminClient            types.AdminClientInterface
+	environment            *env.Environment
+	config                 *commonconfig.EventingKafkaConfig
+	kafkachannelLister     kafkalisters.KafkaChannelLister
+	kafkachannelInformer   cache.SharedIndexInformer
+	deploymentLister       appsv1listers.DeploymentLister
+	statefulSetLister      appsv1listers.StatefulSetLister
+	serviceLister          corev1listers.ServiceLister
+	podLister              corev1listers.PodLister
+	connectionPool         ctrlreconciler.ControlPlaneConnectionPool
+	subscribersStatusStore *ctrlreconciler.NotificationStore
+	scheduler              scheduler.Scheduler // Only Set When The Shared Dispatcher Is Enabled
+	consumerGroupLag       consumerGroupLagFunc
+	isLeaderFor            func(apitypes.NamespacedName) bool // Nil When Leader Election Is Not In Use
+	lagClient              sarama.Client                      // Reused Across Autoscaler Ticks
+	lagClusterAdmin        sarama.ClusterAdmin                // Reused Across Autoscaler Ticks
+	lagMutex               sync.Mutex
+	adminMutex             *sync.Mutex
+	kafkaConfigMapHash     string
 }
 
 var (
@@ -83,6 +98,7 @@ func (r *Reconciler) SetKafkaAdminClient(ctx context.Context) error {
 	_ = r.ClearKafkaAdminClient(ctx) // Attempt to close any lingering connections, ignore errors and continue
 	var err error
 	brokers := strings.Split(r.config.Kafka.Brokers, ",")
+	ctx = strimzi.WithConfig(ctx, r.config.Channel.Strimzi) // Only Used By The Strimzi AdminClient
 	r.adminClient, err = admin.CreateAdminClient(ctx, brokers, r.config.Sarama.Config, r.adminClientType)
 	if err != nil {
 		logger := logging.FromContext(ctx)
@@ -138,6 +154,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 	// Reset The Channel's Status Conditions To Unknown (Addressable, Topic, Service, Deployment, etc...)
 	channel.Status.InitializeConditions()
 
+	// Surface The Durability Class In Effect For Producing Events
+	channel.Status.DurabilityClass = channel.Spec.EffectiveDurabilityClass()
+
 	// Perform The KafkaChannel Reconciliation & Handle Error Response
 	logger.Info("Channel Owned By Controller - Reconciling", zap.Any("Channel.Spec", channel.Spec))
 	err = r.reconcile(ctx, channel)
@@ -176,7 +195,11 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 	defer func() { _ = r.ClearKafkaAdminClient(ctx) }() // Ignore errors as nothing else can be done
 
 	// Finalize The Dispatcher (Manual Finalization Due To Cross-Namespace Ownership)
-	err = r.finalizeDispatcher(ctx, channel)
+	if r.scheduler != nil {
+		err = r.finalizeSharedDispatcher(ctx, channel)
+	} else {
+		err = r.finalizeDispatcher(ctx, channel)
+	}
 	if err != nil {
 		logger.Info("Failed To Finalize KafkaChannel", zap.Error(err))
 		return fmt.Errorf(constants.FinalizationFailedError)
@@ -189,6 +212,9 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 		return fmt.Errorf(constants.FinalizationFailedError)
 	}
 
+	// Finalize The Dispatcher's Control-Protocol Connections & Subscribers' Status
+	r.finalizeSubscribersStatus(ctx, channel)
+
 	// Return Success
 	logger.Info("Successfully Finalized KafkaChannel")
 	return reconciler.NewEvent(corev1.EventTypeNormal, event.KafkaChannelFinalized.String(), "KafkaChannel Finalized Successfully: \"%s/%s\"", channel.Namespace, channel.Name)
@@ -237,6 +263,12 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
+	// Reconcile The KafkaChannel's Subscribers' Status As Reported By The Dispatcher
+	err = r.reconcileSubscribersStatus(ctx, channel)
+	if err != nil {
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
 	// Return Success
 	return nil
 }
@@ -273,6 +305,9 @@ func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.Co
 	logger.Info("ConfigMap Changed; Updating Sarama And Eventing-Kafka Configuration")
 	r.config = ekConfig
 
+	// Close The Autoscaler's Kafka Connections So They Are Recreated With The New Configuration
+	r.closeConsumerGroupLagClient()
+
 	r.kafkaConfigMapHash = commonconfig.ConfigmapDataCheckSum(configMap.Data)
 	return nil
 }
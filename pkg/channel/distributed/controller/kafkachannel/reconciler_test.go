@@ -26,6 +26,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +35,8 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -44,6 +47,7 @@ import (
 	"knative.dev/pkg/system"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
 	kafkaadmintesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/testing"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 	kafkaadminwrapper "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/wrapper"
@@ -55,6 +59,7 @@ import (
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 )
 
@@ -438,19 +443,28 @@ func TestReconcile(t *testing.T) {
 		}
 		configOptions := configOptionsInterface.([]controllertesting.KafkaConfigOption)
 
+		// Use A Mock Control-Protocol ConnectionPool (No Dispatcher Pods Are Running In The Tests)
+		mockConnectionPool := &controlprotocoltesting.MockConnectionPool{}
+		mockConnectionPool.On("ReconcileConnections", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]ctrl.Service{}, nil)
+		mockConnectionPool.On("RemoveAllConnections", mock.Anything, mock.Anything).Return()
+
 		r := &Reconciler{
-			kubeClientset:        kubeclient.Get(ctx),
-			adminClientType:      types.Kafka,
-			adminClient:          nil,
-			environment:          controllertesting.NewEnvironment(),
-			config:               controllertesting.NewConfig(configOptions...),
-			kafkachannelLister:   listers.GetKafkaChannelLister(),
-			kafkachannelInformer: nil,
-			deploymentLister:     listers.GetDeploymentLister(),
-			serviceLister:        listers.GetServiceLister(),
-			kafkaClientSet:       fakekafkaclient.Get(ctx),
-			adminMutex:           &sync.Mutex{},
-			kafkaConfigMapHash:   controllertesting.ConfigMapHash,
+			kubeClientset:          kubeclient.Get(ctx),
+			adminClientType:        types.Kafka,
+			adminClient:            nil,
+			environment:            controllertesting.NewEnvironment(),
+			config:                 controllertesting.NewConfig(configOptions...),
+			kafkachannelLister:     listers.GetKafkaChannelLister(),
+			kafkachannelInformer:   nil,
+			deploymentLister:       listers.GetDeploymentLister(),
+			statefulSetLister:      listers.GetStatefulSetLister(),
+			serviceLister:          listers.GetServiceLister(),
+			podLister:              listers.GetPodLister(),
+			connectionPool:         mockConnectionPool,
+			subscribersStatusStore: ctrlreconciler.NewNotificationStore(func(apitypes.NamespacedName) {}, distributedcontrol.SubscribersStatusParser),
+			kafkaClientSet:         fakekafkaclient.Get(ctx),
+			adminMutex:             &sync.Mutex{},
+			kafkaConfigMapHash:     controllertesting.ConfigMapHash,
 		}
 
 		reconcilerOptions, ok := options["reconcilerOptions"]
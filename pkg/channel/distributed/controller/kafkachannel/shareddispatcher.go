@@ -0,0 +1,423 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing/pkg/scheduler"
+	"knative.dev/pkg/apis/duck"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	distributedmessaging "knative.dev/eventing-kafka/pkg/channel/distributed/apis/messaging"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+//
+// Reconcile The Shared (Multi-Tenant) Dispatcher For The Specified KafkaChannel
+//
+// When the shared dispatcher is enabled, a single StatefulSet of dispatcher replicas serves all
+// KafkaChannels instead of creating a dedicated Deployment/Service per KafkaChannel.  Each
+// KafkaChannel is a "virtual pod" with a single virtual replica which is placed on one of the
+// StatefulSet's replicas by the eventing StatefulSet scheduler, which also takes care of scaling
+// the StatefulSet based on the configured per-replica capacity.  The resulting placement is
+// recorded in the KafkaChannel's Status, from which the dispatcher replicas determine which
+// KafkaChannels they are responsible for.
+//
+
+// Reconcile The Shared Dispatcher & Schedule The Specified KafkaChannel Onto It
+func (r *Reconciler) reconcileSharedDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Get The Channel-Specific Logger Provided Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Reconcile The Shared Dispatcher's Service (For Prometheus Only)
+	serviceErr := r.reconcileSharedDispatcherService(ctx, logger, channel)
+	if serviceErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherServiceReconciliationFailed.String(), "Failed To Reconcile Shared Dispatcher Service: %v", serviceErr)
+		logger.Error("Failed To Reconcile Shared Dispatcher Service", zap.Error(serviceErr))
+	} else {
+		logger.Info("Successfully Reconciled Shared Dispatcher Service")
+	}
+
+	// Reconcile The Shared Dispatcher's StatefulSet & Schedule The KafkaChannel Onto It
+	statefulSetErr := r.reconcileSharedDispatcherStatefulSet(ctx, logger, channel)
+	if statefulSetErr == nil {
+		statefulSetErr = r.scheduleSharedDispatcher(logger, channel)
+	}
+	if statefulSetErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Shared Dispatcher: %v", statefulSetErr)
+		logger.Error("Failed To Reconcile Shared Dispatcher", zap.Error(statefulSetErr))
+	} else {
+		logger.Info("Successfully Reconciled Shared Dispatcher")
+	}
+
+	// Remove Any Dedicated Dispatcher Left Over From Before The Shared Dispatcher Was Enabled
+	dedicatedErr := r.finalizeDispatcher(ctx, channel)
+
+	// Return Results
+	if serviceErr != nil || statefulSetErr != nil || dedicatedErr != nil {
+		return fmt.Errorf("failed to reconcile shared dispatcher resources")
+	} else {
+		return nil
+	}
+}
+
+// Finalize The Shared Dispatcher For The Specified KafkaChannel - De-Schedule From The Shared Dispatcher
+func (r *Reconciler) finalizeSharedDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Get The Channel-Specific Logger Provided Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// The KafkaChannel Is Being Deleted And So Has Zero VReplicas, Which Will Remove All Placements
+	placements, err := r.scheduler.Schedule(channel)
+	if placements != nil || err != nil {
+		channel.Status.Placements = nil
+		logger.Error("Failed To De-Schedule KafkaChannel From Shared Dispatcher", zap.Any("Placements", placements), zap.Error(err))
+		return fmt.Errorf("failed to de-schedule kafkachannel from shared dispatcher")
+	}
+
+	// Remove Any Dedicated Dispatcher Left Over From Before The Shared Dispatcher Was Enabled
+	return r.finalizeDispatcher(ctx, channel)
+}
+
+// Schedule The KafkaChannel Onto The Shared Dispatcher & Update The KafkaChannel's Placements
+func (r *Reconciler) scheduleSharedDispatcher(logger *zap.Logger, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Schedule The KafkaChannel's Single VReplica
+	placements, err := r.scheduler.Schedule(channel)
+
+	// Update Placements, Even Partial Ones
+	if placements != nil {
+		channel.Status.Placements = placements
+	}
+
+	// Mark The Dispatcher "Deployment" Status Based On The Scheduling Results
+	if err != nil {
+		distributedmessaging.MarkDispatcherDeploymentFailed(&channel.Status, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Schedule KafkaChannel On Shared Dispatcher: %v", err)
+		return err
+	}
+	logger.Info("Successfully Scheduled KafkaChannel On Shared Dispatcher", zap.Any("Placements", placements))
+	distributedmessaging.MarkDispatcherDeploymentTrue(&channel.Status)
+	return nil
+}
+
+// Evict The Specified KafkaChannel (VPod) From The Specified Shared Dispatcher Pod
+//
+// The scheduler's autoscaler calls this when compacting the shared dispatcher's replicas.  The Pod is first
+// marked as unschedulable, and the KafkaChannel's placement on that Pod is then removed from its Status so
+// that it will be re-scheduled onto a different replica on the next reconciliation.
+func (r *Reconciler) evictSharedDispatcherPod(ctx context.Context, pod *corev1.Pod, vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+
+	// Get The Logger Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Annotate The Pod As Unschedulable
+	newPod := pod.DeepCopy()
+	if newPod.ObjectMeta.Annotations == nil {
+		newPod.ObjectMeta.Annotations = make(map[string]string)
+	}
+	newPod.ObjectMeta.Annotations[scheduler.PodAnnotationKey] = "true"
+	_, err := r.kubeClientset.CoreV1().Pods(newPod.Namespace).Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		logger.Error("Failed To Mark Shared Dispatcher Pod As Unschedulable", zap.String("Pod", pod.Name), zap.Error(err))
+		return err
+	}
+
+	// Get The Current KafkaChannel
+	key := vpod.GetKey()
+	before, err := r.kafkaClientSet.MessagingV1beta1().KafkaChannels(key.Namespace).Get(ctx, key.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Remove The KafkaChannel's Placements On The Evicted Pod
+	logger.Info("Evicting KafkaChannel From Shared Dispatcher Pod", zap.String("KafkaChannel", key.String()), zap.String("Pod", from.PodName))
+	after := before.DeepCopy()
+	placements := make([]duckv1alpha1.Placement, 0, len(before.Status.Placements))
+	for _, placement := range before.Status.Placements {
+		if placement.PodName != from.PodName {
+			placements = append(placements, placement)
+		}
+	}
+	after.Status.Placements = placements
+
+	// Create The Status Patch (If Nothing To Patch The Patch Will Be Empty)
+	jsonPatch, err := duck.CreatePatch(before, after)
+	if err != nil {
+		return err
+	}
+	if len(jsonPatch) == 0 {
+		return nil
+	}
+	patch, err := jsonPatch.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshaling JSON patch: %w", err)
+	}
+
+	// Patch The KafkaChannel's Status
+	_, err = r.kafkaClientSet.MessagingV1beta1().KafkaChannels(key.Namespace).Patch(ctx, key.Name, types.JSONPatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("failed patching: %w", err)
+	}
+	return nil
+}
+
+// List All KafkaChannels As VPods For The Shared Dispatcher's Scheduler
+func (r *Reconciler) vpodLister() ([]scheduler.VPod, error) {
+	channels, err := r.kafkachannelLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	vpods := make([]scheduler.VPod, len(channels))
+	for index, channel := range channels {
+		vpods[index] = channel
+	}
+	return vpods, nil
+}
+
+//
+// Shared Dispatcher Service (For Prometheus Only)
+//
+
+// Reconcile The Shared Dispatcher Service
+func (r *Reconciler) reconcileSharedDispatcherService(ctx context.Context, logger *zap.Logger, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Create A New Service For Comparison
+	newService := r.newSharedDispatcherService()
+
+	// Attempt To Get The Shared Dispatcher Service
+	existingService, err := r.serviceLister.Services(newService.Namespace).Get(newService.Name)
+	if existingService == nil || err != nil {
+
+		// If The Service Was Not Found - Then Create A New One
+		if errors.IsNotFound(err) {
+			logger.Info("Shared Dispatcher Service Not Found - Creating New One")
+			_, err = r.kubeClientset.CoreV1().Services(newService.Namespace).Create(ctx, newService, metav1.CreateOptions{})
+			if err != nil && !errors.IsAlreadyExists(err) {
+				logger.Error("Failed To Create Shared Dispatcher Service", zap.Error(err))
+				distributedmessaging.MarkDispatcherServiceFailed(&channel.Status, event.DispatcherServiceReconciliationFailed.String(), "Failed To Create Shared Dispatcher Service: %v", err)
+				return err
+			}
+			logger.Info("Successfully Created Shared Dispatcher Service")
+			distributedmessaging.MarkDispatcherServiceTrue(&channel.Status)
+			return nil
+		} else {
+			logger.Error("Failed To Get Shared Dispatcher Service", zap.Error(err))
+			distributedmessaging.MarkDispatcherServiceUnknown(&channel.Status, event.DispatcherServiceReconciliationFailed.String(), "Failed To Get Shared Dispatcher Service: %v", err)
+			return err
+		}
+	}
+
+	// Patch The Existing Service If Necessary
+	patch, needsUpdate := util.CheckServiceChanged(logger, existingService, newService)
+	if needsUpdate {
+		_, err = r.kubeClientset.CoreV1().Services(existingService.Namespace).Patch(ctx, existingService.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			controller.GetEventRecorder(ctx).Event(channel, corev1.EventTypeWarning, event.DispatcherServicePatchFailed.String(), "Shared Dispatcher Service Patch Failed")
+			distributedmessaging.MarkDispatcherServiceFailed(&channel.Status, event.DispatcherServicePatchFailed.String(), "Failed To Patch Shared Dispatcher Service: %v", err)
+			logger.Error("Shared Dispatcher Service Patch Failed", zap.Error(err))
+			return err
+		}
+		controller.GetEventRecorder(ctx).Event(channel, corev1.EventTypeNormal, event.DispatcherServicePatched.String(), "Shared Dispatcher Service Patched")
+		logger.Info("Shared Dispatcher Service Changed - Patch Applied")
+	}
+
+	// Mark Dispatcher Service Status
+	distributedmessaging.MarkDispatcherServiceTrue(&channel.Status)
+	return nil
+}
+
+// Create The Shared Dispatcher Service Model
+func (r *Reconciler) newSharedDispatcherService() *corev1.Service {
+
+	// Create A New Shared Dispatcher Service (Not Owned By Any Single KafkaChannel, And So No Finalizer)
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       constants.ServiceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.SharedDispatcherName,
+			Namespace: r.environment.SystemNamespace,
+			Labels: map[string]string{
+				constants.KafkaChannelDispatcherLabel:   "true",                                  // Identifies the Service as being a KafkaChannel "Dispatcher"
+				constants.K8sAppDispatcherSelectorLabel: constants.K8sAppDispatcherSelectorValue, // Prometheus ServiceMonitor
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       constants.MetricsPortName,
+					Port:       int32(r.environment.MetricsPort),
+					TargetPort: intstr.FromInt(r.environment.MetricsPort),
+				},
+			},
+			Selector: map[string]string{
+				constants.AppLabel: constants.SharedDispatcherName, // Matches StatefulSet Label Key/Value
+			},
+		},
+	}
+
+	// Update The Shared Dispatcher Service's Annotations & Labels With Custom Config Values
+	service.Annotations = commonconfig.JoinStringMaps(service.Annotations, r.config.Channel.Dispatcher.ServiceAnnotations)
+	service.Labels = commonconfig.JoinStringMaps(service.Labels, r.config.Channel.Dispatcher.ServiceLabels)
+
+	// Return The Shared Dispatcher Service
+	return service
+}
+
+//
+// Shared Dispatcher StatefulSet
+//
+
+// Reconcile The Shared Dispatcher StatefulSet
+func (r *Reconciler) reconcileSharedDispatcherStatefulSet(ctx context.Context, logger *zap.Logger, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Create A New StatefulSet For Comparison
+	newStatefulSet, err := r.newSharedDispatcherStatefulSet()
+	if err != nil {
+		logger.Error("Failed To Create Shared Dispatcher StatefulSet YAML", zap.Error(err))
+		distributedmessaging.MarkDispatcherDeploymentFailed(&channel.Status, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Generate Shared Dispatcher StatefulSet: %v", err)
+		return err
+	}
+
+	// Attempt To Get The Shared Dispatcher StatefulSet
+	existingStatefulSet, err := r.statefulSetLister.StatefulSets(newStatefulSet.Namespace).Get(newStatefulSet.Name)
+	if existingStatefulSet == nil || err != nil {
+
+		// If The StatefulSet Was Not Found - Then Create A New One
+		if errors.IsNotFound(err) {
+			logger.Info("Shared Dispatcher StatefulSet Not Found - Creating New One")
+			_, err = r.kubeClientset.AppsV1().StatefulSets(newStatefulSet.Namespace).Create(ctx, newStatefulSet, metav1.CreateOptions{})
+			if err != nil && !errors.IsAlreadyExists(err) {
+				logger.Error("Failed To Create Shared Dispatcher StatefulSet", zap.Error(err))
+				distributedmessaging.MarkDispatcherDeploymentFailed(&channel.Status, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Create Shared Dispatcher StatefulSet: %v", err)
+				return err
+			}
+			logger.Info("Successfully Created Shared Dispatcher StatefulSet")
+			return nil
+		} else {
+			logger.Error("Failed To Get Shared Dispatcher StatefulSet", zap.Error(err))
+			distributedmessaging.MarkDispatcherDeploymentUnknown(&channel.Status, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Get Shared Dispatcher StatefulSet: %v", err)
+			return err
+		}
+	}
+
+	// Update The Existing StatefulSet If Necessary (Preserving The Scheduler-Managed Replicas)
+	updatedStatefulSet, needsUpdate := util.CheckStatefulSetChanged(logger, existingStatefulSet, newStatefulSet)
+	if needsUpdate {
+		_, err = r.kubeClientset.AppsV1().StatefulSets(updatedStatefulSet.Namespace).Update(ctx, updatedStatefulSet, metav1.UpdateOptions{})
+		if err != nil {
+			controller.GetEventRecorder(ctx).Event(channel, corev1.EventTypeWarning, event.DispatcherDeploymentUpdateFailed.String(), "Shared Dispatcher StatefulSet Update Failed")
+			distributedmessaging.MarkDispatcherDeploymentFailed(&channel.Status, event.DispatcherDeploymentUpdateFailed.String(), "Failed To Update Shared Dispatcher StatefulSet: %v", err)
+			logger.Error("Shared Dispatcher StatefulSet Update Failed", zap.Error(err))
+			return err
+		}
+		controller.GetEventRecorder(ctx).Event(channel, corev1.EventTypeNormal, event.DispatcherDeploymentUpdated.String(), "Shared Dispatcher StatefulSet Updated")
+		logger.Info("Shared Dispatcher StatefulSet Changed - Update Applied")
+	}
+	return nil
+}
+
+// Create The Shared Dispatcher StatefulSet Model
+func (r *Reconciler) newSharedDispatcherStatefulSet() (*appsv1.StatefulSet, error) {
+
+	// Validate The Kafka Secret Name - Cannot Proceed Without One
+	if len(r.config.Kafka.AuthSecretName) <= 0 {
+		return nil, fmt.Errorf("invalid authSecretName for shared dispatcher")
+	}
+
+	// The Scheduler's Autoscaler Manages The Replicas, So Start With None
+	replicas := int32(0)
+
+	// Create The Shared Dispatcher Container Environment Variables
+	envVars := r.dispatcherEnvVars([]corev1.EnvVar{
+		{
+			Name:  commonenv.ServiceNameEnvVarKey,
+			Value: constants.SharedDispatcherName,
+		},
+		{
+			Name:  commonenv.SharedDispatcherEnvVarKey,
+			Value: "true",
+		},
+	})
+
+	// Create The Shared Dispatcher StatefulSet
+	statefulSet := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       constants.StatefulSetKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.SharedDispatcherName,
+			Namespace: r.environment.SystemNamespace,
+			Labels: map[string]string{
+				constants.AppLabel:                    constants.SharedDispatcherName, // Matches K8S Service Selector Key/Value
+				constants.KafkaChannelDispatcherLabel: "true",                         // Identifies the StatefulSet as being a KafkaChannel "Dispatcher"
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:            &replicas,
+			ServiceName:         constants.SharedDispatcherName,
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.AppLabel: constants.SharedDispatcherName, // Matches Template ObjectMeta Pods
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						constants.AppLabel:                    constants.SharedDispatcherName,
+						constants.KafkaChannelDispatcherLabel: "true", // Identifies the Pod as being a KafkaChannel "Dispatcher"
+					},
+					Annotations: map[string]string{
+						commonconstants.ConfigMapHashAnnotationKey: r.kafkaConfigMapHash,
+					},
+				},
+				Spec: r.newDispatcherPodSpec(constants.SharedDispatcherName, envVars),
+			},
+		},
+	}
+
+	// Update The Shared Dispatcher StatefulSet's Annotations & Labels With Custom Config Values
+	statefulSet.ObjectMeta.Annotations = commonconfig.JoinStringMaps(statefulSet.ObjectMeta.Annotations, r.config.Channel.Dispatcher.DeploymentAnnotations)
+	statefulSet.ObjectMeta.Labels = commonconfig.JoinStringMaps(statefulSet.ObjectMeta.Labels, r.config.Channel.Dispatcher.DeploymentLabels)
+	statefulSet.Spec.Template.ObjectMeta.Annotations = commonconfig.JoinStringMaps(statefulSet.Spec.Template.ObjectMeta.Annotations, r.config.Channel.Dispatcher.PodAnnotations)
+	statefulSet.Spec.Template.ObjectMeta.Labels = commonconfig.JoinStringMaps(statefulSet.Spec.Template.ObjectMeta.Labels, r.config.Channel.Dispatcher.PodLabels)
+
+	// Return The Shared Dispatcher StatefulSet
+	return statefulSet, nil
+}
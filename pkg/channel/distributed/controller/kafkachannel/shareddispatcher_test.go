@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing/pkg/scheduler"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	distributedmessaging "knative.dev/eventing-kafka/pkg/channel/distributed/apis/messaging"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	fakekafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test The reconcileSharedDispatcher() Functionality
+func TestReconcileSharedDispatcher(t *testing.T) {
+
+	placements := []duckv1alpha1.Placement{{PodName: constants.SharedDispatcherName + "-0", VReplicas: 1}}
+
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		scheduleErr   error
+		wantErr       bool
+		wantScheduled bool
+	}{
+		{
+			name:          "Create Shared Dispatcher & Schedule",
+			wantScheduled: true,
+		},
+		{
+			name:          "Remove Dedicated Dispatcher",
+			objects:       []runtime.Object{controllertesting.NewKafkaChannelDispatcherService(), controllertesting.NewKafkaChannelDispatcherDeployment()},
+			wantScheduled: true,
+		},
+		{
+			name:        "Scheduling Failure",
+			scheduleErr: errors.New("test scheduling error"),
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Reconciler With A Test Scheduler
+			ctx := newDispatcherTestContext(t)
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			r := newDispatcherTestReconciler(test.objects, scheduler.SchedulerFunc(func(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
+				assert.Equal(t, channel.GetKey(), vpod.GetKey())
+				return placements, test.scheduleErr
+			}))
+
+			// Perform The Test
+			err := r.reconcileDispatcher(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, placements, channel.Status.Placements)
+			deploymentCondition := channel.Status.GetCondition(distributedmessaging.KafkaChannelConditionDispatcherDeploymentReady)
+			assert.Equal(t, test.wantScheduled, deploymentCondition.IsTrue())
+			assert.True(t, channel.Status.GetCondition(distributedmessaging.KafkaChannelConditionDispatcherServiceReady).IsTrue())
+
+			service, err := r.kubeClientset.CoreV1().Services(commontesting.SystemNamespace).Get(ctx, constants.SharedDispatcherName, metav1.GetOptions{})
+			assert.Nil(t, err)
+			assert.Equal(t, constants.SharedDispatcherName, service.Spec.Selector[constants.AppLabel])
+
+			statefulSet, err := r.kubeClientset.AppsV1().StatefulSets(commontesting.SystemNamespace).Get(ctx, constants.SharedDispatcherName, metav1.GetOptions{})
+			assert.Nil(t, err)
+			assert.Equal(t, int32(0), *statefulSet.Spec.Replicas)
+			assert.Contains(t, statefulSet.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: commonenv.SharedDispatcherEnvVarKey, Value: "true"})
+
+			deployments, err := r.kubeClientset.AppsV1().Deployments(commontesting.SystemNamespace).List(ctx, metav1.ListOptions{})
+			assert.Nil(t, err)
+			assert.Empty(t, deployments.Items)
+		})
+	}
+}
+
+// Test The finalizeSharedDispatcher() Functionality
+func TestFinalizeSharedDispatcher(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		placements []duckv1alpha1.Placement
+		wantErr    bool
+	}{
+		{
+			name: "De-Scheduled",
+		},
+		{
+			name:       "Still Scheduled",
+			placements: []duckv1alpha1.Placement{{PodName: constants.SharedDispatcherName + "-0", VReplicas: 1}},
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := newDispatcherTestContext(t)
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithDeletionTimestamp)
+			r := newDispatcherTestReconciler(nil, scheduler.SchedulerFunc(func(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
+				assert.Equal(t, int32(0), vpod.GetVReplicas())
+				return test.placements, nil
+			}))
+			err := r.finalizeSharedDispatcher(ctx, channel)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Nil(t, channel.Status.Placements)
+		})
+	}
+}
+
+// Test The evictSharedDispatcherPod() Functionality
+func TestEvictSharedDispatcherPod(t *testing.T) {
+
+	// Test Data
+	ctx := newDispatcherTestContext(t)
+	pod0 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: commontesting.SystemNamespace, Name: constants.SharedDispatcherName + "-0"}}
+	pod1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: commontesting.SystemNamespace, Name: constants.SharedDispatcherName + "-1"}}
+	channel := controllertesting.NewKafkaChannel()
+	channel.Status.Placements = []duckv1alpha1.Placement{{PodName: pod1.Name, VReplicas: 1}}
+	r := newDispatcherTestReconciler([]runtime.Object{pod0, pod1, channel}, nil)
+
+	// Perform The Test
+	err := r.evictSharedDispatcherPod(ctx, pod1, channel, &channel.Status.Placements[0])
+
+	// Verify The Pod Was Marked Unschedulable & The Placement Was Removed
+	assert.Nil(t, err)
+	updatedPod, err := r.kubeClientset.CoreV1().Pods(pod1.Namespace).Get(ctx, pod1.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "true", updatedPod.Annotations[scheduler.PodAnnotationKey])
+	updatedChannel, err := r.kafkaClientSet.MessagingV1beta1().KafkaChannels(channel.Namespace).Get(ctx, channel.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, updatedChannel.Status.Placements)
+}
+
+// Test The vpodLister() Functionality
+func TestVPodLister(t *testing.T) {
+	r := newDispatcherTestReconciler([]runtime.Object{controllertesting.NewKafkaChannel()}, nil)
+	vpods, err := r.vpodLister()
+	assert.Nil(t, err)
+	assert.Len(t, vpods, 1)
+	assert.Equal(t, int32(1), vpods[0].GetVReplicas())
+}
+
+// Create A Context With A Test Logger & Fake EventRecorder
+func newDispatcherTestContext(t *testing.T) context.Context {
+	ctx := logtesting.TestContextWithLogger(t)
+	return controller.WithEventRecorder(ctx, record.NewFakeRecorder(100))
+}
+
+// Create A Reconciler With The Specified Objects & (Optional) Shared Dispatcher Scheduler
+func newDispatcherTestReconciler(objects []runtime.Object, sharedDispatcherScheduler scheduler.Scheduler) *Reconciler {
+	listers := controllertesting.NewListers(objects)
+	return &Reconciler{
+		kubeClientset:      fakekubeclientset.NewSimpleClientset(listers.GetKubeObjects()...),
+		kafkaClientSet:     fakekafkaclientset.NewSimpleClientset(listers.GetKafkaChannelObjects()...),
+		environment:        controllertesting.NewEnvironment(),
+		config:             controllertesting.NewConfig(),
+		kafkachannelLister: listers.GetKafkaChannelLister(),
+		deploymentLister:   listers.GetDeploymentLister(),
+		statefulSetLister:  listers.GetStatefulSetLister(),
+		serviceLister:      listers.GetServiceLister(),
+		podLister:          listers.GetPodLister(),
+		scheduler:          sharedDispatcherScheduler,
+		kafkaConfigMapHash: controllertesting.ConfigMapHash,
+	}
+}
+
+// Verify The KafkaChannel Implements The Scheduler's VPod Interface
+var _ scheduler.VPod = (*kafkav1beta1.KafkaChannel)(nil)
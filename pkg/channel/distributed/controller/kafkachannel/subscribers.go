@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+)
+
+//
+// Reconcile The Subscribers' Status For The Specified KafkaChannel
+//
+// Each Dispatcher replica reports the status of its Subscribers' ConsumerGroups (including
+// the partitions they have claimed) via the control-protocol.  The reports from all replicas
+// are combined here, and a Subscriber is only considered Ready once all of the KafkaChannel's
+// partitions have been claimed by one of the Dispatcher replicas.
+//
+
+// Reconcile The Control-Protocol Connections To The Dispatcher & Update The Subscribers' Status
+func (r *Reconciler) reconcileSubscribersStatus(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Get The Channel-Specific Logger Provided Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Determine The Dispatcher Pods, Connection-Pool Key & Notification-Store Key (Dedicated Or Shared Dispatcher)
+	dispatcherName, poolKey, storeKey := r.subscribersStatusKeys(channel)
+
+	// Always Update The Subscribers' Status With The Latest Known Notifications (Even On Failure)
+	defer func() {
+		notifications, _ := r.subscribersStatusStore.GetPodsNotifications(storeKey)
+		channel.Status.SubscribableStatus = newSubscribableStatus(channel, notifications)
+	}()
+
+	// Get The IPs Of The Dispatcher Pods
+	dispatcherLabels := labels.Set{constants.AppLabel: dispatcherName}
+	podIpGetter := ctrlreconciler.PodIpGetter{Lister: r.podLister}
+	podIPs, err := podIpGetter.GetAllPodsIp(r.environment.SystemNamespace, dispatcherLabels.AsSelector())
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherSubscribersStatusReconciliationFailed.String(), "Failed To Get Dispatcher Pods: %v", err)
+		logger.Error("Failed To Get Dispatcher Pods", zap.Error(err))
+		return err
+	}
+	for index, podIP := range podIPs {
+		podIPs[index] = fmt.Sprintf("%s:%d", podIP, distributedcontrol.ServerPort)
+	}
+
+	// Reconcile The Control-Protocol Connections To The Dispatcher Pods
+	_, err = r.connectionPool.ReconcileConnections(
+		ctx,
+		poolKey,
+		podIPs,
+		func(newHost string, service ctrl.Service) {
+			service.MessageHandler(ctrlservice.MessageRouter{
+				distributedcontrol.NotifySubscribersStatusOpCode: r.subscribersStatusStore.MessageHandler(
+					storeKey,
+					newHost,
+					ctrlreconciler.PassNewValue,
+				),
+			})
+		},
+		func(oldHost string) {
+			r.subscribersStatusStore.CleanPodNotification(storeKey, oldHost)
+		},
+	)
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherSubscribersStatusReconciliationFailed.String(), "Failed To Reconcile Dispatcher Connections: %v", err)
+		logger.Error("Failed To Reconcile Dispatcher Connections", zap.Error(err))
+		return err
+	}
+
+	// Return Success
+	logger.Debug("Successfully Reconciled Dispatcher Connections", zap.Strings("PodIPs", podIPs))
+	return nil
+}
+
+// Finalize The Control-Protocol Connections To The Dispatcher & Their Subscribers' Status Notifications
+func (r *Reconciler) finalizeSubscribersStatus(ctx context.Context, channel *kafkav1beta1.KafkaChannel) {
+
+	// The Shared Dispatcher's Connections & Notifications Are Still In Use By Other KafkaChannels
+	if r.scheduler != nil {
+		return
+	}
+
+	r.connectionPool.RemoveAllConnections(ctx, string(channel.UID))
+	r.subscribersStatusStore.CleanPodsNotifications(types.NamespacedName{Namespace: channel.Namespace, Name: channel.Name})
+}
+
+// Get The Dispatcher Name (App Label), Connection-Pool Key & Notification-Store Key For The Specified KafkaChannel
+//
+// A dedicated Dispatcher reports only the Subscribers of its own KafkaChannel, whereas the replicas
+// of the shared Dispatcher report the Subscribers of all the KafkaChannels placed on them.  In the
+// latter case a single set of connections & notifications is therefore used for all KafkaChannels.
+func (r *Reconciler) subscribersStatusKeys(channel *kafkav1beta1.KafkaChannel) (string, string, types.NamespacedName) {
+	if r.scheduler != nil {
+		return constants.SharedDispatcherName, constants.SharedDispatcherName, sharedDispatcherNamespacedName(r.environment.SystemNamespace)
+	}
+	return util.DispatcherDnsSafeName(channel), string(channel.UID), types.NamespacedName{Namespace: channel.Namespace, Name: channel.Name}
+}
+
+// Get The NamespacedName Used To Track The Shared Dispatcher's Subscribers' Status Notifications
+func sharedDispatcherNamespacedName(systemNamespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: systemNamespace, Name: constants.SharedDispatcherName}
+}
+
+// Create The SubscribableStatus Block From The SubscribersStatus Notifications Of All Dispatcher Pods
+func newSubscribableStatus(channel *kafkav1beta1.KafkaChannel, notifications map[string]interface{}) eventingduck.SubscribableStatus {
+
+	var subscriberStatuses []eventingduck.SubscriberStatus
+
+	for _, subscriber := range channel.Spec.Subscribers {
+
+		// Combine The Status Of The Subscriber From All Dispatcher Pods
+		reported := false
+		stopped := false
+		errMessage := ""
+		claimedPartitions := sets.NewInt32()
+		for _, notification := range notifications {
+			subscribersStatus, ok := notification.(distributedcontrol.SubscribersStatus)
+			if !ok {
+				continue
+			}
+			if status, ok := subscribersStatus[subscriber.UID]; ok {
+				reported = true
+				stopped = stopped || status.Stopped
+				if status.Error != "" {
+					errMessage = status.Error
+				}
+				claimedPartitions.Insert(status.Partitions...)
+			}
+		}
+
+		// Determine The Subscriber's Readiness
+		subscriberStatus := eventingduck.SubscriberStatus{
+			UID:                subscriber.UID,
+			ObservedGeneration: subscriber.Generation,
+		}
+		switch {
+		case !reported:
+			subscriberStatus.Ready = corev1.ConditionUnknown
+			subscriberStatus.Message = constants.SubscriberNoStatusMessage
+		case errMessage != "":
+			subscriberStatus.Ready = corev1.ConditionFalse
+			subscriberStatus.Message = errMessage
+		case stopped:
+			// A stopped group isn't an "error" but it does represent a group that isn't "Ready"
+			subscriberStatus.Ready = corev1.ConditionFalse
+			subscriberStatus.Message = constants.SubscriberGroupStoppedMessage
+		case claimedPartitions.Len() < int(channel.Spec.NumPartitions):
+			subscriberStatus.Ready = corev1.ConditionFalse
+			subscriberStatus.Message = fmt.Sprintf(constants.SubscriberPartitionsClaimedMessage, claimedPartitions.Len(), channel.Spec.NumPartitions)
+		default:
+			subscriberStatus.Ready = corev1.ConditionTrue
+		}
+
+		subscriberStatuses = append(subscriberStatuses, subscriberStatus)
+	}
+
+	return eventingduck.SubscribableStatus{Subscribers: subscriberStatuses}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+)
+
+// Test The newSubscribableStatus() Functionality
+func TestNewSubscribableStatus(t *testing.T) {
+
+	uid := types.UID("test-subscriber-uid")
+
+	tests := []struct {
+		name          string
+		notifications map[string]interface{}
+		wantReady     corev1.ConditionStatus
+		wantMessage   string
+	}{
+		{
+			name:          "No Notifications",
+			notifications: nil,
+			wantReady:     corev1.ConditionUnknown,
+			wantMessage:   constants.SubscriberNoStatusMessage,
+		},
+		{
+			name: "Subscriber Not Reported",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{"other-uid": {Partitions: []int32{0}}},
+			},
+			wantReady:   corev1.ConditionUnknown,
+			wantMessage: constants.SubscriberNoStatusMessage,
+		},
+		{
+			name: "All Partitions Claimed By Single Pod",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 1, 2, 3}}},
+			},
+			wantReady: corev1.ConditionTrue,
+		},
+		{
+			name: "All Partitions Claimed Across Pods",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 2}}},
+				"pod-2": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{1, 3}}},
+			},
+			wantReady: corev1.ConditionTrue,
+		},
+		{
+			name: "Some Partitions Claimed",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 2}}},
+				"pod-2": distributedcontrol.SubscribersStatus{uid: {}},
+			},
+			wantReady:   corev1.ConditionFalse,
+			wantMessage: "2 of 4 partitions claimed",
+		},
+		{
+			name: "Stopped Group",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Stopped: true}},
+			},
+			wantReady:   corev1.ConditionFalse,
+			wantMessage: constants.SubscriberGroupStoppedMessage,
+		},
+		{
+			name: "Error Reported",
+			notifications: map[string]interface{}{
+				"pod-1": distributedcontrol.SubscribersStatus{uid: {Partitions: []int32{0, 1, 2, 3}}},
+				"pod-2": distributedcontrol.SubscribersStatus{uid: {Error: "test error"}},
+			},
+			wantReady:   corev1.ConditionFalse,
+			wantMessage: "test error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channel := controllertesting.NewKafkaChannel()
+			channel.Spec.NumPartitions = 4
+			channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: uid, Generation: 2}}
+
+			status := newSubscribableStatus(channel, test.notifications)
+
+			assert.Len(t, status.Subscribers, 1)
+			assert.Equal(t, uid, status.Subscribers[0].UID)
+			assert.Equal(t, int64(2), status.Subscribers[0].ObservedGeneration)
+			assert.Equal(t, test.wantReady, status.Subscribers[0].Ready)
+			assert.Equal(t, test.wantMessage, status.Subscribers[0].Message)
+		})
+	}
+
+	// Verify No Subscribers Results In An Empty SubscribableStatus
+	assert.Nil(t, newSubscribableStatus(controllertesting.NewKafkaChannel(), nil).Subscribers)
+}
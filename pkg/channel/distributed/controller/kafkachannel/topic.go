@@ -18,6 +18,7 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -34,6 +35,9 @@ import (
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 )
 
+// errExistingTopicUnsupported Is Returned When The AdminClient Cannot Describe An Existing (spec.topic) Topic
+var errExistingTopicUnsupported = errors.New("the custom sidecar implements protocol version 1, which does not support spec.topic")
+
 // reconcileKafkaTopic Reconciles The Kafka Topic Associated With The Specified Channel
 func (r *Reconciler) reconcileKafkaTopic(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
 
@@ -46,22 +50,36 @@ func (r *Reconciler) reconcileKafkaTopic(ctx context.Context, channel *kafkav1be
 	// Get The Topic Configuration From The Channel
 	numPartitions := channel.Spec.NumPartitions
 	replicationFactor := channel.Spec.ReplicationFactor
-	retentionDuration, err := channel.Spec.ParseRetentionDuration()
-	if err != nil {
-		// Should never happen with webhook defaulting and validation in place.
-		logger.Error("Failed To Parse RetentionDuration Using Default Value Instead", zap.String("RetentionDuration", channel.Spec.RetentionDuration), zap.Error(err))
-		retentionDuration = commonconstants.DefaultRetentionDuration
-	}
-	retentionMillis := retentionDuration.Milliseconds()
 
-	// Create The Topic (Handles Case Where Already Exists)
-	err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	var err error
+	if len(channel.Spec.Topic) > 0 {
+
+		// Validate The Existing Topic (Which Is Never Created By The Controller)
+		err = r.validateExistingTopic(ctx, topicName, numPartitions, replicationFactor)
+
+	} else {
+
+		retentionDuration, parseErr := channel.Spec.ParseRetentionDuration()
+		if parseErr != nil {
+			// Should never happen with webhook defaulting and validation in place.
+			logger.Error("Failed To Parse RetentionDuration Using Default Value Instead", zap.String("RetentionDuration", channel.Spec.RetentionDuration), zap.Error(parseErr))
+			retentionDuration = commonconstants.DefaultRetentionDuration
+		}
+		retentionMillis := retentionDuration.Milliseconds()
+
+		// Create The Topic (Handles Case Where Already Exists)
+		err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	}
 
 	// Log Results & Return Status
 	if err != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicReconciliationFailed.String(), "Failed To Reconcile Kafka Topic For Channel: %v", err)
 		logger.Error("Failed To Reconcile Kafka Topic", zap.Error(err))
-		channel.Status.MarkTopicFailed("TopicFailed", fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
+		reason := "TopicFailed"
+		if errors.Is(err, errExistingTopicUnsupported) {
+			reason = "ExistingTopicUnsupported"
+		}
+		channel.Status.MarkTopicFailed(reason, fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
 	} else {
 		logger.Info("Successfully Reconciled Kafka Topic")
 		channel.Status.MarkTopicTrue()
@@ -78,6 +96,12 @@ func (r *Reconciler) finalizeKafkaTopic(ctx context.Context, channel *kafkav1bet
 	// Get Channel Specific Logger (Provided Via Context) & Add Topic Name
 	logger := logging.FromContext(ctx).Desugar().With(zap.String("TopicName", topicName))
 
+	// Leave The Kafka Topic In Place If So Specified
+	if channel.Spec.EffectiveDeletionPolicy() == kafkav1beta1.DeletionPolicyRetain {
+		logger.Info("Retaining Kafka Topic Per DeletionPolicy")
+		return nil
+	}
+
 	// Delete The Kafka Topic & Handle Error Response
 	err := r.deleteTopic(ctx, topicName)
 	if err != nil {
@@ -127,6 +151,29 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 	}
 }
 
+// validateExistingTopic Verifies The Specified Kafka Topic Exists With The Expected Partitions & ReplicationFactor
+func (r *Reconciler) validateExistingTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16) error {
+	topicDetail, topicErr := r.adminClient.DescribeTopic(ctx, topicName)
+	if topicErr != nil && topicErr.Err != sarama.ErrNoError {
+		if topicErr.Err == sarama.ErrUnknownTopicOrPartition {
+			return fmt.Errorf("topic %s does not exist", topicName)
+		}
+		if topicErr.Err == sarama.ErrUnsupportedVersion && r.config.Channel.AdminType == constants.KafkaAdminTypeValueCustom {
+			return fmt.Errorf("failed to describe topic %s: %w", topicName, errExistingTopicUnsupported)
+		}
+		return fmt.Errorf("failed to describe topic %s: %w", topicName, topicErr)
+	} else if topicDetail == nil {
+		return fmt.Errorf("topic %s does not exist", topicName)
+	}
+	if topicDetail.NumPartitions != partitions {
+		return fmt.Errorf("topic %s has %d partitions, expected %d", topicName, topicDetail.NumPartitions, partitions)
+	}
+	if topicDetail.ReplicationFactor != replicationFactor {
+		return fmt.Errorf("topic %s has replication factor %d, expected %d", topicName, topicDetail.ReplicationFactor, replicationFactor)
+	}
+	return nil
+}
+
 // deleteTopic Deletes The Specified Kafka Topic
 func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 
@@ -22,12 +22,14 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/controller"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 )
@@ -281,3 +283,130 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 		},
 	}
 }
+
+// Test The Kafka Topic Reconciliation Of A KafkaChannel With An Existing Topic
+func TestReconcileExistingKafkaTopic(t *testing.T) {
+
+	const existingTopic = "existing-topic"
+
+	tests := []struct {
+		name        string
+		partitions  int32
+		replicas    int16
+		adminType   string
+		describeErr sarama.KError
+		wantErr     bool
+		wantReason  string
+	}{
+		{
+			name:       "Valid Existing Topic",
+			partitions: controllertesting.NumPartitions,
+			replicas:   1,
+		},
+		{
+			name:       "Mismatched Partitions",
+			partitions: controllertesting.NumPartitions + 1,
+			replicas:   1,
+			wantErr:    true,
+		},
+		{
+			name:       "Mismatched ReplicationFactor",
+			partitions: controllertesting.NumPartitions,
+			replicas:   2,
+			wantErr:    true,
+		},
+		{
+			name:        "Missing Topic",
+			describeErr: sarama.ErrUnknownTopicOrPartition,
+			wantErr:     true,
+		},
+		{
+			name:        "Describe Failure",
+			describeErr: sarama.ErrBrokerNotAvailable,
+			wantErr:     true,
+			wantReason:  "TopicFailed",
+		},
+		{
+			name:        "Custom Sidecar Protocol Version 1",
+			adminType:   constants.KafkaAdminTypeValueCustom,
+			describeErr: sarama.ErrUnsupportedVersion,
+			wantErr:     true,
+			wantReason:  "ExistingTopicUnsupported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			channel := controllertesting.NewKafkaChannel()
+			channel.Spec.Topic = existingTopic
+			channel.Spec.ReplicationFactor = 1
+
+			// The AdminClient Must Describe, But Not Create, The Existing Topic
+			r := &Reconciler{
+				adminClient: &controllertesting.MockAdminClient{
+					MockDescribeTopicFunc: func(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+						assert.Equal(t, existingTopic, topicName)
+						if test.describeErr != sarama.ErrNoError {
+							return nil, &sarama.TopicError{Err: test.describeErr}
+						}
+						return &sarama.TopicDetail{NumPartitions: test.partitions, ReplicationFactor: test.replicas}, &sarama.TopicError{Err: sarama.ErrNoError}
+					},
+					MockCreateTopicFunc: func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError {
+						t.Error("Unexpected CreateTopic() Call")
+						return nil
+					},
+				},
+				config: controllertesting.NewConfig(),
+			}
+			if test.adminType != "" {
+				r.config.Channel.AdminType = test.adminType
+			}
+
+			// Perform The Test
+			err := r.reconcileKafkaTopic(newDispatcherTestContext(t), channel)
+
+			// Verify The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, !test.wantErr, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).IsTrue())
+			if test.wantReason != "" {
+				assert.Equal(t, test.wantReason, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).Reason)
+			}
+		})
+	}
+}
+
+// Test The Kafka Topic Finalization Honors The DeletionPolicy
+func TestFinalizeKafkaTopicDeletionPolicy(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		topic          string
+		deletionPolicy kafkav1beta1.DeletionPolicy
+		wantDelete     bool
+	}{
+		{name: "Default Deletes Created Topic", wantDelete: true},
+		{name: "Default Retains Existing Topic", topic: "existing-topic"},
+		{name: "Retain Created Topic", deletionPolicy: kafkav1beta1.DeletionPolicyRetain},
+		{name: "Delete Existing Topic", topic: "existing-topic", deletionPolicy: kafkav1beta1.DeletionPolicyDelete, wantDelete: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channel := controllertesting.NewKafkaChannel()
+			channel.Spec.Topic = test.topic
+			channel.Spec.DeletionPolicy = test.deletionPolicy
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockDeleteTopicFunc: func(_ context.Context, topicName string) *sarama.TopicError {
+					if len(test.topic) > 0 {
+						assert.Equal(t, test.topic, topicName)
+					}
+					return nil
+				},
+			}
+			r := &Reconciler{adminClient: mockAdminClient, config: controllertesting.NewConfig()}
+			assert.Nil(t, r.finalizeKafkaTopic(newDispatcherTestContext(t), channel))
+			assert.Equal(t, test.wantDelete, mockAdminClient.DeleteTopicsCalled())
+		})
+	}
+}
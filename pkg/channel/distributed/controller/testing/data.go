@@ -59,6 +59,7 @@ const (
 
 	// Environment Test Data
 	ServiceAccount     = "TestServiceAccount"
+	DataPlaneAccount   = "TestDataPlaneServiceAccount"
 	KafkaAdminType     = "kafka"
 	MetricsPort        = 9876
 	MetricsDomain      = "eventing-kafka"
@@ -417,13 +418,14 @@ func WithExtraAnnotations(deployment *appsv1.Deployment) {
 // NewEnvironment Sets The Required Environment Variables
 func NewEnvironment() *env.Environment {
 	return &env.Environment{
-		SystemNamespace: commontesting.SystemNamespace,
-		ServiceAccount:  ServiceAccount,
-		MetricsPort:     MetricsPort,
-		MetricsDomain:   MetricsDomain,
-		DispatcherImage: DispatcherImage,
-		ReceiverImage:   ReceiverImage,
-		ResyncPeriod:    ResyncPeriod,
+		SystemNamespace:         commontesting.SystemNamespace,
+		ServiceAccount:          ServiceAccount,
+		DataPlaneServiceAccount: DataPlaneAccount,
+		MetricsPort:             MetricsPort,
+		MetricsDomain:           MetricsDomain,
+		DispatcherImage:         DispatcherImage,
+		ReceiverImage:           ReceiverImage,
+		ResyncPeriod:            ResyncPeriod,
 	}
 }
 
@@ -590,6 +592,7 @@ func NewSecretAndKafkaChannel(options ...KafkaChannelOption) []runtime.Object {
 func WithInitializedConditions(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Status.InitializeConditions()
 	kafkachannel.Status.MarkConfigTrue()
+	kafkachannel.Status.DurabilityClass = kafkachannel.Spec.EffectiveDurabilityClass()
 }
 
 // WithEmptySpec Removes The KafkaChannel's Spec
@@ -850,7 +853,7 @@ func NewKafkaChannelReceiverDeployment(options ...DeploymentOption) *appsv1.Depl
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccount,
+					ServiceAccountName: DataPlaneAccount,
 					Containers: []corev1.Container{
 						{
 							Name: ReceiverDeploymentName,
@@ -1088,7 +1091,7 @@ func NewKafkaChannelDispatcherDeployment(options ...DeploymentOption) *appsv1.De
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccount,
+					ServiceAccountName: DataPlaneAccount,
 					Containers: []corev1.Container{
 						{
 							Name:  dispatcherName,
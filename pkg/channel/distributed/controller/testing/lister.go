@@ -106,3 +106,11 @@ func (l *Listers) GetKafkaChannelLister() kafkalisters.KafkaChannelLister {
 func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
+
+func (l *Listers) GetPodLister() corev1listers.PodLister {
+	return corev1listers.NewPodLister(l.indexerFor(&corev1.Pod{}))
+}
+
+func (l *Listers) GetStatefulSetLister() appsv1listers.StatefulSetLister {
+	return appsv1listers.NewStatefulSetLister(l.indexerFor(&appsv1.StatefulSet{}))
+}
@@ -32,12 +32,15 @@ var _ types.AdminClientInterface = &MockAdminClient{}
 
 // Mock Kafka AdminClient Implementation
 type MockAdminClient struct {
-	closeCalled         bool
-	createTopicsCalled  bool
-	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
-	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
-	MockCloseFunc       func() error
+	closeCalled              bool
+	createTopicsCalled       bool
+	deleteTopicsCalled       bool
+	MockCreateTopicFunc      func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockDeleteTopicFunc      func(context.Context, string) *sarama.TopicError
+	MockDescribeTopicFunc    func(context.Context, string) (*sarama.TopicDetail, *sarama.TopicError)
+	MockAlterTopicConfigFunc func(context.Context, string, map[string]*string) *sarama.TopicError
+	MockCreatePartitionsFunc func(context.Context, string, int32) *sarama.TopicError
+	MockCloseFunc            func() error
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
@@ -70,6 +73,33 @@ func (m *MockAdminClient) DeleteTopicsCalled() bool {
 	return m.deleteTopicsCalled
 }
 
+// Mock Kafka AdminClient DescribeTopic() Function - Calls Custom DescribeTopic() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+	if m.MockDescribeTopicFunc != nil {
+		return m.MockDescribeTopicFunc(ctx, topicName)
+	}
+	errMsg := "mock DescribeTopic() success"
+	return &sarama.TopicDetail{}, &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Mock Kafka AdminClient AlterTopicConfig() Function - Calls Custom AlterTopicConfig() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if m.MockAlterTopicConfigFunc != nil {
+		return m.MockAlterTopicConfigFunc(ctx, topicName, configEntries)
+	}
+	errMsg := "mock AlterTopicConfig() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Mock Kafka AdminClient CreatePartitions() Function - Calls Custom CreatePartitions() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+	if m.MockCreatePartitionsFunc != nil {
+		return m.MockCreatePartitionsFunc(ctx, topicName, count)
+	}
+	errMsg := "mock CreatePartitions() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
 // Mock Kafka AdminClient Close Function - NoOp
 func (m *MockAdminClient) Close() error {
 	m.closeCalled = true
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis/duck"
 )
 
@@ -32,6 +33,7 @@ import (
 //   - ObjectMeta Labels & Annotations
 //   - Spec.Template.ObjectMeta Labels & Annotations
 //   - Spec.Template.Spec.Containers  (excluding certain fields)
+//   - Spec.Template.Spec NodeSelector & Tolerations
 //
 // Note - Spec.Replicas are ignored to avoid overwriting local HPA configuration.
 func CheckDeploymentChanged(logger *zap.Logger, oldDeployment, newDeployment *appsv1.Deployment) (*appsv1.Deployment, bool) {
@@ -132,7 +134,14 @@ func CheckDeploymentChanged(logger *zap.Logger, oldDeployment, newDeployment *ap
 	}
 
 	containersEqual := cmp.Equal(oldContainer, newContainer, ignoreFields...)
-	if containersEqual && !metadataChanged {
+
+	// Verify the pod scheduling constraints (such as those from a KafkaChannel's dispatcher overrides)
+	oldPodSpec := &oldDeployment.Spec.Template.Spec
+	newPodSpec := &newDeployment.Spec.Template.Spec
+	schedulingEqual := equality.Semantic.DeepEqual(oldPodSpec.NodeSelector, newPodSpec.NodeSelector) &&
+		equality.Semantic.DeepEqual(oldPodSpec.Tolerations, newPodSpec.Tolerations)
+
+	if containersEqual && schedulingEqual && !metadataChanged {
 		// Nothing of interest changed, so just keep the old deployment
 		return oldDeployment, false
 	}
@@ -149,6 +158,10 @@ func CheckDeploymentChanged(logger *zap.Logger, oldDeployment, newDeployment *ap
 		updatedDeployment.Spec.Template.Spec.Containers[0] = *newContainer
 		updatedDeployment.Spec.Template.Spec.Volumes = newDeployment.Spec.Template.Spec.Volumes
 	}
+	if !schedulingEqual {
+		updatedDeployment.Spec.Template.Spec.NodeSelector = newPodSpec.NodeSelector
+		updatedDeployment.Spec.Template.Spec.Tolerations = newPodSpec.Tolerations
+	}
 	return updatedDeployment, true
 }
 
@@ -162,6 +175,27 @@ func findContainer(deployment *appsv1.Deployment, name string) *corev1.Container
 	return nil
 }
 
+// CheckStatefulSetChanged returns a new StatefulSet based on the oldStatefulSet but with updated data
+// from the newStatefulSet as well as a boolean indicator of whether any changes were necessary.  The
+// same portions of the StatefulSet are evaluated as in CheckDeploymentChanged(), and Spec.Replicas are
+// likewise ignored (they are managed by the shared dispatcher's scheduler/autoscaler).
+func CheckStatefulSetChanged(logger *zap.Logger, oldStatefulSet, newStatefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, bool) {
+
+	// Wrap The Relevant StatefulSet Fields In Deployments In Order To Re-Use The Deployment Comparison
+	oldDeployment := &appsv1.Deployment{ObjectMeta: oldStatefulSet.ObjectMeta, Spec: appsv1.DeploymentSpec{Template: oldStatefulSet.Spec.Template}}
+	newDeployment := &appsv1.Deployment{ObjectMeta: newStatefulSet.ObjectMeta, Spec: appsv1.DeploymentSpec{Template: newStatefulSet.Spec.Template}}
+	updatedDeployment, changed := CheckDeploymentChanged(logger, oldDeployment, newDeployment)
+	if !changed {
+		return oldStatefulSet, false
+	}
+
+	// Create An Updated StatefulSet From The Old One, But Using The Updated Metadata & Template
+	updatedStatefulSet := oldStatefulSet.DeepCopy()
+	updatedStatefulSet.ObjectMeta = updatedDeployment.ObjectMeta
+	updatedStatefulSet.Spec.Template = updatedDeployment.Spec.Template
+	return updatedStatefulSet, true
+}
+
 // CheckServiceChanged Modifies A Service With New Fields (If Necessary)
 // Returns True If Any Modifications Were Made
 func CheckServiceChanged(logger *zap.Logger, oldService, newService *corev1.Service) ([]byte, bool) {
@@ -136,6 +136,23 @@ func TestCheckDeploymentChanged(t *testing.T) {
 			newDeployment:      getBasicDeployment(withTemplateAnnotation),
 			expectUpdated:      true,
 		},
+		{
+			name:               "Missing Required Node Selector",
+			existingDeployment: getBasicDeployment(),
+			newDeployment:      getBasicDeployment(withNodeSelector),
+			expectUpdated:      true,
+		},
+		{
+			name:               "Extra Existing Tolerations",
+			existingDeployment: getBasicDeployment(withTolerations),
+			newDeployment:      getBasicDeployment(),
+			expectUpdated:      true,
+		},
+		{
+			name:               "Same Node Selector And Tolerations",
+			existingDeployment: getBasicDeployment(withNodeSelector, withTolerations),
+			newDeployment:      getBasicDeployment(withNodeSelector, withTolerations),
+		},
 		{
 			name:               "Container With Incorrect Name",
 			existingDeployment: getBasicDeployment(withDifferentContainer),
@@ -156,6 +173,62 @@ func TestCheckDeploymentChanged(t *testing.T) {
 	}
 }
 
+// Tests the CheckStatefulSetChanged functionality (which largely delegates to CheckDeploymentChanged)
+func TestCheckStatefulSetChanged(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	tests := []struct {
+		name                string
+		existingDeployment  *appsv1.Deployment
+		newDeployment       *appsv1.Deployment
+		existingReplicas    int32
+		expectUpdated       bool
+		expectTemplateLabel bool
+	}{
+		{
+			name:               "Unchanged",
+			existingDeployment: getBasicDeployment(),
+			newDeployment:      getBasicDeployment(),
+			existingReplicas:   3,
+		},
+		{
+			name:               "Different Image",
+			existingDeployment: getBasicDeployment(),
+			newDeployment:      getBasicDeployment(withDifferentImage),
+			existingReplicas:   3,
+			expectUpdated:      true,
+		},
+		{
+			name:                "Missing Required Template Label",
+			existingDeployment:  getBasicDeployment(),
+			newDeployment:       getBasicDeployment(withTemplateLabel),
+			existingReplicas:    2,
+			expectUpdated:       true,
+			expectTemplateLabel: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{
+				ObjectMeta: tt.existingDeployment.ObjectMeta,
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: &tt.existingReplicas,
+					Template: tt.existingDeployment.Spec.Template,
+				},
+			}
+			newStatefulSet := &appsv1.StatefulSet{
+				ObjectMeta: tt.newDeployment.ObjectMeta,
+				Spec:       appsv1.StatefulSetSpec{Template: tt.newDeployment.Spec.Template},
+			}
+			updatedStatefulSet, isUpdated := CheckStatefulSetChanged(logger, existingStatefulSet, newStatefulSet)
+			assert.NotNil(t, updatedStatefulSet)
+			assert.Equal(t, tt.expectUpdated, isUpdated)
+			assert.Equal(t, tt.existingReplicas, *updatedStatefulSet.Spec.Replicas)
+			_, hasTemplateLabel := updatedStatefulSet.Spec.Template.ObjectMeta.Labels["TestTemplateLabelName"]
+			assert.Equal(t, tt.expectTemplateLabel, hasTemplateLabel)
+		})
+	}
+}
+
 // Tests the CheckServiceChanged functionality.  Note that this is also tested fairly extensively
 // as part of the various reconciler tests, and as such the service structs used here are somewhat trivial.
 func TestCheckServiceChanged(t *testing.T) {
@@ -331,6 +404,14 @@ func withDifferentContainer(deployment *appsv1.Deployment) {
 	deployment.Spec.Template.Spec.Containers[0].Name = "TestDifferentContainerName"
 }
 
+func withNodeSelector(deployment *appsv1.Deployment) {
+	deployment.Spec.Template.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+}
+
+func withTolerations(deployment *appsv1.Deployment) {
+	deployment.Spec.Template.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+}
+
 func withDifferentImage(deployment *appsv1.Deployment) {
 	deployment.Spec.Template.Spec.Containers[0].Image = "TestNewImage"
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -28,6 +29,8 @@ import (
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	kafkachannelinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
 )
 
 // SubscriptionLogger returns a Logger with Subscription info.
@@ -44,17 +47,41 @@ func NewSubscriptionControllerRef(subscription *messagingv1.Subscription) metav1
 	})
 }
 
-// TopicNameMapper returns a string representing the Kafka Topic name for the specified Knative Subscription.
+// TopicNameMapper returns a string representing the Kafka Topic name derived from the KafkaChannel of the
+// specified Knative Subscription (ignoring any existing Topic specified by the KafkaChannel).
 func TopicNameMapper(subscription *messagingv1.Subscription) (string, error) {
 	if subscription == nil {
 		return "", fmt.Errorf("unable to format topic name for nil Subscription")
 	}
-	channelName := subscription.Spec.Channel.Name
+	channelNamespace, channelName := subscriptionChannel(subscription)
+	return commonkafkautil.TopicName(channelNamespace, channelName), nil
+}
+
+// NewTopicNameMapper returns a TopicNameMapper which looks up the KafkaChannel of the specified Knative Subscription
+// in order to return its actual Kafka Topic name (including any existing Topic specified by the KafkaChannel).  The
+// Context must have injected informers (KafkaChannelInformer).
+func NewTopicNameMapper(ctx context.Context) refmappers.SubscriptionTopicNameMapper {
+	kafkaChannelLister := kafkachannelinformer.Get(ctx).Lister()
+	return func(subscription *messagingv1.Subscription) (string, error) {
+		if subscription == nil {
+			return "", fmt.Errorf("unable to format topic name for nil Subscription")
+		}
+		channelNamespace, channelName := subscriptionChannel(subscription)
+		channel, err := kafkaChannelLister.KafkaChannels(channelNamespace).Get(channelName)
+		if err != nil {
+			return "", fmt.Errorf("unable to get KafkaChannel %s/%s of Subscription: %v", channelNamespace, channelName, err)
+		}
+		return TopicName(channel), nil
+	}
+}
+
+// subscriptionChannel returns the namespace and name of the specified Knative Subscription's Channel.
+func subscriptionChannel(subscription *messagingv1.Subscription) (string, string) {
 	channelNamespace := subscription.Spec.Channel.Namespace
 	if len(channelNamespace) <= 0 {
 		channelNamespace = subscription.Namespace
 	}
-	return commonkafkautil.TopicName(channelNamespace, channelName), nil
+	return channelNamespace, subscription.Spec.Channel.Name
 }
 
 // GroupIdMapper returns a string representing the Kafka ConsumerGroup ID for the specified Knative Subscription.
@@ -99,3 +126,17 @@ func DataPlaneLabelsMapper(subscription *messagingv1.Subscription) (map[string]s
 
 	return labels, nil
 }
+
+// NewRefMapperFactory returns a Subscription RefMapper Factory using the distributed KafkaChannel Topic / Group naming,
+// which resolves Topic names via the KafkaChannel informer in order to support existing (spec.topic) Topics.
+func NewRefMapperFactory() *refmappers.SubscriptionRefMapperFactory {
+	factory := refmappers.NewSubscriptionRefMapperFactory(
+		TopicNameMapper,
+		GroupIdMapper,
+		ConnectionPoolKeyMapper,
+		DataPlaneNamespaceMapper,
+		DataPlaneLabelsMapper,
+	)
+	factory.TopicNameMapperFactory = NewTopicNameMapper
+	return factory
+}
@@ -25,12 +25,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/injection"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	fakekafkachannelinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel/fake"
 )
 
 // Test Data
@@ -151,6 +155,59 @@ func TestTopicNameMapper(t *testing.T) {
 	}
 }
 
+// Test The NewTopicNameMapper Functionality
+func TestNewTopicNameMapper(t *testing.T) {
+
+	// Create A Context With A Fake KafkaChannel Informer Containing A KafkaChannel With An Existing Topic
+	ctx, _ := injection.Fake.SetupInformers(logtesting.TestContextWithLogger(t), &rest.Config{})
+	channel := &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: channelNamespace, Name: channelName},
+		Spec:       kafkav1beta1.KafkaChannelSpec{Topic: "existing-topic"},
+	}
+	assert.Nil(t, fakekafkachannelinformer.Get(ctx).Informer().GetIndexer().Add(channel))
+
+	// Create The TopicNameMapper To Test
+	topicNameMapper := NewTopicNameMapper(ctx)
+
+	// Define The TestCases
+	tests := []struct {
+		name         string
+		subscription *messagingv1.Subscription
+		expected     string
+		err          bool
+	}{
+		{
+			name: "existing topic",
+			subscription: &messagingv1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: subscriptionName, Namespace: channelNamespace},
+				Spec:       messagingv1.SubscriptionSpec{Channel: duckv1.KReference{Kind: constants.KafkaChannelKind, Name: channelName}},
+			},
+			expected: "existing-topic",
+		},
+		{
+			name: "unknown channel",
+			subscription: &messagingv1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: subscriptionName, Namespace: subscriptionNamespace},
+				Spec:       messagingv1.SubscriptionSpec{Channel: duckv1.KReference{Kind: constants.KafkaChannelKind, Name: channelName}},
+			},
+			err: true,
+		},
+		{
+			name: "nil subscription",
+			err:  true,
+		},
+	}
+
+	// Execute The TestCases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := topicNameMapper(test.subscription)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.err, err != nil)
+		})
+	}
+}
+
 // Test The GroupIdMapper Functionality
 func TestGroupIdMapper(t *testing.T) {
 
@@ -21,7 +21,7 @@ import (
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 )
 
-// Get The TopicName For Specified KafkaChannel (ChannelNamespace.ChannelName)
+// Get The TopicName For Specified KafkaChannel (Existing Spec.Topic Or ChannelNamespace.ChannelName)
 func TopicName(channel *kafkav1beta1.KafkaChannel) string {
-	return commonkafkautil.TopicName(channel.Namespace, channel.Name)
+	return commonkafkautil.ChannelTopicName(channel)
 }
@@ -22,8 +22,8 @@ import "time"
 const (
 	MetricsInterval = 5 * time.Second
 
-	Component = "eventing-kafka-channel-dispatcher"
+	// The Interval At Which The Subscribers' Status Is Resent To The Controller (Restarted Controller, Failed Sends)
+	SubscribersStatusResendInterval = 30 * time.Second
 
-	// GroupStoppedMessage is the message that will be in a subscriber's status when a group is stopped ("paused")
-	GroupStoppedMessage = "consumer group is stopped"
+	Component = "eventing-kafka-channel-dispatcher"
 )
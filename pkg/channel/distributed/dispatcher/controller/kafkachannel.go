@@ -19,12 +19,10 @@ package controller
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
@@ -32,17 +30,18 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	messaginginformers "knative.dev/eventing/pkg/client/informers/externalversions/messaging/v1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
-	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	informers "knative.dev/eventing-kafka/pkg/client/informers/externalversions/messaging/v1beta1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/filter"
 )
 
 const (
@@ -50,35 +49,37 @@ const (
 	ReconcilerName = "KafkaChannels"
 
 	// corev1.Events emitted
-	channelReconciled         = "ChannelReconciled"
-	channelReconcileFailed    = "ChannelReconcileFailed"
-	channelUpdateStatusFailed = "ChannelUpdateStatusFailed"
+	channelReconciled      = "ChannelReconciled"
+	channelReconcileFailed = "ChannelReconcileFailed"
 )
 
 // Reconciler reconciles KafkaChannels.
 type Reconciler struct {
 	logger               *zap.Logger
-	channelKey           string
+	channelKey           string // Empty For A Shared Dispatcher Serving All KafkaChannels Placed On This Pod
+	podName              string
 	dispatcher           dispatcher.Dispatcher
 	kafkachannelInformer cache.SharedIndexInformer
 	kafkachannelLister   listers.KafkaChannelLister
 	impl                 *controller.Impl
 	recorder             record.EventRecorder
-	kafkaClientSet       versioned.Interface
 }
 
 var _ controller.Reconciler = Reconciler{}
 
 // NewController initializes the controller and is called by the generated code.
-// Registers event handlers to enqueue events.
+// Registers event handlers to enqueue events.  An empty channelKey indicates a shared
+// dispatcher, which reconciles every KafkaChannel placed on the specified pod.  The optional
+// subscriptionInformer enqueues the KafkaChannels of Subscriptions whose filter annotation changes.
 func NewController(
 	ctx context.Context,
 	logger *zap.Logger,
 	channelKey string,
+	podName string,
 	dispatcher dispatcher.Dispatcher,
 	kafkachannelInformer informers.KafkaChannelInformer,
+	subscriptionInformer messaginginformers.SubscriptionInformer,
 	kubeClient kubernetes.Interface,
-	kafkaClientSet versioned.Interface,
 	stopChannel <-chan struct{},
 	managerEvents <-chan commonconsumer.ManagerEvent,
 ) *controller.Impl {
@@ -86,10 +87,10 @@ func NewController(
 	reconciler := &Reconciler{
 		logger:               logger,
 		channelKey:           channelKey,
+		podName:              podName,
 		dispatcher:           dispatcher,
 		kafkachannelInformer: kafkachannelInformer.Informer(),
 		kafkachannelLister:   kafkachannelInformer.Lister(),
-		kafkaClientSet:       kafkaClientSet,
 	}
 	reconciler.impl = controller.NewContext(ctx, reconciler, controller.ControllerOptions{
 		WorkQueueName: ReconcilerName,
@@ -100,6 +101,11 @@ func NewController(
 
 	// Watch for kafka channels.
 	kafkachannelInformer.Informer().AddEventHandler(controller.HandleAll(reconciler.impl.Enqueue))
+
+	// Watch for subscriptions (whose filter annotations are applied to the subscribers of their kafka channels).
+	if subscriptionInformer != nil {
+		subscriptionInformer.Informer().AddEventHandler(controller.HandleAll(reconciler.enqueueSubscriptionChannel))
+	}
 	logger.Debug("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
 	watches := []watch.Interface{
@@ -121,6 +127,18 @@ func NewController(
 	return reconciler.impl
 }
 
+// enqueueSubscriptionChannel enqueues the KafkaChannel (if any) of the specified Subscription
+func (r Reconciler) enqueueSubscriptionChannel(obj interface{}) {
+	channelRef, ok := filter.SubscriptionChannel(obj)
+	if !ok {
+		return
+	}
+	if _, err := r.kafkachannelLister.KafkaChannels(channelRef.Namespace).Get(channelRef.Name); err != nil {
+		return // Not A KafkaChannel (Or Not Yet Known)
+	}
+	r.impl.EnqueueKey(channelRef)
+}
+
 // processManagerEvents will listen on the channel provided by the KafkaConsumerGroupManager for events
 // related to a change in the status of a managed ConsumerGroup.  This function is non-blocking, and the
 // internal goroutine will exit when the channel is closed.
@@ -129,24 +147,29 @@ func (r Reconciler) processManagerEvents(events <-chan commonconsumer.ManagerEve
 	if events == nil {
 		return fmt.Errorf("no event channel provided")
 	}
-	// Find the namespace and name of the KafkaChannel this dispatcher is monitoring
-	namespace, name, err := cache.SplitMetaNamespaceKey(r.channelKey)
-	if err != nil {
-		return fmt.Errorf("invalid resource key")
+	// Find the namespace and name of the KafkaChannel this dispatcher is monitoring (a shared dispatcher
+	// cannot tell which KafkaChannel a ConsumerGroup belongs to, and so resyncs all of them instead)
+	enqueue := func() { r.impl.GlobalResync(r.kafkachannelInformer) }
+	if !r.isShared() {
+		namespace, name, err := cache.SplitMetaNamespaceKey(r.channelKey)
+		if err != nil {
+			return fmt.Errorf("invalid resource key")
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		enqueue = func() { r.impl.EnqueueKey(key) }
 	}
-	key := types.NamespacedName{Namespace: namespace, Name: name}
 	go func() {
 		for event := range events {
 			groupLogger := r.logger.With(zap.String("groupId", event.GroupId))
 			// Stopping or Starting a consumer group requires a reconciliation in order to adjust the status block
-			// of the KafkaChannel - EnqueueKey will do that
+			// of the KafkaChannel - enqueue will do that
 			switch event.Event {
 			case commonconsumer.GroupStopped:
 				groupLogger.Debug("Processing GroupStopped Event From Consumer Group Manager")
-				r.impl.EnqueueKey(key)
+				enqueue()
 			case commonconsumer.GroupStarted:
 				groupLogger.Debug("Processing GroupStarted Event From Consumer Group Manager")
-				r.impl.EnqueueKey(key)
+				enqueue()
 			case commonconsumer.GroupCreated:
 				groupLogger.Debug("Processing GroupCreated Event From Consumer Group Manager")
 			case commonconsumer.GroupClosed:
@@ -164,8 +187,8 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	r.logger.Info("Reconcile", zap.String("key", key))
 
-	// Only Reconcile KafkaChannel Associated With This Dispatcher
-	if r.channelKey != key {
+	// Only Reconcile KafkaChannel Associated With This Dispatcher (A Shared Dispatcher Considers All Of Them)
+	if !r.isShared() && r.channelKey != key {
 		return nil
 	}
 
@@ -181,20 +204,27 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			r.logger.Warn("KafkaChannel No Longer Exists", zap.String("namespace", namespace), zap.String("name", name))
+			r.releaseChannel(ctx, types.NamespacedName{Namespace: namespace, Name: name})
 			return nil
 		}
 		r.logger.Error("Error Retrieving KafkaChannel", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name))
 		return err
 	}
 
+	// A Shared Dispatcher Only Serves The KafkaChannels Placed On Its Pod
+	if r.isShared() && !isPlacedOn(original, r.podName) {
+		r.releaseChannel(ctx, types.NamespacedName{Namespace: namespace, Name: name})
+		return nil
+	}
+
 	if !original.Status.IsReady() {
-		return fmt.Errorf("channel is not ready - cannot configure and update subscriber status")
+		return fmt.Errorf("channel is not ready - cannot configure subscribers")
 	}
 
 	// Don't modify the informers copy
 	channel := original.DeepCopy()
 
-	// Perform the reconciliation (will update KafkaChannel.Status)
+	// Perform the reconciliation (Subscriber status is reported to the controller via the control-protocol)
 	reconcileError := r.reconcile(ctx, channel)
 	if reconcileError != nil {
 		r.logger.Error("Error Reconciling KafkaChannel", zap.Error(reconcileError))
@@ -204,16 +234,6 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 		r.recorder.Event(channel, corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled")
 	}
 
-	// Push KafkaChannel Status Changes To K8S
-	_, updateStatusErr := r.updateStatus(ctx, channel)
-	if updateStatusErr != nil {
-		r.logger.Error("Failed To Update KafkaChannel Status", zap.Error(updateStatusErr))
-		r.recorder.Eventf(channel, corev1.EventTypeWarning, channelUpdateStatusFailed, "Failed to update KafkaChannel's status: %v", updateStatusErr)
-		return updateStatusErr
-	} else {
-		r.logger.Info("Successfully Verified / Updated KafkaChannel Status")
-	}
-
 	// Return Reconciliation Errors To Requeue
 	return reconcileError
 }
@@ -236,10 +256,7 @@ func (r Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaCh
 		Namespace: channel.GetNamespace(),
 		Name:      channel.GetName(),
 	}
-	subscriptions := r.dispatcher.UpdateSubscriptions(ctx, channelRef, subscribers)
-
-	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status
-	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, subscriptions)
+	subscriptions := r.dispatcher.UpdateSubscriptions(ctx, channelRef, commonkafkautil.ChannelTopicName(channel), subscribers)
 
 	// Log Failed Subscriptions & Return Error
 	if failed := subscriptions.FailedCount(); failed > 0 {
@@ -251,52 +268,24 @@ func (r Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaCh
 	return nil
 }
 
-// Create The SubscribableStatus Block Based On The Updated Subscriptions
-func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, subscriptions commonconsumer.SubscriberStatusMap) eventingduck.SubscribableStatus {
-
-	subscriberStatus := make([]eventingduck.SubscriberStatus, 0)
-
-	for _, subscriber := range subscribers {
-		status := eventingduck.SubscriberStatus{
-			UID:                subscriber.UID,
-			ObservedGeneration: subscriber.Generation,
-			Ready:              corev1.ConditionTrue,
-		}
-		// If the UID isn't in the subscription map, the zero-value of the subscriptionStatus will have a nil Error
-		// and Stopped will be false.
-		subscriptionStatus := subscriptions[subscriber.UID]
-		if subscriptionStatus.Error != nil {
-			status.Ready = corev1.ConditionFalse
-			status.Message = subscriptionStatus.Error.Error()
-		} else if subscriptionStatus.Stopped {
-			// A stopped group isn't an "error" but it does represent a group that isn't "Ready" as far
-			// as subscriber status goes.
-			status.Ready = corev1.ConditionFalse
-			status.Message = constants.GroupStoppedMessage
-		}
-
-		subscriberStatus = append(subscriberStatus, status)
-	}
-
-	return eventingduck.SubscribableStatus{
-		Subscribers: subscriberStatus,
-	}
+// isShared returns true if this is a shared dispatcher serving many KafkaChannels
+func (r Reconciler) isShared() bool {
+	return len(r.channelKey) == 0
 }
 
-func (r *Reconciler) updateStatus(ctx context.Context, desired *kafkav1beta1.KafkaChannel) (*kafkav1beta1.KafkaChannel, error) {
-	kc, err := r.kafkachannelLister.KafkaChannels(desired.Namespace).Get(desired.Name)
-	if err != nil {
-		return nil, err
+// releaseChannel closes any ConsumerGroups a shared dispatcher holds for a KafkaChannel it no longer serves
+func (r Reconciler) releaseChannel(ctx context.Context, channelRef types.NamespacedName) {
+	if r.isShared() {
+		r.dispatcher.UpdateSubscriptions(ctx, channelRef, "", nil)
 	}
+}
 
-	if reflect.DeepEqual(kc.Status, desired.Status) {
-		r.logger.Debug("KafkaChannel Status Already Current - Skipping Update")
-		return kc, nil
+// isPlacedOn returns true if the KafkaChannel has been scheduled onto the specified shared dispatcher pod
+func isPlacedOn(channel *kafkav1beta1.KafkaChannel, podName string) bool {
+	for _, placement := range channel.Status.Placements {
+		if placement.PodName == podName && placement.VReplicas > 0 {
+			return true
+		}
 	}
-
-	// Don't modify the informers copy.
-	existing := kc.DeepCopy()
-	existing.Status = desired.Status
-	updated, err := r.kafkaClientSet.MessagingV1beta1().KafkaChannels(desired.Namespace).UpdateStatus(ctx, existing, metav1.UpdateOptions{})
-	return updated, err
+	return false
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
@@ -30,7 +31,6 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
-	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/controller"
@@ -41,18 +41,19 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
 	reconciletesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
 )
 
 const (
-	testNS = "test-namespace"
-	kcName = "test-kc"
+	testNS  = "test-namespace"
+	kcName  = "test-kc"
+	podName = "kafkachannel-dispatcher-0"
 )
 
 func init() {
@@ -88,7 +89,7 @@ func TestNewController(t *testing.T) {
 			stopChan := make(chan struct{})
 
 			// Perform The Test
-			c := NewController(context.TODO(), logger, channelKey, mockDispatcher, kafkaChannelInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan, testCase.managerEvents)
+			c := NewController(context.TODO(), logger, channelKey, podName, mockDispatcher, kafkaChannelInformer, nil, fakeK8sClientSet, stopChan, testCase.managerEvents)
 
 			// Verify Results
 			assert.NotNil(t, c)
@@ -121,7 +122,6 @@ func TestProcessManagerEvents(t *testing.T) {
 		dispatcher:           mockDispatcher,
 		kafkachannelInformer: kafkaChannelInformer.Informer(),
 		kafkachannelLister:   kafkaChannelInformer.Lister(),
-		kafkaClientSet:       fakeKafkaChannelClientSet,
 		impl:                 controller.NewContext(context.TODO(), mockReconciler, controller.ControllerOptions{Logger: logger.Sugar()}),
 	}
 	events := make(chan consumer.ManagerEvent)
@@ -131,6 +131,11 @@ func TestProcessManagerEvents(t *testing.T) {
 	assert.NotNil(t, reconciler.processManagerEvents(events))
 	reconciler.channelKey = "test-namespace/test-name"
 	assert.Nil(t, reconciler.processManagerEvents(events))
+	sharedEvents := make(chan consumer.ManagerEvent)
+	reconciler.channelKey = ""
+	assert.Nil(t, reconciler.processManagerEvents(sharedEvents))
+	sharedEvents <- consumer.ManagerEvent{Event: consumer.GroupStopped, GroupId: "test-group-id"}
+	close(sharedEvents)
 
 	// Send all of the supported event types to the events channel
 	events <- consumer.ManagerEvent{Event: consumer.GroupCreated, GroupId: "test-group-id"}
@@ -191,15 +196,6 @@ func TestAllCases(t *testing.T) {
 			},
 			Key:     kcKey,
 			WantErr: false,
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
-					reconciletesting.WithInitKafkaChannelConditions,
-					reconciletesting.WithKafkaChannelReady,
-					reconciletesting.WithKafkaChannelAddress("http://foobar"),
-					reconciletesting.WithSubscriber("1", "http://foobar"),
-					reconciletesting.WithSubscriberReady("1"),
-				),
-			}},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
@@ -232,17 +228,6 @@ func TestAllCases(t *testing.T) {
 			},
 			Key:     kcKey,
 			WantErr: false,
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
-					reconciletesting.WithInitKafkaChannelConditions,
-					reconciletesting.WithKafkaChannelReady,
-					reconciletesting.WithKafkaChannelAddress("http://channel"),
-					reconciletesting.WithSubscriber("1", "http://foobar"),
-					reconciletesting.WithSubscriber("2", "http://foobar2"),
-					reconciletesting.WithSubscriberReady("1"),
-					reconciletesting.WithSubscriberReady("2"),
-				),
-			}},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
@@ -260,17 +245,6 @@ func TestAllCases(t *testing.T) {
 			},
 			Key:     kcKey,
 			WantErr: false,
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
-					reconciletesting.WithInitKafkaChannelConditions,
-					reconciletesting.WithKafkaChannelReady,
-					reconciletesting.WithKafkaChannelAddress("http://channel"),
-					reconciletesting.WithSubscriber("1", "http://foobar"),
-					reconciletesting.WithSubscriber("2", "http://foobar2"),
-					reconciletesting.WithSubscriberNotReady("1", constants.GroupStoppedMessage),
-					reconciletesting.WithSubscriberReady("2"),
-				),
-			}},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
@@ -291,17 +265,6 @@ func TestAllCases(t *testing.T) {
 			},
 			Key:     kcKey,
 			WantErr: true,
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
-					reconciletesting.WithInitKafkaChannelConditions,
-					reconciletesting.WithKafkaChannelReady,
-					reconciletesting.WithKafkaChannelAddress("http://channel"),
-					reconciletesting.WithSubscriber("1", "http://foobar"),
-					reconciletesting.WithSubscriber("2", "http://foobar2"),
-					reconciletesting.WithSubscriberNotReady("1", "test error"),
-					reconciletesting.WithSubscriberReady("2"),
-				),
-			}},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: some kafka subscribers failed to subscribe"),
 			},
@@ -317,7 +280,7 @@ func TestAllCases(t *testing.T) {
 		status consumer.SubscriberStatusMap,
 	) controller.Reconciler {
 		mockDispatcher := &MockDispatcher{}
-		mockDispatcher.On("UpdateSubscriptions", mock.Anything, mock.Anything, mock.Anything).Return(status)
+		mockDispatcher.On("UpdateSubscriptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(status)
 		return &Reconciler{
 			logger:               logtesting.TestLogger(t).Desugar(),
 			channelKey:           kcKey,
@@ -325,7 +288,6 @@ func TestAllCases(t *testing.T) {
 			kafkachannelLister:   listers.GetKafkaChannelLister(),
 			dispatcher:           mockDispatcher,
 			recorder:             eventRecorder,
-			kafkaClientSet:       kafkaClient,
 		}
 	}))
 
@@ -333,6 +295,68 @@ func TestAllCases(t *testing.T) {
 	time.Sleep(1 * time.Second)
 }
 
+// Test Shared Dispatcher KafkaChannel Controller Reconciliation
+func TestSharedDispatcherCases(t *testing.T) {
+	kcKey := testNS + "/" + kcName
+
+	table := reconcilertesting.TableTest{
+		{
+			Name: "key not found, release any subscriptions",
+			Key:  "foo/not-found",
+		},
+		{
+			Name: "channel placed on another pod, release any subscriptions",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithPlacement("kafkachannel-dispatcher-1")),
+			},
+			Key: kcKey,
+		},
+		{
+			Name: "channel placed on this pod, add subscriber",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithPlacement(podName)),
+			},
+			Key: kcKey,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+	}
+
+	table.Test(t, reconciletesting.MakeFactory(func(listers *reconciletesting.Listers,
+		kafkaClient versioned.Interface,
+		eventRecorder record.EventRecorder,
+		status consumer.SubscriberStatusMap,
+	) controller.Reconciler {
+		mockDispatcher := &MockDispatcher{}
+		mockDispatcher.On("UpdateSubscriptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(consumer.SubscriberStatusMap{})
+		return &Reconciler{
+			logger:             logtesting.TestLogger(t).Desugar(),
+			podName:            podName,
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			dispatcher:         mockDispatcher,
+			recorder:           eventRecorder,
+		}
+	}))
+
+	// Verify That Released KafkaChannels Have Their Subscriptions Removed
+	mockDispatcher := &MockDispatcher{}
+	channelRef := types.NamespacedName{Namespace: testNS, Name: kcName}
+	mockDispatcher.On("UpdateSubscriptions", mock.Anything, channelRef, "", []eventingduck.SubscriberSpec(nil)).Return(consumer.SubscriberStatusMap{})
+	reconciler := Reconciler{logger: logtesting.TestLogger(t).Desugar(), dispatcher: mockDispatcher}
+	reconciler.releaseChannel(context.TODO(), channelRef)
+	mockDispatcher.AssertExpectations(t)
+}
+
 // Utility Function For Populating Required Environment Variables For Testing
 func populateEnvironmentVariables(t *testing.T) {
 	// Most of these are not actually used, but they need to exist or the GetEnvironment call will fail
@@ -365,8 +389,8 @@ func (m *MockDispatcher) Shutdown() {
 	m.Called()
 }
 
-func (m *MockDispatcher) UpdateSubscriptions(ctx context.Context, ref types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) consumer.SubscriberStatusMap {
-	args := m.Called(ctx, ref, subscriberSpecs)
+func (m *MockDispatcher) UpdateSubscriptions(ctx context.Context, ref types.NamespacedName, topicName string, subscriberSpecs []eventingduck.SubscriberSpec) consumer.SubscriberStatusMap {
+	args := m.Called(ctx, ref, topicName, subscriberSpecs)
 	return args.Get(0).(consumer.SubscriberStatusMap)
 }
 
@@ -374,6 +398,16 @@ func (m *MockDispatcher) SecretChanged(ctx context.Context, secret *corev1.Secre
 	m.Called(ctx, secret)
 }
 
+func (m *MockDispatcher) DebugChannel(ref types.NamespacedName) (*debugapi.Channel, bool) {
+	args := m.Called(ref)
+	return args.Get(0).(*debugapi.Channel), args.Bool(1)
+}
+
+func (m *MockDispatcher) DebugKafkaConfig() ([]string, *sarama.Config) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Get(1).(*sarama.Config)
+}
+
 //
 // Mock Reconciler Implementation
 //
@@ -18,6 +18,8 @@ package dispatcher
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,15 +28,24 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	dispatcherconstants "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
 
@@ -43,29 +54,41 @@ type DispatcherConfig struct {
 	Logger          *zap.Logger
 	ClientId        string
 	Brokers         []string
-	Topic           string
-	ChannelKey      string
+	Topic           string // Empty for a shared dispatcher, which determines each KafkaChannel's Topic
+	ChannelKey      string // Empty for a shared dispatcher, which serves many KafkaChannels
 	StatsReporter   metrics.StatsReporter
 	MetricsRegistry gometrics.Registry
 	SaramaConfig    *sarama.Config
+	StatusService   ctrl.Service           // Optional control-protocol Service for reporting SubscribersStatus to the controller
+	ClaimCheckStore claimcheck.Store       // Optional Store from which offloaded claim-check payloads are rehydrated
+	KeyProvider     encryption.KeyProvider // Optional provider of the keys with which encrypted event data is decrypted
+
+	// Optional lister of the Subscriptions whose filter annotations are applied to the events of their subscribers
+	SubscriptionLister messaginglisters.SubscriptionLister
+
+	// Optional lister of the KafkaChannels whose encryption Secrets decrypt the events of their subscribers
+	KafkaChannelLister kafkalisters.KafkaChannelLister
 }
 
 // SubscriberWrapper Defines A Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup ID
 type SubscriberWrapper struct {
 	eventingduck.SubscriberSpec
-	GroupId string
+	GroupId    string
+	channelRef types.NamespacedName
+	handler    *Handler
 }
 
 // NewSubscriberWrapper Is The SubscriberWrapper Constructor
 func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string) *SubscriberWrapper {
-	return &SubscriberWrapper{subscriberSpec, groupId}
+	return &SubscriberWrapper{SubscriberSpec: subscriberSpec, GroupId: groupId}
 }
 
-// Dispatcher Interface
+// Dispatcher Interface (Including The Debug API's Access To The KafkaChannels Being Served)
 type Dispatcher interface {
+	debugapi.Dispatcher
 	SecretChanged(ctx context.Context, secret *corev1.Secret)
 	Shutdown()
-	UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap
+	UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, topicName string, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap
 }
 
 // DispatcherImpl Is A Struct With Configuration & ConsumerGroup State
@@ -77,6 +100,12 @@ type DispatcherImpl struct {
 	MetricsStopChan    chan struct{}
 	MetricsStoppedChan chan struct{}
 	consumerMgr        commonconsumer.KafkaConsumerGroupManager
+	lagCollector       *commonconsumer.LagCollector
+	subscriberStatus   map[types.NamespacedName]commonconsumer.SubscriberStatusMap
+	channelTopics      map[types.NamespacedName]string // The Topics Of The KafkaChannels Being Served (For The Debug API)
+	statusNotifyChan   chan struct{}
+	statusStopChan     chan struct{}
+	statusResendPeriod time.Duration // Zero Disables The Periodic Resending Of The SubscribersStatus
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -87,6 +116,10 @@ func NewDispatcher(dispatcherConfig DispatcherConfig, controlServer controlproto
 
 	consumerGroupManager := commonconsumer.NewConsumerGroupManager(dispatcherConfig.Logger, controlServer, dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig, &commonconsumer.NoopConsumerGroupOffsetsChecker{}, enqueue)
 
+	// Collect The Lag Of The Subscribers' ConsumerGroups
+	lagCollector := commonconsumer.NewLagCollector(dispatcherConfig.Logger, commonconsumer.KafkaChannelResourceGroup, dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig)
+	consumerGroupManager.SetLagCollector(lagCollector)
+
 	// Create The DispatcherImpl With Specified Configuration
 	dispatcher := &DispatcherImpl{
 		DispatcherConfig:   dispatcherConfig,
@@ -95,10 +128,20 @@ func NewDispatcher(dispatcherConfig DispatcherConfig, controlServer controlproto
 		MetricsStopChan:    make(chan struct{}),
 		MetricsStoppedChan: make(chan struct{}),
 		consumerMgr:        consumerGroupManager,
+		lagCollector:       lagCollector,
+		subscriberStatus:   make(map[types.NamespacedName]commonconsumer.SubscriberStatusMap),
+		channelTopics:      make(map[types.NamespacedName]string),
+		statusNotifyChan:   make(chan struct{}, 1),
+		statusStopChan:     make(chan struct{}),
+		statusResendPeriod: dispatcherconstants.SubscribersStatusResendInterval,
 	}
 
 	// Start Observing Metrics
 	dispatcher.ObserveMetrics(dispatcherconstants.MetricsInterval)
+	lagCollector.Start(commonconsumer.DefaultLagInterval)
+
+	// Start Reporting The Subscribers' Status To The Controller
+	dispatcher.ReportSubscribersStatus()
 
 	// Return The DispatcherImpl
 	return dispatcher, consumerGroupManager.GetNotificationChannel()
@@ -112,6 +155,13 @@ func (d *DispatcherImpl) Shutdown() {
 		close(d.MetricsStopChan)
 		<-d.MetricsStoppedChan
 	}
+	d.lagCollector.Stop()
+
+	// Stop Reporting Subscribers' Status
+	if d.statusStopChan != nil {
+		close(d.statusStopChan)
+		d.statusStopChan = nil
+	}
 
 	// Close ConsumerGroups Of All Subscriptions
 	for _, subscriber := range d.subscribers {
@@ -123,7 +173,7 @@ func (d *DispatcherImpl) Shutdown() {
 }
 
 // UpdateSubscriptions manages the Dispatcher's Subscriptions to align with new state
-func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap {
+func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, topicName string, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap {
 
 	if d.SaramaConfig == nil {
 		d.Logger.Error("Dispatcher has no config!")
@@ -137,12 +187,26 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
 
+	// Get The Filters Annotated On The Subscriptions In The KafkaChannel's Namespace
+	filters, err := filter.SubscriptionFilters(d.SubscriptionLister, channelRef.Namespace)
+	if err != nil {
+		d.Logger.Error("Failed To List Subscription Filters - Dispatching Unfiltered", zap.Error(err))
+	}
+
 	// Loop Over All The Specified Subscribers
 	for _, subscriberSpec := range subscriberSpecs {
 
 		// Format The GroupId For The Specified Subscriber
 		groupId := commonkafkautil.GroupId(string(subscriberSpec.UID))
 
+		// Parse The Subscriber's Filter (If Any) - An Invalid Filter Fails The Subscriber Rather Than Dispatching Everything
+		subscriberFilter, err := filter.ParseFilter(filters[subscriberSpec.UID])
+		if err != nil {
+			d.Logger.Error("Invalid Subscription Filter", zap.String("GroupId", groupId), zap.Error(err))
+			subscriptions[subscriberSpec.UID] = commonconsumer.SubscriberStatus{Error: fmt.Errorf("invalid filter annotation %s: %w", commonconstants.SubscriptionFilterAnnotationKey, err)}
+			continue
+		}
+
 		// If The Subscriber Wrapper For The SubscriberSpec Does Not Exist Then Create One
 		if _, ok := d.subscribers[subscriberSpec.UID]; !ok {
 
@@ -150,8 +214,14 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 			logger := d.Logger.With(zap.String("GroupId", groupId))
 
 			// Create/Start A New ConsumerGroup With Custom Handler
-			handler := NewHandler(logger, groupId, &subscriberSpec)
-			err := d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{d.Topic}, handler, channelRef)
+			handler := NewHandler(logger, groupId, &subscriberSpec, d.notifySubscribersStatus)
+			handler.ClaimCheckStore = d.ClaimCheckStore
+			handler.KeyProvider = d.KeyProvider
+			handler.ChannelNamespace = channelRef.Namespace
+			handler.ChannelName = channelRef.Name
+			handler.EncryptionSecret = func() string { return d.encryptionSecret(channelRef) }
+			handler.Filter = subscriberFilter
+			err := d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{d.topicName(topicName)}, handler, channelRef)
 			if err != nil {
 
 				// Log & Return Failure
@@ -162,6 +232,8 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 
 				// Create A New SubscriberWrapper With The ConsumerGroup
 				subscriber := NewSubscriberWrapper(subscriberSpec, groupId)
+				subscriber.channelRef = channelRef
+				subscriber.handler = handler
 
 				// Asynchronously Process ConsumerGroup's Error Channel
 				go func() {
@@ -179,7 +251,10 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 
 		} else {
 
-			// Otherwise, Just Add To List Of Active Subscribers
+			// Otherwise, Just Apply The (Possibly Changed) Filter & Add To List Of Active Subscribers
+			if subscriber := d.subscribers[subscriberSpec.UID]; subscriber.handler != nil {
+				subscriber.handler.SetFilter(subscriberFilter)
+			}
 			subscriptions[subscriberSpec.UID] = commonconsumer.SubscriberStatus{}
 
 			// If the group is stopped, it's still active but the reconciler needs to know about it in order
@@ -191,18 +266,183 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 		}
 	}
 
-	// Close ConsumerGroups For Removed/Failed Subscriptions (In Map But No Longer Active)
+	// Close ConsumerGroups For The KafkaChannel's Removed/Failed Subscriptions (In Map But No Longer Active)
 	for _, subscriber := range d.subscribers {
+		if subscriber.channelRef != channelRef {
+			continue
+		}
 		subscription, ok := subscriptions[subscriber.UID]
 		if !ok || subscription.Error != nil {
 			d.closeConsumerGroup(subscriber)
 		}
 	}
 
+	// Track The Latest Subscriber Status Of The KafkaChannel & Report It To The Controller
+	if d.subscriberStatus == nil {
+		d.subscriberStatus = make(map[types.NamespacedName]commonconsumer.SubscriberStatusMap)
+	}
+	if len(subscriptions) > 0 {
+		d.subscriberStatus[channelRef] = subscriptions
+	} else {
+		delete(d.subscriberStatus, channelRef)
+	}
+	d.notifySubscribersStatus()
+
+	// Track The Topic Of The KafkaChannel Being Served (A Released KafkaChannel Has No Topic Or Subscribers)
+	if d.channelTopics == nil {
+		d.channelTopics = make(map[types.NamespacedName]string)
+	}
+	if len(topicName) == 0 && len(subscriberSpecs) == 0 {
+		delete(d.channelTopics, channelRef)
+	} else {
+		d.channelTopics[channelRef] = d.topicName(topicName)
+	}
+
 	// Return Any Failed Subscriber Errors
 	return subscriptions
 }
 
+// topicName returns the Kafka Topic of a KafkaChannel, which is either the single Topic of a dedicated
+// dispatcher or, for a shared dispatcher, the specified Topic of the KafkaChannel being updated.
+func (d *DispatcherImpl) topicName(channelTopicName string) string {
+	if len(d.Topic) > 0 {
+		return d.Topic
+	}
+	return channelTopicName
+}
+
+// DebugChannel implements the debugapi.Dispatcher interface by describing a KafkaChannel served by the dispatcher
+func (d *DispatcherImpl) DebugChannel(ref types.NamespacedName) (*debugapi.Channel, bool) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	topic, ok := d.channelTopics[ref]
+	if !ok {
+		return nil, false
+	}
+
+	debugChannel := &debugapi.Channel{Namespace: ref.Namespace, Name: ref.Name, Topic: topic, EncryptionSecret: d.encryptionSecret(ref)}
+	for _, subscriber := range d.subscribers {
+		if subscriber.channelRef != ref {
+			continue
+		}
+		debugSubscriber := debugapi.Subscriber{UID: subscriber.UID, GroupId: subscriber.GroupId}
+		if !subscriber.SubscriberURI.IsEmpty() {
+			debugSubscriber.URI = subscriber.SubscriberURI.URL()
+		}
+		if subscriber.handler != nil {
+			debugSubscriber.Filter = subscriber.handler.getFilter()
+		}
+		debugChannel.Subscribers = append(debugChannel.Subscribers, debugSubscriber)
+	}
+	sort.Slice(debugChannel.Subscribers, func(i, j int) bool { return debugChannel.Subscribers[i].UID < debugChannel.Subscribers[j].UID })
+	return debugChannel, true
+}
+
+// DebugKafkaConfig implements the debugapi.Dispatcher interface by returning the dispatcher's current Kafka configuration
+func (d *DispatcherImpl) DebugKafkaConfig() ([]string, *sarama.Config) {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	return d.Brokers, d.SaramaConfig
+}
+
+// Get The Name Of The Encryption Secret From The Spec Of The Specified KafkaChannel ("" If It Has Not Opted In)
+func (d *DispatcherImpl) encryptionSecret(channelRef types.NamespacedName) string {
+	if d.KafkaChannelLister == nil {
+		return ""
+	}
+	kafkaChannel, err := d.KafkaChannelLister.KafkaChannels(channelRef.Namespace).Get(channelRef.Name)
+	if err != nil {
+		d.Logger.Warn("Failed To Get KafkaChannel Encryption Secret", zap.Any("ChannelRef", channelRef), zap.Error(err))
+		return ""
+	}
+	return encryption.SecretName(kafkaChannel)
+}
+
+// notifySubscribersStatus requests that the current SubscribersStatus be sent to the controller.  This
+// function is non-blocking and multiple requests made while a send is in progress will be collapsed.
+func (d *DispatcherImpl) notifySubscribersStatus() {
+	if d.statusNotifyChan == nil {
+		return
+	}
+	select {
+	case d.statusNotifyChan <- struct{}{}:
+	default:
+	}
+}
+
+// ReportSubscribersStatus Is An Async Process For Sending The SubscribersStatus To The Controller
+// Whenever It Changes (Subscriptions Updated Or Partitions Claimed / Released), As Well As Periodically
+// So That Failed Sends Are Retried And A Restarted Controller (With An Empty NotificationStore) Catches Up
+func (d *DispatcherImpl) ReportSubscribersStatus() {
+
+	// Nothing To Do If No Control-Protocol Service Was Provided
+	if d.StatusService == nil {
+		d.Logger.Debug("No Status Service Configured - Subscribers' Status Will Not Be Reported")
+		return
+	}
+
+	// Fork A New Process To Send Status Notifications
+	go func() {
+
+		// Periodically Resend The Status (Unless Disabled)
+		var resendChan <-chan time.Time
+		if d.statusResendPeriod > 0 {
+			resendTicker := time.NewTicker(d.statusResendPeriod)
+			defer resendTicker.Stop()
+			resendChan = resendTicker.C
+		}
+
+		for {
+			select {
+			case <-d.statusStopChan:
+				d.Logger.Info("Stopped Subscribers' Status Reporting")
+				return
+			case <-d.statusNotifyChan:
+				d.sendSubscribersStatus()
+			case <-resendChan:
+				d.sendSubscribersStatus()
+			}
+		}
+	}()
+}
+
+// sendSubscribersStatus sends the current SubscribersStatus to the controller and waits for its acknowledgement
+func (d *DispatcherImpl) sendSubscribersStatus() {
+	status := d.subscribersStatus()
+	d.Logger.Debug("Sending Subscribers' Status", zap.Stringer("Status", status))
+	if err := d.StatusService.SendAndWaitForAck(distributedcontrol.NotifySubscribersStatusOpCode, status); err != nil {
+		d.Logger.Warn("Failed To Send Subscribers' Status", zap.Error(err))
+	}
+}
+
+// subscribersStatus returns a snapshot of the current status of all Subscribers, including
+// the partitions which are currently claimed by their ConsumerGroups.
+func (d *DispatcherImpl) subscribersStatus() distributedcontrol.SubscribersStatus {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Combine The Subscribers Of All KafkaChannels (Subscriber UIDs Are Unique Across KafkaChannels)
+	status := make(distributedcontrol.SubscribersStatus)
+	for _, channelSubscriberStatus := range d.subscriberStatus {
+		for uid, subscriptionStatus := range channelSubscriberStatus {
+			subscriberStatus := distributedcontrol.SubscriberStatus{Stopped: subscriptionStatus.Stopped}
+			if subscriptionStatus.Error != nil {
+				subscriberStatus.Error = subscriptionStatus.Error.Error()
+			}
+			if subscriber, ok := d.subscribers[uid]; ok && subscriber.handler != nil {
+				subscriberStatus.Partitions = subscriber.handler.Claims()
+			}
+			status[uid] = subscriberStatus
+		}
+	}
+	return status
+}
+
 // closeConsumerGroup closes the ConsumerGroup associated with a single Subscriber
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 
@@ -249,13 +489,14 @@ func (d *DispatcherImpl) SecretChanged(ctx context.Context, secret *corev1.Secre
 	}
 
 	// Build New Config Using Existing Config And New Auth Settings
-	if kafkaAuthCfg.SASL.User == "" {
+	if !kafkaAuthCfg.SASL.HasCredentials() {
 		// The config builder expects the entire config object to be nil if not using auth
 		kafkaAuthCfg = nil
 		// Any existing SASL config must be cleared explicitly or the "WithExisting" builder will keep the old values
 		d.SaramaConfig.Net.SASL.Enable = false
 		d.SaramaConfig.Net.SASL.User = ""
 		d.SaramaConfig.Net.SASL.Password = ""
+		d.SaramaConfig.Net.SASL.TokenProvider = nil
 	}
 	newConfig, err := client.NewConfigBuilder().WithExisting(d.SaramaConfig).WithAuth(kafkaAuthCfg).Build(ctx)
 	if err != nil {
@@ -26,19 +26,28 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	distributedcontrol "knative.dev/eventing-kafka/pkg/channel/distributed/common/control"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	commonclient "knative.dev/eventing-kafka/pkg/common/client"
 	clienttesting "knative.dev/eventing-kafka/pkg/common/client/testing"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
+	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
 	consumertesting "knative.dev/eventing-kafka/pkg/common/consumer/testing"
 	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	"knative.dev/eventing-kafka/pkg/common/debugapi"
 	kafkatesting "knative.dev/eventing-kafka/pkg/common/kafka/testing"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
@@ -380,7 +389,7 @@ func TestUpdateSubscriptions(t *testing.T) {
 			}
 
 			// Perform The Test
-			result := dispatcher.UpdateSubscriptions(ctx, types.NamespacedName{}, testCase.args.subscriberSpecs)
+			result := dispatcher.UpdateSubscriptions(ctx, types.NamespacedName{}, "", testCase.args.subscriberSpecs)
 
 			close(errorSource)
 
@@ -421,6 +430,127 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test The UpdateSubscriptions() Functionality With Subscription Filter Annotations
+func TestUpdateSubscriptionsFilter(t *testing.T) {
+
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+	channelRef := types.NamespacedName{Namespace: "test-namespace", Name: "test-channel"}
+
+	config, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).Build(ctx)
+	assert.Nil(t, err)
+
+	// Annotate A Valid Filter On Subscription 123 And An Invalid One On Subscription 456
+	newSubscription := func(uid types.UID, filter string) *messagingv1.Subscription {
+		return &messagingv1.Subscription{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   channelRef.Namespace,
+			Name:        "subscription-" + string(uid),
+			UID:         uid,
+			Annotations: map[string]string{commonconstants.SubscriptionFilterAnnotationKey: filter},
+		}}
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.Nil(t, indexer.Add(newSubscription(uid123, `{"exact":{"type":"a"}}`)))
+	assert.Nil(t, indexer.Add(newSubscription(uid456, `{"cesql":"type ="}`)))
+
+	errorSource := make(chan error)
+	close(errorSource)
+
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	mockManager.On("ClearNotifications").Return()
+	mockManager.On("StartConsumerGroup", mock.Anything, "kafka."+id123, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockManager.On("Errors", "kafka."+id123).Return((<-chan error)(errorSource)).Maybe() // Processed Asynchronously
+	mockManager.On("IsStopped", "kafka."+id123).Return(false)
+	mockManager.On("IsManaged", "kafka."+id123).Return(true)
+	mockManager.On("CloseConsumerGroup", "kafka."+id123).Return(nil)
+
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:             logger.Desugar(),
+			Brokers:            []string{configtesting.DefaultKafkaBroker},
+			SaramaConfig:       config,
+			SubscriptionLister: messaginglisters.NewSubscriptionLister(indexer),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+		consumerMgr: mockManager,
+	}
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}}
+
+	// The Invalid Filter Fails Its Subscriber, The Valid One Is Applied To The Handler
+	result := dispatcher.UpdateSubscriptions(ctx, channelRef, "", subscriberSpecs)
+	assert.Equal(t, 1, result.FailedCount())
+	assert.NotNil(t, result[uid456].Error)
+	assert.Nil(t, dispatcher.subscribers[uid456])
+	assert.NotNil(t, dispatcher.subscribers[uid123].handler.getFilter())
+	assert.Equal(t, channelRef.Name, dispatcher.subscribers[uid123].handler.ChannelName)
+
+	// Removing The Annotation Removes The Filter Of The Existing Handler
+	assert.Nil(t, indexer.Update(newSubscription(uid123, "")))
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, "", subscriberSpecs[:1])
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Nil(t, dispatcher.subscribers[uid123].handler.getFilter())
+
+	dispatcher.Shutdown()
+	mockManager.AssertExpectations(t)
+}
+
+// Test The Dispatcher's Debug API Description Of Its KafkaChannels
+func TestDebugChannel(t *testing.T) {
+
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+	channelRef := types.NamespacedName{Namespace: "test-namespace", Name: "test-channel"}
+	otherChannelRef := types.NamespacedName{Namespace: "test-namespace", Name: "other-channel"}
+	emptyChannelRef := types.NamespacedName{Namespace: "test-namespace", Name: "empty-channel"}
+
+	config, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).Build(ctx)
+	assert.Nil(t, err)
+
+	subscriberURI := apis.HTTP("test-subscriber")
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logger.Desugar(),
+			Brokers:      []string{configtesting.DefaultKafkaBroker},
+			SaramaConfig: config,
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			uid456: {SubscriberSpec: eventingduck.SubscriberSpec{UID: uid456}, GroupId: "kafka." + id456, channelRef: channelRef, handler: &Handler{}},
+			uid123: {SubscriberSpec: eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: subscriberURI}, GroupId: "kafka." + id123, channelRef: channelRef},
+			uid789: {SubscriberSpec: eventingduck.SubscriberSpec{UID: uid789}, GroupId: "kafka." + id789, channelRef: otherChannelRef},
+		},
+		channelTopics: map[types.NamespacedName]string{channelRef: "test-topic", otherChannelRef: "other-topic"},
+	}
+
+	// The KafkaChannel Is Described With Only Its Own Subscribers
+	debugChannel, ok := dispatcher.DebugChannel(channelRef)
+	assert.True(t, ok)
+	assert.Equal(t, &debugapi.Channel{
+		Namespace: channelRef.Namespace,
+		Name:      channelRef.Name,
+		Topic:     "test-topic",
+		Subscribers: []debugapi.Subscriber{
+			{UID: uid123, URI: subscriberURI.URL(), GroupId: "kafka." + id123},
+			{UID: uid456, GroupId: "kafka." + id456},
+		},
+	}, debugChannel)
+
+	// A KafkaChannel Is Only Described Once Its Subscriptions Have Been Updated, Until It Is Released
+	_, ok = dispatcher.DebugChannel(emptyChannelRef)
+	assert.False(t, ok)
+	dispatcher.UpdateSubscriptions(ctx, emptyChannelRef, "empty-topic", nil)
+	debugChannel, ok = dispatcher.DebugChannel(emptyChannelRef)
+	assert.True(t, ok)
+	assert.Equal(t, &debugapi.Channel{Namespace: emptyChannelRef.Namespace, Name: emptyChannelRef.Name, Topic: "empty-topic"}, debugChannel)
+	dispatcher.UpdateSubscriptions(ctx, emptyChannelRef, "", nil)
+	_, ok = dispatcher.DebugChannel(emptyChannelRef)
+	assert.False(t, ok)
+
+	// The Kafka Configuration Is The Dispatcher's Current Configuration
+	brokers, saramaConfig := dispatcher.DebugKafkaConfig()
+	assert.Equal(t, []string{configtesting.DefaultKafkaBroker}, brokers)
+	assert.Same(t, config, saramaConfig)
+}
+
 // Test The Dispatcher's SecretChanged Functionality
 func TestSecretChanged(t *testing.T) {
 
@@ -612,6 +742,115 @@ func TestConfigImpl_ObserveMetrics(t *testing.T) {
 	<-dispatcher.MetricsStoppedChan
 }
 
+// Test The ReportSubscribersStatus() Functionality
+func TestReportSubscribersStatus(t *testing.T) {
+
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create Handlers With Claimed Partitions
+	handler123 := NewHandler(logger, util.GroupId(id123), &eventingduck.SubscriberSpec{UID: uid123}, nil)
+	handler123.SetReady(0, true)
+	handler123.SetReady(1, true)
+	subscriber123 := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, util.GroupId(id123))
+	subscriber123.handler = handler123
+	subscriber456 := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid456}, util.GroupId(id456))
+
+	// The Expected Status Snapshot
+	expectedStatus := distributedcontrol.SubscribersStatus{
+		uid123: {Partitions: []int32{0, 1}},
+		uid456: {Stopped: true},
+		uid789: {Error: "test error"},
+	}
+
+	// Create A Mock Control-Protocol Service To Receive The Status
+	sent := make(chan struct{})
+	mockService := &controltesting.MockService{}
+	mockService.On("SendAndWaitForAck", distributedcontrol.NotifySubscribersStatusOpCode, expectedStatus).
+		Run(func(args mock.Arguments) { close(sent) }).
+		Return(nil).
+		Once()
+
+	// Create our own DispatcherImpl instead of using NewDispatcher() to control the Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:        logger,
+			StatusService: mockService,
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			uid123: subscriber123,
+			uid456: subscriber456,
+		},
+		subscriberStatus: map[types.NamespacedName]consumer.SubscriberStatusMap{
+			{Namespace: "ns1", Name: "channel1"}: {
+				uid123: {},
+				uid456: {Stopped: true},
+			},
+			{Namespace: "ns2", Name: "channel2"}: {
+				uid789: {Error: fmt.Errorf("test error")},
+			},
+		},
+		statusNotifyChan: make(chan struct{}, 1),
+		statusStopChan:   make(chan struct{}),
+	}
+
+	// Perform The Test
+	dispatcher.ReportSubscribersStatus()
+	dispatcher.notifySubscribersStatus()
+
+	// Verify The Results
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the subscribers' status to be sent")
+	}
+	close(dispatcher.statusStopChan)
+	mockService.AssertExpectations(t)
+}
+
+// Test The ReportSubscribersStatus() Functionality Resends The Status Periodically (E.g. After A Failed Send)
+func TestReportSubscribersStatusResend(t *testing.T) {
+
+	logger := zap.NewNop() // The Reporting Goroutine May Outlive The Test
+	expectedStatus := distributedcontrol.SubscribersStatus{uid123: {}}
+
+	// The First Send Fails (E.g. Controller Restarting) And The Status Is Resent Without Any Change
+	sent := make(chan struct{})
+	var sentOnce sync.Once
+	mockService := &controltesting.MockService{}
+	mockService.On("SendAndWaitForAck", distributedcontrol.NotifySubscribersStatusOpCode, expectedStatus).
+		Return(fmt.Errorf("test error")).
+		Once()
+	mockService.On("SendAndWaitForAck", distributedcontrol.NotifySubscribersStatusOpCode, expectedStatus).
+		Run(func(args mock.Arguments) { sentOnce.Do(func() { close(sent) }) }).
+		Return(nil)
+
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:        logger,
+			StatusService: mockService,
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+		subscriberStatus: map[types.NamespacedName]consumer.SubscriberStatusMap{
+			{Namespace: "ns1", Name: "channel1"}: {uid123: {}},
+		},
+		statusNotifyChan:   make(chan struct{}, 1),
+		statusStopChan:     make(chan struct{}),
+		statusResendPeriod: 10 * time.Millisecond,
+	}
+
+	// Perform The Test (No Notification - Only The Periodic Resend)
+	dispatcher.ReportSubscribersStatus()
+
+	// Verify The Results
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the subscribers' status to be resent")
+	}
+	close(dispatcher.statusStopChan)
+	mockService.AssertExpectations(t)
+}
+
 // A mock for the StatsReporter that will provide feedback when the Report function is called
 type statsReporterMock struct {
 	reportCalled bool
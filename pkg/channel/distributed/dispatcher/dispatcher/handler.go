@@ -21,18 +21,26 @@ import (
 	"errors"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventfilter"
 	"knative.dev/eventing/pkg/kncloudevents"
 
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
@@ -45,14 +53,26 @@ type Handler struct {
 	GroupId           string
 	Subscriber        *eventingduck.SubscriberSpec
 	MessageDispatcher channel.MessageDispatcher
+	ClaimCheckStore   claimcheck.Store       // Optional Store from which offloaded claim-check payloads are rehydrated
+	KeyProvider       encryption.KeyProvider // Optional provider of the keys with which encrypted event data is decrypted
+	ChannelNamespace  string                 // Namespace of the KafkaChannel (and thus of its encryption Secret)
+	EncryptionSecret  func() string          // Optional resolver of the encryption Secret named by the KafkaChannel's spec
+	ChannelName       string                 // Optional name of the KafkaChannel, with which filter results are reported
+	Filter            eventfilter.Filter     // Optional filter of the events to dispatch (e.g. a Trigger's attribute filter)
+	LatencyArgs       *latency.ReportArgs    // Optional resource with which latency is reported (defaults to the KafkaChannel)
+	filterLock        sync.RWMutex
 	destinationURL    *url.URL
 	replyURL          *url.URL
 	deadLetterURL     *url.URL
 	retryConfig       kncloudevents.RetryConfig
+	claimsLock        sync.RWMutex
+	claims            sets.Int32
+	claimsChanged     func()
 }
 
-// NewHandler creates a new Handler instance.
-func NewHandler(logger *zap.Logger, groupId string, subscriber *eventingduck.SubscriberSpec) *Handler {
+// NewHandler creates a new Handler instance.  The optional claimsChanged function
+// will be called whenever the set of partitions claimed by the Handler changes.
+func NewHandler(logger *zap.Logger, groupId string, subscriber *eventingduck.SubscriberSpec, claimsChanged func()) *Handler {
 
 	// Create The New Handler Instance
 	handler := &Handler{
@@ -60,6 +80,8 @@ func NewHandler(logger *zap.Logger, groupId string, subscriber *eventingduck.Sub
 		GroupId:           groupId,
 		Subscriber:        subscriber,
 		MessageDispatcher: newMessageDispatcherWrapper(logger),
+		claims:            sets.NewInt32(),
+		claimsChanged:     claimsChanged,
 	}
 
 	// Extract The Destination URL From The Subscriber
@@ -98,6 +120,20 @@ func NewHandler(logger *zap.Logger, groupId string, subscriber *eventingduck.Sub
 	return handler
 }
 
+// SetFilter replaces the filter of the events to dispatch (nil to dispatch all events) of a consuming Handler.
+func (h *Handler) SetFilter(eventFilter eventfilter.Filter) {
+	h.filterLock.Lock()
+	defer h.filterLock.Unlock()
+	h.Filter = eventFilter
+}
+
+// getFilter returns the current filter of the events to dispatch (if any)
+func (h *Handler) getFilter() eventfilter.Filter {
+	h.filterLock.RLock()
+	defer h.filterLock.RUnlock()
+	return h.Filter
+}
+
 // Wrapper Function To Facilitate Testing With A Mock Knative MessageDispatcher
 var newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
 	return channel.NewMessageDispatcher(logger)
@@ -105,10 +141,15 @@ var newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispat
 
 // Handle is responsible for processing the individual ConsumerMessages.  The
 // first return bool indicates whether to MarkOffset in the ConsumerGroup and
-// the second error value will be sent to the ConsumerGroups error channel as
-// well as the SetReady() function.
+// the second error value would be sent to the ConsumerGroups error channel,
+// but failures to handle a single message are only logged (see below).
 func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
 
+	// Measure The Latency From The Event's Ingress To Its Consumption, And Track Its Delivery To The Subscriber
+	latencyArgs := h.latencyArgs()
+	latency.ReportIngressLatency(ctx, latencyArgs, consumerMessage)
+	delivery := latency.NewDelivery(h.destinationURL)
+
 	// Debug Log Kafka ConsumerMessage (Verify Debug Level For Efficiency!)
 	if h.Logger.Core().Enabled(zap.DebugLevel) {
 
@@ -122,25 +163,58 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 			zap.Int64("Offset", consumerMessage.Offset))
 	}
 
+	// Restore The Payload Of Claim-Check Messages From The Store (No-Op For Regular Messages)
+	err := claimcheck.Rehydrate(ctx, h.ClaimCheckStore, channel.ChannelReference{Namespace: h.ChannelNamespace, Name: h.ChannelName}, consumerMessage)
+	if err != nil {
+		h.Logger.Error("Failed To Rehydrate Claim-Check Message - Skipping", zap.Error(err))
+		return !errors.Is(err, context.Canceled), nil // Mark As Handled Unless Shutting Down Since Retry Won't Fix A Missing Payload
+	}
+
+	// Decrypt The Event Data Of Encrypted Messages (No-Op For Regular Messages)
+	err = encryption.Decrypt(ctx, h.KeyProvider, h.ChannelNamespace, h.encryptionSecret(), consumerMessage)
+	if err != nil {
+		h.Logger.Error("Failed To Decrypt Message - Skipping", zap.Error(err))
+		return !errors.Is(err, context.Canceled), nil // Mark As Handled Unless Shutting Down Since Retry Won't Fix A Missing Key
+	}
+
 	// Convert ConsumerMessage.Headers Into HTTP Header Struct For Dispatching (Passing-Through of "Additional Headers")
 	// Using Sarama RecordHeaders instead of CloudEvent Message.Headers to support multi-value HTTP Headers without
-	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message.
-	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers))
+	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message, and the
+	// internal ingress time header.
+	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(latency.FilterIngressTimeHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers)))
 
 	// Convert The Sarama ConsumerMessage Into A CloudEvents Message
 	message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
 	if message.ReadEncoding() == binding.EncodingUnknown {
-		h.Logger.Warn("Received A Message With Unknown Encoding - Skipping")
-		return true, errors.New("received a message with unknown encoding - skipping") // Mark As Handled Since Retry Won't Fix Anything : )
+		h.Logger.Warn("Received A Message With Unknown Encoding - Skipping", zap.Int32("Partition", consumerMessage.Partition), zap.Int64("Offset", consumerMessage.Offset))
+		return true, nil // Mark As Handled Since Retry Won't Fix Anything : )
+	}
+
+	// Skip Messages Which Do Not Pass The Filter (If Any) Without Dispatching Them
+	if eventFilter := h.getFilter(); eventFilter != nil {
+		event, err := binding.ToEvent(ctx, message)
+		if err != nil {
+			h.Logger.Warn("Failed To Convert Message To Event For Filtering - Skipping", zap.Error(err))
+			return true, nil // Mark As Handled Since Retry Won't Fix Anything : )
+		}
+		result := eventFilter.Filter(ctx, *event)
+		if h.ChannelName != "" {
+			filter.ReportFilterEventCount(ctx, types.NamespacedName{Namespace: h.ChannelNamespace, Name: h.ChannelName}, h.Subscriber.UID, result)
+		}
+		if result == eventfilter.FailFilter {
+			h.Logger.Debug("Event Did Not Pass Filter - Skipping", zap.String("ID", event.ID()))
+			return true, nil
+		}
 	}
 
 	// Start Tracing
-	ctx, span := tracing.StartTraceFromMessage(h.Logger.Sugar(), ctx, message, "kafkachannel-"+consumerMessage.Topic)
+	ctx, span := tracing.StartConsumerSpan(h.Logger.Sugar(), ctx, consumerMessage, h.GroupId, "kafkachannel-"+consumerMessage.Topic)
 	defer span.End()
 
 	// Dispatch The Message With Configured Retries, DLQ, etc
-	info, err := h.MessageDispatcher.DispatchMessageWithRetries(ctx, message, httpHeader, h.destinationURL, h.replyURL, h.deadLetterURL, &h.retryConfig)
+	info, err := h.MessageDispatcher.DispatchMessageWithRetries(ctx, message, httpHeader, h.destinationURL, h.replyURL, h.deadLetterURL, delivery.RetryConfig(&h.retryConfig))
 	h.Logger.Debug("Received Response", zap.Any("ExecutionInfo", executionInfoWrapper{info}))
+	latency.ReportDelivery(ctx, latencyArgs, delivery)
 
 	//
 	// Determine Whether To Mark The Message As Processed
@@ -175,12 +249,34 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 	return markMessage, nil
 }
 
-// SetReady is used by the "Prober" implementation for tracking ConsumerGroup
-// status which we are not using at the moment, and is believed to be
-// undergoing refactor / replacement in favor of using the control-protocol
-// and is thus not supported here yet ; )
+// SetReady is called by the SaramaConsumerHandler when a partition is claimed (ready)
+// or released (not ready) by the ConsumerGroup.  The claimed partitions are tracked so
+// that they can be reported to the controller via the control-protocol, which uses
+// them to determine the readiness of the Subscriber.
 func (h *Handler) SetReady(partition int32, ready bool) {
-	h.Logger.Debug("No-Op SetReady Handler", zap.Int32("Partition", partition), zap.Bool("Ready", ready))
+	h.Logger.Debug("Setting Partition Readiness", zap.Int32("Partition", partition), zap.Bool("Ready", ready))
+
+	// Update The Claimed Partitions & Determine Whether They Changed
+	h.claimsLock.Lock()
+	changed := h.claims.Has(partition) != ready
+	if ready {
+		h.claims.Insert(partition)
+	} else {
+		h.claims.Delete(partition)
+	}
+	h.claimsLock.Unlock()
+
+	// Notify Any Interested Party Of The Change
+	if changed && h.claimsChanged != nil {
+		h.claimsChanged()
+	}
+}
+
+// Claims returns the sorted list of partitions currently claimed by the Handler
+func (h *Handler) Claims() []int32 {
+	h.claimsLock.RLock()
+	defer h.claimsLock.RUnlock()
+	return h.claims.List()
 }
 
 // GetConsumerGroup returns the ConsumerGroup ID of the Handler
@@ -188,6 +284,24 @@ func (h *Handler) GetConsumerGroup() string {
 	return h.GroupId
 }
 
+// GetSubscriberUID returns the UID of the Subscription to which the Handler dispatches (tagging its consumer group lag)
+func (h *Handler) GetSubscriberUID() types.UID {
+	return h.Subscriber.UID
+}
+
+// latencyArgs returns the resource and subscriber with which the latency of the dispatched events is reported
+func (h *Handler) latencyArgs() latency.ReportArgs {
+	if h.LatencyArgs != nil {
+		return *h.LatencyArgs
+	}
+	return latency.ReportArgs{
+		Namespace:     h.ChannelNamespace,
+		Name:          h.ChannelName,
+		ResourceGroup: commonconsumer.KafkaChannelResourceGroup,
+		Subscriber:    h.Subscriber.UID,
+	}
+}
+
 // executionInfoWrapper wraps a DispatchExecutionInfo struct so that zap.Any can lazily marshal it
 type executionInfoWrapper struct {
 	*channel.DispatchExecutionInfo
@@ -204,3 +318,11 @@ func (w executionInfoWrapper) MarshalLogObject(enc zapcore.ObjectEncoder) error
 	}
 	return nil
 }
+
+// Get The Name Of The KafkaChannel's Encryption Secret ("" If It Has Not Opted In)
+func (h *Handler) encryptionSecret() string {
+	if h.EncryptionSecret == nil {
+		return ""
+	}
+	return h.EncryptionSecret()
+}
@@ -28,15 +28,22 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventfilter/attributes"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	logtesting "knative.dev/pkg/logging/testing"
 
 	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 )
 
 // Test Data
@@ -46,6 +53,8 @@ const (
 	testReplyURIString       = "https://www.something.com/"
 	testDeadLetterURIString  = "https://www.made.up/url"
 	testTopic                = "TestTopic"
+	testEncryptionNamespace  = "test-namespace"
+	testEncryptionSecretName = "test-encryption-keys"
 	testPartition            = 0
 	testOffset               = 1
 	testMsgSpecVersion       = "1.0"
@@ -83,6 +92,8 @@ type HandleTestCase struct {
 	deadLetterUri     *apis.URL
 	dispatchErr       error
 	retry             bool
+	claimCheck        bool
+	encrypted         bool
 	expectMarkMessage bool
 }
 
@@ -142,6 +153,28 @@ func TestHandle(t *testing.T) {
 			dispatchErr:       context.Canceled,
 			expectMarkMessage: false,
 		},
+		{
+			name:              "Claim-Check Message",
+			destinationUri:    testSubscriberURI,
+			retry:             false,
+			claimCheck:        true,
+			expectMarkMessage: true,
+		},
+		{
+			name:              "Encrypted Message",
+			destinationUri:    testSubscriberURI,
+			retry:             false,
+			encrypted:         true,
+			expectMarkMessage: true,
+		},
+		{
+			name:              "Encrypted Claim-Check Message",
+			destinationUri:    testSubscriberURI,
+			retry:             false,
+			claimCheck:        true,
+			encrypted:         true,
+			expectMarkMessage: true,
+		},
 	}
 
 	// Filter To Those With "only" Flag (If Any Specified)
@@ -165,7 +198,20 @@ func TestHandle(t *testing.T) {
 
 func TestSetReady(t *testing.T) {
 	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	changedCount := 0
+	handler.claimsChanged = func() { changedCount++ }
+	assert.Empty(t, handler.Claims())
+
 	handler.SetReady(1, true)
+	handler.SetReady(0, true)
+	handler.SetReady(1, true) // Duplicate Claim Should Not Notify
+	assert.Equal(t, []int32{0, 1}, handler.Claims())
+	assert.Equal(t, 2, changedCount)
+
+	handler.SetReady(1, false)
+	handler.SetReady(2, false) // Unknown Partition Should Not Notify
+	assert.Equal(t, []int32{0}, handler.Claims())
+	assert.Equal(t, 3, changedCount)
 }
 
 func TestGetConsumerGroup(t *testing.T) {
@@ -174,6 +220,11 @@ func TestGetConsumerGroup(t *testing.T) {
 	assert.Equal(t, testConsumerGroupId, actualConsumerGroupId)
 }
 
+func TestGetSubscriberUID(t *testing.T) {
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	assert.Equal(t, testSubscriberUID, handler.GetSubscriberUID())
+}
+
 // Test One Permutation Of The Handler's Handle() Functionality
 func performHandleTest(t *testing.T, testCase HandleTestCase) {
 
@@ -226,8 +277,18 @@ func performHandleTest(t *testing.T, testCase HandleTestCase) {
 	// Create The Handler To Test
 	handler := createTestHandler(t, testCase.destinationUri, testCase.replyUri, &deliverySpec)
 
-	// Perform The Test
+	// Encrypt The Message Payload And/Or Offload It To A Claim-Check Store If Specified (As The Receiver Would)
 	consumerMessage := createConsumerMessage(t)
+	if testCase.encrypted {
+		handler.KeyProvider = createEncryptedConsumerMessage(t, consumerMessage)
+		handler.ChannelNamespace = testEncryptionNamespace
+		handler.EncryptionSecret = func() string { return testEncryptionSecretName }
+	}
+	if testCase.claimCheck {
+		handler.ClaimCheckStore = createClaimCheckConsumerMessage(t, handler, consumerMessage)
+	}
+
+	// Perform The Test
 	result, err := handler.Handle(context.TODO(), consumerMessage)
 
 	// Verify The Results
@@ -237,6 +298,122 @@ func performHandleTest(t *testing.T, testCase HandleTestCase) {
 	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
 }
 
+// Test The Handler's Handle() Functionality For A Claim-Check Message Whose Payload Is Missing
+func TestHandleClaimCheckMissingPayload(t *testing.T) {
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	consumerMessage := createConsumerMessage(t)
+	createClaimCheckConsumerMessage(t, handler, consumerMessage)
+	handler.ClaimCheckStore = claimcheck.NewFilesystemStore(t.TempDir()) // Empty Store
+
+	handler.SetReady(0, true)
+	result, err := handler.Handle(context.TODO(), consumerMessage)
+	assert.Nil(t, err) // Logged Rather Than Returned So The Partition Stays Claimed
+	assert.True(t, result)
+	assert.Equal(t, []int32{0}, handler.Claims())
+}
+
+// Test The Handler's Handle() Functionality For An Encrypted Message Whose Key Is Missing
+func TestHandleEncryptionMissingKey(t *testing.T) {
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	consumerMessage := createConsumerMessage(t)
+	createEncryptedConsumerMessage(t, consumerMessage)
+	handler.KeyProvider = encryption.NewSecretKeyProvider(fake.NewSimpleClientset(newTestEncryptionSecret("other-key-id")))
+	handler.ChannelNamespace = testEncryptionNamespace
+	handler.EncryptionSecret = func() string { return testEncryptionSecretName }
+
+	result, err := handler.Handle(context.TODO(), consumerMessage)
+	assert.Nil(t, err)
+	assert.True(t, result)
+}
+
+// Test The Handler's Handle() Functionality For An Encrypted Message Of A KafkaChannel Without An Encryption Secret
+func TestHandleEncryptionNoChannelSecret(t *testing.T) {
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	consumerMessage := createConsumerMessage(t)
+	handler.KeyProvider = createEncryptedConsumerMessage(t, consumerMessage)
+	handler.ChannelNamespace = testEncryptionNamespace
+
+	result, err := handler.Handle(context.TODO(), consumerMessage)
+	assert.Nil(t, err) // Logged & Skipped Rather Than Decrypted With The Secret Named By The Record
+	assert.True(t, result)
+}
+
+// Test The Handler's Handle() Functionality With An Event Filter
+func TestHandleFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         map[string]string
+		expectDispatch bool
+	}{
+		{name: "Matching Filter", filter: map[string]string{"type": testMsgType, "source": testMsgSource}, expectDispatch: true},
+		{name: "Matching Any Filter", filter: map[string]string{"eventtypeversion": ""}, expectDispatch: true},
+		{name: "Non-Matching Filter", filter: map[string]string{"type": "OtherType"}, expectDispatch: false},
+		{name: "Missing Extension Filter", filter: map[string]string{"missing": "value"}, expectDispatch: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := http.Header{
+				"Content-Type": []string{testMsgContentType},
+				"X-B3-Traceid": []string{testB3TraceId},
+			}
+			retryConfig := kncloudevents.NoRetries()
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, headers, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher { return mockMessageDispatcher }
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.Filter = attributes.NewAttributesFilter(test.filter)
+
+			result, err := handler.Handle(context.TODO(), createConsumerMessage(t))
+			assert.Nil(t, err)
+			assert.True(t, result)
+			if test.expectDispatch {
+				assert.NotNil(t, mockMessageDispatcher.Message())
+				verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+			} else {
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
+		})
+	}
+}
+
+// Utility Function For Encrypting A ConsumerMessage's Payload, Returning A KeyProvider With The Encryption Key
+func createEncryptedConsumerMessage(t *testing.T, consumerMessage *sarama.ConsumerMessage) encryption.KeyProvider {
+	keyProvider := encryption.NewSecretKeyProvider(fake.NewSimpleClientset(newTestEncryptionSecret("key-1")))
+	producerMessage := &sarama.ProducerMessage{Value: sarama.ByteEncoder(consumerMessage.Value)}
+	for _, header := range consumerMessage.Headers {
+		producerMessage.Headers = append(producerMessage.Headers, *header)
+	}
+	assert.Nil(t, encryption.Encrypt(context.TODO(), keyProvider, testEncryptionNamespace, testEncryptionSecretName, producerMessage))
+	consumerMessage.Value, _ = producerMessage.Value.Encode()
+	consumerMessage.Headers = consumerMessage.Headers[:0]
+	for i := range producerMessage.Headers {
+		consumerMessage.Headers = append(consumerMessage.Headers, &producerMessage.Headers[i])
+	}
+	return keyProvider
+}
+
+// Utility Function For Creating An Encryption Secret With A Single Key
+func newTestEncryptionSecret(keyId string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testEncryptionNamespace, Name: testEncryptionSecretName},
+		Data:       map[string][]byte{keyId: []byte("0123456789abcdef")},
+	}
+}
+
+// Utility Function For Offloading A ConsumerMessage's Payload To A New Claim-Check Store Of The Handler's Channel
+func createClaimCheckConsumerMessage(t *testing.T, handler *Handler, consumerMessage *sarama.ConsumerMessage) claimcheck.Store {
+	handler.ChannelNamespace = "test-namespace"
+	handler.ChannelName = "test-channel"
+	store := claimcheck.NewFilesystemStore(t.TempDir())
+	key := "test-namespace/test-channel/test-id"
+	assert.Nil(t, store.Put(context.TODO(), key, consumerMessage.Value))
+	consumerMessage.Value = []byte{}
+	consumerMessage.Headers = append(consumerMessage.Headers, &sarama.RecordHeader{Key: []byte(claimcheck.HeaderKey), Value: []byte(key)})
+	return store
+}
+
 // Verify The Dispatched Message Contains Test Message Contents (Was Not Corrupted)
 func verifyDispatchedMessage(t *testing.T, message binding.MessageReader) {
 	dispatchedEvent, err := binding.ToEvent(context.TODO(), message)
@@ -294,7 +471,7 @@ func createTestHandler(t *testing.T, subscriberURL *apis.URL, replyUrl *apis.URL
 	}
 
 	// Perform The Test Create The Test Handler
-	handler := NewHandler(logger, testConsumerGroupId, testSubscriber)
+	handler := NewHandler(logger, testConsumerGroupId, testSubscriber, nil)
 
 	// Verify The Results
 	assert.NotNil(t, handler)
@@ -348,6 +525,10 @@ func createConsumerMessage(t *testing.T) *sarama.ConsumerMessage {
 				Key:   []byte("x-b3-traceid"),
 				Value: []byte(testB3TraceId),
 			},
+			{
+				Key:   []byte(latency.IngressTimeHeaderKey), // Internal Header Not Passed Through To The Subscriber
+				Value: []byte("1650000000000"),
+			},
 		},
 		Timestamp:      time.Now(),
 		BlockTimestamp: time.Time{},
@@ -45,10 +45,11 @@ type Environment struct {
 	HealthPort int // Required
 
 	// Kafka Configuration
-	KafkaTopic   string        // Required
-	ChannelKey   string        // Required
-	ServiceName  string        // Required
-	ResyncPeriod time.Duration // Optional
+	KafkaTopic       string        // Required (Unless SharedDispatcher)
+	ChannelKey       string        // Required (Unless SharedDispatcher)
+	ServiceName      string        // Required
+	SharedDispatcher bool          // Optional
+	ResyncPeriod     time.Duration // Optional
 
 	// Kafka Authorization
 	KafkaSecretName      string // Required
@@ -112,16 +113,29 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
-	// Get The Required K8S KafkaTopic Config Value
-	environment.KafkaTopic, err = env.GetRequiredConfigValue(logger, env.KafkaTopicEnvVarKey)
+	// Get The Optional SharedDispatcher Config Value
+	environment.SharedDispatcher, err = env.GetOptionalConfigBool(logger, env.SharedDispatcherEnvVarKey, "false", "SharedDispatcher")
 	if err != nil {
 		return nil, err
 	}
 
-	// Get The Required K8S ChannelKey Config Value
-	environment.ChannelKey, err = env.GetRequiredConfigValue(logger, env.ChannelKeyEnvVarKey)
-	if err != nil {
-		return nil, err
+	// A Shared Dispatcher Serves Many KafkaChannels (And Their Topics) Which Are Determined At Runtime
+	if environment.SharedDispatcher {
+		environment.KafkaTopic = env.GetOptionalConfigValue(logger, env.KafkaTopicEnvVarKey, "")
+		environment.ChannelKey = env.GetOptionalConfigValue(logger, env.ChannelKeyEnvVarKey, "")
+	} else {
+
+		// Get The Required K8S KafkaTopic Config Value
+		environment.KafkaTopic, err = env.GetRequiredConfigValue(logger, env.KafkaTopicEnvVarKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get The Required K8S ChannelKey Config Value
+		environment.ChannelKey, err = env.GetRequiredConfigValue(logger, env.ChannelKeyEnvVarKey)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get The Required K8S ServiceName Config Value
@@ -58,6 +58,7 @@ type TestCase struct {
 	kafkaTopic           string
 	channelKey           string
 	serviceName          string
+	sharedDispatcher     string
 	kafkaSecretName      string
 	kafkaSecretNamespace string
 	podName              string
@@ -127,6 +128,17 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(commonenv.ChannelKeyEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - SharedDispatcher Without KafkaTopic Or ChannelKey")
+	testCase.sharedDispatcher = "true"
+	testCase.kafkaTopic = ""
+	testCase.channelKey = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - SharedDispatcher")
+	testCase.sharedDispatcher = "NAB"
+	testCase.expectedError = fmt.Errorf("invalid (non boolean) value '%s' for environment variable '%s'", testCase.sharedDispatcher, commonenv.SharedDispatcherEnvVarKey)
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - ServiceName")
 	testCase.serviceName = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(commonenv.ServiceNameEnvVarKey)
@@ -166,6 +178,7 @@ func TestGetEnvironment(t *testing.T) {
 			assertSetenv(t, commonenv.KafkaTopicEnvVarKey, testCase.kafkaTopic)
 			assertSetenv(t, commonenv.ChannelKeyEnvVarKey, testCase.channelKey)
 			assertSetenv(t, commonenv.ServiceNameEnvVarKey, testCase.serviceName)
+			assertSetenvNonempty(t, commonenv.SharedDispatcherEnvVarKey, testCase.sharedDispatcher)
 			assertSetenv(t, commonenv.KafkaSecretNameEnvVarKey, testCase.kafkaSecretName)
 			assertSetenv(t, commonenv.KafkaSecretNamespaceEnvVarKey, testCase.kafkaSecretNamespace)
 			assertSetenv(t, commonenv.PodNameEnvVarKey, testCase.podName)
@@ -185,6 +198,7 @@ func TestGetEnvironment(t *testing.T) {
 				assert.Equal(t, testCase.kafkaTopic, environment.KafkaTopic)
 				assert.Equal(t, testCase.channelKey, environment.ChannelKey)
 				assert.Equal(t, testCase.serviceName, environment.ServiceName)
+				assert.Equal(t, testCase.sharedDispatcher == "true", environment.SharedDispatcher)
 				assert.Equal(t, testCase.kafkaSecretName, environment.KafkaSecretName)
 				assert.Equal(t, testCase.kafkaSecretNamespace, environment.KafkaSecretNamespace)
 				assert.Equal(t, testCase.podName, environment.PodName)
@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/apis"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
@@ -115,3 +116,12 @@ func WithSubscriberNotReady(uid types.UID, message string) KafkaChannelOption {
 		})
 	}
 }
+
+func WithPlacement(podName string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		kafkachannel.Status.Placements = append(kafkachannel.Status.Placements, duckv1alpha1.Placement{
+			PodName:   podName,
+			VReplicas: 1,
+		})
+	}
+}
@@ -71,8 +71,8 @@ func InitializeKafkaChannelLister(ctx context.Context, client kafkaclientset.Int
 // ValidateKafkaChannel verifies the specified ChannelReference refers to a KafkaChannel capable of receiving events.
 // This ensures the KafkaChannel exists and has Status indicating the Kafka Topic has been created and the shared
 // Receiver Service and Deployment are READY.  The KafkaChannel's overall Status might not be READY depending on
-// Dispatcher state, but the ingress pathway is viable.
-func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) error {
+// Dispatcher state, but the ingress pathway is viable.  The valid KafkaChannel is returned.
+func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) (*messaging.KafkaChannel, error) {
 
 	// Enhance Logger With ChannelReference
 	logger := logger.With(zap.String("ChannelReference", channelReference.String()))
@@ -80,7 +80,7 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	// Validate The Specified Channel Reference
 	if channelReference.Name == "" || channelReference.Namespace == "" {
 		logger.Warn("Invalid KafkaChannel - Invalid ChannelReference")
-		return errors.New("invalid ChannelReference specified")
+		return nil, errors.New("invalid ChannelReference specified")
 	}
 
 	// Attempt To Get The KafkaChannel From The KafkaChannel Lister
@@ -88,10 +88,10 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			logger.Warn("Invalid KafkaChannel - Not Found")
-			return &eventingChannel.UnknownChannelError{Channel: channelReference}
+			return nil, &eventingChannel.UnknownChannelError{Channel: channelReference}
 		} else {
 			logger.Error("Invalid KafkaChannel - Failed To Find", zap.Error(err))
-			return err
+			return nil, err
 		}
 	}
 
@@ -100,7 +100,7 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 		!kafkaChannel.Status.GetCondition(distributedmessaging.KafkaChannelConditionReceiverServiceReady).IsTrue() ||
 		!kafkaChannel.Status.GetCondition(distributedmessaging.KafkaChannelConditionReceiverDeploymentReady).IsTrue() {
 		logger.Info("Invalid KafkaChannel - Ingress (Topic / Receiver) Not READY")
-		return fmt.Errorf("ingress not READY: Kafka Topic=%t, Receiver Service=%t, Receiver Deployment=%t",
+		return nil, fmt.Errorf("ingress not READY: Kafka Topic=%t, Receiver Service=%t, Receiver Deployment=%t",
 			kafkaChannel.Status.GetCondition(messaging.KafkaChannelConditionTopicReady).IsTrue(),
 			kafkaChannel.Status.GetCondition(distributedmessaging.KafkaChannelConditionReceiverServiceReady).IsTrue(),
 			kafkaChannel.Status.GetCondition(distributedmessaging.KafkaChannelConditionReceiverServiceReady).IsTrue())
@@ -108,7 +108,7 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 
 	// Return Valid KafkaChannel
 	logger.Debug("Valid KafkaChannel - Found & READY")
-	return nil
+	return kafkaChannel, nil
 }
 
 // Close The Channel Lister (Stop Processing)
@@ -76,10 +76,11 @@ func performValidateKafkaChannelTest(t *testing.T, channelName string, channelNa
 	kafkaChannelLister = receivertesting.NewMockKafkaChannelLister(channelReference.Name, channelReference.Namespace, exists, ready, err)
 
 	// Perform The Test
-	validationError := ValidateKafkaChannel(channelReference)
+	kafkaChannel, validationError := ValidateKafkaChannel(channelReference)
 
 	// Verify The Results
 	assert.Equal(t, err, validationError != nil)
+	assert.Equal(t, err, kafkaChannel == nil)
 }
 
 // Test The Close() Functionality
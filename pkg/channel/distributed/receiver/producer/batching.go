@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	gometrics "github.com/rcrowley/go-metrics"
+
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+// Name Of The Batching Producer's Latency Histogram (Reported Along With The Sarama Metrics Of The Same Registry,
+// Which Include Sarama's Own "batch-size" & "records-per-request" Histograms Of The Batches Actually Produced)
+const ProduceLatencyMetricName = "receiver-produce-latency-in-ms"
+
+// DefaultMaxBatchMessages is the maximum number of events per batch if not specified in the config-kafka ConfigMap.
+const DefaultMaxBatchMessages = 100
+
+// NewAsyncProducerFn creates the Sarama AsyncProducer of a batching producer (may be stubbed for testing)
+var NewAsyncProducerFn = sarama.NewAsyncProducer
+
+// The pendingMessage struct is the Metadata of a ProducerMessage awaiting its acknowledgement
+type pendingMessage struct {
+	result chan error
+	start  time.Time
+}
+
+// batchingProducer implements the sarama.SyncProducer interface on top of a sarama.AsyncProducer, whose Flush
+// settings batch the messages of concurrent senders, completing each send when the acknowledgement (or error) of
+// its own message arrives through a per-message result channel.
+type batchingProducer struct {
+	producer          sarama.AsyncProducer
+	produceLatency    gometrics.Histogram
+	pending           chan *sarama.ProducerMessage
+	closing           chan struct{}
+	closeOnce         sync.Once
+	forwardingStopped chan struct{}
+	resultsWaitGroup  sync.WaitGroup
+}
+
+// Verify The batchingProducer Implements The Sarama SyncProducer Interface
+var _ sarama.SyncProducer = &batchingProducer{}
+
+// NewBatchingProducerFn returns a function creating batching producers with the specified settings, suitable for
+// use wherever a Sarama SyncProducer is created.
+func NewBatchingProducerFn(producerConfig commonconfig.EKReceiverProducerConfig) func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	return func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		return newBatchingProducer(brokers, config, producerConfig)
+	}
+}
+
+// Create A New batchingProducer (Both Successes & Errors Are Returned By The AsyncProducer To Complete Each Send)
+func newBatchingProducer(brokers []string, config *sarama.Config, producerConfig commonconfig.EKReceiverProducerConfig) (*batchingProducer, error) {
+	asyncConfig := *config
+	asyncConfig.Producer.Return.Successes = true
+	asyncConfig.Producer.Return.Errors = true
+	if asyncConfig.MetricRegistry == nil {
+		asyncConfig.MetricRegistry = gometrics.NewRegistry()
+	}
+
+	// Batch Up To maxBatchMessages Per Request, Waiting Up To The Linger For A Batch To Fill (Without A Linger The
+	// AsyncProducer Flushes As Soon As Possible, Batching Only The Messages Accumulated While A Request Is In Flight)
+	maxBatchMessages := producerConfig.MaxBatchMessages
+	if maxBatchMessages <= 0 {
+		maxBatchMessages = DefaultMaxBatchMessages
+	}
+	asyncConfig.Producer.Flush.Bytes = 0
+	asyncConfig.Producer.Flush.Messages = 0
+	asyncConfig.Producer.Flush.Frequency = 0
+	asyncConfig.Producer.Flush.MaxMessages = maxBatchMessages
+	if producerConfig.LingerMillis > 0 {
+		asyncConfig.Producer.Flush.Messages = maxBatchMessages
+		asyncConfig.Producer.Flush.Frequency = time.Duration(producerConfig.LingerMillis) * time.Millisecond
+	}
+
+	asyncProducer, err := NewAsyncProducerFn(brokers, &asyncConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &batchingProducer{
+		producer:          asyncProducer,
+		produceLatency:    gometrics.GetOrRegisterHistogram(ProduceLatencyMetricName, asyncConfig.MetricRegistry, gometrics.NewExpDecaySample(1028, 0.015)),
+		pending:           make(chan *sarama.ProducerMessage),
+		closing:           make(chan struct{}),
+		forwardingStopped: make(chan struct{}),
+	}
+
+	go p.forwardMessages()
+	p.resultsWaitGroup.Add(2)
+	go p.handleSuccesses()
+	go p.handleErrors()
+
+	return p, nil
+}
+
+// SendMessage passes the message to the AsyncProducer and waits for its acknowledgement.
+func (p *batchingProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	pending := &pendingMessage{result: make(chan error, 1), start: time.Now()}
+	message.Metadata = pending
+
+	select {
+	case p.pending <- message:
+	case <-p.closing:
+		return -1, -1, sarama.ErrClosedClient
+	}
+
+	if err := <-pending.result; err != nil {
+		return -1, -1, err
+	}
+	return message.Partition, message.Offset, nil
+}
+
+// SendMessages passes the messages to the AsyncProducer and waits for all of their acknowledgements.
+func (p *batchingProducer) SendMessages(messages []*sarama.ProducerMessage) error {
+	errs := make(chan *sarama.ProducerError, len(messages))
+	for _, message := range messages {
+		go func(message *sarama.ProducerMessage) {
+			if _, _, err := p.SendMessage(message); err != nil {
+				errs <- &sarama.ProducerError{Msg: message, Err: err}
+			} else {
+				errs <- nil
+			}
+		}(message)
+	}
+
+	var producerErrors sarama.ProducerErrors
+	for range messages {
+		if err := <-errs; err != nil {
+			producerErrors = append(producerErrors, err)
+		}
+	}
+	if len(producerErrors) > 0 {
+		return producerErrors
+	}
+	return nil
+}
+
+// Close stops accepting messages, flushes those already passed to the AsyncProducer and waits for their acknowledgements.
+func (p *batchingProducer) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		<-p.forwardingStopped
+		p.producer.AsyncClose()
+	})
+	p.resultsWaitGroup.Wait()
+	return nil
+}
+
+// Forward The Pending Messages To The AsyncProducer Until Closing (So That No Message Is Input After AsyncClose)
+func (p *batchingProducer) forwardMessages() {
+	defer close(p.forwardingStopped)
+	for {
+		select {
+		case message := <-p.pending:
+			p.producer.Input() <- message
+		case <-p.closing:
+			return
+		}
+	}
+}
+
+// Complete The Sends Of Successfully Produced Messages
+func (p *batchingProducer) handleSuccesses() {
+	defer p.resultsWaitGroup.Done()
+	for message := range p.producer.Successes() {
+		p.complete(message, nil)
+	}
+}
+
+// Complete The Sends Of Messages Which Failed To Be Produced
+func (p *batchingProducer) handleErrors() {
+	defer p.resultsWaitGroup.Done()
+	for producerError := range p.producer.Errors() {
+		p.complete(producerError.Msg, producerError.Err)
+	}
+}
+
+// Record The Produce Latency Of The Message & Deliver The Result To Its Sender
+func (p *batchingProducer) complete(message *sarama.ProducerMessage, err error) {
+	if message == nil {
+		return
+	}
+	if pending, ok := message.Metadata.(*pendingMessage); ok {
+		p.produceLatency.Update(time.Since(pending.start).Milliseconds())
+		pending.result <- err
+	}
+}
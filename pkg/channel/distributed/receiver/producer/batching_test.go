@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
+)
+
+// Test Concurrent Sends Each Complete With Their Own Acknowledgement
+func TestBatchingProducerSendMessage(t *testing.T) {
+
+	// Expect Each Of The Concurrent Sends
+	config := sarama.NewConfig()
+	stubNewAsyncProducerFn(t, func(mockProducer *mocks.AsyncProducer) {
+		for i := 0; i < 3; i++ {
+			mockProducer.ExpectInputAndSucceed()
+		}
+	})
+	batchingProducer, err := newBatchingProducer([]string{configtesting.DefaultKafkaBroker}, config, commonconfig.EKReceiverProducerConfig{Async: true, MaxBatchMessages: 3, LingerMillis: 60000})
+	assert.Nil(t, err)
+
+	// Perform The Test With Concurrent Senders
+	offsets := make(chan int64, 3)
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			_, offset, err := batchingProducer.SendMessage(&sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder("test-value")})
+			assert.Nil(t, err)
+			offsets <- offset
+		}()
+	}
+	waitGroup.Wait()
+	close(offsets)
+
+	// Verify Each Sender Received The Offset Of Its Own Message
+	distinctOffsets := make(map[int64]bool)
+	for offset := range offsets {
+		distinctOffsets[offset] = true
+	}
+	assert.Len(t, distinctOffsets, 3)
+	assert.Nil(t, batchingProducer.Close())
+
+	// Verify The Latency Histogram Was Updated In The Config's Metric Registry
+	produceLatency, ok := config.MetricRegistry.Get(ProduceLatencyMetricName).(gometrics.Histogram)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), produceLatency.Count())
+}
+
+// Test The AsyncProducer's Flush Settings Are Configured From The Batching Settings
+func TestBatchingProducerFlushConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		producerConfig  commonconfig.EKReceiverProducerConfig
+		wantMessages    int
+		wantFrequency   time.Duration
+		wantMaxMessages int
+	}{
+		{name: "Defaults", producerConfig: commonconfig.EKReceiverProducerConfig{Async: true}, wantMaxMessages: DefaultMaxBatchMessages},
+		{name: "No Linger", producerConfig: commonconfig.EKReceiverProducerConfig{Async: true, MaxBatchMessages: 10}, wantMaxMessages: 10},
+		{name: "Linger", producerConfig: commonconfig.EKReceiverProducerConfig{Async: true, MaxBatchMessages: 10, LingerMillis: 5}, wantMessages: 10, wantFrequency: 5 * time.Millisecond, wantMaxMessages: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := sarama.NewConfig()
+			config.Producer.Flush.Bytes = 1000
+			config.Producer.Flush.Frequency = time.Second
+			NewAsyncProducerFn = func(_ []string, asyncConfig *sarama.Config) (sarama.AsyncProducer, error) {
+				assert.Zero(t, asyncConfig.Producer.Flush.Bytes)
+				assert.Equal(t, test.wantMessages, asyncConfig.Producer.Flush.Messages)
+				assert.Equal(t, test.wantFrequency, asyncConfig.Producer.Flush.Frequency)
+				assert.Equal(t, test.wantMaxMessages, asyncConfig.Producer.Flush.MaxMessages)
+				assert.Nil(t, asyncConfig.Validate())
+				return mocks.NewAsyncProducer(t, asyncConfig), nil
+			}
+			t.Cleanup(func() { NewAsyncProducerFn = sarama.NewAsyncProducer })
+
+			batchingProducer, err := newBatchingProducer([]string{configtesting.DefaultKafkaBroker}, config, test.producerConfig)
+			assert.Nil(t, err)
+			assert.Nil(t, batchingProducer.Close())
+			assert.Equal(t, time.Second, config.Producer.Flush.Frequency) // Base Config Unaltered
+		})
+	}
+}
+
+// Test A Failed Message Returns Its Error To The Sender
+func TestBatchingProducerSendMessageError(t *testing.T) {
+	testErr := errors.New("test produce error")
+	stubNewAsyncProducerFn(t, func(mockProducer *mocks.AsyncProducer) {
+		mockProducer.ExpectInputAndSucceed()
+		mockProducer.ExpectInputAndFail(testErr)
+	})
+	batchingProducer, err := newBatchingProducer([]string{configtesting.DefaultKafkaBroker}, sarama.NewConfig(), commonconfig.EKReceiverProducerConfig{Async: true})
+	assert.Nil(t, err)
+
+	_, _, err = batchingProducer.SendMessage(&sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder("test-value-1")})
+	assert.Nil(t, err)
+	_, _, err = batchingProducer.SendMessage(&sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder("test-value-2")})
+	assert.Equal(t, testErr, err)
+	assert.Nil(t, batchingProducer.Close())
+}
+
+// Test The SendMessages() Functionality Reports The Failed Messages
+func TestBatchingProducerSendMessages(t *testing.T) {
+	testErr := errors.New("test produce error")
+	stubNewAsyncProducerFn(t, func(mockProducer *mocks.AsyncProducer) {
+		mockProducer.ExpectInputAndSucceed()
+		mockProducer.ExpectInputAndFail(testErr)
+	})
+	batchingProducer, err := newBatchingProducer([]string{configtesting.DefaultKafkaBroker}, sarama.NewConfig(), commonconfig.EKReceiverProducerConfig{Async: true, MaxBatchMessages: 2, LingerMillis: 60000})
+	assert.Nil(t, err)
+
+	err = batchingProducer.SendMessages([]*sarama.ProducerMessage{
+		{Topic: "test-topic", Value: sarama.StringEncoder("test-value-1")},
+		{Topic: "test-topic", Value: sarama.StringEncoder("test-value-2")},
+	})
+	var producerErrors sarama.ProducerErrors
+	assert.True(t, errors.As(err, &producerErrors))
+	assert.Len(t, producerErrors, 1)
+	assert.Equal(t, testErr, producerErrors[0].Err)
+	assert.Nil(t, batchingProducer.Close())
+}
+
+// Test Messages Are Rejected After Close()
+func TestBatchingProducerClose(t *testing.T) {
+	stubNewAsyncProducerFn(t, func(*mocks.AsyncProducer) {})
+	batchingProducer, err := newBatchingProducer([]string{configtesting.DefaultKafkaBroker}, sarama.NewConfig(), commonconfig.EKReceiverProducerConfig{Async: true})
+	assert.Nil(t, err)
+	assert.Nil(t, batchingProducer.Close())
+	assert.Nil(t, batchingProducer.Close())
+
+	_, _, err = batchingProducer.SendMessage(&sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder("test-value")})
+	assert.Equal(t, sarama.ErrClosedClient, err)
+}
+
+// Stub The NewAsyncProducerFn With A Mock AsyncProducer Having The Specified Expectations
+func stubNewAsyncProducerFn(t *testing.T, expect func(mockProducer *mocks.AsyncProducer)) {
+	NewAsyncProducerFn = func(_ []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+		assert.True(t, config.Producer.Return.Successes)
+		assert.True(t, config.Producer.Return.Errors)
+		mockProducer := mocks.NewAsyncProducer(t, config)
+		expect(mockProducer)
+		return mockProducer, nil
+	}
+	t.Cleanup(func() { NewAsyncProducerFn = sarama.NewAsyncProducer })
+}
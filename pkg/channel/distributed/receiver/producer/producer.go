@@ -26,18 +26,22 @@ import (
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	gometrics "github.com/rcrowley/go-metrics"
-	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	eventingChannel "knative.dev/eventing/pkg/channel"
+	eventingchannel "knative.dev/eventing/pkg/channel"
 
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/durability"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
@@ -46,6 +50,10 @@ import (
 type Producer struct {
 	logger             *zap.Logger
 	kafkaProducer      sarama.SyncProducer
+	producerPool       *durability.Pool
+	sizeLimiter        *payload.Limiter
+	claimCheckStore    claimcheck.Store
+	keyProvider        encryption.KeyProvider
 	healthServer       *health.Server
 	statsReporter      metrics.StatsReporter
 	metricsRegistry    gometrics.Registry
@@ -53,6 +61,10 @@ type Producer struct {
 	metricsStoppedChan chan struct{}
 	configuration      *sarama.Config
 	brokers            []string
+	producerConfig     commonconfig.EKReceiverProducerConfig
+
+	// OmitIngressTime Disables The Ingress Time Header For Topics Not Consumed By The Dispatchers (e.g. KafkaSinks)
+	OmitIngressTime bool
 }
 
 // NewProducer returns a new Producer instance with specified configuration.
@@ -60,11 +72,21 @@ func NewProducer(logger *zap.Logger,
 	config *sarama.Config,
 	brokers []string,
 	statsReporter metrics.StatsReporter,
-	healthServer *health.Server) (*Producer, error) {
+	healthServer *health.Server,
+	claimCheckStore claimcheck.Store,
+	keyProvider encryption.KeyProvider,
+	producerConfig commonconfig.EKReceiverProducerConfig) (*Producer, error) {
+
+	// Select Synchronous Or Batching Producers (Used For Every Durability Class)
+	var newProducerFn durability.NewSyncProducerFn = producer.CreateSyncProducer
+	if producerConfig.Async {
+		logger.Info("Using Batching Kafka Producer", zap.Any("ProducerConfig", producerConfig))
+		newProducerFn = NewBatchingProducerFn(producerConfig)
+	}
 
 	// Create The Kafka Producer Using The Specified Kafka Authentication
 	logger.Info("Creating Kafka SyncProducer")
-	kafkaProducer, err := producer.CreateSyncProducer(brokers, config)
+	kafkaProducer, err := newProducerFn(brokers, config)
 	if err != nil {
 		logger.Error("Failed To Create Kafka SyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", brokers))
 		return nil, err
@@ -76,6 +98,10 @@ func NewProducer(logger *zap.Logger,
 	newProducer := &Producer{
 		logger:             logger,
 		kafkaProducer:      kafkaProducer,
+		producerPool:       durability.NewPool(logger, brokers, config, kafkaProducer, newProducerFn),
+		sizeLimiter:        payload.NewLimiter(logger, brokers, config),
+		claimCheckStore:    claimCheckStore,
+		keyProvider:        keyProvider,
 		healthServer:       healthServer,
 		statsReporter:      statsReporter,
 		metricsRegistry:    config.MetricRegistry,
@@ -83,6 +109,7 @@ func NewProducer(logger *zap.Logger,
 		metricsStoppedChan: make(chan struct{}),
 		configuration:      config,
 		brokers:            brokers,
+		producerConfig:     producerConfig,
 	}
 
 	// Start Observing Metrics
@@ -97,7 +124,11 @@ func NewProducer(logger *zap.Logger,
 }
 
 // ProduceKafkaMessage creates and sends a Sarama ProducerMessage to the specified Topic and waits for the delivery confirmation.
-func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, message binding.Message, httpHeader http.Header, transformers ...binding.Transformer) error {
+// The event data is encrypted with the active key of the options' EncryptionSecret (the event being produced in binary
+// mode so that its CloudEvent attributes remain readable headers), and produced with the producer of their DurabilityClass.
+// Event payloads larger than a positive ClaimCheckThreshold are first offloaded to the claim-check Store, and messages
+// still exceeding the maximum message size of the Topic are rejected with a payload.MessageTooLargeError before producing.
+func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingchannel.ChannelReference, topicName string, options produce.Options, message binding.Message, httpHeader http.Header, transformers ...binding.Transformer) error {
 
 	// Validate The Kafka Producer (Must Be Pre-Initialized)
 	if p.kafkaProducer == nil {
@@ -105,13 +136,22 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 		return errors.New("uninitialized kafka producer - unable to produce message")
 	}
 
-	// Get The Topic Name From The ChannelReference
-	topicName := util.TopicName(channelReference)
+	// Add The Topic Name To The Logger
 	logger := p.logger.With(zap.String("Topic", topicName))
 
 	// Initialize The Sarama ProducerMessage With The Specified Topic Name
 	producerMessage := &sarama.ProducerMessage{Topic: topicName}
 
+	// Encrypted Events Must Be Produced In Binary Mode (Applying The Transformers During The Conversion)
+	if options.EncryptionSecret != "" {
+		binaryMessage, err := encryption.ToBinaryMessage(ctx, message, transformers...)
+		if err != nil {
+			logger.Error("Failed To Convert BindingMessage To Binary Mode", zap.Error(err))
+			return err
+		}
+		message, transformers = binaryMessage, nil
+	}
+
 	// Use The SaramaKafka Protocol To Convert The Binding Message To A ProducerMessage
 	err := kafkasaramaprotocol.WriteProducerMessage(ctx, message, producerMessage, transformers...)
 	if err != nil {
@@ -119,12 +159,38 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 		return err
 	}
 
-	// Add The "traceparent" And "tracestate" Headers To The Message (Helps Tie Related Messages Together In Traces)
-	producerMessage.Headers = append(producerMessage.Headers, tracing.SerializeTrace(trace.FromContext(ctx).SpanContext())...)
+	// Start A Producer Span Whose "traceparent" And "tracestate" Headers Are Added To The Message (Helps Tie Related Messages Together In Traces)
+	ctx, span := tracing.StartProducerSpan(ctx, producerMessage)
+	defer span.End()
 
 	// Add any additional headers ("x-b3", etc)
 	producerMessage.Headers = append(producerMessage.Headers, tracing.ConvertHttpHeaderToRecordHeaders(httpHeader)...)
 
+	// Stamp The Ingress Time From Which The Dispatchers Measure The Event's End-To-End Latency
+	if !p.OmitIngressTime {
+		latency.StampIngressTime(producerMessage)
+	}
+
+	// Encrypt The Event Data (If The KafkaChannel Opted In)
+	err = encryption.Encrypt(ctx, p.keyProvider, channelReference.Namespace, options.EncryptionSecret, producerMessage)
+	if err != nil {
+		logger.Error("Failed To Encrypt Kafka Message", zap.Error(err))
+		return err
+	}
+
+	// Offload Large Payloads To The Claim-Check Store (If The KafkaChannel Opted In)
+	err = claimcheck.Offload(ctx, p.claimCheckStore, channelReference, options.ClaimCheckThreshold, producerMessage)
+	if err != nil {
+		logger.Error("Failed To Offload Claim-Check Payload", zap.Error(err))
+		return err
+	}
+
+	// Reject Messages Which The Kafka Topic Would Not Accept
+	err = p.sizeLimiter.Check(ctx, channelReference, producerMessage)
+	if err != nil {
+		return err
+	}
+
 	// Produce The Kafka Message To The Kafka Topic
 	if logger.Core().Enabled(zap.DebugLevel) {
 		// Checked Logging Level First To Avoid Calling StringifyHeaders and Encode Functions In Production
@@ -134,7 +200,13 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 			zap.Any("Headers", kafkasarama.StringifyHeaders(producerMessage.Headers)), // Log human-readable strings, not base64
 			zap.ByteString("Message", msgBytes))
 	}
-	partition, offset, err := p.kafkaProducer.SendMessage(producerMessage)
+	kafkaProducer, err := p.producerPool.Producer(topicName, options.DurabilityClass)
+	if err != nil {
+		logger.Error("Failed To Get Kafka Producer For Durability Class", zap.String("DurabilityClass", string(options.DurabilityClass)), zap.Error(err))
+		return err
+	}
+	partition, offset, err := kafkaProducer.SendMessage(producerMessage)
+	tracing.RecordProducerResult(span, producerMessage, partition, offset, err)
 	if err != nil {
 		logger.Error("Failed To Send Message To Kafka", zap.Error(err))
 		return err
@@ -196,13 +268,14 @@ func (p *Producer) SecretChanged(ctx context.Context, secret *corev1.Secret) *Pr
 	}
 
 	// Build New Config Using Existing Config And New Auth Settings
-	if kafkaAuthCfg.SASL.User == "" {
+	if !kafkaAuthCfg.SASL.HasCredentials() {
 		// The config builder expects the entire config object to be nil if not using auth
 		kafkaAuthCfg = nil
 		// Any existing SASL config must be cleared explicitly or the "WithExisting" builder will keep the old values
 		p.configuration.Net.SASL.Enable = false
 		p.configuration.Net.SASL.User = ""
 		p.configuration.Net.SASL.Password = ""
+		p.configuration.Net.SASL.TokenProvider = nil
 	}
 	newConfig, err := client.NewConfigBuilder().WithExisting(p.configuration).WithAuth(kafkaAuthCfg).Build(ctx)
 	if err != nil {
@@ -215,7 +288,7 @@ func (p *Producer) SecretChanged(ctx context.Context, secret *corev1.Secret) *Pr
 
 	// Shut down the current producer and recreate it with new settings
 	p.Close()
-	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.statsReporter, p.healthServer)
+	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.statsReporter, p.healthServer, p.claimCheckStore, p.keyProvider, p.producerConfig)
 	if err != nil {
 		p.logger.Fatal("Failed To Create Kafka Producer With New Configuration", zap.Error(err))
 		return nil
@@ -236,6 +309,9 @@ func (p *Producer) Close() {
 	close(p.metricsStopChan)
 	<-p.metricsStoppedChan
 
+	// Close The Kafka Producers Of The Non-Default Durability Classes
+	_ = p.producerPool.Close()
+
 	// Close The Kafka Producer & Log Results
 	err := p.kafkaProducer.Close()
 	if err != nil {
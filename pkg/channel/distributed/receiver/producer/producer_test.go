@@ -18,22 +18,35 @@ package producer
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	producertesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/testing"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
 	commonclient "knative.dev/eventing-kafka/pkg/common/client"
 	clienttesting "knative.dev/eventing-kafka/pkg/common/client/testing"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	payloadtesting "knative.dev/eventing-kafka/pkg/common/kafka/payload/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 )
@@ -65,7 +78,6 @@ func TestProduceKafkaMessage(t *testing.T) {
 	// Test Data
 	brokers := []string{configtesting.DefaultKafkaBroker}
 	config := sarama.NewConfig()
-	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
 	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
 	httpHeader := map[string][]string{
 		"x-request-id": {"TestRequestId"},
@@ -80,11 +92,15 @@ func TestProduceKafkaMessage(t *testing.T) {
 	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
 	defer producertesting.RestoreNewSyncProducerFn()
 
+	// Stub The Payload Limiter's ClusterAdmin With A Topic Limit Large Enough For The Message
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 1000000, nil, nil))
+
 	// Create Producer To Test
 	producer := createTestProducer(t, brokers, config, mockSyncProducer)
 
 	// Perform The Test & Verify Results
-	err := producer.ProduceKafkaMessage(context.Background(), channelReference, bindingMessage, httpHeader)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{}, bindingMessage, httpHeader)
 	assert.Nil(t, err)
 
 	// Verify Message Was Produced Correctly
@@ -110,6 +126,221 @@ func TestProduceKafkaMessage(t *testing.T) {
 			receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, headerKey, headerValue)
 		}
 	}
+
+	// Verify The Ingress Time Was Stamped
+	ingressTime := latency.IngressTime(&sarama.ConsumerMessage{Headers: toHeaderPtrs(producerMessage.Headers)})
+	assert.WithinDuration(t, time.Now(), ingressTime, time.Minute)
+}
+
+// toHeaderPtrs converts the RecordHeaders of a ProducerMessage to those of a ConsumerMessage
+func toHeaderPtrs(headers []sarama.RecordHeader) []*sarama.RecordHeader {
+	headerPtrs := make([]*sarama.RecordHeader, len(headers))
+	for index := range headers {
+		headerPtrs[index] = &headers[index]
+	}
+	return headerPtrs
+}
+
+// Test The ProduceKafkaMessage() Functionality For Event Exceeding The Topic's Maximum Message Size
+func TestProduceKafkaMessageTooLarge(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	config := sarama.NewConfig()
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// Create A Mock Kafka SyncProducer
+	mockSyncProducer := producertesting.NewMockSyncProducer()
+
+	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
+	defer producertesting.RestoreNewSyncProducerFn()
+
+	// Stub The Payload Limiter's ClusterAdmin With A Topic Limit Too Small For The Message
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 10, nil, nil))
+
+	// Create Producer To Test
+	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{}, bindingMessage, nil)
+	tooLargeErr := &payload.MessageTooLargeError{}
+	assert.True(t, errors.As(err, &tooLargeErr))
+	assert.Equal(t, 10, tooLargeErr.Limit)
+}
+
+// Test The ProduceKafkaMessage() Functionality For Event Exceeding The Claim-Check Threshold
+func TestProduceKafkaMessageClaimCheck(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	config := sarama.NewConfig()
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	claimCheckStore := claimcheck.NewFilesystemStore(t.TempDir())
+
+	// Create A Mock Kafka SyncProducer
+	mockSyncProducer := producertesting.NewMockSyncProducer()
+
+	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
+	defer producertesting.RestoreNewSyncProducerFn()
+
+	// Stub The Payload Limiter's ClusterAdmin With A Topic Limit Large Enough For The Message
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 1000000, nil, nil))
+
+	// Create Producer To Test
+	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+	producer.claimCheckStore = claimCheckStore
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{ClaimCheckThreshold: 10}, bindingMessage, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Verify The Produced Message References The Offloaded Payload
+	producerMessage := mockSyncProducer.GetMessage()
+	assert.Equal(t, 0, producerMessage.Value.Length())
+	var claimCheckKey string
+	for _, header := range producerMessage.Headers {
+		if string(header.Key) == claimcheck.HeaderKey {
+			claimCheckKey = string(header.Value)
+		}
+	}
+	payload, err := claimCheckStore.Get(context.Background(), claimCheckKey)
+	assert.Nil(t, err)
+	assert.Equal(t, receivertesting.EventDataJson, payload)
+}
+
+// Test The ProduceKafkaMessage() Functionality For A KafkaChannel With Encryption Enabled
+func TestProduceKafkaMessageEncryption(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	config := sarama.NewConfig()
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	secretName := "test-encryption-keys"
+	keyProvider := encryption.NewSecretKeyProvider(fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: receivertesting.ChannelNamespace, Name: secretName},
+		Data:       map[string][]byte{"key-1": []byte("0123456789abcdef0123456789abcdef")},
+	}))
+
+	// Create A Mock Kafka SyncProducer
+	mockSyncProducer := producertesting.NewMockSyncProducer()
+
+	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
+	defer producertesting.RestoreNewSyncProducerFn()
+
+	// Stub The Payload Limiter's ClusterAdmin With A Topic Limit Large Enough For The Message
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 1000000, nil, nil))
+
+	// Create Producer To Test
+	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+	producer.keyProvider = keyProvider
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{EncryptionSecret: secretName}, bindingMessage, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Verify The Produced Message Has Readable CloudEvent Attributes But Encrypted Data
+	producerMessage := mockSyncProducer.GetMessage()
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyId, receivertesting.EventId)
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, encryption.SecretHeaderKey, secretName)
+	value, _ := producerMessage.Value.Encode()
+	assert.NotEqual(t, receivertesting.EventDataJson, value)
+
+	// Verify The Data Is Decrypted As The Original
+	consumerMessage := &sarama.ConsumerMessage{Value: value}
+	for i := range producerMessage.Headers {
+		consumerMessage.Headers = append(consumerMessage.Headers, &producerMessage.Headers[i])
+	}
+	assert.Nil(t, encryption.Decrypt(context.Background(), keyProvider, receivertesting.ChannelNamespace, secretName, consumerMessage))
+	assert.Equal(t, receivertesting.EventDataJson, consumerMessage.Value)
+}
+
+// Test The ProduceKafkaMessage() Functionality For A KafkaChannel With A Non-Default Durability Class
+func TestProduceKafkaMessageDurabilityClass(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	config := sarama.NewConfig()
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// Create The Mock Kafka SyncProducers Of The Default & Fast Durability Classes
+	mockSyncProducer := producertesting.NewMockSyncProducer()
+	mockFastSyncProducer := producertesting.NewMockSyncProducer()
+
+	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
+	defer producertesting.RestoreNewSyncProducerFn()
+
+	// Stub The Payload Limiter's ClusterAdmin With A Topic Limit Large Enough For The Message
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 1000000, nil, nil))
+
+	// Create Producer To Test & Stub The Creation Of The Fast Durability Class Producer
+	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+	producertesting.StubNewSyncProducerFn(func(_ []string, fastConfig *sarama.Config) (sarama.SyncProducer, error) {
+		assert.Equal(t, sarama.WaitForLocal, fastConfig.Producer.RequiredAcks)
+		assert.Equal(t, sarama.CompressionLZ4, fastConfig.Producer.Compression)
+		return mockFastSyncProducer, nil
+	})
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{DurabilityClass: v1beta1.DurabilityClassFast}, bindingMessage, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	producerMessage := mockFastSyncProducer.GetMessage()
+	assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
+
+	// Verify Both Producers Are Closed
+	producer.Close()
+	assert.True(t, mockSyncProducer.Closed())
+	assert.True(t, mockFastSyncProducer.Closed())
+}
+
+// Test The ProduceKafkaMessage() Functionality With The Async Batching Producer
+func TestProduceKafkaMessageAsync(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	config := sarama.NewConfig()
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// Stub The Batching Producer's AsyncProducer & The Payload Limiter's ClusterAdmin
+	var producedMessage *sarama.ProducerMessage
+	stubNewAsyncProducerFn(t, func(mockProducer *mocks.AsyncProducer) {
+		mockProducer.ExpectInputWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+			producedMessage = message
+			return nil
+		})
+	})
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, receivertesting.TopicName, 1000000, nil, nil))
+
+	// Create The Producer In Async Mode
+	logger := logtesting.TestLogger(t).Desugar()
+	healthServer := channelhealth.NewChannelHealthServer("12345")
+	producerConfig := commonconfig.EKReceiverProducerConfig{Async: true, MaxBatchMessages: 10}
+	producer, err := NewProducer(logger, config, brokers, metrics.NewStatsReporter(logger), healthServer, nil, nil, producerConfig)
+	assert.Nil(t, err)
+	assert.IsType(t, &batchingProducer{}, producer.kafkaProducer)
+	assert.Equal(t, producerConfig, producer.producerConfig)
+
+	// Perform The Test & Verify The Message Was Acknowledged Before Returning
+	err = producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.TopicName, produce.Options{}, bindingMessage, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, producedMessage)
+	assert.Equal(t, receivertesting.TopicName, producedMessage.Topic)
+	assert.NotNil(t, config.MetricRegistry.Get(ProduceLatencyMetricName))
+	producer.Close()
 }
 
 // Test The Producer's SecretChanged Functionality
@@ -252,7 +483,7 @@ func createTestProducer(t *testing.T, brokers []string, config *sarama.Config, s
 	statsReporter := metrics.NewStatsReporter(logger)
 
 	// Create The Producer
-	producer, err := NewProducer(logger, config, brokers, statsReporter, healthServer)
+	producer, err := NewProducer(logger, config, brokers, statsReporter, healthServer, nil, nil, commonconfig.EKReceiverProducerConfig{})
 
 	// Verify Expected State
 	assert.Nil(t, err)
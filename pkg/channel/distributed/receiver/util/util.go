@@ -17,11 +17,11 @@ limitations under the License.
 package util
 
 import (
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
-	eventingChannel "knative.dev/eventing/pkg/channel"
 )
 
-// Utility Function For Getting The Kafka Topic Name From The Specified ChannelReference
-func TopicName(channelReference eventingChannel.ChannelReference) string {
-	return commonkafkautil.TopicName(channelReference.Namespace, channelReference.Name)
+// Utility Function For Getting The Kafka Topic Name Of The Specified KafkaChannel
+func TopicName(kafkaChannel *kafkav1beta1.KafkaChannel) string {
+	return commonkafkautil.ChannelTopicName(kafkaChannel)
 }
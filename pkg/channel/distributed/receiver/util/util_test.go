@@ -20,7 +20,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"knative.dev/eventing/pkg/channel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 )
 
 // Test The TopicName() Functionality
@@ -30,16 +32,22 @@ func TestTopicName(t *testing.T) {
 	topicName := "TestTopicName"
 	topicNamespace := "TestTopicNamespace"
 
-	// The ChannelReference To Test
-	channelReference := channel.ChannelReference{
-		Name:      topicName,
-		Namespace: topicNamespace,
+	// The KafkaChannel To Test
+	kafkaChannel := &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      topicName,
+			Namespace: topicNamespace,
+		},
 	}
 
 	// Perform The Test
-	actualTopicName := TopicName(channelReference)
+	actualTopicName := TopicName(kafkaChannel)
 
 	// Validate The Results
-	expectedTopicName := channelReference.Namespace + "." + channelReference.Name
+	expectedTopicName := kafkaChannel.Namespace + "." + kafkaChannel.Name
 	assert.Equal(t, expectedTopicName, actualTopicName)
+
+	// Verify An Existing Topic Is Used As-Is
+	kafkaChannel.Spec.Topic = "existing-topic"
+	assert.Equal(t, "existing-topic", TopicName(kafkaChannel))
 }
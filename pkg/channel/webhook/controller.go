@@ -19,9 +19,12 @@ package webhook
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/resourcesemantics"
 	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
@@ -30,6 +33,8 @@ import (
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/messaging"
 	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
@@ -92,6 +97,35 @@ func NewValidationAdmissionController(ctx context.Context, _ configmap.Watcher)
 	)
 }
 
+func NewConfigValidationController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+	return configmaps.NewAdmissionController(ctx,
+		// Name of the configmap webhook.
+		"config.webhook.kafka.messaging.knative.dev",
+
+		// The path on which to serve the webhook.
+		"/config-validation",
+
+		// The configmaps to validate.
+		configmap.Constructors{
+			constants.SettingsConfigMapName: func(configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+				return validateSettingsConfigMap(ctx, configMap)
+			},
+		},
+	)
+}
+
+// validateSettingsConfigMap validates the config-kafka ConfigMap in the system namespace (ConfigMaps of the same
+// name in other namespaces are not used by eventing-kafka and are therefore accepted as-is).
+func validateSettingsConfigMap(ctx context.Context, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	if configMap.Namespace != system.Namespace() {
+		return configMap, nil
+	}
+	if err := kafkasarama.ValidateSettings(ctx, configMap.Data); err != nil {
+		return nil, err.ViaField("data")
+	}
+	return configMap, nil
+}
+
 func NewConversionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	var (
 		messagingv1beta1_ = messagingv1beta1.SchemeGroupVersion.Version
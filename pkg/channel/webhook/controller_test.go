@@ -17,11 +17,20 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing"
+
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
 )
 
 func TestDefaultTypeMap(t *testing.T) {
@@ -47,3 +56,35 @@ func TestIncludeResetOffset(t *testing.T) {
 	assert.NotNil(t, roTypeEntry)
 	assert.IsType(t, &kafkav1alpha1.ResetOffset{}, roTypeEntry)
 }
+
+func TestValidateSettingsConfigMap(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	invalidData := map[string]string{
+		constants.VersionConfigKey:        constants.CurrentConfigVersion,
+		constants.SaramaSettingsConfigKey: "config: |\n  Net:\n    SASL:\n      Enabled: true\n",
+	}
+
+	// Valid ConfigMap Is Accepted
+	validConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.SettingsConfigMapName, Namespace: system.Namespace()},
+		Data:       map[string]string{constants.VersionConfigKey: constants.CurrentConfigVersion},
+	}
+	configMap, err := validateSettingsConfigMap(ctx, validConfigMap)
+	assert.Nil(t, err)
+	assert.Equal(t, validConfigMap, configMap)
+
+	// Invalid ConfigMap Is Rejected With The Path Of The Offending Field
+	invalidConfigMap := validConfigMap.DeepCopy()
+	invalidConfigMap.Data = invalidData
+	configMap, err = validateSettingsConfigMap(ctx, invalidConfigMap)
+	assert.NotNil(t, err)
+	assert.Nil(t, configMap)
+	assert.Contains(t, err.Error(), "data.sarama.config")
+
+	// Invalid ConfigMap In Another Namespace Is Ignored
+	otherConfigMap := invalidConfigMap.DeepCopy()
+	otherConfigMap.Namespace = "other-namespace"
+	configMap, err = validateSettingsConfigMap(ctx, otherConfigMap)
+	assert.Nil(t, err)
+	assert.Equal(t, otherConfigMap, configMap)
+}
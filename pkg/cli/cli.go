@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/klog/v2"
+	"knative.dev/pkg/environment"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+)
+
+// The CLI Commands
+const (
+	DescribeCommand    = "describe"
+	TailCommand        = "tail"
+	ResetOffsetCommand = "reset-offset"
+)
+
+// DefaultSystemNamespace is the default namespace of the config-kafka ConfigMap.
+const DefaultSystemNamespace = "knative-eventing"
+
+// The usage of the CLI
+const usage = `kafka-eventing inspects the Kafka Topics & ConsumerGroups of Knative Kafka resources.
+
+Usage:
+  kafka-eventing describe [flags] <kind>/<name>       Show the topics, consumer groups, offsets and lag
+  kafka-eventing tail [flags] <kind>/<name>           Print the events of the topics as CloudEvents
+  kafka-eventing reset-offset [flags] <kind>/<name>   Create a ResetOffset for a subscription or trigger
+
+Kinds:
+  kafkachannel (kc), subscription (sub), trigger, kafkasource (ks)
+
+Use "kafka-eventing <command> -h" for the flags of a command.
+`
+
+// NewResolverFn is a function reference for creating the Resolver (stubbed in tests).
+var NewResolverFn = NewResolver
+
+// The options common to all commands
+type commonOptions struct {
+	clientConfig    environment.ClientConfig
+	namespace       string
+	systemNamespace string
+}
+
+// Run executes the CLI command specified by the arguments (excluding the program name) and returns the exit code.
+func Run(ctx context.Context, args []string, in io.Reader, out io.Writer, errOut io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		_, _ = fmt.Fprint(errOut, usage)
+		return 2
+	}
+	command := args[0]
+
+	// Parse The Common & Command-Specific Flags
+	flagSet := flag.NewFlagSet("kafka-eventing "+command, flag.ContinueOnError)
+	flagSet.SetOutput(errOut)
+	common := &commonOptions{}
+	common.clientConfig.InitFlags(flagSet)
+	flagSet.StringVar(&common.namespace, "n", "default", "The namespace of the resource (shorthand).")
+	flagSet.StringVar(&common.namespace, "namespace", "default", "The namespace of the resource.")
+	flagSet.StringVar(&common.systemNamespace, "system-namespace", systemNamespaceDefault(), "The namespace of the config-kafka ConfigMap.")
+	tailOptions := TailOptions{}
+	resetOffsetOptions := ResetOffsetOptions{}
+	switch command {
+	case DescribeCommand:
+	case TailCommand:
+		flagSet.BoolVar(&tailOptions.FromBeginning, "from-beginning", false, "Tail the events from the oldest offset of each partition.")
+		flagSet.IntVar(&tailOptions.Max, "max", 0, "The number of events after which to stop (0 for no limit).")
+	case ResetOffsetCommand:
+		flagSet.StringVar(&resetOffsetOptions.Time, "time", "", "The offset time (earliest, latest or an RFC3339 time), prompted for if empty.")
+		flagSet.BoolVar(&resetOffsetOptions.Yes, "yes", false, "Create the ResetOffset without confirmation.")
+	default:
+		_, _ = fmt.Fprintf(errOut, "Unknown command %q\n\n%s", command, usage)
+		return 2
+	}
+	// Parse Repeatedly In Order To Support Flags After The <kind>/<name> Argument (As With kubectl)
+	var positionalArgs []string
+	remainingArgs := args[1:]
+	for {
+		if err := flagSet.Parse(remainingArgs); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			return 2
+		}
+		if flagSet.NArg() == 0 {
+			break
+		}
+		positionalArgs = append(positionalArgs, flagSet.Arg(0))
+		remainingArgs = flagSet.Args()[1:]
+	}
+	if len(positionalArgs) != 1 {
+		_, _ = fmt.Fprintf(errOut, "Expected a single <kind>/<name> argument\n\n%s", usage)
+		return 2
+	}
+	target, err := ParseTarget(positionalArgs[0], common.namespace)
+	if err != nil {
+		_, _ = fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	if err = run(ctx, command, target, common, tailOptions, resetOffsetOptions, in, out); err != nil {
+		_, _ = fmt.Fprintf(errOut, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// Execute The Parsed Command Against The Target
+func run(ctx context.Context,
+	command string,
+	target Target,
+	common *commonOptions,
+	tailOptions TailOptions,
+	resetOffsetOptions ResetOffsetOptions,
+	in io.Reader,
+	out io.Writer) error {
+
+	// The config-kafka ConfigMap & The RefMappers' Data-Plane Namespace Are Relative To The System Namespace
+	if err := os.Setenv(system.NamespaceEnvKey, common.systemNamespace); err != nil {
+		return err
+	}
+
+	// Silence The Logging Of The Injected Informers & RefMappers
+	ctx = logging.WithLogger(ctx, zap.NewNop().Sugar())
+	klog.LogToStderr(false)
+	klog.SetOutput(io.Discard)
+
+	restConfig, err := common.clientConfig.GetRESTConfig()
+	if err != nil {
+		return err
+	}
+	ctx, resolver, err := NewResolverFn(ctx, restConfig, target.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if command == ResetOffsetCommand {
+		_, err = resolver.ResetOffset(ctx, in, out, target, resetOffsetOptions)
+		return err
+	}
+
+	resolution, err := resolver.Resolve(ctx, target)
+	if err != nil {
+		return err
+	}
+	client, err := resolver.NewKafkaClient(ctx, resolution)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	defer client.Close()
+
+	if command == TailCommand {
+		tailOptions.KeyProvider = encryption.NewSecretKeyProvider(resolver.kubeClient)
+		resolver.resolveEncryptionSecret(ctx, resolution)
+		return Tail(ctx, out, client, resolution, tailOptions)
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka admin client: %w", err)
+	}
+	return Describe(out, resolution, client, admin)
+}
+
+// Default The System Namespace To The SYSTEM_NAMESPACE Environment Variable If Set
+func systemNamespaceDefault() string {
+	if systemNamespace := os.Getenv(system.NamespaceEnvKey); systemNamespace != "" {
+		return systemNamespace
+	}
+	return DefaultSystemNamespace
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/system"
+
+	kafkafake "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+)
+
+// Test The Run() Functionality For Invalid Usage
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "No Arguments", args: []string{}, wantCode: 2, wantErr: "Usage:"},
+		{name: "Help", args: []string{"help"}, wantCode: 2, wantErr: "Usage:"},
+		{name: "Unknown Command", args: []string{"delete", "kc/my-channel"}, wantCode: 2, wantErr: `Unknown command "delete"`},
+		{name: "Missing Target", args: []string{"describe"}, wantCode: 2, wantErr: "Expected a single <kind>/<name> argument"},
+		{name: "Multiple Targets", args: []string{"describe", "kc/a", "kc/b"}, wantCode: 2, wantErr: "Expected a single <kind>/<name> argument"},
+		{name: "Invalid Target", args: []string{"tail", "pod/my-pod"}, wantCode: 2, wantErr: `unsupported kind "pod"`},
+		{name: "Unknown Flag", args: []string{"describe", "-time", "latest", "kc/my-channel"}, wantCode: 2, wantErr: "flag provided but not defined: -time"},
+		{name: "Command Help", args: []string{"reset-offset", "-h"}, wantCode: 0, wantErr: "-yes"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+			code := Run(context.TODO(), test.args, strings.NewReader(""), out, errOut)
+			assert.Equal(t, test.wantCode, code)
+			assert.Contains(t, errOut.String(), test.wantErr)
+		})
+	}
+}
+
+// Test The Run() Functionality For The reset-offset Command (With Flags Following The Target)
+func TestRunResetOffset(t *testing.T) {
+	t.Setenv(system.NamespaceEnvKey, "my-system-ns")
+
+	kafkaClient := kafkafake.NewSimpleClientset()
+	var resolverNamespace string
+	defer restoreNewResolverFn(NewResolverFn)
+	NewResolverFn = func(ctx context.Context, restConfig *rest.Config, namespace string) (context.Context, *Resolver, error) {
+		resolverNamespace = namespace
+		return ctx, &Resolver{
+			kafkaClient:        kafkaClient,
+			subscriptionMapper: newMockRefMapper(subscriptionName, topicName, groupId),
+		}, nil
+	}
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"reset-offset", "-server", "https://127.0.0.1:6443", "sub/my-subscription", "-n", namespace, "-time", "earliest"}
+	code := Run(context.TODO(), args, strings.NewReader("y\n"), out, errOut)
+
+	assert.Equal(t, 0, code, errOut.String())
+	assert.Equal(t, namespace, resolverNamespace)
+	assert.Contains(t, out.String(), "Created ResetOffset my-ns/")
+	resetOffsets, err := kafkaClient.KafkaV1alpha1().ResetOffsets(namespace).List(context.TODO(), metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, resetOffsets.Items, 1)
+	assert.Equal(t, "earliest", resetOffsets.Items[0].Spec.Offset.Time)
+	assert.Equal(t, "my-system-ns", system.Namespace())
+}
+
+// Restore The NewResolverFn Function Reference
+func restoreNewResolverFn(original func(context.Context, *rest.Config, string) (context.Context, *Resolver, error)) {
+	NewResolverFn = original
+}
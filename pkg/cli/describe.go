@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// Describe writes the Topics, ConsumerGroups (with their per-partition offsets & lag) and any KafkaSource claims
+// of the Resolution.  The end offsets are written on their own if the Resolution has no ConsumerGroups (e.g. a
+// KafkaChannel without Subscriptions).
+func Describe(out io.Writer, resolution *Resolution, client sarama.Client, admin sarama.ClusterAdmin) error {
+	_, _ = fmt.Fprintf(out, "Resource: %s\n", resolution.Target)
+	_, _ = fmt.Fprintf(out, "Topics:   %s\n", strings.Join(resolution.Topics, ", "))
+	if resolution.source != nil {
+		claims := resolution.Claims
+		if claims == "" {
+			claims = "<none>"
+		}
+		_, _ = fmt.Fprintf(out, "Claims:   %s\n", claims)
+	}
+
+	if len(resolution.Groups) == 0 {
+		_, _ = fmt.Fprintln(out, "\nConsumer Groups: <none>")
+		lags, err := GetPartitionLags(client, admin, resolution.Topics, "")
+		if err != nil {
+			return err
+		}
+		return WritePartitionLags(out, lags)
+	}
+
+	for _, group := range resolution.Groups {
+		_, _ = fmt.Fprintf(out, "\nConsumer Group: %s (%s)\n", group.Id, group.Owner)
+		lags, err := GetPartitionLags(client, admin, resolution.Topics, group.Id)
+		if err != nil {
+			return err
+		}
+		if err = WritePartitionLags(out, lags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Shopify/sarama"
+
+	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+)
+
+// PartitionLag is the committed offset of a ConsumerGroup and the end offset of a single Topic partition.  A
+// negative Committed offset indicates that the ConsumerGroup has not committed an offset for the partition.
+type PartitionLag struct {
+	Topic     string
+	Partition int32
+	Committed int64
+	End       int64
+}
+
+// Lag returns the number of records in the partition which the ConsumerGroup has not yet consumed, or -1 if the
+// ConsumerGroup has not committed an offset for the partition.
+func (p PartitionLag) Lag() int64 {
+	if p.Committed < 0 {
+		return -1
+	}
+	if p.End < p.Committed {
+		return 0
+	}
+	return p.End - p.Committed
+}
+
+// GetPartitionLags returns the PartitionLags of the specified ConsumerGroup for all partitions of the specified
+// Topics.  An empty groupId results in the end offsets only (with no committed offsets).
+func GetPartitionLags(client sarama.Client, admin sarama.ClusterAdmin, topics []string, groupId string) ([]PartitionLag, error) {
+	topicPartitions := make(map[string][]int32, len(topics))
+	for _, topic := range topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+		}
+		topicPartitions[topic] = partitions
+	}
+
+	endOffsets, err := kafkasarama.GetOffsets(client, topicPartitions, sarama.OffsetNewest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the end offsets of topics %v: %w", topics, err)
+	}
+
+	var committedOffsets *sarama.OffsetFetchResponse
+	if groupId != "" {
+		committedOffsets, err = admin.ListConsumerGroupOffsets(groupId, topicPartitions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the committed offsets of consumer group %s: %w", groupId, err)
+		}
+	}
+
+	return newPartitionLags(topicPartitions, endOffsets, committedOffsets), nil
+}
+
+// Combine The End & (Optional) Committed Offsets Into PartitionLags Sorted By Topic & Partition
+func newPartitionLags(topicPartitions map[string][]int32, endOffsets map[string]map[int32]int64, committedOffsets *sarama.OffsetFetchResponse) []PartitionLag {
+	lags := make([]PartitionLag, 0)
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			lag := PartitionLag{Topic: topic, Partition: partition, Committed: -1, End: endOffsets[topic][partition]}
+			if committedOffsets != nil {
+				if block := committedOffsets.GetBlock(topic, partition); block != nil && block.Err == sarama.ErrNoError {
+					lag.Committed = block.Offset
+				}
+			}
+			lags = append(lags, lag)
+		}
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].Topic != lags[j].Topic {
+			return lags[i].Topic < lags[j].Topic
+		}
+		return lags[i].Partition < lags[j].Partition
+	})
+	return lags
+}
+
+// WritePartitionLags writes the PartitionLags as a table followed by the total lag (of the partitions with
+// committed offsets).
+func WritePartitionLags(out io.Writer, lags []PartitionLag) error {
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "TOPIC\tPARTITION\tCOMMITTED\tEND\tLAG")
+	var total int64
+	for _, lag := range lags {
+		committed, lagString := "-", "-"
+		if lag.Committed >= 0 {
+			committed = strconv.FormatInt(lag.Committed, 10)
+			lagString = strconv.FormatInt(lag.Lag(), 10)
+			total += lag.Lag()
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%s\t%d\t%s\n", lag.Topic, lag.Partition, committed, lag.End, lagString)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "Total Lag: %d\n", total)
+	return err
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The PartitionLag Lag() Functionality
+func TestPartitionLagLag(t *testing.T) {
+	assert.Equal(t, int64(7), PartitionLag{Committed: 3, End: 10}.Lag())
+	assert.Equal(t, int64(0), PartitionLag{Committed: 10, End: 10}.Lag())
+	assert.Equal(t, int64(0), PartitionLag{Committed: 12, End: 10}.Lag())
+	assert.Equal(t, int64(-1), PartitionLag{Committed: -1, End: 10}.Lag())
+}
+
+// Test The newPartitionLags() Functionality
+func TestNewPartitionLags(t *testing.T) {
+	topicPartitions := map[string][]int32{"topic-b": {0}, "topic-a": {1, 0}}
+	endOffsets := map[string]map[int32]int64{"topic-a": {0: 10, 1: 20}, "topic-b": {0: 5}}
+
+	// Without Committed Offsets
+	lags := newPartitionLags(topicPartitions, endOffsets, nil)
+	assert.Equal(t, []PartitionLag{
+		{Topic: "topic-a", Partition: 0, Committed: -1, End: 10},
+		{Topic: "topic-a", Partition: 1, Committed: -1, End: 20},
+		{Topic: "topic-b", Partition: 0, Committed: -1, End: 5},
+	}, lags)
+
+	// With Committed Offsets (Including A Partition Without A Committed Offset & One With An Error)
+	committedOffsets := &sarama.OffsetFetchResponse{}
+	committedOffsets.AddBlock("topic-a", 0, &sarama.OffsetFetchResponseBlock{Offset: 4})
+	committedOffsets.AddBlock("topic-a", 1, &sarama.OffsetFetchResponseBlock{Offset: 15, Err: sarama.ErrUnknownTopicOrPartition})
+	lags = newPartitionLags(topicPartitions, endOffsets, committedOffsets)
+	assert.Equal(t, []PartitionLag{
+		{Topic: "topic-a", Partition: 0, Committed: 4, End: 10},
+		{Topic: "topic-a", Partition: 1, Committed: -1, End: 20},
+		{Topic: "topic-b", Partition: 0, Committed: -1, End: 5},
+	}, lags)
+}
+
+// Test The WritePartitionLags() Functionality
+func TestWritePartitionLags(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := WritePartitionLags(out, []PartitionLag{
+		{Topic: "my-topic", Partition: 0, Committed: 4, End: 10},
+		{Topic: "my-topic", Partition: 1, Committed: 20, End: 22},
+		{Topic: "my-topic", Partition: 2, Committed: -1, End: 7},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ""+
+		"TOPIC     PARTITION  COMMITTED  END  LAG\n"+
+		"my-topic  0          4          10   6\n"+
+		"my-topic  1          20         22   2\n"+
+		"my-topic  2          -          7    -\n"+
+		"Total Lag: 8\n", out.String())
+}
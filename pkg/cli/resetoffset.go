@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// ResetOffsetOptions configures the creation of a ResetOffset.
+type ResetOffsetOptions struct {
+	// Time is the offset time ("earliest", "latest" or an RFC3339 time), prompted for if empty.
+	Time string
+
+	// Yes skips the confirmation prompt.
+	Yes bool
+}
+
+// NewResetOffset returns a ResetOffset (with a generated name) resetting the offsets of the specified
+// Subscription or Trigger Target to the specified time.
+func NewResetOffset(target Target, offsetTime string) (*kafkav1alpha1.ResetOffset, error) {
+	ref, err := target.KReference()
+	if err != nil {
+		return nil, err
+	}
+	return &kafkav1alpha1.ResetOffset{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    target.Namespace,
+			GenerateName: target.Name + "-",
+		},
+		Spec: kafkav1alpha1.ResetOffsetSpec{
+			Offset: kafkav1alpha1.OffsetSpec{Time: offsetTime},
+			Ref:    ref,
+		},
+	}, nil
+}
+
+// ResetOffset interactively creates a ResetOffset for the specified Subscription or Trigger Target, after
+// writing the Topic & ConsumerGroup which will be reset.  The offset time is prompted for if not specified, and
+// the creation is confirmed unless the Yes option is set.  A nil ResetOffset is returned if the creation is not
+// confirmed.
+func (r *Resolver) ResetOffset(ctx context.Context, in io.Reader, out io.Writer, target Target, options ResetOffsetOptions) (*kafkav1alpha1.ResetOffset, error) {
+	if _, err := target.KReference(); err != nil {
+		return nil, err
+	}
+	resolution, err := r.Resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(out, "Resource:       %s\n", target)
+	_, _ = fmt.Fprintf(out, "Topic:          %s\n", strings.Join(resolution.Topics, ", "))
+	_, _ = fmt.Fprintf(out, "Consumer Group: %s\n", resolution.Groups[0].Id)
+
+	reader := bufio.NewReader(in)
+	offsetTime := options.Time
+	if offsetTime == "" {
+		offsetTime, err = prompt(reader, out, "Reset offsets to (earliest, latest or an RFC3339 time): ")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resetOffset, err := NewResetOffset(target, offsetTime)
+	if err != nil {
+		return nil, err
+	}
+	if fieldErr := resetOffset.Validate(ctx); fieldErr != nil {
+		return nil, fmt.Errorf("invalid ResetOffset: %w", fieldErr)
+	}
+
+	if !options.Yes {
+		answer, err := prompt(reader, out, fmt.Sprintf("Create a ResetOffset resetting consumer group %s to %q? [y/N]: ", resolution.Groups[0].Id, offsetTime))
+		if err != nil {
+			return nil, err
+		}
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			_, _ = fmt.Fprintln(out, "Aborted")
+			return nil, nil
+		}
+	}
+
+	resetOffset, err = r.kafkaClient.KafkaV1alpha1().ResetOffsets(target.Namespace).Create(ctx, resetOffset, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ResetOffset: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "Created ResetOffset %s/%s\n", resetOffset.Namespace, resetOffset.Name)
+	return resetOffset, nil
+}
+
+// Write The Prompt And Return The Trimmed Line Read In Response
+func prompt(reader *bufio.Reader, out io.Writer, message string) (string, error) {
+	_, _ = fmt.Fprint(out, message)
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
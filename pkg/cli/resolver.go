@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/system"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	brokerutil "knative.dev/eventing-kafka/pkg/broker/util"
+	controllerutil "knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	sourceclient "knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// ClientId is the Kafka ClientId used by the CLI.
+const ClientId = "kafka-eventing-cli"
+
+// InformerSyncTimeout is the maximum duration to wait for the informers required by the RefMappers to sync.
+const InformerSyncTimeout = 30 * time.Second
+
+// ConsumerGroup is a Kafka ConsumerGroup and the resource (e.g. "Subscription/my-subscription") owning it.
+type ConsumerGroup struct {
+	Owner string
+	Id    string
+}
+
+// Resolution is the Kafka Topics and ConsumerGroups of a Target.
+type Resolution struct {
+	Target Target
+	Topics []string
+	Groups []ConsumerGroup
+
+	// Claims is the status.claims of a KafkaSource Target.
+	Claims string
+
+	// EncryptionNamespace & EncryptionSecret identify the encryption Secret named by the spec of the KafkaChannel
+	// Target (or the KafkaChannel of a Subscription Target), and are "" if the Target's events are never encrypted.
+	EncryptionNamespace string
+	EncryptionSecret    string
+
+	// The KafkaSource Target, whose spec (rather than the config-kafka ConfigMap) configures the Kafka client
+	source *sourcesv1beta1.KafkaSource
+}
+
+// Resolver resolves Targets to their Kafka Topics & ConsumerGroups, using the same ResetOffset RefMappers (and
+// thus Topic / Group naming) as the distributed KafkaChannel and Kafka Broker controllers.
+type Resolver struct {
+	kubeClient         kubernetes.Interface
+	kafkaClient        kafkaclientset.Interface
+	eventingClient     eventingclientset.Interface
+	subscriptionMapper refmappers.ResetOffsetRefMapper
+	triggerMapper      refmappers.ResetOffsetRefMapper
+}
+
+// NewResolver sets up the injected clients & informers (scoped to the specified namespace) required by the
+// RefMappers, waits for the informers to sync, and returns the injected Context along with a new Resolver.
+func NewResolver(ctx context.Context, restConfig *rest.Config, namespace string) (context.Context, *Resolver, error) {
+	ctx = injection.WithNamespaceScope(ctx, namespace)
+	ctx, informers := injection.Default.SetupInformers(ctx, restConfig)
+
+	// Start The Informers, Failing Rather Than Waiting Indefinitely If They Can't Sync (e.g. Unreachable Cluster)
+	hasSynced := make([]cache.InformerSynced, 0, len(informers))
+	for _, informer := range informers {
+		go informer.Run(ctx.Done())
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, InformerSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), hasSynced...) {
+		return ctx, nil, fmt.Errorf("failed to sync informers within %v (check the cluster connection and permissions)", InformerSyncTimeout)
+	}
+	return ctx, &Resolver{
+		kubeClient:         kubeclient.Get(ctx),
+		kafkaClient:        kafkaclient.Get(ctx),
+		eventingClient:     eventingclient.Get(ctx),
+		subscriptionMapper: controllerutil.NewRefMapperFactory().Create(ctx),
+		triggerMapper:      brokerutil.NewRefMapperFactory().Create(ctx),
+	}, nil
+}
+
+// Resolve returns the Kafka Topics & ConsumerGroups of the specified Target.
+func (r *Resolver) Resolve(ctx context.Context, target Target) (*Resolution, error) {
+	switch target.Kind {
+	case SubscriptionKind:
+		// The Events Of The (Same Namespace) KafkaChannel Of A Subscription May Be Encrypted
+		return r.resolveRef(target, r.subscriptionMapper, target.Namespace)
+	case TriggerKind:
+		return r.resolveRef(target, r.triggerMapper, "")
+	case KafkaChannelKind:
+		return r.resolveKafkaChannel(ctx, target)
+	case KafkaSourceKind:
+		return r.resolveKafkaSource(ctx, target)
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", target.Kind)
+	}
+}
+
+// Resolve A Subscription Or Trigger Via The RefMapper Used By The ResetOffset Controller
+func (r *Resolver) resolveRef(target Target, mapper refmappers.ResetOffsetRefMapper, encryptionNamespace string) (*Resolution, error) {
+	refInfo, err := r.mapRef(target, mapper)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolution{
+		Target:              target,
+		Topics:              []string{refInfo.TopicName},
+		Groups:              []ConsumerGroup{{Owner: fmt.Sprintf("%s/%s", target.Kind, target.Name), Id: refInfo.GroupId}},
+		EncryptionNamespace: encryptionNamespace,
+	}, nil
+}
+
+// Map The Target To Its Topic / Group Exactly As A ResetOffset Referencing It Would Be
+func (r *Resolver) mapRef(target Target, mapper refmappers.ResetOffsetRefMapper) (*refmappers.RefInfo, error) {
+	ref, err := target.KReference()
+	if err != nil {
+		return nil, err
+	}
+	resetOffset := &kafkav1alpha1.ResetOffset{
+		ObjectMeta: metav1.ObjectMeta{Namespace: target.Namespace},
+		Spec:       kafkav1alpha1.ResetOffsetSpec{Ref: ref},
+	}
+	return mapper.MapRef(resetOffset)
+}
+
+// Resolve A KafkaChannel To Its Topic And The ConsumerGroups Of Its Subscriptions
+func (r *Resolver) resolveKafkaChannel(ctx context.Context, target Target) (*Resolution, error) {
+	channel, err := r.kafkaClient.MessagingV1beta1().KafkaChannels(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KafkaChannel %s/%s: %w", target.Namespace, target.Name, err)
+	}
+
+	subscriptions, err := r.eventingClient.MessagingV1().Subscriptions(target.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Subscriptions in namespace %s: %w", target.Namespace, err)
+	}
+
+	channelName := types.NamespacedName{Namespace: channel.Namespace, Name: channel.Name}
+	groups := make([]ConsumerGroup, 0, len(subscriptions.Items))
+	for i := range subscriptions.Items {
+		subscription := &subscriptions.Items[i]
+		if subscriptionChannel, ok := filter.SubscriptionChannel(subscription); !ok || subscriptionChannel != channelName {
+			continue
+		}
+		subscriptionTarget := Target{Kind: SubscriptionKind, Namespace: subscription.Namespace, Name: subscription.Name}
+		refInfo, err := r.mapRef(subscriptionTarget, r.subscriptionMapper)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, ConsumerGroup{Owner: fmt.Sprintf("%s/%s", SubscriptionKind, subscription.Name), Id: refInfo.GroupId})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Owner < groups[j].Owner })
+
+	return &Resolution{
+		Target:              target,
+		Topics:              []string{controllerutil.TopicName(channel)},
+		Groups:              groups,
+		EncryptionNamespace: channel.Namespace,
+		EncryptionSecret:    encryption.SecretName(channel),
+	}, nil
+}
+
+// Resolve The Encryption Secret Of A Subscription Target From The Spec Of Its KafkaChannel (Only Needed To Decrypt
+// Its Events, So Left Empty If It Can't Be Determined)
+func (r *Resolver) resolveEncryptionSecret(ctx context.Context, resolution *Resolution) {
+	if resolution.Target.Kind != SubscriptionKind || resolution.EncryptionSecret != "" {
+		return
+	}
+	resolution.EncryptionSecret = r.subscriptionEncryptionSecret(ctx, resolution.Target)
+}
+
+// Get The Encryption Secret Named By The Spec Of A Subscription's KafkaChannel ("" If It Can't Be Determined)
+func (r *Resolver) subscriptionEncryptionSecret(ctx context.Context, target Target) string {
+	subscription, err := r.eventingClient.MessagingV1().Subscriptions(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	channelName, ok := filter.SubscriptionChannel(subscription)
+	if !ok {
+		return ""
+	}
+	channel, err := r.kafkaClient.MessagingV1beta1().KafkaChannels(channelName.Namespace).Get(ctx, channelName.Name, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	return encryption.SecretName(channel)
+}
+
+// Resolve A KafkaSource To Its Topics, ConsumerGroup And Claims
+func (r *Resolver) resolveKafkaSource(ctx context.Context, target Target) (*Resolution, error) {
+	source, err := r.kafkaClient.SourcesV1beta1().KafkaSources(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KafkaSource %s/%s: %w", target.Namespace, target.Name, err)
+	}
+	return &Resolution{
+		Target: target,
+		Topics: source.Spec.Topics,
+		Groups: []ConsumerGroup{{Owner: fmt.Sprintf("%s/%s", KafkaSourceKind, source.Name), Id: source.Spec.ConsumerGroup}},
+		Claims: source.Status.Claims,
+		source: source,
+	}, nil
+}
+
+// NewKafkaClient returns a Kafka client for the cluster of the Resolution's Topics, configured by the spec of a
+// KafkaSource or else by the config-kafka ConfigMap in the system namespace (shared by KafkaChannels & Brokers).
+// The Context must have an injected Kubernetes client (for loading the config-kafka authentication Secret).
+func (r *Resolver) NewKafkaClient(ctx context.Context, resolution *Resolution) (sarama.Client, error) {
+	var brokers []string
+	var config *sarama.Config
+	if resolution.source != nil {
+		var err error
+		brokers, config, err = sourceclient.NewConfigFromSpec(ctx, r.kubeClient, resolution.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the Kafka configuration of the KafkaSource: %w", err)
+		}
+		config.ClientID = ClientId
+	} else {
+		configMap, err := r.kubeClient.CoreV1().ConfigMaps(system.Namespace()).Get(ctx, constants.SettingsConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", system.Namespace(), constants.SettingsConfigMapName, err)
+		}
+		ekConfig, err := kafkasarama.LoadSettings(ctx, ClientId, configMap.Data, kafkasarama.LoadAuthConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the Kafka configuration from ConfigMap %s: %w", constants.SettingsConfigMapName, err)
+		}
+		brokers = strings.Split(ekConfig.Kafka.Brokers, ",")
+		config = ekConfig.Sarama.Config
+	}
+	return sarama.NewClient(brokers, config)
+}
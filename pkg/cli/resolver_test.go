@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	eventingfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkafake "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
+)
+
+// Test Data
+const (
+	namespace        = "my-ns"
+	channelName      = "my-channel"
+	subscriptionName = "my-subscription"
+	triggerName      = "my-trigger"
+	sourceName       = "my-source"
+	topicName        = "my-ns.my-channel"
+	groupId          = "kafka.my-uid"
+)
+
+// Returns A Mock RefMapper Mapping ResetOffsets Referencing The Specified Name To The Specified Topic / Group
+func newMockRefMapper(refName string, topic string, group string) *refmapperstesting.MockResetOffsetRefMapper {
+	mapper := &refmapperstesting.MockResetOffsetRefMapper{}
+	mapper.On("MapRef", mock.MatchedBy(func(resetOffset *kafkav1alpha1.ResetOffset) bool {
+		return resetOffset.Spec.Ref.Name == refName
+	})).Return(&refmappers.RefInfo{TopicName: topic, GroupId: group}, nil)
+	return mapper
+}
+
+// Returns A Subscription To The Specified Channel
+func newSubscription(name string, channel string) *messagingv1.Subscription {
+	return &messagingv1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: messagingv1.SubscriptionSpec{
+			Channel: duckv1.KReference{APIVersion: kafkav1beta1.SchemeGroupVersion.String(), Kind: "KafkaChannel", Name: channel},
+		},
+	}
+}
+
+// Test The Resolver Resolve() Functionality For Subscriptions & Triggers
+func TestResolveRef(t *testing.T) {
+	channel := &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: channelName},
+		Spec:       kafkav1beta1.KafkaChannelSpec{Encryption: &kafkav1beta1.KafkaChannelEncryptionSpec{SecretName: "my-keys"}},
+	}
+	resolver := &Resolver{
+		kafkaClient:        kafkafake.NewSimpleClientset(channel),
+		eventingClient:     eventingfake.NewSimpleClientset(newSubscription(subscriptionName, channelName)),
+		subscriptionMapper: newMockRefMapper(subscriptionName, topicName, groupId),
+		triggerMapper:      newMockRefMapper(triggerName, "knative-broker-my-ns-my-broker", "kafka.my-trigger-uid"),
+	}
+
+	resolution, err := resolver.Resolve(context.TODO(), Target{Kind: SubscriptionKind, Namespace: namespace, Name: subscriptionName})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{topicName}, resolution.Topics)
+	assert.Equal(t, []ConsumerGroup{{Owner: "Subscription/my-subscription", Id: groupId}}, resolution.Groups)
+	assert.Equal(t, namespace, resolution.EncryptionNamespace)
+	assert.Equal(t, "", resolution.EncryptionSecret)
+	resolver.resolveEncryptionSecret(context.TODO(), resolution)
+	assert.Equal(t, "my-keys", resolution.EncryptionSecret)
+
+	resolution, err = resolver.Resolve(context.TODO(), Target{Kind: TriggerKind, Namespace: namespace, Name: triggerName})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"knative-broker-my-ns-my-broker"}, resolution.Topics)
+	assert.Equal(t, []ConsumerGroup{{Owner: "Trigger/my-trigger", Id: "kafka.my-trigger-uid"}}, resolution.Groups)
+	assert.Equal(t, "", resolution.EncryptionNamespace)
+}
+
+// Test The Resolver Resolve() Functionality For KafkaChannels
+func TestResolveKafkaChannel(t *testing.T) {
+	channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: channelName}}
+	subscriptionMapper := &refmapperstesting.MockResetOffsetRefMapper{}
+	for _, name := range []string{"sub-b", "sub-a"} {
+		name := name
+		subscriptionMapper.On("MapRef", mock.MatchedBy(func(resetOffset *kafkav1alpha1.ResetOffset) bool {
+			return resetOffset.Spec.Ref.Name == name
+		})).Return(&refmappers.RefInfo{TopicName: topicName, GroupId: "kafka." + name}, nil)
+	}
+	resolver := &Resolver{
+		kafkaClient: kafkafake.NewSimpleClientset(channel),
+		eventingClient: eventingfake.NewSimpleClientset(
+			newSubscription("sub-b", channelName),
+			newSubscription("sub-a", channelName),
+			newSubscription("sub-other", "other-channel")),
+		subscriptionMapper: subscriptionMapper,
+	}
+
+	resolution, err := resolver.Resolve(context.TODO(), Target{Kind: KafkaChannelKind, Namespace: namespace, Name: channelName})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{topicName}, resolution.Topics)
+	assert.Equal(t, []ConsumerGroup{{Owner: "Subscription/sub-a", Id: "kafka.sub-a"}, {Owner: "Subscription/sub-b", Id: "kafka.sub-b"}}, resolution.Groups)
+	assert.Equal(t, namespace, resolution.EncryptionNamespace)
+	subscriptionMapper.AssertNumberOfCalls(t, "MapRef", 2)
+
+	_, err = resolver.Resolve(context.TODO(), Target{Kind: KafkaChannelKind, Namespace: namespace, Name: "missing-channel"})
+	assert.NotNil(t, err)
+}
+
+// Test The Resolver Resolve() Functionality For KafkaSources
+func TestResolveKafkaSource(t *testing.T) {
+	source := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: sourceName},
+		Spec:       sourcesv1beta1.KafkaSourceSpec{Topics: []string{"topic-1", "topic-2"}, ConsumerGroup: "my-group"},
+		Status:     sourcesv1beta1.KafkaSourceStatus{Claims: `{"topic-1":[0]}`},
+	}
+	resolver := &Resolver{kafkaClient: kafkafake.NewSimpleClientset(source)}
+
+	resolution, err := resolver.Resolve(context.TODO(), Target{Kind: KafkaSourceKind, Namespace: namespace, Name: sourceName})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"topic-1", "topic-2"}, resolution.Topics)
+	assert.Equal(t, []ConsumerGroup{{Owner: "KafkaSource/my-source", Id: "my-group"}}, resolution.Groups)
+	assert.Equal(t, `{"topic-1":[0]}`, resolution.Claims)
+	assert.Equal(t, source, resolution.source)
+}
+
+// Test The Resolver ResetOffset() Functionality
+func TestResetOffset(t *testing.T) {
+	subscriptionTarget := Target{Kind: SubscriptionKind, Namespace: namespace, Name: subscriptionName}
+	tests := []struct {
+		name        string
+		target      Target
+		options     ResetOffsetOptions
+		input       string
+		wantCreated bool
+		wantTime    string
+		wantErr     bool
+	}{
+		{name: "Prompted Time & Confirmed", target: subscriptionTarget, input: "earliest\ny\n", wantCreated: true, wantTime: "earliest"},
+		{name: "Specified Time & Confirmed", target: subscriptionTarget, options: ResetOffsetOptions{Time: "2022-01-02T03:04:05Z"}, input: "yes\n", wantCreated: true, wantTime: "2022-01-02T03:04:05Z"},
+		{name: "Specified Time Without Confirmation", target: subscriptionTarget, options: ResetOffsetOptions{Time: "latest", Yes: true}, wantCreated: true, wantTime: "latest"},
+		{name: "Not Confirmed", target: subscriptionTarget, input: "latest\nn\n"},
+		{name: "Invalid Time", target: subscriptionTarget, input: "yesterday\n", wantErr: true},
+		{name: "No Input", target: subscriptionTarget, wantErr: true},
+		{name: "KafkaChannel Target", target: Target{Kind: KafkaChannelKind, Namespace: namespace, Name: channelName}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kafkaClient := kafkafake.NewSimpleClientset()
+			resolver := &Resolver{
+				kafkaClient:        kafkaClient,
+				subscriptionMapper: newMockRefMapper(subscriptionName, topicName, groupId),
+			}
+			out := &bytes.Buffer{}
+
+			resetOffset, err := resolver.ResetOffset(context.TODO(), strings.NewReader(test.input), out, test.target, test.options)
+
+			assert.Equal(t, test.wantErr, err != nil)
+			resetOffsets, listErr := kafkaClient.KafkaV1alpha1().ResetOffsets(namespace).List(context.TODO(), metav1.ListOptions{})
+			assert.Nil(t, listErr)
+			if test.wantCreated {
+				assert.NotNil(t, resetOffset)
+				assert.Len(t, resetOffsets.Items, 1)
+				assert.Equal(t, "my-subscription-", resetOffset.GenerateName)
+				assert.Equal(t, test.wantTime, resetOffset.Spec.Offset.Time)
+				assert.Equal(t, subscriptionName, resetOffset.Spec.Ref.Name)
+				assert.Contains(t, out.String(), "Consumer Group: "+groupId)
+			} else {
+				assert.Nil(t, resetOffset)
+				assert.Len(t, resetOffsets.Items, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+)
+
+// TailOptions configures the tailing of the events of a Resolution's Topics.
+type TailOptions struct {
+	// FromBeginning tails the events from the oldest (rather than the newest) offset of each partition.
+	FromBeginning bool
+
+	// Max is the number of events after which tailing stops (zero or less tails until the Context is done).
+	Max int
+
+	// KeyProvider is used to decrypt the events of an encrypted KafkaChannel (nil disables decryption).
+	KeyProvider encryption.KeyProvider
+}
+
+// Tail consumes the events of all partitions of the Resolution's Topics (without a ConsumerGroup, so that no
+// offsets are committed) and writes them, decoded as CloudEvents where possible, until the Context is done or
+// the maximum number of events has been written.
+func Tail(ctx context.Context, out io.Writer, client sarama.Client, resolution *Resolution, options TailOptions) error {
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	initialOffset := sarama.OffsetNewest
+	if options.FromBeginning {
+		initialOffset = sarama.OffsetOldest
+	}
+
+	// Forward The Records Of All Partitions To A Single Channel Until Done
+	done := make(chan struct{})
+	records := make(chan *sarama.ConsumerMessage)
+	waitGroup := &sync.WaitGroup{}
+	defer waitGroup.Wait()
+	defer close(done)
+	for _, topic := range resolution.Topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+		}
+		for _, partition := range partitions {
+			partitionConsumer, err := consumer.ConsumePartition(topic, partition, initialOffset)
+			if err != nil {
+				return fmt.Errorf("failed to consume partition %d of topic %s: %w", partition, topic, err)
+			}
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				defer partitionConsumer.AsyncClose()
+				for {
+					select {
+					case record, ok := <-partitionConsumer.Messages():
+						if !ok {
+							return
+						}
+						select {
+						case records <- record:
+						case <-done:
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+	}
+
+	for count := 0; options.Max <= 0 || count < options.Max; count++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case record := <-records:
+			if _, err := fmt.Fprint(out, FormatRecord(ctx, record, resolution.EncryptionNamespace, resolution.EncryptionSecret, options.KeyProvider)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FormatRecord returns a header line identifying the Kafka record followed by the record decoded as a CloudEvent,
+// or by its raw key & value if it is not a CloudEvent.  Encrypted records are decrypted first if a KeyProvider is
+// specified (using the specified encryption Secret).
+func FormatRecord(ctx context.Context, record *sarama.ConsumerMessage, encryptionNamespace string, encryptionSecret string, keyProvider encryption.KeyProvider) string {
+	formatted := fmt.Sprintf("--- %s/%d@%d %s\n", record.Topic, record.Partition, record.Offset, record.Timestamp.Format(time.RFC3339Nano))
+
+	if keyProvider != nil && encryptionNamespace != "" {
+		if err := encryption.Decrypt(ctx, keyProvider, encryptionNamespace, encryptionSecret, record); err != nil {
+			formatted += fmt.Sprintf("(unable to decrypt: %v)\n", err)
+		}
+	}
+
+	message := protocolkafka.NewMessageFromConsumerMessage(record)
+	if message.ReadEncoding() != binding.EncodingUnknown {
+		event, err := binding.ToEvent(ctx, message)
+		if err == nil {
+			return formatted + event.String()
+		}
+		formatted += fmt.Sprintf("(unable to decode cloudevent: %v)\n", err)
+	}
+
+	return formatted + fmt.Sprintf("Key: %s\nValue: %s\n", record.Key, record.Value)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The FormatRecord() Functionality
+func TestFormatRecord(t *testing.T) {
+	timestamp := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		record   *sarama.ConsumerMessage
+		contains []string
+	}{
+		{
+			name: "Binary CloudEvent",
+			record: &sarama.ConsumerMessage{
+				Topic:     "my-topic",
+				Partition: 1,
+				Offset:    42,
+				Timestamp: timestamp,
+				Headers: []*sarama.RecordHeader{
+					{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+					{Key: []byte("ce_id"), Value: []byte("my-id")},
+					{Key: []byte("ce_type"), Value: []byte("my-type")},
+					{Key: []byte("ce_source"), Value: []byte("my-source")},
+					{Key: []byte("content-type"), Value: []byte("application/json")},
+				},
+				Value: []byte(`{"hello":"world"}`),
+			},
+			contains: []string{"--- my-topic/1@42 2022-01-02T03:04:05Z\n", "id: my-id", "type: my-type", "source: my-source", `"hello": "world"`},
+		},
+		{
+			name: "Structured CloudEvent",
+			record: &sarama.ConsumerMessage{
+				Topic:     "my-topic",
+				Timestamp: timestamp,
+				Headers:   []*sarama.RecordHeader{{Key: []byte("content-type"), Value: []byte("application/cloudevents+json")}},
+				Value:     []byte(`{"specversion":"1.0","id":"my-id","type":"my-type","source":"my-source"}`),
+			},
+			contains: []string{"--- my-topic/0@0 ", "id: my-id", "type: my-type"},
+		},
+		{
+			name: "Not A CloudEvent",
+			record: &sarama.ConsumerMessage{
+				Topic:     "my-topic",
+				Timestamp: timestamp,
+				Key:       []byte("my-key"),
+				Value:     []byte("my-value"),
+			},
+			contains: []string{"--- my-topic/0@0 ", "Key: my-key\nValue: my-value\n"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatted := FormatRecord(context.TODO(), test.record, "my-ns", "", nil)
+			for _, expected := range test.contains {
+				assert.Contains(t, formatted, expected)
+			}
+		})
+	}
+}
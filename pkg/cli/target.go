@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Kind identifies the type of resource targeted by a CLI command.
+type Kind string
+
+// The Kinds Of Resources Supported By The CLI
+const (
+	KafkaChannelKind Kind = "KafkaChannel"
+	SubscriptionKind Kind = "Subscription"
+	TriggerKind      Kind = "Trigger"
+	KafkaSourceKind  Kind = "KafkaSource"
+)
+
+// The (Lowercase) Names & Abbreviations Accepted For Each Kind
+var kindNames = map[string]Kind{
+	"kafkachannel":  KafkaChannelKind,
+	"kafkachannels": KafkaChannelKind,
+	"kc":            KafkaChannelKind,
+	"subscription":  SubscriptionKind,
+	"subscriptions": SubscriptionKind,
+	"sub":           SubscriptionKind,
+	"trigger":       TriggerKind,
+	"triggers":      TriggerKind,
+	"kafkasource":   KafkaSourceKind,
+	"kafkasources":  KafkaSourceKind,
+	"ks":            KafkaSourceKind,
+}
+
+// Target is a namespaced resource targeted by a CLI command.
+type Target struct {
+	Kind      Kind
+	Namespace string
+	Name      string
+}
+
+// String returns the "<Kind>/<Namespace>/<Name>" form of the Target.
+func (t Target) String() string {
+	return fmt.Sprintf("%s/%s/%s", t.Kind, t.Namespace, t.Name)
+}
+
+// ParseTarget parses a "<kind>/<name>" argument (e.g. "kc/my-channel" or "subscription/my-subscription") into a
+// Target in the specified namespace.
+func ParseTarget(arg string, namespace string) (Target, error) {
+	parts := strings.Split(arg, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Target{}, fmt.Errorf("invalid resource %q, expected <kind>/<name>", arg)
+	}
+	kind, ok := kindNames[strings.ToLower(parts[0])]
+	if !ok {
+		return Target{}, fmt.Errorf("unsupported kind %q, expected one of kafkachannel (kc), subscription (sub), trigger or kafkasource (ks)", parts[0])
+	}
+	return Target{Kind: kind, Namespace: namespace, Name: parts[1]}, nil
+}
+
+// KReference returns a reference to the Target suitable for a ResetOffset, which only supports Subscriptions and
+// Triggers (the resources owning a single consumer group).
+func (t Target) KReference() (duckv1.KReference, error) {
+	switch t.Kind {
+	case SubscriptionKind:
+		return duckv1.KReference{
+			APIVersion: messagingv1.SchemeGroupVersion.String(),
+			Kind:       string(SubscriptionKind),
+			Namespace:  t.Namespace,
+			Name:       t.Name,
+		}, nil
+	case TriggerKind:
+		return duckv1.KReference{
+			APIVersion: eventingv1.SchemeGroupVersion.String(),
+			Kind:       string(TriggerKind),
+			Namespace:  t.Namespace,
+			Name:       t.Name,
+		}, nil
+	default:
+		return duckv1.KReference{}, fmt.Errorf("offsets can only be reset for a subscription or trigger, not a %s", t.Kind)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Test The ParseTarget() Functionality
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    Target
+		wantErr bool
+	}{
+		{name: "KafkaChannel", arg: "kafkachannel/my-channel", want: Target{Kind: KafkaChannelKind, Namespace: "my-ns", Name: "my-channel"}},
+		{name: "KafkaChannel Abbreviation", arg: "kc/my-channel", want: Target{Kind: KafkaChannelKind, Namespace: "my-ns", Name: "my-channel"}},
+		{name: "Subscription Mixed Case", arg: "Subscription/my-sub", want: Target{Kind: SubscriptionKind, Namespace: "my-ns", Name: "my-sub"}},
+		{name: "Subscription Abbreviation", arg: "sub/my-sub", want: Target{Kind: SubscriptionKind, Namespace: "my-ns", Name: "my-sub"}},
+		{name: "Trigger", arg: "triggers/my-trigger", want: Target{Kind: TriggerKind, Namespace: "my-ns", Name: "my-trigger"}},
+		{name: "KafkaSource", arg: "ks/my-source", want: Target{Kind: KafkaSourceKind, Namespace: "my-ns", Name: "my-source"}},
+		{name: "Missing Kind", arg: "my-channel", wantErr: true},
+		{name: "Missing Name", arg: "kc/", wantErr: true},
+		{name: "Too Many Parts", arg: "kc/my-ns/my-channel", wantErr: true},
+		{name: "Unsupported Kind", arg: "pod/my-pod", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := ParseTarget(test.arg, "my-ns")
+			if test.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.want, target)
+			}
+		})
+	}
+}
+
+// Test The Target KReference() Functionality
+func TestTargetKReference(t *testing.T) {
+	ref, err := Target{Kind: SubscriptionKind, Namespace: "my-ns", Name: "my-sub"}.KReference()
+	assert.Nil(t, err)
+	assert.Equal(t, duckv1.KReference{APIVersion: "messaging.knative.dev/v1", Kind: "Subscription", Namespace: "my-ns", Name: "my-sub"}, ref)
+
+	ref, err = Target{Kind: TriggerKind, Namespace: "my-ns", Name: "my-trigger"}.KReference()
+	assert.Nil(t, err)
+	assert.Equal(t, duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Trigger", Namespace: "my-ns", Name: "my-trigger"}, ref)
+
+	_, err = Target{Kind: KafkaChannelKind, Namespace: "my-ns", Name: "my-channel"}.KReference()
+	assert.NotNil(t, err)
+}
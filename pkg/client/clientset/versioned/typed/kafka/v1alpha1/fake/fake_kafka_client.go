@@ -28,6 +28,10 @@ type FakeKafkaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKafkaV1alpha1) KafkaSinks(namespace string) v1alpha1.KafkaSinkInterface {
+	return &FakeKafkaSinks{c, namespace}
+}
+
 func (c *FakeKafkaV1alpha1) ResetOffsets(namespace string) v1alpha1.ResetOffsetInterface {
 	return &FakeResetOffsets{c, namespace}
 }
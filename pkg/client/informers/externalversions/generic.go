@@ -60,6 +60,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bindings().V1beta1().KafkaBindings().Informer()}, nil
 
 		// Group=kafka.eventing.knative.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("kafkasinks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kafka().V1alpha1().KafkaSinks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("resetoffsets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kafka().V1alpha1().ResetOffsets().Informer()}, nil
 
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// KafkaSinks returns a KafkaSinkInformer.
+	KafkaSinks() KafkaSinkInformer
 	// ResetOffsets returns a ResetOffsetInformer.
 	ResetOffsets() ResetOffsetInformer
 }
@@ -39,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// KafkaSinks returns a KafkaSinkInformer.
+func (v *version) KafkaSinks() KafkaSinkInformer {
+	return &kafkaSinkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ResetOffsets returns a ResetOffsetInformer.
 func (v *version) ResetOffsets() ResetOffsetInformer {
 	return &resetOffsetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
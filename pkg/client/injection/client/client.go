@@ -264,6 +264,137 @@ func (w *wrapKafkaV1alpha1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapKafkaV1alpha1) KafkaSinks(namespace string) typedkafkav1alpha1.KafkaSinkInterface {
+	return &wrapKafkaV1alpha1KafkaSinkImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kafka.eventing.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "kafkasinks",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKafkaV1alpha1KafkaSinkImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkafkav1alpha1.KafkaSinkInterface = (*wrapKafkaV1alpha1KafkaSinkImpl)(nil)
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Create(ctx context.Context, in *v1alpha1.KafkaSink, opts v1.CreateOptions) (*v1alpha1.KafkaSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KafkaSink, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KafkaSinkList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSinkList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KafkaSink, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Update(ctx context.Context, in *v1alpha1.KafkaSink, opts v1.UpdateOptions) (*v1alpha1.KafkaSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) UpdateStatus(ctx context.Context, in *v1alpha1.KafkaSink, opts v1.UpdateOptions) (*v1alpha1.KafkaSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaSinkImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKafkaV1alpha1) ResetOffsets(namespace string) typedkafkav1alpha1.ResetOffsetInterface {
 	return &wrapKafkaV1alpha1ResetOffsetImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
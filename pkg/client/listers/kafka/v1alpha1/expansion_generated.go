@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// KafkaSinkListerExpansion allows custom methods to be added to
+// KafkaSinkLister.
+type KafkaSinkListerExpansion interface{}
+
+// KafkaSinkNamespaceListerExpansion allows custom methods to be added to
+// KafkaSinkNamespaceLister.
+type KafkaSinkNamespaceListerExpansion interface{}
+
 // ResetOffsetListerExpansion allows custom methods to be added to
 // ResetOffsetLister.
 type ResetOffsetListerExpansion interface{}
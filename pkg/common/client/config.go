@@ -49,13 +49,29 @@ type KafkaSaslConfig struct {
 	User     string
 	Password string
 	SaslType string
+	OAuth    *KafkaOAuthConfig // Only used with the OAUTHBEARER SaslType
 }
 
 // HasSameSettings returns true if all of the SASL settings in the provided config are the same as in this struct
 func (c *KafkaSaslConfig) HasSameSettings(saramaConfig *sarama.Config) bool {
 	return saramaConfig.Net.SASL.User == c.User &&
 		saramaConfig.Net.SASL.Password == c.Password &&
-		string(saramaConfig.Net.SASL.Mechanism) == c.SaslType
+		string(saramaConfig.Net.SASL.Mechanism) == c.SaslType &&
+		c.hasSameOAuthSettings(saramaConfig.Net.SASL.TokenProvider)
+}
+
+// HasCredentials returns true if the SASL settings contain a user, or an OAuth client for the OAUTHBEARER SaslType
+func (c *KafkaSaslConfig) HasCredentials() bool {
+	return c.User != "" || (c.SaslType == sarama.SASLTypeOAuth && c.OAuth != nil)
+}
+
+// Compare The OAuth Settings With Those Of An Existing Sarama TokenProvider
+func (c *KafkaSaslConfig) hasSameOAuthSettings(tokenProvider sarama.AccessTokenProvider) bool {
+	provider, ok := tokenProvider.(*ClientCredentialsTokenProvider)
+	if c.SaslType != sarama.SASLTypeOAuth || c.OAuth == nil {
+		return !ok
+	}
+	return ok && cmp.Equal(*c.OAuth, provider.Config(), cmpopts.EquateEmpty())
 }
 
 // HasSameBrokers returns true if all of the brokers in the slice are present and in the same order as
@@ -106,6 +122,11 @@ type ConfigBuilder interface {
 	// in a YAML-string for Sarama.
 	FromYaml(saramaYaml string) ConfigBuilder
 
+	// WithStrictYaml makes the builder fail if the YAML-string
+	// contains fields which are not part of the Sarama config
+	// (e.g. misspelled settings, which are otherwise ignored)
+	WithStrictYaml() ConfigBuilder
+
 	// WithAuth makes the builder apply the TLS/SASL settings
 	// on the config for the given KafkaAuthConfig
 	WithAuth(kafkaAuthConfig *KafkaAuthConfig) ConfigBuilder
@@ -139,6 +160,7 @@ type configBuilder struct {
 	version       *sarama.KafkaVersion
 	clientId      string
 	yaml          string
+	strictYaml    bool
 	auth          *KafkaAuthConfig
 	initialOffset v1beta1.Offset
 }
@@ -168,6 +190,11 @@ func (b *configBuilder) FromYaml(saramaSettingsYamlString string) ConfigBuilder
 	return b
 }
 
+func (b *configBuilder) WithStrictYaml() ConfigBuilder {
+	b.strictYaml = true
+	return b
+}
+
 func (b *configBuilder) WithAuth(kafkaAuthCfg *KafkaAuthConfig) ConfigBuilder {
 	b.auth = kafkaAuthCfg
 	return b
@@ -215,10 +242,17 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 			return nil, fmt.Errorf("failed to extract RootPEMs from Sarama Config YAML: err=%s : config=%+v", err, saramaSettingsYamlString)
 		}
 
-		// Unmarshall The Sarama Config Yaml Into The Provided Sarama.Config Object
-		err = yaml.Unmarshal([]byte(saramaSettingsYamlString), &config)
-		if err != nil {
-			return nil, fmt.Errorf("ConfigMap's sarama value could not be converted to a Sarama.Config struct: %s : %v", err, saramaSettingsYamlString)
+		// Unmarshall The Sarama Config Yaml Into The Provided Sarama.Config Object (Skipped If Nothing Remains After
+		// The Extractions, As Unmarshalling An Empty Document Would Reset The Config To nil)
+		if strings.TrimSpace(saramaSettingsYamlString) != "" {
+			unmarshal := yaml.Unmarshal
+			if b.strictYaml {
+				unmarshal = yaml.UnmarshalStrict
+			}
+			err = unmarshal([]byte(saramaSettingsYamlString), &config)
+			if err != nil {
+				return nil, fmt.Errorf("ConfigMap's sarama value could not be converted to a Sarama.Config struct: %s : %v", err, saramaSettingsYamlString)
+			}
 		}
 
 		// Override The Custom Parsed KafkaVersion, if it is specified in the YAML
@@ -254,6 +288,7 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 
 			// if SaslType is not provided we are defaulting to PLAIN
 			config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+			config.Net.SASL.TokenProvider = nil
 
 			if b.auth.SASL.SaslType == sarama.SASLTypeSCRAMSHA256 {
 				config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &XDGSCRAMClient{HashGeneratorFcn: SHA256} }
@@ -264,6 +299,14 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 				config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &XDGSCRAMClient{HashGeneratorFcn: SHA512} }
 				config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 			}
+
+			// OAUTHBEARER tokens are fetched from the token endpoint with the client credentials
+			if b.auth.SASL.SaslType == sarama.SASLTypeOAuth {
+				if b.auth.SASL.OAuth == nil || b.auth.SASL.OAuth.TokenUrl == "" {
+					return nil, fmt.Errorf("SASL type %s requires an OAuth token URL", sarama.SASLTypeOAuth)
+				}
+				config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			}
 			config.Net.SASL.User = b.auth.SASL.User
 		}
 	}
@@ -290,6 +333,9 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 
 	if b.auth != nil && b.auth.SASL != nil {
 		config.Net.SASL.Password = b.auth.SASL.Password
+		if b.auth.SASL.SaslType == sarama.SASLTypeOAuth {
+			config.Net.SASL.TokenProvider = NewClientCredentialsTokenProvider(*b.auth.SASL.OAuth)
+		}
 	}
 
 	return config, nil
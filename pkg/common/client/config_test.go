@@ -273,6 +273,16 @@ func TestBuildSaramaConfig(t *testing.T) {
 		Build(ctx)
 	assert.NotNil(t, err)
 
+	// Verify that a YAML containing only the Version is applied to the defaults
+	config, err = NewConfigBuilder().
+		WithDefaults().
+		FromYaml("Version: 2.3.0\n").
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.NotNil(t, config)
+	assert.Equal(t, sarama.V2_3_0_0, config.Version)
+	assert.Equal(t, defaultConfig.Producer.Timeout, config.Producer.Timeout)
+
 	// Verify error when an invalid RootPEMs is provided
 	_, err = NewConfigBuilder().
 		WithDefaults().
@@ -465,6 +475,35 @@ Metadata:
 	assert.False(t, config.Net.TLS.Enable)
 }
 
+func TestBuildSaramaConfigWithStrictYaml(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	// Verify that known fields (including the custom Version) are accepted
+	validYaml := `
+Version: 2.3.0
+Net:
+  SASL:
+    Enable: true
+    Version: 1
+`
+	config, err := NewConfigBuilder().WithDefaults().FromYaml(validYaml).WithStrictYaml().Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.V2_3_0_0, config.Version)
+	assert.True(t, config.Net.SASL.Enable)
+
+	// Verify that a misspelled field is only rejected in strict mode
+	misspelledYaml := `
+Net:
+  SASL:
+    Enabled: true
+`
+	config, err = NewConfigBuilder().WithDefaults().FromYaml(misspelledYaml).Build(ctx)
+	assert.Nil(t, err)
+	assert.False(t, config.Net.SASL.Enable)
+	_, err = NewConfigBuilder().WithDefaults().FromYaml(misspelledYaml).WithStrictYaml().Build(ctx)
+	assert.NotNil(t, err)
+}
+
 // Verify that comparisons of sarama config structs function as expected
 func TestSaramaConfigEqual(t *testing.T) {
 	logger := logtesting.TestLogger(t)
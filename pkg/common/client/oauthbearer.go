@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// TokenRefreshFraction is the fraction of a token's lifetime after which it is refreshed (ahead of its expiry).
+const TokenRefreshFraction = 0.8
+
+// DefaultTokenLifetime is assumed for tokens whose response does not include an "expires_in" value.
+const DefaultTokenLifetime = 5 * time.Minute
+
+// TokenRequestTimeout bounds each request to the token endpoint.
+const TokenRequestTimeout = 30 * time.Second
+
+// KafkaOAuthConfig holds the OAuth 2.0 client-credentials settings of the OAUTHBEARER SaslType.
+type KafkaOAuthConfig struct {
+	ClientId     string
+	ClientSecret string
+	TokenUrl     string
+	Scopes       []string
+}
+
+// ParseScopes splits a comma and/or space separated list of OAuth scopes.
+func ParseScopes(scopes string) []string {
+	return strings.FieldsFunc(scopes, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// ClientCredentialsTokenProvider implements the sarama.AccessTokenProvider interface by fetching tokens from an
+// OAuth 2.0 token endpoint with the client-credentials grant.  Tokens are cached and refreshed once
+// TokenRefreshFraction of their lifetime has elapsed, so that connections are never authenticated with a token
+// about to expire.
+type ClientCredentialsTokenProvider struct {
+	config     KafkaOAuthConfig
+	httpClient *http.Client
+	mutex      sync.Mutex
+	token      *sarama.AccessToken
+	refreshAt  time.Time
+}
+
+// Verify The ClientCredentialsTokenProvider Implements The Sarama AccessTokenProvider Interface
+var _ sarama.AccessTokenProvider = &ClientCredentialsTokenProvider{}
+
+// NewClientCredentialsTokenProvider returns a ClientCredentialsTokenProvider for the specified OAuth config.
+func NewClientCredentialsTokenProvider(config KafkaOAuthConfig) *ClientCredentialsTokenProvider {
+	return &ClientCredentialsTokenProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: TokenRequestTimeout},
+	}
+}
+
+// Config returns the OAuth config with which the provider fetches tokens.
+func (p *ClientCredentialsTokenProvider) Config() KafkaOAuthConfig {
+	return p.config
+}
+
+// Token returns the cached token, fetching a new one if there is none or it is due for refresh.
+func (p *ClientCredentialsTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	if p.token != nil && now.Before(p.refreshAt) {
+		return p.token, nil
+	}
+
+	token, lifetime, err := p.fetchToken()
+	if err != nil {
+		return nil, err
+	}
+	p.token = token
+	p.refreshAt = now.Add(time.Duration(float64(lifetime) * TokenRefreshFraction))
+	return token, nil
+}
+
+// The tokenResponse struct is the successful response of an OAuth 2.0 token endpoint (RFC 6749 section 5.1)
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Request A New Token From The Token Endpoint Using The Client-Credentials Grant
+func (p *ClientCredentialsTokenProvider) fetchToken() (*sarama.AccessToken, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(p.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.config.Scopes, " "))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TokenRequestTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create oauth token request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(p.config.ClientId), url.QueryEscape(p.config.ClientSecret))
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to request oauth token from %s: %w", p.config.TokenUrl, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read oauth token response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("oauth token endpoint %s returned status %d: %s", p.config.TokenUrl, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse oauth token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, 0, fmt.Errorf("oauth token response from %s does not contain an access_token", p.config.TokenUrl)
+	}
+
+	lifetime := DefaultTokenLifetime
+	if tokenResp.ExpiresIn > 0 {
+		lifetime = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+	return &sarama.AccessToken{Token: tokenResp.AccessToken}, lifetime, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Data
+const (
+	testClientId     = "test-client-id"
+	testClientSecret = "test-client-secret"
+)
+
+// Test The ClientCredentialsTokenProvider Caches Tokens Until They Are Due For Refresh
+func TestClientCredentialsTokenProvider(t *testing.T) {
+	tokenServer, requestCount := newTestTokenServer(t, 3600)
+	provider := NewClientCredentialsTokenProvider(KafkaOAuthConfig{
+		ClientId:     testClientId,
+		ClientSecret: testClientSecret,
+		TokenUrl:     tokenServer.URL,
+		Scopes:       []string{"kafka", "kafka-admin"},
+	})
+
+	// Verify The Token Is Fetched Once And Then Cached
+	for i := 0; i < 3; i++ {
+		token, err := provider.Token()
+		assert.Nil(t, err)
+		assert.Equal(t, "test-token-1", token.Token)
+	}
+	assert.Equal(t, 1, *requestCount)
+	assert.WithinDuration(t, time.Now().Add(48*time.Minute), provider.refreshAt, time.Minute)
+
+	// Verify A Token Due For Refresh Is Replaced
+	provider.refreshAt = time.Now().Add(-time.Second)
+	token, err := provider.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "test-token-2", token.Token)
+	assert.Equal(t, 2, *requestCount)
+}
+
+// Test The ClientCredentialsTokenProvider Errors
+func TestClientCredentialsTokenProviderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{name: "Unauthorized", handler: func(writer http.ResponseWriter, _ *http.Request) {
+			http.Error(writer, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		}},
+		{name: "Invalid JSON", handler: func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = writer.Write([]byte("not-json"))
+		}},
+		{name: "Missing Access Token", handler: func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = writer.Write([]byte(`{"token_type":"Bearer","expires_in":60}`))
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokenServer := httptest.NewServer(test.handler)
+			defer tokenServer.Close()
+			provider := NewClientCredentialsTokenProvider(KafkaOAuthConfig{TokenUrl: tokenServer.URL})
+			token, err := provider.Token()
+			assert.NotNil(t, err)
+			assert.Nil(t, token)
+		})
+	}
+}
+
+// Test Building A Sarama Config With OAUTHBEARER Authentication
+func TestBuildSaramaConfigWithOAuth(t *testing.T) {
+	tokenServer, _ := newTestTokenServer(t, 0)
+	oauthConfig := &KafkaOAuthConfig{ClientId: testClientId, ClientSecret: testClientSecret, TokenUrl: tokenServer.URL}
+	authConfig := &KafkaAuthConfig{SASL: &KafkaSaslConfig{SaslType: sarama.SASLTypeOAuth, OAuth: oauthConfig}}
+
+	config, err := NewConfigBuilder().WithDefaults().WithAuth(authConfig).Build(context.TODO())
+	assert.Nil(t, err)
+	assert.True(t, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+	assert.Nil(t, config.Validate())
+	token, err := config.Net.SASL.TokenProvider.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "test-token-1", token.Token)
+
+	// Verify The Settings Are Compared With Those Of The TokenProvider
+	assert.True(t, authConfig.SASL.HasCredentials())
+	assert.True(t, authConfig.SASL.HasSameSettings(config))
+	rotated := &KafkaSaslConfig{SaslType: sarama.SASLTypeOAuth, OAuth: &KafkaOAuthConfig{ClientId: testClientId, ClientSecret: "rotated-secret", TokenUrl: tokenServer.URL}}
+	assert.False(t, rotated.HasSameSettings(config))
+	assert.False(t, (&KafkaSaslConfig{SaslType: sarama.SASLTypePlaintext}).HasSameSettings(config))
+
+	// Verify Switching To PLAIN Removes The TokenProvider
+	config, err = NewConfigBuilder().WithExisting(config).WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{User: "user", Password: "password"}}).Build(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypePlaintext), config.Net.SASL.Mechanism)
+	assert.Nil(t, config.Net.SASL.TokenProvider)
+
+	// Verify A Token URL Is Required
+	_, err = NewConfigBuilder().WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{SaslType: sarama.SASLTypeOAuth}}).Build(context.TODO())
+	assert.NotNil(t, err)
+}
+
+// Test The ParseScopes() Functionality
+func TestParseScopes(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, ParseScopes("a, b c"))
+	assert.Empty(t, ParseScopes(""))
+}
+
+// Create A Local Token Endpoint Issuing Sequentially Numbered Tokens With The Specified Lifetime
+func newTestTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int) {
+	requestCount := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		clientId, clientSecret, ok := request.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, testClientId, clientId)
+		assert.Equal(t, testClientSecret, clientSecret)
+		assert.Nil(t, request.ParseForm())
+		assert.Equal(t, "client_credentials", request.PostForm.Get("grant_type"))
+		if scope := request.PostForm.Get("scope"); scope != "" {
+			assert.Equal(t, "kafka kafka-admin", scope)
+		}
+		requestCount++
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(writer, `{"access_token":"test-token-%d","token_type":"Bearer","expires_in":%d}`, requestCount, expiresIn)
+	}))
+	t.Cleanup(tokenServer.Close)
+	return tokenServer, &requestCount
+}
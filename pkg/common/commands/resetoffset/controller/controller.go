@@ -22,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
@@ -30,6 +31,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset"
 	resetoffsetreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/resetoffset"
 	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
@@ -76,18 +78,39 @@ func NewControllerFactory(
 			logger.Fatal("Failed To Initialize ConfigMap Watcher", zap.Error(err))
 		}
 
+		// Only Reconcile The ResetOffsets Whose Ref Can Be Mapped (If The Factory Restricts The Refs It Handles)
+		filterFunc := newRefFilterFunc(refMapperFactory)
+
 		// Create A New ResetOffset Controller Impl With The Reconciler
-		controllerImpl := resetoffsetreconciler.NewImpl(ctx, reconciler)
+		controllerImpl := resetoffsetreconciler.NewImpl(ctx, reconciler, func(impl *controller.Impl) controller.Options {
+			return controller.Options{PromoteFilterFunc: filterFunc}
+		})
 
 		// Configure The Informers' EventHandlers
 		logger.Info("Setting Up EventHandlers")
-		resetoffsetInformer.Informer().AddEventHandler(controller.HandleAll(controllerImpl.Enqueue))
+		resetoffsetInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: filterFunc,
+			Handler:    controller.HandleAll(controllerImpl.Enqueue),
+		})
 
 		// Return The ResetOffset Controller
 		return controllerImpl
 	}
 }
 
+// newRefFilterFunc returns a filter function accepting the ResetOffsets whose Ref is handled by the specified
+// ResetOffsetRefMapperFactory, which accepts all ResetOffsets unless it implements the ResetOffsetRefFilter.
+func newRefFilterFunc(refMapperFactory refmappers.ResetOffsetRefMapperFactory) func(obj interface{}) bool {
+	refFilter, ok := refMapperFactory.(refmappers.ResetOffsetRefFilter)
+	if !ok {
+		return func(interface{}) bool { return true }
+	}
+	return func(obj interface{}) bool {
+		resetOffset, ok := obj.(*kafkav1alpha1.ResetOffset)
+		return ok && refFilter.Handles(resetOffset.Spec.Ref)
+	}
+}
+
 // Shutdown performs clean tear-down of resources.
 func Shutdown() {
 	// Currently nothing to do
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/rest"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/client/injection/kube/client/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake" // Knative Fake Informer Injection
 	"knative.dev/pkg/injection"
@@ -31,6 +32,8 @@ import (
 
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset/fake" // Force Fake Informer Injection
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
 	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
@@ -90,6 +93,27 @@ func TestNewControllerFactory(t *testing.T) {
 	mockResetOffsetRefMapper.AssertExpectations(t)
 }
 
+// Test The newRefFilterFunc() Functionality
+func TestNewRefFilterFunc(t *testing.T) {
+	triggerResetOffset := controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Trigger", APIVersion: "eventing.knative.dev/v1", Name: "trigger"}))
+	subscriptionResetOffset := controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Subscription", APIVersion: "messaging.knative.dev/v1", Name: "subscription"}))
+
+	// A Factory Which Does Not Implement ResetOffsetRefFilter Accepts All ResetOffsets
+	filterFunc := newRefFilterFunc(&refmapperstesting.MockResetOffsetRefMapperFactory{})
+	assert.True(t, filterFunc(triggerResetOffset))
+	assert.True(t, filterFunc(subscriptionResetOffset))
+
+	// Factories Implementing ResetOffsetRefFilter Only Accept The ResetOffsets They Handle
+	filterFunc = newRefFilterFunc(&refmappers.TriggerRefMapperFactory{})
+	assert.True(t, filterFunc(triggerResetOffset))
+	assert.False(t, filterFunc(subscriptionResetOffset))
+	assert.False(t, filterFunc("not-a-resetoffset"))
+
+	filterFunc = newRefFilterFunc(&refmappers.SubscriptionRefMapperFactory{})
+	assert.False(t, filterFunc(triggerResetOffset))
+	assert.True(t, filterFunc(subscriptionResetOffset))
+}
+
 // Test The Shutdown() Functionality
 func TestShutdown(t *testing.T) {
 	Shutdown() // Currently nothing to test
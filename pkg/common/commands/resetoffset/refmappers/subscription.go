@@ -26,6 +26,7 @@ import (
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	subscriptioninformers "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
 	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
@@ -35,12 +36,15 @@ import (
 // SubscriptionRefMapperFactory
 //
 
-// Verify The Subscription ResetOffsetRefMapperFactory Implements The Interface
+// Verify The Subscription ResetOffsetRefMapperFactory Implements The Interfaces
 var _ ResetOffsetRefMapperFactory = &SubscriptionRefMapperFactory{}
+var _ ResetOffsetRefFilter = &SubscriptionRefMapperFactory{}
 
-// SubscriptionRefMapperFactory implements the ResetOffsetRefMapperFactory for Knative Subscriptions
+// SubscriptionRefMapperFactory implements the ResetOffsetRefMapperFactory for Knative Subscriptions.  The optional
+// TopicNameMapperFactory takes precedence over the TopicNameMapper for mappings which require injected informers.
 type SubscriptionRefMapperFactory struct {
 	TopicNameMapper          SubscriptionTopicNameMapper
+	TopicNameMapperFactory   SubscriptionTopicNameMapperFactory
 	GroupIdMapper            SubscriptionConsumerGroupIdMapper
 	ConnectionPoolKeyMapper  SubscriptionConnectionPoolKeyMapper
 	DataPlaneNamespaceMapper SubscriptionDataPlaneNamespaceMapper
@@ -67,14 +71,28 @@ func NewSubscriptionRefMapperFactory(topicNameMapper SubscriptionTopicNameMapper
 // a new SubscriptionRefMapper instance using the specific TopicName / GroupId mappers.  It also relies on
 // the Context having injected informers (SubscriptionInformer).
 func (f *SubscriptionRefMapperFactory) Create(ctx context.Context) ResetOffsetRefMapper {
+	topicNameMapper := f.TopicNameMapper
+	if f.TopicNameMapperFactory != nil {
+		topicNameMapper = f.TopicNameMapperFactory(ctx)
+	}
 	return NewSubscriptionRefMapper(ctx,
-		f.TopicNameMapper,
+		topicNameMapper,
 		f.GroupIdMapper,
 		f.ConnectionPoolKeyMapper,
 		f.DataPlaneNamespaceMapper,
 		f.DataPlaneLabelsMapper)
 }
 
+// Handles implements the ResetOffsetRefFilter interface for Subscription references.
+func (f *SubscriptionRefMapperFactory) Handles(ref duckv1.KReference) bool {
+	return isSubscriptionRef(ref)
+}
+
+// isSubscriptionRef returns whether the specified reference is to a Knative Subscription.
+func isSubscriptionRef(ref duckv1.KReference) bool {
+	return strings.HasPrefix(ref.APIVersion, messaging.GroupName) && ref.Kind == "Subscription"
+}
+
 //
 // SubscriptionRefMapper
 //
@@ -82,6 +100,10 @@ func (f *SubscriptionRefMapperFactory) Create(ctx context.Context) ResetOffsetRe
 // SubscriptionTopicNameMapper defines a function signature for mapping a Subscription to a Kafka Topic name.
 type SubscriptionTopicNameMapper func(*messagingv1.Subscription) (string, error)
 
+// SubscriptionTopicNameMapperFactory defines a function signature for creating a SubscriptionTopicNameMapper from a
+// Context with injected informers (e.g. in order to look up the Subscription's Channel).
+type SubscriptionTopicNameMapperFactory func(ctx context.Context) SubscriptionTopicNameMapper
+
 // SubscriptionConsumerGroupIdMapper defines a function signature for mapping a Subscription to a Kafka ConsumerGroup ID.
 type SubscriptionConsumerGroupIdMapper func(*messagingv1.Subscription) (string, error)
 
@@ -149,7 +171,7 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 	logger := m.logger.With(zap.Any("Ref", ref))
 
 	// Validate The Reference
-	if !strings.HasPrefix(ref.APIVersion, messaging.GroupName) || ref.Kind != "Subscription" {
+	if !isSubscriptionRef(ref) {
 		m.logger.Warn("Received ResetOffset with non Subscription reference")
 		return nil, fmt.Errorf("received ResetOffset with non Subscription reference: %v", ref)
 	}
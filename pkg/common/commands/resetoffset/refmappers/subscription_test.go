@@ -82,6 +82,31 @@ func TestNewSubscriptionRefMapperFactory(t *testing.T) {
 	assert.NotNil(t, refMapper)
 }
 
+func TestSubscriptionRefMapperFactoryTopicNameMapperFactory(t *testing.T) {
+
+	// Create A Context With Test Logger & Fake Informers
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+
+	// Create A Factory Whose TopicNameMapperFactory Overrides The TopicNameMapper
+	factory := NewSubscriptionRefMapperFactory(
+		newMockSubscriptionTopicNameMapper(t, nil, "UnexpectedTopicName", nil),
+		newMockSubscriptionConsumerGroupIdMapper(t, nil, GroupId, nil),
+		newMockSubscriptionConnectionPoolKeyMapper(t, nil, ConnectionPoolKey, nil),
+		newMockSubscriptionDataPlaneNamespaceMapper(t, nil, DataPlaneNamespace, nil),
+		newMockSubscriptionDataPlaneLabelsMapper(t, nil, DataPlaneLabels, nil))
+	factory.TopicNameMapperFactory = func(factoryCtx context.Context) SubscriptionTopicNameMapper {
+		assert.Equal(t, ctx, factoryCtx)
+		return newMockSubscriptionTopicNameMapper(t, nil, TopicName, nil)
+	}
+
+	// Perform The Test & Verify The TopicNameMapper From The Factory Is Used
+	refMapper := factory.Create(ctx).(*SubscriptionRefMapper)
+	topicName, err := refMapper.topicNameMapper(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, TopicName, topicName)
+}
+
 func TestNewResetOffsetSubscriptionRefMapper(t *testing.T) {
 
 	// Create A Context With Test Logger
@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	triggerinformers "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+//
+// TriggerRefMapperFactory
+//
+
+// Verify The Trigger ResetOffsetRefMapperFactory Implements The Interfaces
+var _ ResetOffsetRefMapperFactory = &TriggerRefMapperFactory{}
+var _ ResetOffsetRefFilter = &TriggerRefMapperFactory{}
+
+// TriggerRefMapperFactory implements the ResetOffsetRefMapperFactory for Knative Triggers.  The optional
+// TopicNameMapperFactory takes precedence over the TopicNameMapper for mappings which require injected informers.
+type TriggerRefMapperFactory struct {
+	TopicNameMapper          TriggerTopicNameMapper
+	TopicNameMapperFactory   TriggerTopicNameMapperFactory
+	GroupIdMapper            TriggerConsumerGroupIdMapper
+	ConnectionPoolKeyMapper  TriggerConnectionPoolKeyMapper
+	DataPlaneNamespaceMapper TriggerDataPlaneNamespaceMapper
+	DataPlaneLabelsMapper    TriggerDataPlaneLabelsMapper
+}
+
+// NewTriggerRefMapperFactory returns an initialized TriggerRefMapperFactory
+func NewTriggerRefMapperFactory(topicNameMapper TriggerTopicNameMapper,
+	groupIdMapper TriggerConsumerGroupIdMapper,
+	connectionPoolKeyMapper TriggerConnectionPoolKeyMapper,
+	dataPlaneNamespaceMapper TriggerDataPlaneNamespaceMapper,
+	dataPlaneLabelsMapper TriggerDataPlaneLabelsMapper) *TriggerRefMapperFactory {
+
+	return &TriggerRefMapperFactory{
+		TopicNameMapper:          topicNameMapper,
+		GroupIdMapper:            groupIdMapper,
+		ConnectionPoolKeyMapper:  connectionPoolKeyMapper,
+		DataPlaneNamespaceMapper: dataPlaneNamespaceMapper,
+		DataPlaneLabelsMapper:    dataPlaneLabelsMapper,
+	}
+}
+
+// Create implements the ResetOffsetRefMapperFactory interface for Trigger references.  It will return
+// a new TriggerRefMapper instance using the specific TopicName / GroupId mappers.  It also relies on
+// the Context having injected informers (TriggerInformer).
+func (f *TriggerRefMapperFactory) Create(ctx context.Context) ResetOffsetRefMapper {
+	topicNameMapper := f.TopicNameMapper
+	if f.TopicNameMapperFactory != nil {
+		topicNameMapper = f.TopicNameMapperFactory(ctx)
+	}
+	return NewTriggerRefMapper(ctx,
+		topicNameMapper,
+		f.GroupIdMapper,
+		f.ConnectionPoolKeyMapper,
+		f.DataPlaneNamespaceMapper,
+		f.DataPlaneLabelsMapper)
+}
+
+// Handles implements the ResetOffsetRefFilter interface for Trigger references.
+func (f *TriggerRefMapperFactory) Handles(ref duckv1.KReference) bool {
+	return isTriggerRef(ref)
+}
+
+// isTriggerRef returns whether the specified reference is to a Knative Trigger.
+func isTriggerRef(ref duckv1.KReference) bool {
+	return strings.HasPrefix(ref.APIVersion, eventing.GroupName) && ref.Kind == "Trigger"
+}
+
+//
+// TriggerRefMapper
+//
+
+// TriggerTopicNameMapper defines a function signature for mapping a Trigger to a Kafka Topic name.
+type TriggerTopicNameMapper func(*eventingv1.Trigger) (string, error)
+
+// TriggerTopicNameMapperFactory defines a function signature for creating a TriggerTopicNameMapper from a
+// Context with injected informers (e.g. in order to look up the Trigger's Broker).
+type TriggerTopicNameMapperFactory func(ctx context.Context) TriggerTopicNameMapper
+
+// TriggerConsumerGroupIdMapper defines a function signature for mapping a Trigger to a Kafka ConsumerGroup ID.
+type TriggerConsumerGroupIdMapper func(*eventingv1.Trigger) (string, error)
+
+// TriggerConnectionPoolKeyMapper defines a function signature for mapping a Trigger to a control-protocol ControlPlaneConnectionPool Key.
+type TriggerConnectionPoolKeyMapper func(*eventingv1.Trigger) (string, error)
+
+// TriggerDataPlaneNamespaceMapper defines a function signature for mapping a Trigger to the Kubernetes namespace of the DataPlane components.
+type TriggerDataPlaneNamespaceMapper func(*eventingv1.Trigger) (string, error)
+
+// TriggerDataPlaneLabelsMapper defines a function signature for mapping a Trigger to the Kubernetes labels of the DataPlane Pods.
+type TriggerDataPlaneLabelsMapper func(*eventingv1.Trigger) (map[string]string, error)
+
+// Verify The Trigger ResetOffsetRefMapper Implements The Interface
+var _ ResetOffsetRefMapper = &TriggerRefMapper{}
+
+// TriggerRefMapper implements the ResetOffsetRefMapper for Knative Triggers
+type TriggerRefMapper struct {
+	logger                   *zap.Logger
+	triggerLister            eventinglisters.TriggerLister
+	topicNameMapper          TriggerTopicNameMapper
+	groupIdMapper            TriggerConsumerGroupIdMapper
+	connectionPoolKeyMapper  TriggerConnectionPoolKeyMapper
+	dataPlaneNamespaceMapper TriggerDataPlaneNamespaceMapper
+	dataPlaneLabelsMapper    TriggerDataPlaneLabelsMapper
+}
+
+// NewTriggerRefMapper returns an initialized TriggerRefMapper
+func NewTriggerRefMapper(ctx context.Context,
+	topicNameMapper TriggerTopicNameMapper,
+	groupIdMapper TriggerConsumerGroupIdMapper,
+	connectionPoolKeyMapper TriggerConnectionPoolKeyMapper,
+	dataPlaneNamespaceMapper TriggerDataPlaneNamespaceMapper,
+	dataPlaneLabelsMapper TriggerDataPlaneLabelsMapper) *TriggerRefMapper {
+
+	// Get The Trigger Informer From Context (Context Must Have Injected Informers From SharedMain())
+	triggerInformer := triggerinformers.Get(ctx)
+
+	// Return An Initialized TriggerRefMapper
+	return &TriggerRefMapper{
+		logger:                   logging.FromContext(ctx).Desugar(),
+		triggerLister:            triggerInformer.Lister(),
+		topicNameMapper:          topicNameMapper,
+		groupIdMapper:            groupIdMapper,
+		connectionPoolKeyMapper:  connectionPoolKeyMapper,
+		dataPlaneNamespaceMapper: dataPlaneNamespaceMapper,
+		dataPlaneLabelsMapper:    dataPlaneLabelsMapper,
+	}
+}
+
+// MapRef implements the ResetOffsetRefMapper interface for Trigger references. It will return an
+// error in all cases other than successfully mapping the ResetOffset.Spec.Ref to a Kafka Topic / Group.
+func (m *TriggerRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (*RefInfo, error) {
+
+	// Validate The ResetOffset
+	if resetOffset == nil {
+		m.logger.Warn("Received nil ResetOffset argument")
+		return nil, fmt.Errorf("unable to map nil ResetOffset")
+	}
+
+	// Get The ResetOffset Ref From Spec & Enhance Logger
+	ref := resetOffset.Spec.Ref
+	logger := m.logger.With(zap.Any("Ref", ref))
+
+	// Validate The Reference
+	if !isTriggerRef(ref) {
+		logger.Warn("Received ResetOffset with non Trigger reference")
+		return nil, fmt.Errorf("received ResetOffset with non Trigger reference: %v", ref)
+	}
+	if ref.Name == "" {
+		logger.Warn("Received ResetOffset with unnamed Trigger reference")
+		return nil, fmt.Errorf("received ResetOffset with unnamed Trigger reference: %v", ref)
+	}
+
+	// Default Optional Ref.Namespace If Not Provided
+	refNamespace := ref.Namespace
+	if refNamespace == "" {
+		refNamespace = resetOffset.Namespace
+	}
+
+	// Attempt To Get The Specified Trigger
+	trigger, err := m.triggerLister.Triggers(refNamespace).Get(ref.Name)
+	if err != nil {
+		logger.Error("Failed to get Trigger referenced by ResetOffset", zap.Error(err))
+		return nil, fmt.Errorf("failed to get Trigger referenced by ResetOffset.Spec.Ref '%v': %v", ref, err)
+	}
+
+	// Map The Trigger To Kafka Topic Name Via Custom TriggerTopicNameMapper
+	topicName, err := m.topicNameMapper(trigger)
+	if err != nil {
+		logger.Error("Failed to map Knative Trigger to Kafka Topic name", zap.Error(err))
+		return nil, fmt.Errorf("failed to map Knative Trigger '%v' to Kafka Topic name: %v", ref, err)
+	}
+
+	// Map The Trigger To Kafka ConsumerGroup ID Via Custom TriggerConsumerGroupIdMapper
+	groupId, err := m.groupIdMapper(trigger)
+	if err != nil {
+		logger.Error("Failed to map Knative Trigger to Kafka ConsumerGroup ID", zap.Error(err))
+		return nil, fmt.Errorf("failed to map Knative Trigger '%v' to Kafka ConsumerGroup ID: %v", ref, err)
+	}
+
+	// Map The Trigger To The control-protocol ControlPlaneConnectionPool Key Via Custom TriggerConnectionPoolKeyMapper
+	connectionPoolKey, err := m.connectionPoolKeyMapper(trigger)
+	if err != nil {
+		logger.Error("Failed to map Knative Trigger to ControlPlaneConnectionPool Key", zap.Error(err))
+		return nil, fmt.Errorf("failed to map Knative Trigger '%v' to ConnectionPool Key: %v", ref, err)
+	}
+
+	// Map The Trigger To The Kubernetes Namespace Of The DataPlane Pods
+	dataPlaneNamespace, err := m.dataPlaneNamespaceMapper(trigger)
+	if err != nil {
+		logger.Error("Failed to map Knative Trigger to DataPlane Namespace", zap.Error(err))
+		return nil, fmt.Errorf("failed to map Knative Trigger '%v' to DataPlane Namespace: %v", ref, err)
+	}
+
+	// Map The Trigger To The Kubernetes Labels Of The DataPlane Pods
+	dataPlaneLabels, err := m.dataPlaneLabelsMapper(trigger)
+	if err != nil {
+		logger.Error("Failed to map Knative Trigger to DataPlane Pod Labels", zap.Error(err))
+		return nil, fmt.Errorf("failed to map Knative Trigger '%v' to DataPlane Pod Labels: %v", ref, err)
+	}
+
+	// Successfully Mapped The Ref - Return Results
+	return &RefInfo{
+		TopicName:          topicName,
+		GroupId:            groupId,
+		ConnectionPoolKey:  connectionPoolKey,
+		DataPlaneNamespace: dataPlaneNamespace,
+		DataPlaneLabels:    dataPlaneLabels,
+	}, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger/fake" // Knative Fake Informer Injection
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+)
+
+const (
+	TriggerNamespace = "trigger-namespace"
+	TriggerName      = "trigger-name"
+)
+
+func TestNewTriggerRefMapperFactory(t *testing.T) {
+
+	// Create A Context With Test Logger & Fake Informers (See Injection "_" Imports Above!)
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+
+	// Create A Factory Whose TopicNameMapperFactory Overrides The TopicNameMapper
+	factory := NewTriggerRefMapperFactory(
+		newMockTriggerTopicNameMapper(t, nil, "UnexpectedTopicName", nil),
+		newMockTriggerConsumerGroupIdMapper(t, nil, GroupId, nil),
+		newMockTriggerConnectionPoolKeyMapper(t, nil, ConnectionPoolKey, nil),
+		newMockTriggerDataPlaneNamespaceMapper(t, nil, DataPlaneNamespace, nil),
+		newMockTriggerDataPlaneLabelsMapper(t, nil, DataPlaneLabels, nil))
+	factory.TopicNameMapperFactory = func(factoryCtx context.Context) TriggerTopicNameMapper {
+		assert.Equal(t, ctx, factoryCtx)
+		return newMockTriggerTopicNameMapper(t, nil, TopicName, nil)
+	}
+
+	// Perform The Test & Verify The TopicNameMapper From The Factory Is Used
+	refMapper := factory.Create(ctx).(*TriggerRefMapper)
+	assert.NotNil(t, refMapper.triggerLister)
+	topicName, err := refMapper.topicNameMapper(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, TopicName, topicName)
+}
+
+func TestRefMapperFactoriesHandles(t *testing.T) {
+	triggerRef := duckv1.KReference{Kind: "Trigger", APIVersion: eventingv1.SchemeGroupVersion.String(), Name: TriggerName}
+	subscriptionRef := duckv1.KReference{Kind: "Subscription", APIVersion: messagingv1.SchemeGroupVersion.String(), Name: SubscriptionName}
+	otherRef := duckv1.KReference{Kind: "Trigger", APIVersion: "example.com/v1", Name: TriggerName}
+
+	triggerFactory := &TriggerRefMapperFactory{}
+	assert.True(t, triggerFactory.Handles(triggerRef))
+	assert.False(t, triggerFactory.Handles(subscriptionRef))
+	assert.False(t, triggerFactory.Handles(otherRef))
+
+	subscriptionFactory := &SubscriptionRefMapperFactory{}
+	assert.True(t, subscriptionFactory.Handles(subscriptionRef))
+	assert.False(t, subscriptionFactory.Handles(triggerRef))
+	assert.False(t, subscriptionFactory.Handles(otherRef))
+}
+
+func TestTriggerRefMapper_MapRef(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	testErr := fmt.Errorf("test-error")
+
+	// Create A Test Trigger In The Lister
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: TriggerNamespace,
+			Name:      TriggerName,
+		},
+	}
+	resetOffsetNamespaceTrigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controllertesting.ResetOffsetNamespace,
+			Name:      TriggerName,
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.Nil(t, indexer.Add(trigger))
+	assert.Nil(t, indexer.Add(resetOffsetNamespaceTrigger))
+
+	triggerRef := &duckv1.KReference{
+		Kind:       "Trigger",
+		APIVersion: eventingv1.SchemeGroupVersion.String(),
+		Namespace:  TriggerNamespace,
+		Name:       TriggerName,
+	}
+	successRefInfo := &RefInfo{
+		TopicName:          TopicName,
+		GroupId:            GroupId,
+		ConnectionPoolKey:  ConnectionPoolKey,
+		DataPlaneNamespace: DataPlaneNamespace,
+		DataPlaneLabels:    DataPlaneLabels,
+	}
+
+	// Define The Test Cases (The Mapper Errors Are Those Of The Mapper Of The Specified Index)
+	tests := []struct {
+		name        string
+		resetOffset *kafkav1alpha1.ResetOffset
+		trigger     *eventingv1.Trigger
+		mapperErr   int
+		wantRefInfo *RefInfo
+		wantErr     bool
+	}{
+		{
+			name:        "Success",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantRefInfo: successRefInfo,
+		},
+		{
+			name:        "ResetOffset.Spec.Ref Without Namespace",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Trigger", APIVersion: eventingv1.SchemeGroupVersion.String(), Name: TriggerName})),
+			trigger:     resetOffsetNamespaceTrigger,
+			wantRefInfo: successRefInfo,
+		},
+		{
+			name:    "Nil ResetOffset",
+			wantErr: true,
+		},
+		{
+			name:        "Subscription Reference",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Subscription", APIVersion: messagingv1.SchemeGroupVersion.String(), Namespace: TriggerNamespace, Name: TriggerName})),
+			wantErr:     true,
+		},
+		{
+			name:        "ResetOffset.Spec.Ref Without Name",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Trigger", APIVersion: eventingv1.SchemeGroupVersion.String(), Namespace: TriggerNamespace})),
+			wantErr:     true,
+		},
+		{
+			name:        "Trigger Not Found",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Trigger", APIVersion: eventingv1.SchemeGroupVersion.String(), Namespace: TriggerNamespace, Name: "missing"})),
+			wantErr:     true,
+		},
+		{name: "TopicName Mapper Error", resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)), mapperErr: 1, wantErr: true},
+		{name: "GroupId Mapper Error", resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)), mapperErr: 2, wantErr: true},
+		{name: "ConnectionPoolKey Mapper Error", resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)), mapperErr: 3, wantErr: true},
+		{name: "DataPlaneNamespace Mapper Error", resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)), mapperErr: 4, wantErr: true},
+		{name: "DataPlaneLabels Mapper Error", resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)), mapperErr: 5, wantErr: true},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expectedTrigger := trigger
+			if test.trigger != nil {
+				expectedTrigger = test.trigger
+			}
+			mapperErr := func(index int) error {
+				if index == test.mapperErr {
+					return testErr
+				}
+				return nil
+			}
+			triggerRefMapper := &TriggerRefMapper{
+				logger:                   logger,
+				triggerLister:            eventinglisters.NewTriggerLister(indexer),
+				topicNameMapper:          newMockTriggerTopicNameMapper(t, expectedTrigger, TopicName, mapperErr(1)),
+				groupIdMapper:            newMockTriggerConsumerGroupIdMapper(t, expectedTrigger, GroupId, mapperErr(2)),
+				connectionPoolKeyMapper:  newMockTriggerConnectionPoolKeyMapper(t, expectedTrigger, ConnectionPoolKey, mapperErr(3)),
+				dataPlaneNamespaceMapper: newMockTriggerDataPlaneNamespaceMapper(t, expectedTrigger, DataPlaneNamespace, mapperErr(4)),
+				dataPlaneLabelsMapper:    newMockTriggerDataPlaneLabelsMapper(t, expectedTrigger, DataPlaneLabels, mapperErr(5)),
+			}
+
+			// Perform The Test - Map A Trigger To Kafka Topic Name & ConsumerGroup ID
+			refInfo, err := triggerRefMapper.MapRef(test.resetOffset)
+
+			// Validate The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantRefInfo, refInfo)
+		})
+	}
+}
+
+//
+// Mock Mappers
+//
+
+func newMockTriggerTopicNameMapper(t *testing.T, expectedTrigger *eventingv1.Trigger, topicName string, err error) TriggerTopicNameMapper {
+	return func(trigger *eventingv1.Trigger) (string, error) {
+		assert.Equal(t, expectedTrigger, trigger)
+		return topicName, err
+	}
+}
+
+func newMockTriggerConsumerGroupIdMapper(t *testing.T, expectedTrigger *eventingv1.Trigger, groupId string, err error) TriggerConsumerGroupIdMapper {
+	return func(trigger *eventingv1.Trigger) (string, error) {
+		assert.Equal(t, expectedTrigger, trigger)
+		return groupId, err
+	}
+}
+
+func newMockTriggerConnectionPoolKeyMapper(t *testing.T, expectedTrigger *eventingv1.Trigger, connectionPoolKey string, err error) TriggerConnectionPoolKeyMapper {
+	return func(trigger *eventingv1.Trigger) (string, error) {
+		assert.Equal(t, expectedTrigger, trigger)
+		return connectionPoolKey, err
+	}
+}
+
+func newMockTriggerDataPlaneNamespaceMapper(t *testing.T, expectedTrigger *eventingv1.Trigger, dataPlaneNamespace string, err error) TriggerDataPlaneNamespaceMapper {
+	return func(trigger *eventingv1.Trigger) (string, error) {
+		assert.Equal(t, expectedTrigger, trigger)
+		return dataPlaneNamespace, err
+	}
+}
+
+func newMockTriggerDataPlaneLabelsMapper(t *testing.T, expectedTrigger *eventingv1.Trigger, dataPlaneLabels map[string]string, err error) TriggerDataPlaneLabelsMapper {
+	return func(trigger *eventingv1.Trigger) (map[string]string, error) {
+		assert.Equal(t, expectedTrigger, trigger)
+		return dataPlaneLabels, err
+	}
+}
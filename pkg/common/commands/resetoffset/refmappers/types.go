@@ -19,6 +19,8 @@ package refmappers
 import (
 	"context"
 
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 )
 
@@ -37,6 +39,14 @@ type ResetOffsetRefMapper interface {
 	MapRef(*kafkav1alpha1.ResetOffset) (*RefInfo, error)
 }
 
+// ResetOffsetRefFilter is an optional interface of ResetOffsetRefMapperFactory implementations which only
+// map ResetOffset.Spec.Refs of particular Kinds.  The ResetOffset Controller ignores the ResetOffsets whose
+// Ref is not handled, so that the Controllers of different Kafka Channels/Brokers/etc may run side by side
+// without failing each other's ResetOffsets.
+type ResetOffsetRefFilter interface {
+	Handles(ref duckv1.KReference) bool
+}
+
 // RefInfo contains the data necessary for ResetOffset reconciliation which is specific
 // to a particular use-case, as provided by a customized ResetOffsetRefMapper implementation.
 // This allows implementations of Kafka Channels/Brokers/etc to differ from one another
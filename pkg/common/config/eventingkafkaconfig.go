@@ -42,14 +42,84 @@ type EKKubernetesConfig struct {
 	Replicas              int               `json:"replicas,omitempty"`
 }
 
-// EKReceiverConfig has the base Kubernetes fields (Cpu, Memory, Replicas) only
+// EKReceiverConfig has the base Kubernetes fields (Cpu, Memory, Replicas) and the Producer settings
 type EKReceiverConfig struct {
 	EKKubernetesConfig
+	Producer EKReceiverProducerConfig `json:"producer,omitempty"`
 }
 
-// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas) only
+// EKReceiverProducerConfig selects the producer with which the distributed KafkaChannel's receiver produces
+// events.  In Async mode the events of concurrent requests are batched (up to MaxBatchMessages, waiting at most
+// LingerMillis for a batch to fill) onto an asynchronous producer, and each request completes when its own
+// event is acknowledged.  A zero MaxBatchMessages is replaced with a default.
+type EKReceiverProducerConfig struct {
+	Async            bool `json:"async,omitempty"`
+	MaxBatchMessages int  `json:"maxBatchMessages,omitempty"`
+	LingerMillis     int  `json:"lingerMillis,omitempty"`
+}
+
+// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas) and the optional debug API
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+	DebugApi EKDebugApiConfig `json:"debugApi,omitempty"`
+}
+
+// EKDebugApiConfig enables the dispatcher's authenticated debug API, which lists the partitions & offsets of its
+// KafkaChannels, fetches their events and replays them to a single subscriber on the specified Port (the default
+// port of the debug API is used if zero).
+type EKDebugApiConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+	Port    int  `json:"port,omitempty"`
+}
+
+// EKSharedDispatcherConfig enables a shared pool of dispatcher replicas which serves many KafkaChannels,
+// rather than a dedicated dispatcher Deployment per KafkaChannel.  The pool's runtime characteristics
+// (Cpu, Memory, Replicas, etc.) are taken from the EKDispatcherConfig.
+type EKSharedDispatcherConfig struct {
+	Enabled  bool  `json:"enabled,omitempty"`
+	Capacity int32 `json:"capacity,omitempty"` // Maximum number of KafkaChannels served by each dispatcher replica
+}
+
+// EKDispatcherAutoscalingConfig enables lag-driven autoscaling of the distributed KafkaChannel's dedicated
+// dispatcher Deployments.  The consumer-group lag of each KafkaChannel's subscribers is periodically evaluated
+// and the dispatcher is scaled between MinReplicas and MaxReplicas (capped at the KafkaChannel's NumPartitions)
+// so that each replica handles at most LagPerReplica messages.  Zero values are replaced with defaults.
+type EKDispatcherAutoscalingConfig struct {
+	Enabled                  bool  `json:"enabled,omitempty"`
+	MinReplicas              int32 `json:"minReplicas,omitempty"`
+	MaxReplicas              int32 `json:"maxReplicas,omitempty"` // Zero indicates the KafkaChannel's NumPartitions
+	LagPerReplica            int64 `json:"lagPerReplica,omitempty"`
+	IntervalSeconds          int   `json:"intervalSeconds,omitempty"`
+	ScaleUpCooldownSeconds   int   `json:"scaleUpCooldownSeconds,omitempty"`
+	ScaleDownCooldownSeconds int   `json:"scaleDownCooldownSeconds,omitempty"`
+}
+
+// EKClaimCheckConfig configures the blob store to which the payloads of large events are offloaded by
+// KafkaChannels that opt-in to claim-check mode.  The Store must be one of "s3" or "filesystem", and the
+// ThresholdBytes is used by KafkaChannels which do not specify their own threshold.
+type EKClaimCheckConfig struct {
+	Store          string                       `json:"store,omitempty"`
+	ThresholdBytes int64                        `json:"thresholdBytes,omitempty"`
+	S3             EKClaimCheckS3Config         `json:"s3,omitempty"`
+	Filesystem     EKClaimCheckFilesystemConfig `json:"filesystem,omitempty"`
+}
+
+// EKClaimCheckS3Config configures an S3-compatible (AWS S3, MinIO, etc.) claim-check blob store.  The optional
+// CredentialsSecret (in the namespace of the receiver / dispatcher Deployments) must contain "accessKeyId"
+// and "secretAccessKey" keys.
+type EKClaimCheckS3Config struct {
+	Endpoint          string `json:"endpoint,omitempty"`
+	Region            string `json:"region,omitempty"`
+	Bucket            string `json:"bucket,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// EKClaimCheckFilesystemConfig configures a filesystem claim-check blob store rooted at Path, which should be
+// a shared (ReadWriteMany) volume.  The optional PersistentVolumeClaim is mounted at Path in the receiver and
+// dispatcher Deployments.
+type EKClaimCheckFilesystemConfig struct {
+	Path                  string `json:"path,omitempty"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
 }
 
 // EKCloudEventConfig contains the values send to the Knative cloudevents' ConfigureConnectionArgs function
@@ -66,6 +136,13 @@ type EKKafkaConfig struct {
 	AuthSecretNamespace string `json:"authSecretNamespace,omitempty"`
 }
 
+// EKStrimziConfig identifies the Strimzi-managed Kafka cluster whose Topic Operator manages the KafkaTopic
+// resources created by the "strimzi" AdminType.  Namespace is the namespace watched by the Topic Operator.
+type EKStrimziConfig struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
 // EKSourceConfig is reserved for configuration fields needed by the Kafka Source component
 type EKSourceConfig struct {
 }
@@ -73,9 +150,20 @@ type EKSourceConfig struct {
 // EKChannelConfig contains items relevant to the eventing-kafka channels
 // NOTE:  Currently the consolidated channel type does not make use of most of these fields
 type EKChannelConfig struct {
-	Dispatcher EKDispatcherConfig `json:"dispatcher,omitempty"` // Consolidated and Distributed channels
-	Receiver   EKReceiverConfig   `json:"receiver,omitempty"`   // Distributed channel only
-	AdminType  string             `json:"adminType,omitempty"`  // Distributed channel only
+	Dispatcher       EKDispatcherConfig            `json:"dispatcher,omitempty"`       // Consolidated and Distributed channels
+	SharedDispatcher EKSharedDispatcherConfig      `json:"sharedDispatcher,omitempty"` // Distributed channel only
+	Autoscaling      EKDispatcherAutoscalingConfig `json:"autoscaling,omitempty"`      // Distributed channel only
+	Receiver         EKReceiverConfig              `json:"receiver,omitempty"`         // Distributed channel only
+	AdminType        string                        `json:"adminType,omitempty"`        // Distributed channel only
+	Strimzi          EKStrimziConfig               `json:"strimzi,omitempty"`          // Distributed channel only
+	ClaimCheck       EKClaimCheckConfig            `json:"claimCheck,omitempty"`       // Consolidated and Distributed channels
+}
+
+// EKBrokerConfig contains items relevant to the Kafka Broker, whose Topics are created with these settings
+type EKBrokerConfig struct {
+	NumPartitions     int32  `json:"numPartitions,omitempty"`
+	ReplicationFactor int16  `json:"replicationFactor,omitempty"`
+	RetentionDuration string `json:"retentionDuration,omitempty"` // ISO-8601 Duration (e.g. "PT168H")
 }
 
 // EKSaramaConfig holds the sarama.Config struct (populated separately), and the global Sarama debug logging flag
@@ -87,6 +175,7 @@ type EKSaramaConfig struct {
 // EventingKafkaConfig is the main struct that holds the Receiver, Dispatcher, and Kafka sub-items
 type EventingKafkaConfig struct {
 	Channel     EKChannelConfig         `json:"channel,omitempty"`
+	Broker      EKBrokerConfig          `json:"broker,omitempty"`
 	CloudEvents EKCloudEventConfig      `json:"cloudevents,omitempty"`
 	Kafka       EKKafkaConfig           `json:"kafka,omitempty"`
 	Sarama      EKSaramaConfig          `json:"sarama,omitempty"`
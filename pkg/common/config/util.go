@@ -103,6 +103,16 @@ func GetAuthConfigFromSecret(secret *corev1.Secret) *client.KafkaAuthConfig {
 		SaslType: saslType,
 	}
 
+	// OAUTHBEARER Uses The Client Credentials Of The Secret Instead Of The Username & Password
+	if saslType == sarama.SASLTypeOAuth {
+		authConfig.SASL.OAuth = &client.KafkaOAuthConfig{
+			ClientId:     string(secret.Data[constants.KafkaSecretKeyOAuthClientId]),
+			ClientSecret: string(secret.Data[constants.KafkaSecretKeyOAuthClientSecret]),
+			TokenUrl:     string(secret.Data[constants.KafkaSecretKeyOAuthTokenUrl]),
+			Scopes:       client.ParseScopes(string(secret.Data[constants.KafkaSecretKeyOAuthScopes])),
+		}
+	}
+
 	return &authConfig
 }
 
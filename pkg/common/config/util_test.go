@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -160,6 +161,16 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 				SaslPassword: []byte("test-password"),
 			},
 		},
+		{
+			name: "Valid secret, OAUTHBEARER",
+			data: map[string][]byte{
+				constants.KafkaSecretKeySaslType:          []byte(sarama.SASLTypeOAuth),
+				constants.KafkaSecretKeyOAuthClientId:     []byte("test-client-id"),
+				constants.KafkaSecretKeyOAuthClientSecret: []byte("test-client-secret"),
+				constants.KafkaSecretKeyOAuthTokenUrl:     []byte("https://test-idp/token"),
+				constants.KafkaSecretKeyOAuthScopes:       []byte("kafka"),
+			},
+		},
 		{
 			name:      "Valid secret, backwards-compatibility, nil data",
 			data:      nil,
@@ -192,6 +203,13 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 				assertKey(t, testCase.data, constants.KafkaSecretKeySaslType, kafkaAuth.SASL.SaslType)
 				assertKey(t, testCase.data, SaslUser, kafkaAuth.SASL.User)
 				assertKey(t, testCase.data, SaslPassword, kafkaAuth.SASL.Password)
+				if kafkaAuth.SASL.OAuth != nil {
+					assertKey(t, testCase.data, constants.KafkaSecretKeyOAuthClientId, kafkaAuth.SASL.OAuth.ClientId)
+					assertKey(t, testCase.data, constants.KafkaSecretKeyOAuthClientSecret, kafkaAuth.SASL.OAuth.ClientSecret)
+					assertKey(t, testCase.data, constants.KafkaSecretKeyOAuthTokenUrl, kafkaAuth.SASL.OAuth.TokenUrl)
+					assert.Equal(t, []string{"kafka"}, kafkaAuth.SASL.OAuth.Scopes)
+					assert.True(t, kafkaAuth.SASL.HasCredentials())
+				}
 				if kafkaAuth.TLS != nil {
 					assertKey(t, testCase.data, TlsCacert, kafkaAuth.TLS.Cacert)
 					assertKey(t, testCase.data, TlsUsercert, kafkaAuth.TLS.Usercert)
@@ -56,6 +56,14 @@ const (
 	// to config-kafka and apply them in the dispatcher deployment
 	ConfigMapHashAnnotationKey = "kafka.eventing.knative.dev/configmap-hash"
 
+	// SecretVersionsAnnotationKey is a pod template annotation used by the KafkaSource controller to track updates
+	// to the secrets referenced by a source (by resourceVersion) and roll its receive adapter deployment when they change
+	SecretVersionsAnnotationKey = "kafka.eventing.knative.dev/secret-versions"
+
+	// SubscriptionFilterAnnotationKey is an annotation of a Subscription to a KafkaChannel holding the JSON serialized
+	// SubscriptionsAPIFilter which events must pass in order to be dispatched to its subscriber
+	SubscriptionFilterAnnotationKey = "kafka.eventing.knative.dev/filter"
+
 	// CurrentConfigVersion is the current version which should be in the "version" field of the config-kafka configmap
 	CurrentConfigVersion = "1.0.0"
 
@@ -67,10 +75,24 @@ const (
 	KafkaSecretKeyPassword = "password"
 	// KafkaSecretKeySaslType is the SASL type key in the Kafka Auth Config Secret
 	KafkaSecretKeySaslType = "sasltype"
+	// KafkaSecretKeyOAuthClientId is the OAUTHBEARER client id key in the Kafka Auth Config Secret
+	KafkaSecretKeyOAuthClientId = "clientid"
+	// KafkaSecretKeyOAuthClientSecret is the OAUTHBEARER client secret key in the Kafka Auth Config Secret
+	KafkaSecretKeyOAuthClientSecret = "clientsecret"
+	// KafkaSecretKeyOAuthTokenUrl is the OAUTHBEARER token endpoint URL key in the Kafka Auth Config Secret
+	KafkaSecretKeyOAuthTokenUrl = "tokenurl"
+	// KafkaSecretKeyOAuthScopes is the OAUTHBEARER (comma or space separated) scopes key in the Kafka Auth Config Secret
+	KafkaSecretKeyOAuthScopes = "scopes"
 
 	// KnativeLoggingConfigMapNameEnvVarKey Is The Environment Variable Used For Knative Logging Configuration
 	KnativeLoggingConfigMapNameEnvVarKey = "CONFIG_LOGGING_NAME" // Note - Matches value of configMapNameEnv constant in Knative.dev/pkg/logging !
 
 	// KafkaTopicConfigRetentionMs is the key in the Sarama TopicDetail ConfigEntries map for retention time (in ms)
 	KafkaTopicConfigRetentionMs = "retention.ms"
+
+	// KafkaTopicConfigMaxMessageBytes is the Kafka Topic config key for the largest allowed record batch size
+	KafkaTopicConfigMaxMessageBytes = "max.message.bytes"
+
+	// KafkaTopicConfigMinInsyncReplicas is the Kafka Topic config key for the replicas which must acknowledge "acks=all" writes
+	KafkaTopicConfigMinInsyncReplicas = "min.insync.replicas"
 )
@@ -32,6 +32,7 @@ type KafkaConsumerHandler interface {
 	// When this function returns true, the consumer group offset is marked as consumed.
 	// The returned error is enqueued in errors channel.
 	Handle(context context.Context, message *sarama.ConsumerMessage) (bool, error)
+	// SetReady is called when a partition is claimed (ready) or released (not ready), not when handling a message fails.
 	SetReady(partition int32, ready bool)
 	GetConsumerGroup() string
 }
@@ -161,7 +162,6 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 			if err != nil {
 				consumer.logger.Infow("Failure while handling a message", zap.String("topic", message.Topic), zap.Int32("partition", message.Partition), zap.Int64("offset", message.Offset), zap.Error(err))
 				consumer.errors <- err
-				consumer.handler.SetReady(claim.Partition(), false)
 			}
 
 			c <- mustMark
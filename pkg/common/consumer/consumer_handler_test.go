@@ -104,6 +104,7 @@ func (m mockConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 type mockMessageHandler struct {
 	shouldErr  bool
 	shouldMark bool
+	ready      *bool
 }
 
 func (m mockMessageHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) (bool, error) {
@@ -114,7 +115,10 @@ func (m mockMessageHandler) Handle(ctx context.Context, message *sarama.Consumer
 	}
 }
 
-func (m mockMessageHandler) SetReady(int32, bool) {
+func (m mockMessageHandler) SetReady(_ int32, ready bool) {
+	if m.ready != nil {
+		*m.ready = ready
+	}
 }
 
 func (m mockMessageHandler) GetConsumerGroup() string {
@@ -141,6 +145,7 @@ func Test(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("shouldErr: %v, shouldMark: %v", test.shouldErr, test.shouldMark), func(t *testing.T) {
 			errorCh := make(chan error, 1)
+			test.ready = new(bool)
 			cgh := NewConsumerHandler(zap.NewNop().Sugar(), test, errorCh)
 
 			session := mockConsumerGroupSession{}
@@ -162,6 +167,11 @@ func Test(t *testing.T) {
 				}
 			}
 
+			// A single failed message must not release the claimed partition
+			if !*test.ready {
+				t.Errorf("Partition was not ready after consuming")
+			}
+
 			_ = cgh.Cleanup(&session)
 			close(errorCh)
 
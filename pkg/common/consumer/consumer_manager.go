@@ -28,6 +28,7 @@ Usage:
 - IsManaged() returns true if a given GroupId is under management
 - Reconfigure() allows you to change consumer factory settings (automatically stopping and
   restarting all managed ConsumerGroups)
+- SetLagCollector() optionally collects the consumer group lag of all managed ConsumerGroups
 */
 
 package consumer
@@ -101,6 +102,7 @@ type KafkaConsumerGroupManager interface {
 	IsStopped(groupId string) bool
 	GetNotificationChannel() <-chan ManagerEvent
 	ClearNotifications()
+	SetLagCollector(collector *LagCollector)
 }
 
 // groupMap is a mapping of GroupIDs to managed Consumer Group interfaces
@@ -117,6 +119,7 @@ type kafkaConsumerGroupManagerImpl struct {
 	notifyChannels []chan ManagerEvent
 	eventLock      sync.Mutex
 	offsetsChecker ConsumerGroupOffsetsChecker
+	lagCollector   *LagCollector // Optional collector of the consumer group lag of the managed groups
 }
 
 // Verify that the kafkaConsumerGroupManagerImpl satisfies the KafkaConsumerGroupManager interface
@@ -240,6 +243,7 @@ func (m *kafkaConsumerGroupManagerImpl) Reconfigure(brokers []string, config *sa
 	}
 
 	m.factory = &kafkaConsumerGroupFactoryImpl{addrs: brokers, config: config, offsetsChecker: m.offsetsChecker}
+	m.lagCollector.Reconfigure(brokers, config)
 
 	// Restart any groups this function stopped
 	m.logger.Info("Reconfigure Consumer Group Manager - Starting All Managed Consumer Groups")
@@ -293,6 +297,7 @@ func (m *kafkaConsumerGroupManagerImpl) StartConsumerGroup(ctx context.Context,
 	// Add the Sarama ConsumerGroup we obtained from the factory to the managed group map,
 	// so that it can be stopped and started via control-protocol messages.
 	m.setGroup(groupId, managedGrp)
+	m.registerLag(groupId, topics, handler, ref)
 	m.notify(ManagerEvent{Event: GroupCreated, GroupId: groupId})
 	return nil
 }
@@ -320,6 +325,21 @@ func (m *kafkaConsumerGroupManagerImpl) CloseConsumerGroup(groupId string) error
 	return nil
 }
 
+// SetLagCollector sets the LagCollector with which the consumer group lag of every managed group is collected.
+func (m *kafkaConsumerGroupManagerImpl) SetLagCollector(collector *LagCollector) {
+	m.lagCollector = collector
+}
+
+// registerLag registers a newly managed group with the LagCollector, tagged with the Subscription
+// UID of its handler (if the handler dispatches to a single Subscription)
+func (m *kafkaConsumerGroupManagerImpl) registerLag(groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName) {
+	target := LagTarget{Ref: ref, Topics: topics}
+	if subscriberHandler, ok := handler.(SubscriberHandler); ok {
+		target.Subscriber = subscriberHandler.GetSubscriberUID()
+	}
+	m.lagCollector.Register(groupId, target)
+}
+
 // Errors returns the errors channel of the managedGroup associated with the given groupId.  This channel
 // is different than using the Errors() channel of a ConsumerGroup directly, as it will remain open during
 //
@@ -447,6 +467,7 @@ func (m *kafkaConsumerGroupManagerImpl) removeGroup(groupId string) {
 	m.groupLock.Lock()
 	defer m.groupLock.Unlock()
 	delete(m.groups, groupId)
+	m.lagCollector.Unregister(groupId)
 }
 
 // lockBefore will lock the managedGroup corresponding to the groupId, if lock.LockBefore is true
@@ -178,6 +178,31 @@ func TestStartConsumerGroup(t *testing.T) {
 	}
 }
 
+// subscriberHandler is a KafkaConsumerHandler dispatching to a single Subscription
+type subscriberHandler struct {
+	KafkaConsumerHandler
+	uid types.UID
+}
+
+func (h subscriberHandler) GetSubscriberUID() types.UID {
+	return h.uid
+}
+
+func TestSetLagCollector(t *testing.T) {
+	defer restoreNewConsumerGroup(newConsumerGroup)
+	manager, _, _, _ := getManagerWithMockGroup(t, "", false)
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), KafkaChannelResourceGroup, []string{}, &sarama.Config{})
+	manager.SetLagCollector(collector)
+
+	ref := types.NamespacedName{Namespace: "test-namespace", Name: "test-name"}
+	err := manager.StartConsumerGroup(context.TODO(), "testid", []string{"test-topic"}, subscriberHandler{uid: "test-uid"}, ref)
+	assert.Nil(t, err)
+	assert.Equal(t, LagTarget{Ref: ref, Subscriber: "test-uid", Topics: []string{"test-topic"}}, collector.targets["testid"])
+
+	manager.(*kafkaConsumerGroupManagerImpl).removeGroup("testid")
+	assert.Empty(t, collector.targets)
+}
+
 func TestCloseConsumerGroup(t *testing.T) {
 	defer restoreNewConsumerGroup(newConsumerGroup) // must use if calling getManagerWithMockGroup in the test
 
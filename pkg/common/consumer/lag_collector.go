@@ -0,0 +1,446 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+const (
+	// ConsumerGroupLagN is the number of records by which a consumer group trails the high-watermark of a partition.
+	ConsumerGroupLagN = "consumer_group_lag"
+
+	// ConsumerGroupLagSecondsN is the age of the oldest record of a partition not yet consumed by a consumer group.
+	ConsumerGroupLagSecondsN = "consumer_group_lag_seconds"
+
+	// DefaultLagInterval is the default interval at which the consumer group lag is collected.
+	DefaultLagInterval = 30 * time.Second
+
+	// Resource Groups With Which The Lag Of The KafkaChannel & Trigger ConsumerGroups Is Tagged
+	KafkaChannelResourceGroup = "kafkachannels.messaging.knative.dev"
+	TriggerResourceGroup      = "triggers.eventing.knative.dev"
+
+	// lagRecordTimeout bounds the wait for the oldest unconsumed record whose timestamp determines the lag in seconds.
+	lagRecordTimeout = 5 * time.Second
+
+	// lagSecondsBudget bounds the total time spent determining the lag in seconds during a single collection, after
+	// which the lag in seconds of the remaining partitions is omitted until the next collection.
+	lagSecondsBudget = 10 * time.Second
+
+	// lagSecondsConcurrency bounds the number of partitions of a ConsumerGroup whose oldest unconsumed record is
+	// fetched concurrently.
+	lagSecondsConcurrency = 8
+
+	// lagFetchBytes is the (small) default fetch size with which the oldest unconsumed records are fetched, as only
+	// the timestamp of the first record of each fetch is needed.
+	lagFetchBytes = 1024
+
+	// Label Keys Of The Consumer Group Lag Metrics
+	subscriberUidLabel = "subscriber_uid"
+	consumerGroupLabel = "consumer_group"
+	topicLabel         = "topic"
+	partitionLabel     = "partition"
+)
+
+// wrapper function for the Sarama function, to facilitate unit testing
+var newConsumerFromClient = sarama.NewConsumerFromClient
+
+// lagLabelKeys are the label keys of every consumer group lag TimeSeries (in the order of their label values)
+var lagLabelKeys = []metricdata.LabelKey{
+	{Key: eventingmetrics.LabelNamespaceName},
+	{Key: eventingmetrics.LabelName},
+	{Key: eventingmetrics.LabelResourceGroup},
+	{Key: subscriberUidLabel},
+	{Key: consumerGroupLabel},
+	{Key: topicLabel},
+	{Key: partitionLabel},
+}
+
+// LagTarget identifies the resource whose ConsumerGroup lag is collected, and the topics it consumes.
+type LagTarget struct {
+	Ref        types.NamespacedName // The KafkaChannel, KafkaSource or Trigger served by the ConsumerGroup
+	Subscriber types.UID            // Optional UID of the Subscription served by the ConsumerGroup
+	Topics     []string
+}
+
+// SubscriberHandler may be implemented by a KafkaConsumerHandler which dispatches to a single Subscription, so
+// that the lag of the managed ConsumerGroup is tagged with the Subscription's UID.
+type SubscriberHandler interface {
+	GetSubscriberUID() types.UID
+}
+
+// lagSample is the lag of a ConsumerGroup in a single partition, as of the most recent collection.
+type lagSample struct {
+	topic      string
+	partition  int32
+	lag        int64
+	seconds    float64
+	hasSeconds bool // False if the timestamp of the oldest unconsumed record could not be determined
+}
+
+// LagCollector periodically compares the committed offsets of the registered ConsumerGroups with the
+// high-watermarks of their partitions, and exports the resulting lag as OpenCensus gauges.  All of its
+// functions may be called on a nil LagCollector, which makes it optional wherever it is used.
+type LagCollector struct {
+	logger        *zap.Logger
+	resourceGroup string
+	lock          sync.RWMutex // Synchronizes access to the brokers, config, targets and samples
+	brokers       []string
+	config        *sarama.Config
+	targets       map[string]LagTarget   // LagTargets keyed by ConsumerGroup ID
+	samples       map[string][]lagSample // Most recently collected samples keyed by ConsumerGroup ID
+	startTime     time.Time
+	stopChan      chan struct{}
+	stoppedChan   chan struct{}
+}
+
+// Verify The LagCollector Implements The OpenCensus Producer Interface
+var _ metricproducer.Producer = (*LagCollector)(nil)
+
+// NewLagCollector returns a LagCollector for the ConsumerGroups of the specified resource group
+// (e.g. "kafkachannels.messaging.knative.dev") which uses the specified brokers and Sarama config.
+func NewLagCollector(logger *zap.Logger, resourceGroup string, brokers []string, config *sarama.Config) *LagCollector {
+	return &LagCollector{
+		logger:        logger,
+		resourceGroup: resourceGroup,
+		brokers:       brokers,
+		config:        config,
+		targets:       make(map[string]LagTarget),
+		samples:       make(map[string][]lagSample),
+	}
+}
+
+// Register starts collecting the lag of the specified ConsumerGroup.
+func (c *LagCollector) Register(groupId string, target LagTarget) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.targets[groupId] = target
+}
+
+// Unregister stops collecting the lag of the specified ConsumerGroup and removes its TimeSeries.
+func (c *LagCollector) Unregister(groupId string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.targets, groupId)
+	delete(c.samples, groupId)
+}
+
+// Reconfigure sets the brokers and Sarama config used by subsequent collections.
+func (c *LagCollector) Reconfigure(brokers []string, config *sarama.Config) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.brokers = brokers
+	c.config = config
+}
+
+// Start adds the LagCollector to the OpenCensus producers and collects the lag at the specified interval until stopped.
+func (c *LagCollector) Start(interval time.Duration) {
+	if c == nil || c.stopChan != nil {
+		return
+	}
+
+	c.startTime = time.Now()
+	c.stopChan = make(chan struct{})
+	c.stoppedChan = make(chan struct{})
+	metricproducer.GlobalManager().AddProducer(c)
+
+	go func() {
+		defer close(c.stoppedChan)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopChan:
+				c.logger.Info("Stopped Consumer Group Lag Collection")
+				return
+			case <-ticker.C:
+				c.collect()
+			}
+		}
+	}()
+}
+
+// Stop ends the periodic collection and removes the LagCollector from the OpenCensus producers.
+func (c *LagCollector) Stop() {
+	if c == nil || c.stopChan == nil {
+		return
+	}
+	metricproducer.GlobalManager().DeleteProducer(c)
+	close(c.stopChan)
+	<-c.stoppedChan
+	c.stopChan = nil
+}
+
+// Read implements the OpenCensus Producer interface, returning the lag of every registered ConsumerGroup
+func (c *LagCollector) Read() []*metricdata.Metric {
+	if c == nil {
+		return nil
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	now := time.Now()
+	var lagSeries, secondsSeries []*metricdata.TimeSeries
+	for _, groupId := range c.sortedGroupIds() {
+		target := c.targets[groupId]
+		for _, sample := range c.samples[groupId] {
+			labelValues := []metricdata.LabelValue{
+				metricdata.NewLabelValue(target.Ref.Namespace),
+				metricdata.NewLabelValue(target.Ref.Name),
+				metricdata.NewLabelValue(c.resourceGroup),
+				metricdata.NewLabelValue(string(target.Subscriber)),
+				metricdata.NewLabelValue(groupId),
+				metricdata.NewLabelValue(sample.topic),
+				metricdata.NewLabelValue(strconv.Itoa(int(sample.partition))),
+			}
+			lagSeries = append(lagSeries, &metricdata.TimeSeries{
+				LabelValues: labelValues,
+				Points:      []metricdata.Point{metricdata.NewInt64Point(now, sample.lag)},
+				StartTime:   c.startTime,
+			})
+			if sample.hasSeconds {
+				secondsSeries = append(secondsSeries, &metricdata.TimeSeries{
+					LabelValues: labelValues,
+					Points:      []metricdata.Point{metricdata.NewFloat64Point(now, sample.seconds)},
+					StartTime:   c.startTime,
+				})
+			}
+		}
+	}
+
+	return []*metricdata.Metric{
+		{
+			Descriptor: metricdata.Descriptor{
+				Name:        ConsumerGroupLagN,
+				Description: "Number of records by which the consumer group trails the high-watermark of the partition",
+				Unit:        metricdata.UnitDimensionless,
+				Type:        metricdata.TypeGaugeInt64,
+				LabelKeys:   lagLabelKeys,
+			},
+			TimeSeries: lagSeries,
+		},
+		{
+			Descriptor: metricdata.Descriptor{
+				Name:        ConsumerGroupLagSecondsN,
+				Description: "Age in seconds of the oldest record of the partition not yet consumed by the consumer group",
+				Unit:        metricdata.UnitDimensionless,
+				Type:        metricdata.TypeGaugeFloat64,
+				LabelKeys:   lagLabelKeys,
+			},
+			TimeSeries: secondsSeries,
+		},
+	}
+}
+
+// sortedGroupIds returns the IDs of the registered ConsumerGroups in a stable order (the lock must be held)
+func (c *LagCollector) sortedGroupIds() []string {
+	groupIds := make([]string, 0, len(c.targets))
+	for groupId := range c.targets {
+		groupIds = append(groupIds, groupId)
+	}
+	sort.Strings(groupIds)
+	return groupIds
+}
+
+// collect measures the lag of every registered ConsumerGroup with a single Kafka client
+func (c *LagCollector) collect() {
+
+	// Take A Snapshot Of The Targets So That Registration Isn't Blocked By Kafka
+	c.lock.RLock()
+	brokers := c.brokers
+	config := c.config
+	targets := make(map[string]LagTarget, len(c.targets))
+	for groupId, target := range c.targets {
+		targets[groupId] = target
+	}
+	c.lock.RUnlock()
+
+	if len(targets) == 0 {
+		return
+	}
+
+	// Only The Timestamp Of The First Record Of Each Fetch Is Needed, So Fetch As Little As Possible
+	lagConfig := *config
+	lagConfig.Consumer.Fetch.Default = lagFetchBytes
+
+	client, err := newSaramaClient(brokers, &lagConfig)
+	if err != nil {
+		c.logger.Error("Failed To Create Kafka Client For Consumer Group Lag", zap.Error(err))
+		return
+	}
+	defer client.Close()
+
+	clusterAdmin, err := newClusterAdminFromClient(client)
+	if err != nil {
+		c.logger.Error("Failed To Create Kafka ClusterAdmin For Consumer Group Lag", zap.Error(err))
+		return
+	}
+
+	consumer, err := newConsumerFromClient(client)
+	if err != nil {
+		c.logger.Error("Failed To Create Kafka Consumer For Consumer Group Lag", zap.Error(err))
+		return
+	}
+	defer consumer.Close()
+
+	secondsDeadline := time.Now().Add(lagSecondsBudget)
+	for groupId, target := range targets {
+		samples, err := c.collectGroup(client, clusterAdmin, consumer, groupId, target.Topics, secondsDeadline)
+		if err != nil {
+			c.logger.Warn("Failed To Collect Consumer Group Lag", zap.String("GroupId", groupId), zap.Error(err))
+			continue
+		}
+
+		// Only Record The Samples If The Group Wasn't Unregistered In The Meantime
+		c.lock.Lock()
+		if _, ok := c.targets[groupId]; ok {
+			c.samples[groupId] = samples
+		}
+		c.lock.Unlock()
+	}
+}
+
+// collectGroup measures the lag of a ConsumerGroup in every partition of its topics for which it has committed an
+// offset, omitting the lag in seconds of the partitions whose oldest unconsumed record isn't fetched before the deadline
+func (c *LagCollector) collectGroup(client sarama.Client, clusterAdmin sarama.ClusterAdmin, consumer sarama.Consumer, groupId string, topics []string, secondsDeadline time.Time) ([]lagSample, error) {
+
+	topicPartitions := make(map[string][]int32, len(topics))
+	for _, topic := range topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, err
+		}
+		topicPartitions[topic] = partitions
+	}
+
+	offsets, err := clusterAdmin.ListConsumerGroupOffsets(groupId, topicPartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []lagSample
+	var trailing []int           // Indices Of The Samples Of The Partitions In Which The ConsumerGroup Trails
+	var committedOffsets []int64 // Committed Offsets Of The Trailing Partitions
+	var newestOffsets []int64    // Newest Offsets Of The Trailing Partitions
+	for _, topic := range topics {
+		for _, partition := range topicPartitions[topic] {
+
+			// Partitions Without A Committed Offset Have Not Been Consumed Yet
+			block := offsets.GetBlock(topic, partition)
+			if block == nil || block.Err != sarama.ErrNoError || block.Offset < 0 {
+				continue
+			}
+
+			newestOffset, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, err
+			}
+
+			sample := lagSample{topic: topic, partition: partition, hasSeconds: true}
+			if newestOffset > block.Offset {
+				sample.lag = newestOffset - block.Offset
+				trailing = append(trailing, len(samples))
+				committedOffsets = append(committedOffsets, block.Offset)
+				newestOffsets = append(newestOffsets, newestOffset)
+			}
+			samples = append(samples, sample)
+		}
+	}
+
+	// Determine The Lag In Seconds Of The Trailing Partitions Concurrently (Each Partition Is Consumed At Most Once)
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, lagSecondsConcurrency)
+	for index, sampleIndex := range trailing {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(sample *lagSample, committedOffset int64, newestOffset int64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			sample.seconds, sample.hasSeconds = c.lagSeconds(client, consumer, sample.topic, sample.partition, committedOffset, newestOffset, secondsDeadline)
+		}(&samples[sampleIndex], committedOffsets[index], newestOffsets[index])
+	}
+	wg.Wait()
+
+	return samples, nil
+}
+
+// lagSeconds returns the age of the oldest unconsumed record of the partition, as indicated by its timestamp, unless
+// the record cannot be fetched before the specified deadline
+func (c *LagCollector) lagSeconds(client sarama.Client, consumer sarama.Consumer, topic string, partition int32, committedOffset int64, newestOffset int64, deadline time.Time) (float64, bool) {
+
+	// The Lag In Seconds Is Omitted Once The Collection's Time Budget Is Exhausted
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return 0, false
+	}
+	if timeout > lagRecordTimeout {
+		timeout = lagRecordTimeout
+	}
+
+	// The Committed Offset May Precede The Oldest Retained Record
+	oldestOffset, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, false
+	}
+	if oldestOffset > committedOffset {
+		committedOffset = oldestOffset
+	}
+	if committedOffset >= newestOffset {
+		return 0, true
+	}
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, committedOffset)
+	if err != nil {
+		c.logger.Debug("Failed To Consume The Oldest Unconsumed Record", zap.String("Topic", topic), zap.Int32("Partition", partition), zap.Error(err))
+		return 0, false
+	}
+	defer partitionConsumer.Close()
+
+	select {
+	case message := <-partitionConsumer.Messages():
+		if message == nil || message.Timestamp.IsZero() {
+			return 0, false
+		}
+		seconds := time.Since(message.Timestamp).Seconds()
+		if seconds < 0 {
+			seconds = 0
+		}
+		return seconds, true
+	case <-time.After(timeout):
+		return 0, false
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"k8s.io/apimachinery/pkg/types"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+const (
+	lagTestGroupId = "test-group"
+	lagTestTopic   = "test-topic"
+)
+
+// lagClusterAdmin is a MockClusterAdmin returning the specified committed offsets of the test topic
+type lagClusterAdmin struct {
+	commontesting.MockClusterAdmin
+	offsets map[int32]int64
+	err     error
+}
+
+func (ca *lagClusterAdmin) ListConsumerGroupOffsets(_ string, _ map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	if ca.err != nil {
+		return nil, ca.err
+	}
+	response := &sarama.OffsetFetchResponse{}
+	for partition, offset := range ca.offsets {
+		response.AddBlock(lagTestTopic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset, Err: sarama.ErrNoError})
+	}
+	return response, nil
+}
+
+// Test The LagCollector's Collection Of The Lag Of A Registered ConsumerGroup
+func TestLagCollector_Collect(t *testing.T) {
+
+	// Partition 0 Trails By 6 Records, Partition 1 Is Caught Up, Partition 2 Has No Committed Offset
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(lagTestTopic, []int32{0, 1, 2}, nil),
+		controllertesting.WithClientMockGetOffset(lagTestTopic, 0, sarama.OffsetNewest, 10, nil),
+		controllertesting.WithClientMockGetOffset(lagTestTopic, 0, sarama.OffsetOldest, 0, nil),
+		controllertesting.WithClientMockGetOffset(lagTestTopic, 1, sarama.OffsetNewest, 5, nil),
+		controllertesting.WithClientMockClose(nil))
+	clusterAdmin := &lagClusterAdmin{offsets: map[int32]int64{0: 4, 1: 5, 2: -1}}
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition(lagTestTopic, 0, 4).YieldMessage(&sarama.ConsumerMessage{Timestamp: time.Now().Add(-time.Minute)})
+	stubLagKafka(t, client, clusterAdmin, consumer)
+
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), KafkaChannelResourceGroup, []string{"broker"}, sarama.NewConfig())
+	ref := types.NamespacedName{Namespace: "test-namespace", Name: "test-channel"}
+	collector.Register(lagTestGroupId, LagTarget{Ref: ref, Subscriber: "test-uid", Topics: []string{lagTestTopic}})
+	collector.collect()
+
+	metrics := collector.Read()
+	require.Len(t, metrics, 2)
+
+	lagMetric := metrics[0]
+	assert.Equal(t, ConsumerGroupLagN, lagMetric.Descriptor.Name)
+	assert.Equal(t, metricdata.TypeGaugeInt64, lagMetric.Descriptor.Type)
+	require.Len(t, lagMetric.TimeSeries, 2)
+	assert.Equal(t, []metricdata.LabelValue{
+		metricdata.NewLabelValue("test-namespace"),
+		metricdata.NewLabelValue("test-channel"),
+		metricdata.NewLabelValue(KafkaChannelResourceGroup),
+		metricdata.NewLabelValue("test-uid"),
+		metricdata.NewLabelValue(lagTestGroupId),
+		metricdata.NewLabelValue(lagTestTopic),
+		metricdata.NewLabelValue("0"),
+	}, lagMetric.TimeSeries[0].LabelValues)
+	assert.Equal(t, int64(6), lagMetric.TimeSeries[0].Points[0].Value)
+	assert.Equal(t, metricdata.NewLabelValue("1"), lagMetric.TimeSeries[1].LabelValues[6])
+	assert.Equal(t, int64(0), lagMetric.TimeSeries[1].Points[0].Value)
+
+	secondsMetric := metrics[1]
+	assert.Equal(t, ConsumerGroupLagSecondsN, secondsMetric.Descriptor.Name)
+	assert.Equal(t, metricdata.TypeGaugeFloat64, secondsMetric.Descriptor.Type)
+	require.Len(t, secondsMetric.TimeSeries, 2)
+	assert.InDelta(t, 60, secondsMetric.TimeSeries[0].Points[0].Value, 5)
+	assert.Equal(t, float64(0), secondsMetric.TimeSeries[1].Points[0].Value)
+
+	// Unregistering The ConsumerGroup Removes Its TimeSeries
+	collector.Unregister(lagTestGroupId)
+	metrics = collector.Read()
+	assert.Empty(t, metrics[0].TimeSeries)
+	assert.Empty(t, metrics[1].TimeSeries)
+
+	require.NoError(t, consumer.Close())
+	client.AssertExpectations(t)
+}
+
+// Test That A Failure To List The Committed Offsets Leaves The Previous Samples In Place
+func TestLagCollector_CollectError(t *testing.T) {
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(lagTestTopic, []int32{0}, nil),
+		controllertesting.WithClientMockClose(nil))
+	clusterAdmin := &lagClusterAdmin{err: errors.New("test error")}
+	stubLagKafka(t, client, clusterAdmin, mocks.NewConsumer(t, nil))
+
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), TriggerResourceGroup, []string{"broker"}, sarama.NewConfig())
+	collector.Register(lagTestGroupId, LagTarget{Topics: []string{lagTestTopic}})
+	collector.samples[lagTestGroupId] = []lagSample{{topic: lagTestTopic, lag: 3}}
+	collector.collect()
+
+	metrics := collector.Read()
+	require.Len(t, metrics[0].TimeSeries, 1)
+	assert.Equal(t, int64(3), metrics[0].TimeSeries[0].Points[0].Value)
+	assert.Empty(t, metrics[1].TimeSeries)
+}
+
+// Test That The Lag In Seconds Is Omitted Once The Collection's Time Budget Is Exhausted
+func TestLagCollector_CollectGroupSecondsDeadline(t *testing.T) {
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(lagTestTopic, []int32{0, 1}, nil),
+		controllertesting.WithClientMockGetOffset(lagTestTopic, 0, sarama.OffsetNewest, 10, nil),
+		controllertesting.WithClientMockGetOffset(lagTestTopic, 1, sarama.OffsetNewest, 8, nil))
+	clusterAdmin := &lagClusterAdmin{offsets: map[int32]int64{0: 4, 1: 5}}
+	consumer := mocks.NewConsumer(t, nil) // No Partition Is Consumed
+
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), KafkaChannelResourceGroup, []string{"broker"}, sarama.NewConfig())
+	samples, err := collector.collectGroup(client, clusterAdmin, consumer, lagTestGroupId, []string{lagTestTopic}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, int64(6), samples[0].lag)
+	assert.False(t, samples[0].hasSeconds)
+	assert.Equal(t, int64(3), samples[1].lag)
+	assert.False(t, samples[1].hasSeconds)
+	require.NoError(t, consumer.Close())
+}
+
+// Test That The Oldest Unconsumed Records Are Fetched With A Small Fetch Size (Leaving The Specified Config Intact)
+func TestLagCollector_CollectFetchSize(t *testing.T) {
+	config := sarama.NewConfig()
+	defaultFetch := config.Consumer.Fetch.Default
+	var clientConfig *sarama.Config
+	newSaramaClientRestore := newSaramaClient
+	t.Cleanup(func() { newSaramaClient = newSaramaClientRestore })
+	newSaramaClient = func(_ []string, config *sarama.Config) (sarama.Client, error) {
+		clientConfig = config
+		return nil, errors.New("test error")
+	}
+
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), KafkaChannelResourceGroup, []string{"broker"}, config)
+	collector.Register(lagTestGroupId, LagTarget{Topics: []string{lagTestTopic}})
+	collector.collect()
+	require.NotNil(t, clientConfig)
+	assert.Equal(t, int32(lagFetchBytes), clientConfig.Consumer.Fetch.Default)
+	assert.Equal(t, defaultFetch, config.Consumer.Fetch.Default)
+}
+
+// Test That A Nil LagCollector Is Safe To Use
+func TestLagCollector_Nil(t *testing.T) {
+	var collector *LagCollector
+	collector.Register(lagTestGroupId, LagTarget{})
+	collector.Unregister(lagTestGroupId)
+	collector.Reconfigure(nil, nil)
+	collector.Start(time.Millisecond)
+	collector.Stop()
+	assert.Nil(t, collector.Read())
+}
+
+// Test Starting And Stopping The Periodic Collection
+func TestLagCollector_StartStop(t *testing.T) {
+	collector := NewLagCollector(logtesting.TestLogger(t).Desugar(), KafkaChannelResourceGroup, []string{"broker"}, sarama.NewConfig())
+	collector.Start(time.Millisecond)
+	collector.Start(time.Millisecond) // Idempotent
+	time.Sleep(5 * time.Millisecond)  // Collections Without Targets Do Nothing
+	collector.Stop()
+	collector.Stop() // Idempotent
+	assert.Nil(t, collector.stopChan)
+}
+
+// stubLagKafka stubs the creation of the Kafka client, ClusterAdmin and Consumer for the duration of the test
+func stubLagKafka(t *testing.T, client sarama.Client, clusterAdmin sarama.ClusterAdmin, consumer sarama.Consumer) {
+	newSaramaClientRestore := newSaramaClient
+	newClusterAdminFromClientRestore := newClusterAdminFromClient
+	newConsumerFromClientRestore := newConsumerFromClient
+	t.Cleanup(func() {
+		newSaramaClient = newSaramaClientRestore
+		newClusterAdminFromClient = newClusterAdminFromClientRestore
+		newConsumerFromClient = newConsumerFromClientRestore
+	})
+	newSaramaClient = func([]string, *sarama.Config) (sarama.Client, error) { return client, nil }
+	newClusterAdminFromClient = func(sarama.Client) (sarama.ClusterAdmin, error) { return clusterAdmin, nil }
+	newConsumerFromClient = func(sarama.Client) (sarama.Consumer, error) { return consumer, nil }
+}
@@ -49,7 +49,8 @@ var _ consumer.KafkaConsumerGroupFactory = (*MockKafkaConsumerGroupFactory)(nil)
 // MockConsumerGroupManager implements the KafkaConsumerGroupManager interface
 type MockConsumerGroupManager struct {
 	mock.Mock
-	Groups map[string]sarama.ConsumerGroup
+	Groups       map[string]sarama.ConsumerGroup
+	LagCollector *consumer.LagCollector
 }
 
 func NewMockConsumerGroupManager() *MockConsumerGroupManager {
@@ -94,3 +95,7 @@ func (m *MockConsumerGroupManager) GetNotificationChannel() <-chan consumer.Mana
 func (m *MockConsumerGroupManager) ClearNotifications() {
 	_ = m.Called()
 }
+
+func (m *MockConsumerGroupManager) SetLagCollector(collector *consumer.LagCollector) {
+	m.LagCollector = collector
+}
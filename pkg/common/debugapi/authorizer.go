@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Errors Returned By An Authorizer Which Are Mapped To The HTTP 401 & 403 Status Codes
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+)
+
+// Authorizer determines whether the bearer token of a debug API request grants access to the specified resource.
+// An ErrUnauthenticated or ErrForbidden error is returned when access is refused.
+type Authorizer interface {
+	Authorize(ctx context.Context, token string, attributes authorizationv1.ResourceAttributes) error
+}
+
+// KubernetesAuthorizer authenticates the bearer token with a TokenReview and authorizes its user with a
+// SubjectAccessReview, so that access to the debug API is granted by regular Kubernetes RBAC.
+type KubernetesAuthorizer struct {
+	kubeClient kubernetes.Interface
+}
+
+// Verify The KubernetesAuthorizer Implements The Authorizer Interface
+var _ Authorizer = &KubernetesAuthorizer{}
+
+// NewKubernetesAuthorizer creates a KubernetesAuthorizer which reviews tokens & access with the specified client
+func NewKubernetesAuthorizer(kubeClient kubernetes.Interface) *KubernetesAuthorizer {
+	return &KubernetesAuthorizer{kubeClient: kubeClient}
+}
+
+// Authorize implements the Authorizer interface
+func (a *KubernetesAuthorizer) Authorize(ctx context.Context, token string, attributes authorizationv1.ResourceAttributes) error {
+
+	// Authenticate The Token
+	tokenReview, err := a.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review token: %w", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return ErrUnauthenticated
+	}
+
+	// Authorize The Token's User
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := a.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review access: %w", err)
+	}
+	if !accessReview.Status.Allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// bearerToken returns the token of the request's "Authorization: Bearer <token>" header (empty if there is none)
+func bearerToken(request *http.Request) string {
+	const prefix = "Bearer "
+	authorization := request.Header.Get("Authorization")
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// Test The KubernetesAuthorizer's Token & Access Reviews
+func TestKubernetesAuthorizer(t *testing.T) {
+
+	attributes := authorizationv1.ResourceAttributes{
+		Namespace:   "test-namespace",
+		Name:        "test-channel",
+		Group:       ResourceGroup,
+		Resource:    Resource,
+		Verb:        "get",
+		Subresource: EventsSubresource,
+	}
+
+	tests := []struct {
+		name          string
+		authenticated bool
+		allowed       bool
+		reviewErr     error
+		expectedErr   error
+	}{
+		{name: "Allowed", authenticated: true, allowed: true},
+		{name: "Unauthenticated", authenticated: false, expectedErr: ErrUnauthenticated},
+		{name: "Forbidden", authenticated: true, allowed: false, expectedErr: ErrForbidden},
+		{name: "Review Error", reviewErr: errors.New("test-error"), expectedErr: errors.New("failed to review token: test-error")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			var accessReview *authorizationv1.SubjectAccessReview
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "tokenreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				tokenReview := action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				assert.Equal(t, "test-token", tokenReview.Spec.Token)
+				tokenReview.Status = authenticationv1.TokenReviewStatus{
+					Authenticated: test.authenticated,
+					User: authenticationv1.UserInfo{
+						Username: "test-user",
+						Groups:   []string{"test-group"},
+						Extra:    map[string]authenticationv1.ExtraValue{"test-key": {"test-value"}},
+					},
+				}
+				return true, tokenReview, test.reviewErr
+			})
+			kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				accessReview = action.(clientgotesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				accessReview.Status.Allowed = test.allowed
+				return true, accessReview, nil
+			})
+
+			err := NewKubernetesAuthorizer(kubeClient).Authorize(context.TODO(), "test-token", attributes)
+			if test.expectedErr != nil {
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			if test.authenticated {
+				assert.NotNil(t, accessReview)
+				assert.Equal(t, attributes, *accessReview.Spec.ResourceAttributes)
+				assert.Equal(t, "test-user", accessReview.Spec.User)
+				assert.Equal(t, []string{"test-group"}, accessReview.Spec.Groups)
+				assert.Equal(t, authorizationv1.ExtraValue{"test-value"}, accessReview.Spec.Extra["test-key"])
+			}
+		})
+	}
+}
+
+// Test Extracting The Bearer Token Of A Request
+func TestBearerToken(t *testing.T) {
+	for authorization, expected := range map[string]string{
+		"":                   "",
+		"Bearer":             "",
+		"Basic dXNlcjpwdw==": "",
+		"Bearer test-token":  "test-token",
+		"bearer test-token":  "test-token",
+	} {
+		request, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		assert.Equal(t, expected, bearerToken(request), authorization)
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Range selects the records of a KafkaChannel's Topic which are browsed or replayed.  The records start at
+// either the From offset of a single Partition or the first records at (or after) Time, and end before the
+// optional To offset or the newest offset (at the time of the request), up to a maximum of Limit records.
+type Range struct {
+	Partition *int32     // Optional partition (all partitions if nil)
+	From      *int64     // Optional offset of the first record (requires Partition)
+	To        *int64     // Optional exclusive offset of the last record (requires Partition)
+	Time      *time.Time // Optional time of the first records (alternative to From)
+	Limit     int        // Maximum number of records
+}
+
+// ParseRange parses the Range of records specified by the "partition", "from", "to", "time" & "limit" query parameters
+func ParseRange(query url.Values) (*Range, error) {
+
+	recordRange := &Range{Limit: DefaultLimit}
+
+	if value := query.Get("partition"); value != "" {
+		partition, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || partition < 0 {
+			return nil, fmt.Errorf("invalid partition %q", value)
+		}
+		partition32 := int32(partition)
+		recordRange.Partition = &partition32
+	}
+
+	for _, param := range []struct {
+		name   string
+		offset **int64
+	}{{name: "from", offset: &recordRange.From}, {name: "to", offset: &recordRange.To}} {
+		if value := query.Get(param.name); value != "" {
+			offset, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || offset < 0 {
+				return nil, fmt.Errorf("invalid %s offset %q", param.name, value)
+			}
+			if recordRange.Partition == nil {
+				return nil, fmt.Errorf("the %s offset requires a partition", param.name)
+			}
+			*param.offset = &offset
+		}
+	}
+
+	if value := query.Get("time"); value != "" {
+		startTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q (expected RFC3339)", value)
+		}
+		recordRange.Time = &startTime
+	}
+
+	if recordRange.From == nil && recordRange.Time == nil {
+		return nil, fmt.Errorf("either a from offset or a time is required")
+	}
+	if recordRange.From != nil && recordRange.Time != nil {
+		return nil, fmt.Errorf("the from offset and time are mutually exclusive")
+	}
+	if recordRange.From != nil && recordRange.To != nil && *recordRange.To <= *recordRange.From {
+		return nil, fmt.Errorf("the to offset must be greater than the from offset")
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > MaxLimit {
+			return nil, fmt.Errorf("invalid limit %q (expected 1 to %d)", value, MaxLimit)
+		}
+		recordRange.Limit = limit
+	}
+
+	return recordRange, nil
+}
+
+// PartitionOffsets holds the oldest & newest (next) offsets of a Topic's partition
+type PartitionOffsets struct {
+	Partition    int32 `json:"partition"`
+	OldestOffset int64 `json:"oldestOffset"`
+	NewestOffset int64 `json:"newestOffset"`
+}
+
+// getPartitionOffsets returns the sorted offsets of all (or only the specified) partitions of the Topic
+func getPartitionOffsets(client sarama.Client, topic string, partition *int32) ([]PartitionOffsets, error) {
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	offsets := make([]PartitionOffsets, 0, len(partitions))
+	for _, p := range partitions {
+		if partition != nil && *partition != p {
+			continue
+		}
+		oldest, err := client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the oldest offset of partition %d: %w", p, err)
+		}
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the newest offset of partition %d: %w", p, err)
+		}
+		offsets = append(offsets, PartitionOffsets{Partition: p, OldestOffset: oldest, NewestOffset: newest})
+	}
+
+	if partition != nil && len(offsets) == 0 {
+		return nil, fmt.Errorf("%w: partition %d does not exist in topic %s", errInvalidRange, *partition, topic)
+	}
+	return offsets, nil
+}
+
+// readRecords reads the Range of records of the Topic (partition by partition) without joining a ConsumerGroup,
+// so that no offsets are committed, and passes them to the specified function.  Reading a partition stops at the
+// end of the Range or when no record is received within the ReadTimeout (e.g. a gap of a compacted Topic).
+func readRecords(ctx context.Context, client sarama.Client, topic string, recordRange *Range, handle func(*sarama.ConsumerMessage)) error {
+
+	offsets, err := getPartitionOffsets(client, topic, recordRange.Partition)
+	if err != nil {
+		return err
+	}
+
+	consumer, err := NewConsumerFromClientFn(client)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	count := 0
+	for _, partitionOffsets := range offsets {
+		if count >= recordRange.Limit {
+			break
+		}
+
+		// Determine The Partition's Offsets To Read Between
+		start := partitionOffsets.OldestOffset
+		if recordRange.From != nil && *recordRange.From > start {
+			start = *recordRange.From
+		}
+		if recordRange.Time != nil {
+			start, err = client.GetOffset(topic, partitionOffsets.Partition, recordRange.Time.UnixMilli())
+			if err != nil {
+				return fmt.Errorf("failed to get the offset of partition %d at %s: %w", partitionOffsets.Partition, recordRange.Time, err)
+			}
+			if start < 0 {
+				start = partitionOffsets.NewestOffset // No Records At Or After The Time
+			}
+		}
+		end := partitionOffsets.NewestOffset
+		if recordRange.To != nil && *recordRange.To < end {
+			end = *recordRange.To
+		}
+		if start >= end {
+			continue
+		}
+
+		count, err = readPartition(ctx, consumer, topic, partitionOffsets.Partition, start, end, count, recordRange.Limit, handle)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPartition reads the records of a single partition between the start & (exclusive) end offsets and returns the
+// updated count of records read.
+func readPartition(ctx context.Context, consumer sarama.Consumer, topic string, partition int32, start int64, end int64, count int, limit int, handle func(*sarama.ConsumerMessage)) (int, error) {
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, start)
+	if err != nil {
+		return count, fmt.Errorf("failed to consume partition %d from offset %d: %w", partition, start, err)
+	}
+	defer partitionConsumer.AsyncClose()
+
+	timer := time.NewTimer(ReadTimeout)
+	defer timer.Stop()
+	for count < limit {
+		select {
+		case <-ctx.Done():
+			return count, ctx.Err()
+		case <-timer.C:
+			return count, nil
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok || message.Offset >= end {
+				return count, nil
+			}
+			handle(message)
+			count++
+			if message.Offset >= end-1 {
+				return count, nil
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(ReadTimeout)
+		}
+	}
+	return count, nil
+}
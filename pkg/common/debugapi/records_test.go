@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Parsing The Range Of Records From The Query Parameters
+func TestParseRange(t *testing.T) {
+
+	int32Ptr := func(i int32) *int32 { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+	startTime := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected *Range
+		err      string
+	}{
+		{
+			name:     "From Offset",
+			query:    "partition=1&from=10&to=20&limit=5",
+			expected: &Range{Partition: int32Ptr(1), From: int64Ptr(10), To: int64Ptr(20), Limit: 5},
+		},
+		{
+			name:     "Time Of All Partitions",
+			query:    "time=2022-06-01T12:00:00Z",
+			expected: &Range{Time: &startTime, Limit: DefaultLimit},
+		},
+		{name: "No Start", query: "partition=0", err: "either a from offset or a time is required"},
+		{name: "From And Time", query: "partition=0&from=1&time=2022-06-01T12:00:00Z", err: "the from offset and time are mutually exclusive"},
+		{name: "From Without Partition", query: "from=1", err: "the from offset requires a partition"},
+		{name: "To Without Partition", query: "time=2022-06-01T12:00:00Z&to=1", err: "the to offset requires a partition"},
+		{name: "To Before From", query: "partition=0&from=5&to=5", err: "the to offset must be greater than the from offset"},
+		{name: "Invalid Partition", query: "partition=-1&from=0", err: `invalid partition "-1"`},
+		{name: "Invalid Offset", query: "partition=0&from=x", err: `invalid from offset "x"`},
+		{name: "Invalid Time", query: "time=yesterday", err: `invalid time "yesterday" (expected RFC3339)`},
+		{name: "Invalid Limit", query: "partition=0&from=0&limit=1001", err: `invalid limit "1001" (expected 1 to 1000)`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			assert.Nil(t, err)
+			recordRange, err := ParseRange(query)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, recordRange)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, recordRange)
+			}
+		})
+	}
+}
@@ -0,0 +1,519 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cloudevent "github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventfilter"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+	"knative.dev/eventing-kafka/pkg/common/latency"
+	"knative.dev/eventing-kafka/pkg/common/tracing"
+)
+
+// Constants
+const (
+	DefaultPort     = 8090            // The Default Port Of The Debug API
+	DefaultLimit    = 100             // The Default Maximum Number Of Records Browsed / Replayed Per Request
+	MaxLimit        = 1000            // The Largest Maximum Number Of Records Browsed / Replayed Per Request
+	MaxReplayErrors = 10              // The Maximum Number Of Dispatch Errors Included In A ReplayResult
+	ReadTimeout     = 5 * time.Second // The Time After Which Reading An Idle Partition Stops
+
+	ChannelsPath = "/channels/" // The Path Prefix Of The Debug API's KafkaChannel Resources
+
+	// The RBAC Attributes Authorizing Access To The Debug API
+	ResourceGroup     = "messaging.knative.dev"
+	Resource          = "kafkachannels"
+	EventsSubresource = "events" // "get" Lists The Partitions & Offsets And Fetches The Events Of A KafkaChannel
+	ReplaySubresource = "replay" // "create" Replays The Events Of A KafkaChannel To One Of Its Subscribers
+)
+
+// errInvalidRange is wrapped by the errors of a Range which cannot be read from the Topic (HTTP 400)
+var errInvalidRange = errors.New("invalid range")
+
+// Subscriber describes a subscriber of a KafkaChannel served by the dispatcher
+type Subscriber struct {
+	UID     types.UID
+	URI     *url.URL
+	GroupId string
+	Filter  eventfilter.Filter // Optional filter of the events dispatched to the subscriber
+}
+
+// Channel describes a KafkaChannel served by the dispatcher
+type Channel struct {
+	Namespace        string
+	Name             string
+	Topic            string
+	EncryptionSecret string // The Secret named by the KafkaChannel's spec with which its events are decrypted
+	Subscribers      []Subscriber
+}
+
+// Dispatcher is implemented by the KafkaChannel dispatchers which expose their KafkaChannels via the debug API
+type Dispatcher interface {
+
+	// DebugChannel returns the specified KafkaChannel (false if it is not served by the dispatcher)
+	DebugChannel(ref types.NamespacedName) (*Channel, bool)
+
+	// DebugKafkaConfig returns the brokers & Sarama config with which the KafkaChannels' Topics are read
+	DebugKafkaConfig() ([]string, *sarama.Config)
+}
+
+// ServerConfig defines the configuration of the debug API Server
+type ServerConfig struct {
+	Logger          *zap.Logger
+	HttpPort        string
+	Dispatcher      Dispatcher
+	Authorizer      Authorizer
+	ClaimCheckStore claimcheck.Store       // Optional Store from which offloaded claim-check payloads are rehydrated
+	KeyProvider     encryption.KeyProvider // Optional provider of the keys with which encrypted event data is decrypted
+}
+
+// Server is an authenticated HTTP server which lists the partitions & offsets of the dispatcher's KafkaChannels,
+// fetches their events as CloudEvents JSON, and replays them to a single subscriber.  The Topics are read without
+// a ConsumerGroup so that the subscribers' committed offsets are never moved.
+type Server struct {
+	ServerConfig
+	server            *http.Server
+	messageDispatcher channel.MessageDispatcher
+}
+
+// ChannelInfo is the response of a KafkaChannel request
+type ChannelInfo struct {
+	Namespace   string             `json:"namespace"`
+	Name        string             `json:"name"`
+	Topic       string             `json:"topic"`
+	Partitions  []PartitionOffsets `json:"partitions"`
+	Subscribers []SubscriberInfo   `json:"subscribers"`
+}
+
+// SubscriberInfo describes a subscriber & the offsets committed by its ConsumerGroup
+type SubscriberInfo struct {
+	UID              types.UID       `json:"uid"`
+	URI              string          `json:"uri,omitempty"`
+	ConsumerGroup    string          `json:"consumerGroup"`
+	CommittedOffsets map[int32]int64 `json:"committedOffsets,omitempty"`
+}
+
+// Record is a record of a KafkaChannel's Topic.  Event holds the CloudEvent (in structured JSON), or Key, Value &
+// Error describe a record which could not be decoded as a CloudEvent.
+type Record struct {
+	Partition int32           `json:"partition"`
+	Offset    int64           `json:"offset"`
+	Timestamp time.Time       `json:"timestamp"`
+	Event     json.RawMessage `json:"event,omitempty"`
+	Key       string          `json:"key,omitempty"`
+	Value     string          `json:"value,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// ReplayResult is the response of a replay request
+type ReplayResult struct {
+	Subscriber types.UID `json:"subscriber"`
+	URI        string    `json:"uri"`
+	Dispatched int       `json:"dispatched"`
+	Filtered   int       `json:"filtered"`
+	Failed     int       `json:"failed"`
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+// Wrapper Functions To Facilitate Testing With A Mock Sarama Client, Consumer & ClusterAdmin
+var NewClientFn = sarama.NewClient
+var NewConsumerFromClientFn = sarama.NewConsumerFromClient
+var NewClusterAdminFromClientFn = sarama.NewClusterAdminFromClient
+
+// NewServer creates a new debug API Server with the specified configuration
+func NewServer(config ServerConfig) *Server {
+	server := &Server{
+		ServerConfig:      config,
+		messageDispatcher: channel.NewMessageDispatcher(config.Logger),
+	}
+	server.server = &http.Server{Addr: ":" + config.HttpPort, Handler: server.Handler()}
+	return server
+}
+
+// Handler returns the HTTP Handler of the debug API
+func (s *Server) Handler() http.Handler {
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc(ChannelsPath, s.handleChannels)
+	return serveMux
+}
+
+// Start The HTTP Server (Non-Blocking Call)
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", ":"+s.HttpPort)
+	if err != nil {
+		s.Logger.Error("Debug API HTTP Listen Returned Error", zap.Error(err))
+		return err
+	}
+
+	// Set The Port Actually Used (If "0" Is Passed In Then Listen Assigns One Arbitrarily)
+	s.HttpPort = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	go func() {
+		s.Logger.Info("Starting Debug API HTTP Server on port " + s.HttpPort)
+		err := s.server.Serve(listener)
+		if err != nil {
+			s.Logger.Info("Debug API HTTP Serve Returned Error", zap.Error(err)) // Info log since it could just be normal shutdown
+		}
+	}()
+
+	return nil
+}
+
+// Stop The HTTP Server Listening For Requests
+func (s *Server) Stop() {
+	s.Logger.Info("Stopping Debug API HTTP Server")
+	err := s.server.Shutdown(context.TODO())
+	if err != nil {
+		s.Logger.Error("Failed To Shutdown Debug API HTTP Server", zap.Error(err))
+	}
+}
+
+// HttpPort returns the HTTP port of the debug API (the DefaultPort if unspecified)
+func HttpPort(port int) string {
+	if port <= 0 {
+		port = DefaultPort
+	}
+	return strconv.Itoa(port)
+}
+
+// handleChannels routes the "/channels/<namespace>/<name>[/events|/replay]" requests after authorizing them
+func (s *Server) handleChannels(responseWriter http.ResponseWriter, request *http.Request) {
+
+	// Parse The Path Into The KafkaChannel Reference & Action
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(request.URL.Path, ChannelsPath), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		writeError(responseWriter, http.StatusNotFound, "not found")
+		return
+	}
+	ref := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	action := ""
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+
+	// Determine The Handler, Method & RBAC Attributes Of The Action
+	var handle func(http.ResponseWriter, *http.Request, *Channel)
+	method := http.MethodGet
+	attributes := authorizationv1.ResourceAttributes{
+		Namespace:   ref.Namespace,
+		Name:        ref.Name,
+		Group:       ResourceGroup,
+		Resource:    Resource,
+		Verb:        "get",
+		Subresource: EventsSubresource,
+	}
+	switch action {
+	case "":
+		handle = s.handleChannel
+	case "events":
+		handle = s.handleEvents
+	case "replay":
+		handle = s.handleReplay
+		method = http.MethodPost
+		attributes.Verb = "create"
+		attributes.Subresource = ReplaySubresource
+	default:
+		writeError(responseWriter, http.StatusNotFound, "not found")
+		return
+	}
+	if request.Method != method {
+		writeError(responseWriter, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// Authorize The Request Before Revealing Whether The KafkaChannel Exists
+	token := bearerToken(request)
+	if token == "" {
+		writeError(responseWriter, http.StatusUnauthorized, "a bearer token is required")
+		return
+	}
+	err := s.Authorizer.Authorize(request.Context(), token, attributes)
+	if errors.Is(err, ErrUnauthenticated) {
+		writeError(responseWriter, http.StatusUnauthorized, err.Error())
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		writeError(responseWriter, http.StatusForbidden, fmt.Sprintf("%s %s/%s of %s is forbidden", attributes.Verb, Resource, attributes.Subresource, ref))
+		return
+	} else if err != nil {
+		s.Logger.Error("Failed To Authorize Debug API Request", zap.Error(err))
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Get The KafkaChannel From The Dispatcher
+	kafkaChannel, ok := s.Dispatcher.DebugChannel(ref)
+	if !ok {
+		writeError(responseWriter, http.StatusNotFound, fmt.Sprintf("kafkachannel %s is not served by this dispatcher", ref))
+		return
+	}
+
+	handle(responseWriter, request, kafkaChannel)
+}
+
+// handleChannel responds with the partitions & offsets of the KafkaChannel's Topic and the committed offsets of its subscribers
+func (s *Server) handleChannel(responseWriter http.ResponseWriter, _ *http.Request, kafkaChannel *Channel) {
+
+	client, err := s.newClient()
+	if err != nil {
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+	admin, err := NewClusterAdminFromClientFn(client)
+	if err != nil {
+		_ = client.Close()
+		writeError(responseWriter, http.StatusInternalServerError, fmt.Sprintf("failed to create cluster admin: %v", err))
+		return
+	}
+	defer admin.Close() // Also Closes The Client
+
+	offsets, err := getPartitionOffsets(client, kafkaChannel.Topic, nil)
+	if err != nil {
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+	partitions := make([]int32, len(offsets))
+	for i, partitionOffsets := range offsets {
+		partitions[i] = partitionOffsets.Partition
+	}
+
+	info := ChannelInfo{
+		Namespace:   kafkaChannel.Namespace,
+		Name:        kafkaChannel.Name,
+		Topic:       kafkaChannel.Topic,
+		Partitions:  offsets,
+		Subscribers: make([]SubscriberInfo, 0, len(kafkaChannel.Subscribers)),
+	}
+	for _, subscriber := range kafkaChannel.Subscribers {
+		subscriberInfo := SubscriberInfo{UID: subscriber.UID, URI: urlString(subscriber.URI), ConsumerGroup: subscriber.GroupId}
+		response, err := admin.ListConsumerGroupOffsets(subscriber.GroupId, map[string][]int32{kafkaChannel.Topic: partitions})
+		if err != nil {
+			writeError(responseWriter, http.StatusInternalServerError, fmt.Sprintf("failed to list the offsets of consumer group %s: %v", subscriber.GroupId, err))
+			return
+		}
+		for _, partition := range partitions {
+			if block := response.GetBlock(kafkaChannel.Topic, partition); block != nil && block.Offset >= 0 {
+				if subscriberInfo.CommittedOffsets == nil {
+					subscriberInfo.CommittedOffsets = make(map[int32]int64)
+				}
+				subscriberInfo.CommittedOffsets[partition] = block.Offset
+			}
+		}
+		info.Subscribers = append(info.Subscribers, subscriberInfo)
+	}
+
+	writeJSON(responseWriter, http.StatusOK, info)
+}
+
+// handleEvents responds with the Range of records of the KafkaChannel's Topic
+func (s *Server) handleEvents(responseWriter http.ResponseWriter, request *http.Request, kafkaChannel *Channel) {
+
+	recordRange, err := ParseRange(request.URL.Query())
+	if err != nil {
+		writeError(responseWriter, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	client, err := s.newClient()
+	if err != nil {
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer client.Close()
+
+	ctx := request.Context()
+	records := make([]Record, 0)
+	err = readRecords(ctx, client, kafkaChannel.Topic, recordRange, func(consumerMessage *sarama.ConsumerMessage) {
+		record := Record{Partition: consumerMessage.Partition, Offset: consumerMessage.Offset, Timestamp: consumerMessage.Timestamp}
+		_, event, err := s.decode(ctx, kafkaChannel, consumerMessage)
+		if err == nil {
+			record.Event, err = json.Marshal(event)
+		}
+		if err != nil {
+			record.Event = nil
+			record.Key = string(consumerMessage.Key)
+			record.Value = string(consumerMessage.Value)
+			record.Error = err.Error()
+		}
+		records = append(records, record)
+	})
+	if err != nil {
+		writeRangeError(responseWriter, err)
+		return
+	}
+
+	writeJSON(responseWriter, http.StatusOK, records)
+}
+
+// handleReplay dispatches the Range of records of the KafkaChannel's Topic to the subscriber specified by the
+// "subscriber" query parameter (without retries, reply or dead-letter sink) and responds with the ReplayResult.
+func (s *Server) handleReplay(responseWriter http.ResponseWriter, request *http.Request, kafkaChannel *Channel) {
+
+	uid := types.UID(request.URL.Query().Get("subscriber"))
+	if uid == "" {
+		writeError(responseWriter, http.StatusBadRequest, "a subscriber is required")
+		return
+	}
+	var subscriber *Subscriber
+	for i := range kafkaChannel.Subscribers {
+		if kafkaChannel.Subscribers[i].UID == uid {
+			subscriber = &kafkaChannel.Subscribers[i]
+		}
+	}
+	if subscriber == nil {
+		writeError(responseWriter, http.StatusNotFound, fmt.Sprintf("subscriber %s does not subscribe to kafkachannel %s/%s", uid, kafkaChannel.Namespace, kafkaChannel.Name))
+		return
+	}
+	if subscriber.URI == nil {
+		writeError(responseWriter, http.StatusBadRequest, fmt.Sprintf("subscriber %s has no uri", uid))
+		return
+	}
+
+	recordRange, err := ParseRange(request.URL.Query())
+	if err != nil {
+		writeError(responseWriter, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	client, err := s.newClient()
+	if err != nil {
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer client.Close()
+
+	ctx := request.Context()
+	logger := s.Logger.With(zap.String("Channel", kafkaChannel.Namespace+"/"+kafkaChannel.Name), zap.String("Subscriber", string(uid)))
+	result := ReplayResult{Subscriber: uid, URI: subscriber.URI.String()}
+	fail := func(consumerMessage *sarama.ConsumerMessage, err error) {
+		result.Failed++
+		if len(result.Errors) < MaxReplayErrors {
+			result.Errors = append(result.Errors, fmt.Sprintf("partition %d offset %d: %v", consumerMessage.Partition, consumerMessage.Offset, err))
+		}
+	}
+	err = readRecords(ctx, client, kafkaChannel.Topic, recordRange, func(consumerMessage *sarama.ConsumerMessage) {
+
+		message, event, err := s.decode(ctx, kafkaChannel, consumerMessage)
+		if err != nil {
+			fail(consumerMessage, err)
+			return
+		}
+
+		// Skip Events Which Do Not Pass The Subscriber's Filter (If Any)
+		if subscriber.Filter != nil && subscriber.Filter.Filter(ctx, *event) == eventfilter.FailFilter {
+			result.Filtered++
+			return
+		}
+
+		// Pass Through The Record's Non-CloudEvent Headers (Except The Internal Ingress Time) As The Handlers Do
+		httpHeader := tracing.ConvertRecordHeadersToHttpHeader(latency.FilterIngressTimeHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers)))
+
+		dispatchCtx, span := tracing.StartTraceFromMessage(logger.Sugar(), ctx, message, "kafkachannel-"+consumerMessage.Topic)
+		_, err = s.messageDispatcher.DispatchMessage(dispatchCtx, message, httpHeader, subscriber.URI, nil, nil)
+		span.End()
+		if err != nil {
+			fail(consumerMessage, err)
+			return
+		}
+		result.Dispatched++
+	})
+	if err != nil {
+		writeRangeError(responseWriter, err)
+		return
+	}
+
+	logger.Info("Replayed Events", zap.Int("Dispatched", result.Dispatched), zap.Int("Filtered", result.Filtered), zap.Int("Failed", result.Failed))
+	writeJSON(responseWriter, http.StatusOK, result)
+}
+
+// decode rehydrates & decrypts a record (as the handlers do) and converts it into a CloudEvent
+func (s *Server) decode(ctx context.Context, kafkaChannel *Channel, consumerMessage *sarama.ConsumerMessage) (*kafkasaramaprotocol.Message, *cloudevent.Event, error) {
+	channelRef := channel.ChannelReference{Namespace: kafkaChannel.Namespace, Name: kafkaChannel.Name}
+	if err := claimcheck.Rehydrate(ctx, s.ClaimCheckStore, channelRef, consumerMessage); err != nil {
+		return nil, nil, err
+	}
+	if err := encryption.Decrypt(ctx, s.KeyProvider, kafkaChannel.Namespace, kafkaChannel.EncryptionSecret, consumerMessage); err != nil {
+		return nil, nil, err
+	}
+	message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
+	if message.ReadEncoding() == binding.EncodingUnknown {
+		return nil, nil, errors.New("the record is not a cloudevent")
+	}
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, event, nil
+}
+
+// newClient creates a Sarama Client with the dispatcher's current Kafka configuration
+func (s *Server) newClient() (sarama.Client, error) {
+	brokers, config := s.Dispatcher.DebugKafkaConfig()
+	client, err := NewClientFn(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	return client, nil
+}
+
+// urlString returns the string of the (possibly nil) URL
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// writeRangeError writes the error of reading a Range (HTTP 400 if the Range is invalid for the Topic)
+func writeRangeError(responseWriter http.ResponseWriter, err error) {
+	if errors.Is(err, errInvalidRange) {
+		writeError(responseWriter, http.StatusBadRequest, err.Error())
+	} else {
+		writeError(responseWriter, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// writeError writes a JSON error response
+func writeError(responseWriter http.ResponseWriter, status int, message string) {
+	writeJSON(responseWriter, status, map[string]string{"error": message})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(responseWriter http.ResponseWriter, status int, body interface{}) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(status)
+	_ = json.NewEncoder(responseWriter).Encode(body)
+}
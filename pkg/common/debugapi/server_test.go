@@ -0,0 +1,415 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/latency"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test Data
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-channel"
+	testTopic     = "test-topic"
+	testToken     = "test-token"
+	testGroupId   = "test-group-id"
+	testUID       = types.UID("test-uid")
+)
+
+// Test The Routing & Authorization Of Requests
+func TestHandleChannelsAuthorization(t *testing.T) {
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		token              string
+		authorizeErr       error
+		expectedStatus     int
+		expectedAttributes *authorizationv1.ResourceAttributes
+	}{
+		{
+			name:           "No Token",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/test-channel",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unauthenticated",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/test-channel",
+			token:          testToken,
+			authorizeErr:   ErrUnauthenticated,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Forbidden Events",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/test-channel/events",
+			token:          testToken,
+			authorizeErr:   ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   testNamespace,
+				Name:        testName,
+				Group:       ResourceGroup,
+				Resource:    Resource,
+				Verb:        "get",
+				Subresource: EventsSubresource,
+			},
+		},
+		{
+			name:           "Forbidden Replay",
+			method:         http.MethodPost,
+			path:           "/channels/test-namespace/test-channel/replay",
+			token:          testToken,
+			authorizeErr:   ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   testNamespace,
+				Name:        testName,
+				Group:       ResourceGroup,
+				Resource:    Resource,
+				Verb:        "create",
+				Subresource: ReplaySubresource,
+			},
+		},
+		{
+			name:           "Unknown Channel",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/unknown-channel",
+			token:          testToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Unknown Action",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/test-channel/unknown",
+			token:          testToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Missing Name",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace",
+			token:          testToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Wrong Method",
+			method:         http.MethodPost,
+			path:           "/channels/test-namespace/test-channel/events",
+			token:          testToken,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "Replay Without Subscriber",
+			method:         http.MethodPost,
+			path:           "/channels/test-namespace/test-channel/replay",
+			token:          testToken,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Events Without Range",
+			method:         http.MethodGet,
+			path:           "/channels/test-namespace/test-channel/events",
+			token:          testToken,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorizer := &mockAuthorizer{err: test.authorizeErr}
+			server := newTestServer(t, authorizer, nil)
+
+			response := serve(server, test.method, test.path, test.token)
+			assert.Equal(t, test.expectedStatus, response.Code)
+			assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+			assert.Contains(t, response.Body.String(), `"error"`)
+			if test.expectedAttributes != nil {
+				assert.Equal(t, testToken, authorizer.token)
+				assert.Equal(t, *test.expectedAttributes, authorizer.attributes)
+			}
+		})
+	}
+}
+
+// Test Describing A KafkaChannel's Partitions, Offsets & Subscribers
+func TestHandleChannel(t *testing.T) {
+
+	client := newMockClient()
+	stubKafka(t, client, nil)
+
+	response := serve(newTestServer(t, &mockAuthorizer{}, nil), http.MethodGet, "/channels/test-namespace/test-channel", testToken)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	info := ChannelInfo{}
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &info))
+	assert.Equal(t, ChannelInfo{
+		Namespace:  testNamespace,
+		Name:       testName,
+		Topic:      testTopic,
+		Partitions: []PartitionOffsets{{Partition: 0, OldestOffset: 1, NewestOffset: 3}, {Partition: 1, OldestOffset: 0, NewestOffset: 0}},
+		Subscribers: []SubscriberInfo{{
+			UID:              testUID,
+			URI:              "http://test-subscriber",
+			ConsumerGroup:    testGroupId,
+			CommittedOffsets: map[int32]int64{0: 2},
+		}},
+	}, info)
+}
+
+// Test Fetching A KafkaChannel's Events
+func TestHandleEvents(t *testing.T) {
+
+	client := newMockClient()
+	consumer := mocks.NewConsumer(t, nil)
+	partitionConsumer := consumer.ExpectConsumePartition(testTopic, 0, 1)
+	partitionConsumer.YieldMessage(newCloudEventMessage("test-id-1", "test.type.a"))
+	partitionConsumer.YieldMessage(&sarama.ConsumerMessage{Key: []byte("test-key"), Value: []byte("test-value")})
+	stubKafka(t, client, consumer)
+
+	response := serve(newTestServer(t, &mockAuthorizer{}, nil), http.MethodGet, "/channels/test-namespace/test-channel/events?partition=0&from=0", testToken)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var records []Record
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &records))
+	assert.Len(t, records, 2)
+	assert.Equal(t, int64(1), records[0].Offset)
+	assert.Empty(t, records[0].Error)
+	event := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(records[0].Event, &event))
+	assert.Equal(t, "test-id-1", event["id"])
+	assert.Equal(t, "test.type.a", event["type"])
+	assert.Equal(t, map[string]interface{}{"test": "data"}, event["data"])
+	assert.Equal(t, int64(2), records[1].Offset)
+	assert.Nil(t, records[1].Event)
+	assert.Equal(t, "test-key", records[1].Key)
+	assert.Equal(t, "test-value", records[1].Value)
+	assert.Equal(t, "the record is not a cloudevent", records[1].Error)
+}
+
+// Test Replaying A KafkaChannel's Events To A Subscriber
+func TestHandleReplay(t *testing.T) {
+
+	// Create A Subscriber Recording The IDs Of The Received Events
+	var receivedLock sync.Mutex
+	var received []string
+	subscriber := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		receivedLock.Lock()
+		received = append(received, request.Header.Get("ce-id"))
+		receivedLock.Unlock()
+		assert.Equal(t, "test-header-value", request.Header.Get("test-header"))
+		assert.NotContains(t, request.Header, http.CanonicalHeaderKey(latency.IngressTimeHeaderKey))
+		writer.WriteHeader(http.StatusAccepted)
+	}))
+	defer subscriber.Close()
+	subscriberURI, err := url.Parse(subscriber.URL)
+	assert.Nil(t, err)
+
+	client := newMockClient()
+	consumer := mocks.NewConsumer(t, nil)
+	partitionConsumer := consumer.ExpectConsumePartition(testTopic, 0, 1)
+	partitionConsumer.YieldMessage(newCloudEventMessage("test-id-1", "test.type.a"))
+	partitionConsumer.YieldMessage(newCloudEventMessage("test-id-2", "test.type.b"))
+	stubKafka(t, client, consumer)
+
+	subscriberFilter, err := filter.ParseFilter(`{"exact": {"type": "test.type.a"}}`)
+	assert.Nil(t, err)
+	server := newTestServer(t, &mockAuthorizer{}, func(subscriber *Subscriber) {
+		subscriber.URI = subscriberURI
+		subscriber.Filter = subscriberFilter
+	})
+
+	response := serve(server, http.MethodPost, "/channels/test-namespace/test-channel/replay?subscriber=test-uid&partition=0&from=1&to=3", testToken)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	result := ReplayResult{}
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &result))
+	assert.Equal(t, ReplayResult{Subscriber: testUID, URI: subscriber.URL, Dispatched: 1, Filtered: 1}, result)
+	assert.Equal(t, []string{"test-id-1"}, received)
+
+	// Unknown Subscribers Are Not Replayed To
+	response = serve(server, http.MethodPost, "/channels/test-namespace/test-channel/replay?subscriber=unknown-uid&partition=0&from=1", testToken)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+// Test Starting & Stopping The Server
+func TestServerStartStop(t *testing.T) {
+	server := NewServer(ServerConfig{Logger: logtesting.TestLogger(t).Desugar(), HttpPort: "0", Authorizer: &mockAuthorizer{}, Dispatcher: &mockDispatcher{}})
+	assert.Nil(t, server.Start())
+	assert.NotEqual(t, "0", server.HttpPort)
+
+	response, err := http.Get("http://localhost:" + server.HttpPort + "/channels/test-namespace/test-channel")
+	assert.Nil(t, err)
+	body, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+	assert.Nil(t, response.Body.Close())
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	assert.Equal(t, "{\"error\":\"a bearer token is required\"}\n", string(body))
+
+	server.Stop()
+}
+
+// Test The Default HTTP Port
+func TestHttpPort(t *testing.T) {
+	assert.Equal(t, "8090", HttpPort(0))
+	assert.Equal(t, "9999", HttpPort(9999))
+}
+
+//
+// Test Utilities
+//
+
+// mockAuthorizer records the authorized token & attributes and returns the configured error
+type mockAuthorizer struct {
+	err        error
+	token      string
+	attributes authorizationv1.ResourceAttributes
+}
+
+func (a *mockAuthorizer) Authorize(_ context.Context, token string, attributes authorizationv1.ResourceAttributes) error {
+	a.token = token
+	a.attributes = attributes
+	return a.err
+}
+
+// mockDispatcher serves the single test KafkaChannel
+type mockDispatcher struct {
+	channel *Channel
+}
+
+func (d *mockDispatcher) DebugChannel(ref types.NamespacedName) (*Channel, bool) {
+	if d.channel == nil || ref != (types.NamespacedName{Namespace: d.channel.Namespace, Name: d.channel.Name}) {
+		return nil, false
+	}
+	return d.channel, true
+}
+
+func (d *mockDispatcher) DebugKafkaConfig() ([]string, *sarama.Config) {
+	return []string{"test-broker"}, sarama.NewConfig()
+}
+
+// mockClusterAdmin returns the committed offsets of the test subscriber
+type mockClusterAdmin struct {
+	commontesting.MockClusterAdmin
+}
+
+func (a *mockClusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	response := &sarama.OffsetFetchResponse{}
+	if group == testGroupId {
+		response.AddBlock(testTopic, 0, &sarama.OffsetFetchResponseBlock{Offset: 2})
+		response.AddBlock(testTopic, 1, &sarama.OffsetFetchResponseBlock{Offset: -1})
+	}
+	return response, nil
+}
+
+// newTestServer creates a Server for the test KafkaChannel, whose subscriber is optionally customized
+func newTestServer(t *testing.T, authorizer Authorizer, customizeSubscriber func(*Subscriber)) *Server {
+	subscriberURI, err := url.Parse("http://test-subscriber")
+	assert.Nil(t, err)
+	subscriber := Subscriber{UID: testUID, URI: subscriberURI, GroupId: testGroupId}
+	if customizeSubscriber != nil {
+		customizeSubscriber(&subscriber)
+	}
+	return NewServer(ServerConfig{
+		Logger:     logtesting.TestLogger(t).Desugar(),
+		HttpPort:   "0",
+		Authorizer: authorizer,
+		Dispatcher: &mockDispatcher{channel: &Channel{Namespace: testNamespace, Name: testName, Topic: testTopic, Subscribers: []Subscriber{subscriber}}},
+	})
+}
+
+// newMockClient creates a mock Sarama Client of the test Topic with two partitions
+func newMockClient() *controllertesting.MockClient {
+	return controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(testTopic, []int32{1, 0}, nil),
+		controllertesting.WithClientMockGetOffset(testTopic, 0, sarama.OffsetOldest, 1, nil),
+		controllertesting.WithClientMockGetOffset(testTopic, 0, sarama.OffsetNewest, 3, nil),
+		controllertesting.WithClientMockGetOffset(testTopic, 1, sarama.OffsetOldest, 0, nil),
+		controllertesting.WithClientMockGetOffset(testTopic, 1, sarama.OffsetNewest, 0, nil),
+		controllertesting.WithClientMockClose(nil),
+	)
+}
+
+// stubKafka replaces the Sarama Client, Consumer & ClusterAdmin wrapper functions for the duration of the test
+func stubKafka(t *testing.T, client sarama.Client, consumer sarama.Consumer) {
+	newClientFn, newConsumerFromClientFn, newClusterAdminFromClientFn := NewClientFn, NewConsumerFromClientFn, NewClusterAdminFromClientFn
+	t.Cleanup(func() {
+		NewClientFn, NewConsumerFromClientFn, NewClusterAdminFromClientFn = newClientFn, newConsumerFromClientFn, newClusterAdminFromClientFn
+	})
+	NewClientFn = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		assert.Equal(t, []string{"test-broker"}, addrs)
+		return client, nil
+	}
+	NewConsumerFromClientFn = func(sarama.Client) (sarama.Consumer, error) {
+		return consumer, nil
+	}
+	NewClusterAdminFromClientFn = func(sarama.Client) (sarama.ClusterAdmin, error) {
+		return &mockClusterAdmin{}, nil
+	}
+}
+
+// newCloudEventMessage creates a binary mode CloudEvent ConsumerMessage with an additional & an ingress time header
+func newCloudEventMessage(id string, eventType string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+			{Key: []byte("ce_id"), Value: []byte(id)},
+			{Key: []byte("ce_type"), Value: []byte(eventType)},
+			{Key: []byte("ce_source"), Value: []byte("test-source")},
+			{Key: []byte("content-type"), Value: []byte("application/json")},
+			{Key: []byte("test-header"), Value: []byte("test-header-value")},
+			{Key: []byte(latency.IngressTimeHeaderKey), Value: []byte("1600000000000")},
+		},
+		Value:     []byte(`{"test": "data"}`),
+		Timestamp: time.Now(),
+	}
+}
+
+// serve serves a request with the optional bearer token and returns the recorded response
+func serve(server *Server, method string, path string, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(""))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	return response
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/network"
+)
+
+// NewService returns the model of the ExternalName Service, in the namespace of (and controlled by) the specified
+// owner, referencing the specified shared ingress Service of the system namespace.
+func NewService(owner kmeta.OwnerRefable, name string, labels map[string]string, ingressServiceName string, systemNamespace string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetObjectMeta().GetNamespace(),
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(owner),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: network.GetServiceHostname(ingressServiceName, systemNamespace),
+		},
+	}
+}
+
+// ReconcileService creates the desired ExternalName Service of the specified owner if it does not exist, or updates
+// the existing one if its type or external name differ.  An existing Service which is not controlled by the owner,
+// or which is being deleted, is reported as an error.
+func ReconcileService(ctx context.Context,
+	kubeClientSet kubernetes.Interface,
+	serviceLister corev1listers.ServiceLister,
+	owner kmeta.OwnerRefable,
+	desired *corev1.Service) (*corev1.Service, error) {
+
+	kind := owner.GetGroupVersionKind().Kind
+
+	// Create The Service If It Does Not Exist
+	existing, err := serviceLister.Services(desired.Namespace).Get(desired.Name)
+	if apierrors.IsNotFound(err) {
+		return kubeClientSet.CoreV1().Services(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Verify The Service Is Owned By The Owner And Is Not Terminating
+	if !metav1.IsControlledBy(existing, owner.GetObjectMeta()) {
+		return nil, fmt.Errorf("service %s/%s is not owned by the %s", existing.Namespace, existing.Name, kind)
+	}
+	if !existing.DeletionTimestamp.IsZero() {
+		return nil, errors.New("encountered " + kind + " Service with DeletionTimestamp - potential race condition")
+	}
+
+	// Update The Service If It Differs From The Desired Service
+	if existing.Spec.Type != desired.Spec.Type || existing.Spec.ExternalName != desired.Spec.ExternalName {
+		updated := existing.DeepCopy()
+		updated.Spec = desired.Spec
+		return kubeClientSet.CoreV1().Services(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	}
+	return existing, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+const (
+	testNamespace       = "test-namespace"
+	testSystemNamespace = "knative-eventing"
+	testServiceName     = "test-broker-kn-broker"
+	testIngressName     = "kafka-broker-ingress"
+	testExternalName    = "kafka-broker-ingress.knative-eventing.svc.cluster.local"
+)
+
+// Test The NewService() Functionality
+func TestNewService(t *testing.T) {
+	owner := newOwner()
+	service := NewService(owner, testServiceName, map[string]string{"test-label": "test-value"}, testIngressName, testSystemNamespace)
+	assert.Equal(t, testNamespace, service.Namespace)
+	assert.Equal(t, testServiceName, service.Name)
+	assert.Equal(t, map[string]string{"test-label": "test-value"}, service.Labels)
+	assert.True(t, metav1.IsControlledBy(service, owner))
+	assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+	assert.Equal(t, testExternalName, service.Spec.ExternalName)
+}
+
+// Test The ReconcileService() Functionality
+func TestReconcileService(t *testing.T) {
+
+	owner := newOwner()
+	desired := NewService(owner, testServiceName, nil, testIngressName, testSystemNamespace)
+
+	outdated := desired.DeepCopy()
+	outdated.Spec.ExternalName = "other-ingress.knative-eventing.svc.cluster.local"
+
+	notOwned := desired.DeepCopy()
+	notOwned.OwnerReferences = nil
+
+	terminating := desired.DeepCopy()
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+
+	tests := []struct {
+		name      string
+		existing  *corev1.Service
+		expectErr string
+	}{
+		{name: "Created"},
+		{name: "Unchanged", existing: desired.DeepCopy()},
+		{name: "Updated", existing: outdated},
+		{name: "Not Owned", existing: notOwned, expectErr: "service test-namespace/test-broker-kn-broker is not owned by the Broker"},
+		{name: "Terminating", existing: terminating, expectErr: "encountered Broker Service with DeletionTimestamp - potential race condition"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			// Create The Clientset & Lister With Any Existing Service
+			kubeClient := fake.NewSimpleClientset()
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if test.existing != nil {
+				assert.Nil(t, indexer.Add(test.existing))
+				_, err := kubeClient.CoreV1().Services(testNamespace).Create(ctx, test.existing, metav1.CreateOptions{})
+				assert.Nil(t, err)
+			}
+
+			// Perform The Test
+			service, err := ReconcileService(ctx, kubeClient, corev1listers.NewServiceLister(indexer), owner, desired.DeepCopy())
+
+			// Verify The Results
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testExternalName, service.Spec.ExternalName)
+			stored, err := kubeClient.CoreV1().Services(testNamespace).Get(ctx, testServiceName, metav1.GetOptions{})
+			assert.Nil(t, err)
+			assert.Equal(t, corev1.ServiceTypeExternalName, stored.Spec.Type)
+			assert.Equal(t, testExternalName, stored.Spec.ExternalName)
+		})
+	}
+}
+
+// Create A New Owner (A Broker) For Testing
+func newOwner() *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-broker", UID: "test-broker-uid"},
+	}
+}
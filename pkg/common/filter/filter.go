@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+)
+
+// NewFilter materializes the specified SubscriptionsAPIFilter (exact / prefix / suffix attribute matches, CESQL
+// expressions, and their all / any / not combinations) into an eventfilter.Filter, returning nil for a nil filter.
+func NewFilter(filter *eventingv1.SubscriptionsAPIFilter) (eventfilter.Filter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	switch {
+	case len(filter.Exact) > 0:
+		return subscriptionsapi.NewExactFilter(filter.Exact)
+	case len(filter.Prefix) > 0:
+		return subscriptionsapi.NewPrefixFilter(filter.Prefix)
+	case len(filter.Suffix) > 0:
+		return subscriptionsapi.NewSuffixFilter(filter.Suffix)
+	case len(filter.All) > 0:
+		filters, err := newFilters(filter.All)
+		if err != nil {
+			return nil, fmt.Errorf("invalid all filter: %w", err)
+		}
+		return subscriptionsapi.NewAllFilter(filters...), nil
+	case len(filter.Any) > 0:
+		filters, err := newFilters(filter.Any)
+		if err != nil {
+			return nil, fmt.Errorf("invalid any filter: %w", err)
+		}
+		return subscriptionsapi.NewAnyFilter(filters...), nil
+	case filter.Not != nil:
+		notFilter, err := NewFilter(filter.Not)
+		if err != nil {
+			return nil, fmt.Errorf("invalid not filter: %w", err)
+		}
+		return subscriptionsapi.NewNotFilter(notFilter), nil
+	case filter.CESQL != "":
+		return newCESQLFilter(filter.CESQL)
+	default:
+		return nil, fmt.Errorf("empty filter")
+	}
+}
+
+// ParseFilter unmarshals a JSON serialized SubscriptionsAPIFilter (as passed to the data plane in environment
+// variables or annotations) and materializes it, returning nil for an empty string.
+func ParseFilter(filterJson string) (eventfilter.Filter, error) {
+	if filterJson == "" {
+		return nil, nil
+	}
+	filter := &eventingv1.SubscriptionsAPIFilter{}
+	if err := json.Unmarshal([]byte(filterJson), filter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal filter: %w", err)
+	}
+	return NewFilter(filter)
+}
+
+// newCESQLFilter parses the CESQL expression, recovering from the panics of the parser on some syntax errors
+func newCESQLFilter(expression string) (filter eventfilter.Filter, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			filter, err = nil, fmt.Errorf("error while parsing expression %s: %v", expression, r)
+		}
+	}()
+	return subscriptionsapi.NewCESQLFilter(expression)
+}
+
+// newFilters materializes each of the specified SubscriptionsAPIFilters
+func newFilters(filters []eventingv1.SubscriptionsAPIFilter) ([]eventfilter.Filter, error) {
+	materialized := make([]eventfilter.Filter, 0, len(filters))
+	for i := range filters {
+		filter, err := NewFilter(&filters[i])
+		if err != nil {
+			return nil, err
+		}
+		materialized = append(materialized, filter)
+	}
+	return materialized, nil
+}
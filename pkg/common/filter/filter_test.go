@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+)
+
+func TestNewFilter(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("id")
+	event.SetType("dev.knative.kafka.event")
+	event.SetSource("/apis/v1/namespaces/ns/kafkasources/source#topic")
+	event.SetExtension("key", "key1")
+
+	testCases := map[string]struct {
+		filter *eventingv1.SubscriptionsAPIFilter
+		expect eventfilter.FilterResult
+	}{
+		"exact pass":  {filter: &eventingv1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "dev.knative.kafka.event"}}, expect: eventfilter.PassFilter},
+		"exact fail":  {filter: &eventingv1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "other"}}, expect: eventfilter.FailFilter},
+		"prefix pass": {filter: &eventingv1.SubscriptionsAPIFilter{Prefix: map[string]string{"source": "/apis/v1/namespaces/ns"}}, expect: eventfilter.PassFilter},
+		"suffix fail": {filter: &eventingv1.SubscriptionsAPIFilter{Suffix: map[string]string{"source": "#other"}}, expect: eventfilter.FailFilter},
+		"cesql pass":  {filter: &eventingv1.SubscriptionsAPIFilter{CESQL: "key = 'key1'"}, expect: eventfilter.PassFilter},
+		"cesql fail":  {filter: &eventingv1.SubscriptionsAPIFilter{CESQL: "key = 'key2'"}, expect: eventfilter.FailFilter},
+		"all fail": {filter: &eventingv1.SubscriptionsAPIFilter{All: []eventingv1.SubscriptionsAPIFilter{
+			{Exact: map[string]string{"type": "dev.knative.kafka.event"}},
+			{CESQL: "key = 'key2'"},
+		}}, expect: eventfilter.FailFilter},
+		"any pass": {filter: &eventingv1.SubscriptionsAPIFilter{Any: []eventingv1.SubscriptionsAPIFilter{
+			{Exact: map[string]string{"type": "other"}},
+			{CESQL: "key = 'key1'"},
+		}}, expect: eventfilter.PassFilter},
+		"not pass": {filter: &eventingv1.SubscriptionsAPIFilter{Not: &eventingv1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "other"}}}, expect: eventfilter.PassFilter},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := NewFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, filter.Filter(context.TODO(), event))
+		})
+	}
+}
+
+func TestNewFilterInvalid(t *testing.T) {
+	filter, err := NewFilter(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	for name, invalid := range map[string]*eventingv1.SubscriptionsAPIFilter{
+		"empty":              {},
+		"invalid cesql":      {CESQL: "key = "},
+		"unterminated cesql": {CESQL: "key = 'key1"},
+		"invalid nested":     {All: []eventingv1.SubscriptionsAPIFilter{{CESQL: "key LIKE"}}},
+		"invalid not":        {Not: &eventingv1.SubscriptionsAPIFilter{}},
+		"invalid any":        {Any: []eventingv1.SubscriptionsAPIFilter{{}}},
+		"empty exact value":  {Exact: map[string]string{"type": ""}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewFilter(invalid)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = ParseFilter(`{"exact":{"type":"dev.knative.kafka.event"}}`)
+	require.NoError(t, err)
+	event := cloudevents.NewEvent()
+	event.SetType("dev.knative.kafka.event")
+	assert.Equal(t, eventfilter.PassFilter, filter.Filter(context.TODO(), event))
+
+	_, err = ParseFilter("{")
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+// The Kinds Of Channels Whose Subscribers May Be Those Of A KafkaChannel (Channels Backed By A KafkaChannel Share Its Name)
+var subscribableKinds = map[string]bool{"KafkaChannel": true, "Channel": true}
+
+// SubscriptionFilters returns the JSON serialized filters annotated on the Subscriptions in the specified namespace,
+// keyed by the UID of the Subscription (which is also the UID of its Subscriber in the spec of the Channel).  A nil
+// lister (filtering disabled) results in no filters.
+func SubscriptionFilters(lister messaginglisters.SubscriptionLister, namespace string) (map[types.UID]string, error) {
+	filters := make(map[types.UID]string)
+	if lister == nil {
+		return filters, nil
+	}
+	subscriptions, err := lister.Subscriptions(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, subscription := range subscriptions {
+		if filterJson := subscription.GetAnnotations()[constants.SubscriptionFilterAnnotationKey]; filterJson != "" {
+			filters[subscription.UID] = filterJson
+		}
+	}
+	return filters, nil
+}
+
+// SubscriptionChannel returns the namespace/name of the (Kafka)Channel of the specified Subscription (or tombstone
+// thereof), so that changes to the filter of the Subscription can be applied by enqueueing its KafkaChannel.  The
+// returned bool is false if the object is not a Subscription to a Channel which may be a KafkaChannel.
+func SubscriptionChannel(obj interface{}) (types.NamespacedName, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	subscription, ok := obj.(*messagingv1.Subscription)
+	if !ok || !subscribableKinds[subscription.Spec.Channel.Kind] {
+		return types.NamespacedName{}, false
+	}
+	namespace := subscription.Spec.Channel.Namespace
+	if namespace == "" {
+		namespace = subscription.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: subscription.Spec.Channel.Name}, true
+}
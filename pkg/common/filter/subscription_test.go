@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+func newSubscription(namespace, name, uid, channelKind, filter string) *messagingv1.Subscription {
+	subscription := &messagingv1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(uid)},
+		Spec: messagingv1.SubscriptionSpec{
+			Channel: duckv1.KReference{Kind: channelKind, Name: "channel"},
+		},
+	}
+	if filter != "" {
+		subscription.Annotations = map[string]string{constants.SubscriptionFilterAnnotationKey: filter}
+	}
+	return subscription
+}
+
+func TestSubscriptionFilters(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(newSubscription("ns", "filtered", "uid-1", "KafkaChannel", `{"exact":{"type":"a"}}`)))
+	require.NoError(t, indexer.Add(newSubscription("ns", "unfiltered", "uid-2", "KafkaChannel", "")))
+	require.NoError(t, indexer.Add(newSubscription("other-ns", "filtered", "uid-3", "KafkaChannel", `{"cesql":"true"}`)))
+
+	filters, err := SubscriptionFilters(messaginglisters.NewSubscriptionLister(indexer), "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, map[types.UID]string{"uid-1": `{"exact":{"type":"a"}}`}, filters)
+
+	filters, err = SubscriptionFilters(nil, "ns")
+	assert.NoError(t, err)
+	assert.Empty(t, filters)
+}
+
+func TestSubscriptionChannel(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      interface{}
+		expected types.NamespacedName
+		ok       bool
+	}{
+		{
+			name:     "KafkaChannel",
+			obj:      newSubscription("ns", "sub", "uid", "KafkaChannel", ""),
+			expected: types.NamespacedName{Namespace: "ns", Name: "channel"},
+			ok:       true,
+		},
+		{
+			name:     "Channel",
+			obj:      newSubscription("ns", "sub", "uid", "Channel", ""),
+			expected: types.NamespacedName{Namespace: "ns", Name: "channel"},
+			ok:       true,
+		},
+		{
+			name:     "Tombstone",
+			obj:      cache.DeletedFinalStateUnknown{Key: "ns/sub", Obj: newSubscription("ns", "sub", "uid", "KafkaChannel", "")},
+			expected: types.NamespacedName{Namespace: "ns", Name: "channel"},
+			ok:       true,
+		},
+		{
+			name: "InMemoryChannel",
+			obj:  newSubscription("ns", "sub", "uid", "InMemoryChannel", ""),
+		},
+		{
+			name: "Not A Subscription",
+			obj:  &metav1.ObjectMeta{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channelRef, ok := SubscriptionChannel(test.obj)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, channelRef)
+		})
+	}
+}
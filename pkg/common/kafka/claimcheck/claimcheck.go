@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// HeaderKey is the Kafka header referencing the offloaded payload of a claim-check event.  It uses the
+// CloudEvents binary-mode prefix so that the reference is visible as a "claimcheck" extension attribute
+// to any consumer not rehydrating the event.
+const HeaderKey = "ce_claimcheck"
+
+// Threshold returns the payload size (in bytes) above which events of the specified KafkaChannel are offloaded,
+// or 0 if the KafkaChannel has not opted in to claim-check.  The channel's own threshold takes precedence over
+// the specified default (from the config-kafka ConfigMap).
+func Threshold(channel *v1beta1.KafkaChannel, defaultThreshold int64) int64 {
+	if channel == nil || channel.Spec.ClaimCheck == nil {
+		return 0
+	}
+	if channel.Spec.ClaimCheck.ThresholdBytes > 0 {
+		return channel.Spec.ClaimCheck.ThresholdBytes
+	}
+	return defaultThreshold
+}
+
+// Offload moves the value of the ProducerMessage into the Store if it exceeds the threshold, replacing it with
+// an empty value and a HeaderKey header referencing the stored payload.  Nothing is offloaded if the Store is
+// nil or the threshold is not positive.  Any HeaderKey header supplied by the event sender (i.e. an inbound
+// "claimcheck" extension) is always removed so that consumers never rehydrate a payload they were pointed at.
+func Offload(ctx context.Context, store Store, channel eventingchannel.ChannelReference, threshold int64, message *sarama.ProducerMessage) error {
+	message.Headers = removeHeader(message.Headers)
+
+	if store == nil || threshold <= 0 || message.Value == nil || int64(message.Value.Length()) <= threshold {
+		return nil
+	}
+
+	payload, err := message.Value.Encode()
+	if err != nil {
+		return err
+	}
+
+	key := keyPrefix(channel) + uuid.New().String()
+	if err = store.Put(ctx, key, payload); err != nil {
+		return fmt.Errorf("failed to offload claim-check payload of channel %s: %w", channel.String(), err)
+	}
+
+	message.Value = sarama.ByteEncoder{}
+	message.Headers = append(message.Headers, sarama.RecordHeader{Key: []byte(HeaderKey), Value: []byte(key)})
+	return nil
+}
+
+// Rehydrate restores the offloaded payload of a claim-check ConsumerMessage of the specified channel from the
+// Store, removing the HeaderKey header.  Messages without the header are left untouched, and keys which do not
+// belong to the channel are rejected so that a message cannot reference the payloads of other channels.
+func Rehydrate(ctx context.Context, store Store, channel eventingchannel.ChannelReference, message *sarama.ConsumerMessage) error {
+	index := -1
+	for i, header := range message.Headers {
+		if header != nil && string(header.Key) == HeaderKey {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	key := string(message.Headers[index].Value)
+	if !strings.HasPrefix(key, keyPrefix(channel)) {
+		return fmt.Errorf("unable to rehydrate claim-check payload %s: key does not belong to channel %s", key, channel.String())
+	}
+	if store == nil {
+		return fmt.Errorf("unable to rehydrate claim-check payload %s: no claim-check store configured", key)
+	}
+
+	payload, err := store.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to rehydrate claim-check payload %s: %w", key, err)
+	}
+
+	message.Value = payload
+	message.Headers = append(message.Headers[:index:index], message.Headers[index+1:]...)
+	return nil
+}
+
+// The prefix of the Store keys of the specified channel's payloads
+func keyPrefix(channel eventingchannel.ChannelReference) string {
+	return channel.Namespace + "/" + channel.Name + "/"
+}
+
+// Remove any HeaderKey headers from the specified RecordHeaders
+func removeHeader(headers []sarama.RecordHeader) []sarama.RecordHeader {
+	filtered := headers[:0]
+	for _, header := range headers {
+		if string(header.Key) != HeaderKey {
+			filtered = append(filtered, header)
+		}
+	}
+	return filtered
+}
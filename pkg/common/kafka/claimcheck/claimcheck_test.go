@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+var testChannel = eventingchannel.ChannelReference{Namespace: "test-namespace", Name: "test-channel"}
+
+// Test The Threshold() Functionality
+func TestThreshold(t *testing.T) {
+	assert.Equal(t, int64(0), Threshold(nil, 1000))
+	assert.Equal(t, int64(0), Threshold(&v1beta1.KafkaChannel{}, 1000))
+	optedIn := &v1beta1.KafkaChannel{Spec: v1beta1.KafkaChannelSpec{ClaimCheck: &v1beta1.KafkaChannelClaimCheckSpec{}}}
+	assert.Equal(t, int64(1000), Threshold(optedIn, 1000))
+	optedIn.Spec.ClaimCheck.ThresholdBytes = 500
+	assert.Equal(t, int64(500), Threshold(optedIn, 1000))
+}
+
+// Test An Offloaded ProducerMessage Is Rehydrated As The Original Payload
+func TestOffloadRehydrate(t *testing.T) {
+	ctx := context.TODO()
+	store := NewFilesystemStore(t.TempDir())
+	value := strings.Repeat("x", 200)
+	idHeader := sarama.RecordHeader{Key: []byte("ce_id"), Value: []byte("1234")}
+
+	producerMessage := &sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder(value), Headers: []sarama.RecordHeader{idHeader}}
+	assert.Nil(t, Offload(ctx, store, testChannel, 100, producerMessage))
+	assert.Equal(t, 0, producerMessage.Value.Length())
+	assert.Len(t, producerMessage.Headers, 2)
+	assert.Equal(t, HeaderKey, string(producerMessage.Headers[1].Key))
+	assert.True(t, strings.HasPrefix(string(producerMessage.Headers[1].Value), "test-namespace/test-channel/"))
+
+	consumerMessage := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{&producerMessage.Headers[0], &producerMessage.Headers[1]}}
+	assert.Nil(t, Rehydrate(ctx, store, testChannel, consumerMessage))
+	assert.Equal(t, []byte(value), consumerMessage.Value)
+	assert.Equal(t, []*sarama.RecordHeader{&idHeader}, consumerMessage.Headers)
+}
+
+// Test Messages Which Are Not Offloaded
+func TestOffloadSkipped(t *testing.T) {
+	ctx := context.TODO()
+	store := NewFilesystemStore(t.TempDir())
+	tests := []struct {
+		name      string
+		store     Store
+		threshold int64
+	}{
+		{name: "Within Threshold", store: store, threshold: 100},
+		{name: "Not Opted In", store: store},
+		{name: "No Store", threshold: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message := &sarama.ProducerMessage{Value: sarama.StringEncoder("small-value")}
+			assert.Nil(t, Offload(ctx, test.store, testChannel, test.threshold, message))
+			assert.Equal(t, sarama.StringEncoder("small-value"), message.Value)
+			assert.Empty(t, message.Headers)
+		})
+	}
+}
+
+// Test Rehydrate Failures
+func TestRehydrateErrors(t *testing.T) {
+	ctx := context.TODO()
+	message := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{{Key: []byte(HeaderKey), Value: []byte("test-namespace/test-channel/missing")}}}
+	assert.NotNil(t, Rehydrate(ctx, nil, testChannel, message))
+	assert.NotNil(t, Rehydrate(ctx, NewFilesystemStore(t.TempDir()), testChannel, message))
+	assert.Nil(t, Rehydrate(ctx, nil, testChannel, &sarama.ConsumerMessage{Value: []byte("plain")}))
+}
+
+// Test Rehydrate Rejects Keys Belonging To Other Channels
+func TestRehydrateOtherChannel(t *testing.T) {
+	ctx := context.TODO()
+	store := NewFilesystemStore(t.TempDir())
+	otherChannel := eventingchannel.ChannelReference{Namespace: "test-namespace", Name: "other-channel"}
+	producerMessage := &sarama.ProducerMessage{Value: sarama.StringEncoder(strings.Repeat("x", 200))}
+	assert.Nil(t, Offload(ctx, store, otherChannel, 100, producerMessage))
+
+	for _, key := range []string{string(producerMessage.Headers[0].Value), "test-namespace/test-channel-suffix/payload"} {
+		message := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{{Key: []byte(HeaderKey), Value: []byte(key)}}}
+		err := Rehydrate(ctx, store, testChannel, message)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "does not belong to channel")
+		assert.Nil(t, message.Value)
+	}
+}
+
+// Test Offload Removes Any Inbound Claim-Check Header, Whether Or Not The Payload Is Offloaded
+func TestOffloadStripsInboundHeader(t *testing.T) {
+	ctx := context.TODO()
+	store := NewFilesystemStore(t.TempDir())
+	idHeader := sarama.RecordHeader{Key: []byte("ce_id"), Value: []byte("1234")}
+	inboundHeader := sarama.RecordHeader{Key: []byte(HeaderKey), Value: []byte("test-namespace/test-channel/forged")}
+
+	message := &sarama.ProducerMessage{Value: sarama.StringEncoder("small-value"), Headers: []sarama.RecordHeader{inboundHeader, idHeader}}
+	assert.Nil(t, Offload(ctx, nil, testChannel, 0, message))
+	assert.Equal(t, []sarama.RecordHeader{idHeader}, message.Headers)
+
+	message = &sarama.ProducerMessage{Value: sarama.StringEncoder(strings.Repeat("x", 200)), Headers: []sarama.RecordHeader{inboundHeader, idHeader}}
+	assert.Nil(t, Offload(ctx, store, testChannel, 100, message))
+	assert.Len(t, message.Headers, 2)
+	assert.Equal(t, idHeader, message.Headers[0])
+	assert.NotEqual(t, inboundHeader.Value, message.Headers[1].Value)
+}
+
+// Test The Kubernetes EnvVars / Volumes / VolumeMounts For Each Store Type
+func TestKubernetesResources(t *testing.T) {
+	s3Config := commonconfig.EKClaimCheckConfig{Store: StoreTypeS3, S3: commonconfig.EKClaimCheckS3Config{CredentialsSecret: "s3-credentials"}}
+	envVars := EnvVars(s3Config)
+	assert.Len(t, envVars, 2)
+	assert.Equal(t, AccessKeyIdEnvVarKey, envVars[0].Name)
+	assert.Equal(t, &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3-credentials"}, Key: AccessKeyIdSecretKey}, envVars[0].ValueFrom.SecretKeyRef)
+	assert.Empty(t, Volumes(s3Config))
+	assert.Empty(t, VolumeMounts(s3Config))
+
+	fsConfig := commonconfig.EKClaimCheckConfig{Store: StoreTypeFilesystem, Filesystem: commonconfig.EKClaimCheckFilesystemConfig{Path: "/claim-check", PersistentVolumeClaim: "claim-check-pvc"}}
+	assert.Empty(t, EnvVars(fsConfig))
+	volumes := Volumes(fsConfig)
+	assert.Len(t, volumes, 1)
+	assert.Equal(t, "claim-check-pvc", volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, []corev1.VolumeMount{{Name: VolumeName, MountPath: "/claim-check"}}, VolumeMounts(fsConfig))
+
+	assert.Empty(t, EnvVars(commonconfig.EKClaimCheckConfig{}))
+	assert.Empty(t, Volumes(commonconfig.EKClaimCheckConfig{}))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Verify The FilesystemStore Implements The Store Interface
+var _ Store = &FilesystemStore{}
+
+// FilesystemStore is a Store which keeps payloads as files below a root directory, typically a shared
+// (ReadWriteMany) PersistentVolume mounted into the receivers and dispatchers.
+type FilesystemStore struct {
+	root string
+}
+
+// NewFilesystemStore returns a FilesystemStore rooted at the specified directory.
+func NewFilesystemStore(root string) *FilesystemStore {
+	return &FilesystemStore{root: root}
+}
+
+// Put writes the payload to a temporary file which is then renamed, so that readers never see partial payloads.
+func (s *FilesystemStore) Put(_ context.Context, key string, payload []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".claimcheck-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(file.Name()) }() // No-Op After Successful Rename
+
+	if _, err = file.Write(payload); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Get reads the payload file of the specified key.
+func (s *FilesystemStore) Get(_ context.Context, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	payload, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return payload, err
+}
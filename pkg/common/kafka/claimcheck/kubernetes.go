@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+// Keys Of The S3 Credentials In The Configured CredentialsSecret
+const (
+	AccessKeyIdSecretKey     = "accessKeyId"
+	SecretAccessKeySecretKey = "secretAccessKey"
+)
+
+// VolumeName is the name of the Volume mounting the filesystem store's PersistentVolumeClaim.
+const VolumeName = "claim-check"
+
+// EnvVars returns the environment variables providing the S3 credentials to the receiver / dispatcher Pods.
+func EnvVars(config commonconfig.EKClaimCheckConfig) []corev1.EnvVar {
+	if strings.ToLower(config.Store) != StoreTypeS3 || config.S3.CredentialsSecret == "" {
+		return nil
+	}
+	return []corev1.EnvVar{
+		secretEnvVar(AccessKeyIdEnvVarKey, config.S3.CredentialsSecret, AccessKeyIdSecretKey),
+		secretEnvVar(SecretAccessKeyEnvVarKey, config.S3.CredentialsSecret, SecretAccessKeySecretKey),
+	}
+}
+
+// Volumes returns the Volumes mounting the filesystem store's PersistentVolumeClaim into the receiver / dispatcher Pods.
+func Volumes(config commonconfig.EKClaimCheckConfig) []corev1.Volume {
+	if strings.ToLower(config.Store) != StoreTypeFilesystem || config.Filesystem.PersistentVolumeClaim == "" {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: config.Filesystem.PersistentVolumeClaim},
+		},
+	}}
+}
+
+// VolumeMounts returns the VolumeMounts of the Volumes returned by Volumes().
+func VolumeMounts(config commonconfig.EKClaimCheckConfig) []corev1.VolumeMount {
+	if len(Volumes(config)) == 0 {
+		return nil
+	}
+	return []corev1.VolumeMount{{Name: VolumeName, MountPath: config.Filesystem.Path}}
+}
+
+// Create An EnvVar Referencing The Specified Secret Key
+func secretEnvVar(name string, secretName string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
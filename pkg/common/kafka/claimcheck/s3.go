@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultS3Region is used to sign requests when no region is configured (as expected by MinIO).
+const DefaultS3Region = "us-east-1"
+
+// Verify The S3Store Implements The Store Interface
+var _ Store = &S3Store{}
+
+// S3Store is a Store which keeps payloads as objects in a bucket of an S3-compatible service (AWS S3, MinIO,
+// etc.) using path-style requests.  Requests are signed with AWS Signature Version 4 when credentials are
+// provided, and are anonymous otherwise.
+type S3Store struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyId     string
+	secretAccessKey string
+	httpClient      *http.Client
+	now             func() time.Time
+}
+
+// NewS3Store returns an S3Store for the specified bucket.
+func NewS3Store(endpoint string, region string, bucket string, accessKeyId string, secretAccessKey string) *S3Store {
+	if region == "" {
+		region = DefaultS3Region
+	}
+	return &S3Store{
+		endpoint:        strings.TrimSuffix(endpoint, "/"),
+		region:          region,
+		bucket:          bucket,
+		accessKeyId:     accessKeyId,
+		secretAccessKey: secretAccessKey,
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		now:             time.Now,
+	}
+}
+
+// Put uploads the payload as the object with the specified key.
+func (s *S3Store) Put(ctx context.Context, key string, payload []byte) error {
+	response, err := s.do(ctx, http.MethodPut, key, payload)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return s.responseError(http.MethodPut, key, response)
+	}
+	return nil
+}
+
+// Get downloads the object with the specified key.
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	response, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(response.Body)
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, s.responseError(http.MethodGet, key, response)
+	}
+}
+
+// Perform A Signed Request Against The Object With The Specified Key
+func (s *S3Store) do(ctx context.Context, method string, key string, payload []byte) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, s.endpoint+"/"+awsURIEscape(s.bucket+"/"+key), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	request.Header.Set("x-amz-content-sha256", hex.EncodeToString(payloadHash[:]))
+	request.Header.Set("x-amz-date", s.now().UTC().Format(amzDateFormat))
+	if s.accessKeyId != "" {
+		signRequest(request, s.accessKeyId, s.secretAccessKey, s.region)
+	}
+
+	return s.httpClient.Do(request)
+}
+
+// Create An Error Describing An Unexpected Response (Including The Beginning Of The S3 Error Document)
+func (s *S3Store) responseError(method string, key string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+	return fmt.Errorf("claim-check s3 %s of %s/%s failed with status %d: %s", method, s.bucket, key, response.StatusCode, string(body))
+}
+
+//
+// AWS Signature Version 4 (https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html)
+//
+
+const (
+	amzDateFormat    = "20060102T150405Z"
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	signingService   = "s3"
+)
+
+// Sign The Request (Which Must Already Have x-amz-date & x-amz-content-sha256 Headers) By Adding An
+// Authorization Header Covering The Host And All Other Request Headers
+func signRequest(request *http.Request, accessKeyId string, secretAccessKey string, region string) {
+
+	amzDate := request.Header.Get("x-amz-date")
+	date := amzDate[:8]
+	scope := strings.Join([]string{date, region, signingService, "aws4_request"}, "/")
+
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		request.Header.Get("x-amz-content-sha256"),
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hex.EncodeToString(canonicalRequestHash[:])}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, signingService)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, accessKeyId, scope, signedHeaders, signature))
+}
+
+// Compute The HMAC-SHA256 Of The Data With The Specified Key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// URI-Encode The Path Per The AWS Rules (Everything But Unreserved Characters And "/" Is Percent-Encoded)
+func awsURIEscape(path string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(path) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '.', b == '_', b == '~', b == '/':
+			escaped.WriteByte(b)
+		default:
+			escaped.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return escaped.String()
+}
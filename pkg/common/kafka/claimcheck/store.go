@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package claimcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+// Supported Claim-Check Store Types
+const (
+	StoreTypeS3         = "s3"
+	StoreTypeFilesystem = "filesystem"
+)
+
+// Environment Variables Providing The S3 Credentials (Populated From The Configured CredentialsSecret)
+const (
+	AccessKeyIdEnvVarKey     = "CLAIM_CHECK_S3_ACCESS_KEY_ID"
+	SecretAccessKeyEnvVarKey = "CLAIM_CHECK_S3_SECRET_ACCESS_KEY"
+)
+
+// ErrNotFound is returned by a Store when the requested payload does not exist.
+var ErrNotFound = errors.New("claim-check payload not found")
+
+// Store is a blob store holding the offloaded payloads of claim-check events.  Payloads are never deleted, their
+// expiration being left to the lifecycle configuration of the store itself (see the KafkaChannel README).
+type Store interface {
+	// Put stores the payload under the specified key.
+	Put(ctx context.Context, key string, payload []byte) error
+
+	// Get returns the payload stored under the specified key, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// NewStore returns the Store described by the specified configuration, or nil if no Store is configured.
+func NewStore(config commonconfig.EKClaimCheckConfig) (Store, error) {
+	switch strings.ToLower(config.Store) {
+	case "":
+		return nil, nil
+	case StoreTypeS3:
+		if config.S3.Endpoint == "" || config.S3.Bucket == "" {
+			return nil, errors.New("claim-check s3 store requires an endpoint and bucket")
+		}
+		return NewS3Store(config.S3.Endpoint, config.S3.Region, config.S3.Bucket,
+			os.Getenv(AccessKeyIdEnvVarKey), os.Getenv(SecretAccessKeyEnvVarKey)), nil
+	case StoreTypeFilesystem:
+		if config.Filesystem.Path == "" {
+			return nil, errors.New("claim-check filesystem store requires a path")
+		}
+		return NewFilesystemStore(config.Filesystem.Path), nil
+	default:
+		return nil, fmt.Errorf("invalid / unknown claim-check store type: %s", config.Store)
+	}
+}
+
+// Verify The Key Is A Relative Path Without Any Parent References (Keys Are Read From Untrusted Kafka Headers)
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid claim-check key: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid claim-check key: %q", key)
+		}
+	}
+	return nil
+}
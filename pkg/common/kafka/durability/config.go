@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package durability
+
+import (
+	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// Class returns the DurabilityClass of the specified KafkaChannel (DurabilityClassDefault for a nil KafkaChannel).
+func Class(channel *v1beta1.KafkaChannel) v1beta1.DurabilityClass {
+	if channel == nil {
+		return v1beta1.DurabilityClassDefault
+	}
+	return channel.Spec.EffectiveDurabilityClass()
+}
+
+// Config returns the Sarama config with which events of the specified DurabilityClass are produced.  This is the
+// base config itself for the default class, and otherwise a copy of it with the producer settings of the class.
+func Config(class v1beta1.DurabilityClass, base *sarama.Config) *sarama.Config {
+	switch class {
+
+	case v1beta1.DurabilityClassFast:
+		config := *base
+		config.Producer.RequiredAcks = sarama.WaitForLocal
+		config.Producer.Idempotent = false
+		config.Producer.Compression = sarama.CompressionLZ4
+		config.Producer.Flush.Frequency = 0
+		config.Producer.Flush.Bytes = 0
+		config.Producer.Flush.Messages = 0
+		if !config.Version.IsAtLeast(sarama.V0_10_0_0) {
+			config.Version = sarama.V0_10_0_0 // Minimum Version Supporting LZ4 Compression
+		}
+		return &config
+
+	case v1beta1.DurabilityClassDurable:
+		config := *base
+		config.Producer.RequiredAcks = sarama.WaitForAll
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+		if config.Producer.Retry.Max < 1 {
+			config.Producer.Retry.Max = 1
+		}
+		if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+			config.Version = sarama.V0_11_0_0 // Minimum Version Supporting Idempotence
+		}
+		return &config
+
+	default:
+		return base
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package durability
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+// MinimumDurableInsyncReplicas is the smallest "min.insync.replicas" of a topic to which durable events are produced.
+const MinimumDurableInsyncReplicas = 2
+
+// DefaultReplicasCacheDuration is the length of time a topic's "min.insync.replicas" is cached before being re-read.
+const DefaultReplicasCacheDuration = 5 * time.Minute
+
+// NewClusterAdminFn creates the ClusterAdmin used to describe topic configs (may be stubbed for testing)
+var NewClusterAdminFn = sarama.NewClusterAdmin
+
+// NewSyncProducerFn creates the SyncProducer of a DurabilityClass.
+type NewSyncProducerFn func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error)
+
+// InsufficientReplicasError is returned when a durable event is produced to a topic whose "min.insync.replicas"
+// would allow it to be acknowledged by a single replica.
+type InsufficientReplicasError struct {
+	Topic             string
+	MinInsyncReplicas int
+}
+
+// Error implements the error interface with a description suitable for returning to the event sender.
+func (e *InsufficientReplicasError) Error() string {
+	return fmt.Sprintf("topic %s has %s=%d, but the %s durability class requires at least %d",
+		e.Topic, constants.KafkaTopicConfigMinInsyncReplicas, e.MinInsyncReplicas, v1beta1.DurabilityClassDurable, MinimumDurableInsyncReplicas)
+}
+
+// The cachedReplicas struct holds the "min.insync.replicas" of a single topic
+type cachedReplicas struct {
+	minInsyncReplicas int
+	expires           time.Time
+}
+
+// Pool holds a SyncProducer for each DurabilityClass in use, the default class using the pre-existing producer
+// and the others being created upon first use.
+type Pool struct {
+	logger            *zap.Logger
+	brokers           []string
+	config            *sarama.Config
+	newProducerFn     NewSyncProducerFn
+	cacheDuration     time.Duration
+	producersMutex    sync.Mutex
+	defaultProducer   sarama.SyncProducer
+	producers         map[v1beta1.DurabilityClass]sarama.SyncProducer
+	replicasMutex     sync.Mutex
+	minInsyncReplicas map[string]cachedReplicas
+	describes         singleflight.Group // de-duplicates concurrent describes of the same topic
+}
+
+// NewPool returns a new Pool using the specified producer for the default class, and the newProducerFn to create
+// the producers of the other classes from the specified base config.
+func NewPool(logger *zap.Logger, brokers []string, config *sarama.Config, defaultProducer sarama.SyncProducer, newProducerFn NewSyncProducerFn) *Pool {
+	return &Pool{
+		logger:            logger,
+		brokers:           brokers,
+		config:            config,
+		newProducerFn:     newProducerFn,
+		cacheDuration:     DefaultReplicasCacheDuration,
+		defaultProducer:   defaultProducer,
+		producers:         make(map[v1beta1.DurabilityClass]sarama.SyncProducer),
+		minInsyncReplicas: make(map[string]cachedReplicas),
+	}
+}
+
+// Producer returns the SyncProducer with which events of the specified DurabilityClass are produced to the
+// specified topic, returning an InsufficientReplicasError if the topic cannot provide durable writes.
+func (p *Pool) Producer(topic string, class v1beta1.DurabilityClass) (sarama.SyncProducer, error) {
+	switch class {
+	case "", v1beta1.DurabilityClassDefault:
+		return p.defaultProducer, nil
+	case v1beta1.DurabilityClassDurable:
+		minInsyncReplicas, err := p.topicMinInsyncReplicas(topic)
+		if err != nil {
+			// Fail Closed - Durable Events Are Never Produced Without Verifying The Topic Can Provide Durable Writes
+			p.logger.Warn("Failed To Describe Topic Minimum In-Sync Replicas", zap.String("Topic", topic), zap.Error(err))
+			return nil, fmt.Errorf("failed to verify %s of topic %s for the %s durability class: %w",
+				constants.KafkaTopicConfigMinInsyncReplicas, topic, v1beta1.DurabilityClassDurable, err)
+		}
+		if minInsyncReplicas < MinimumDurableInsyncReplicas {
+			return nil, &InsufficientReplicasError{Topic: topic, MinInsyncReplicas: minInsyncReplicas}
+		}
+	}
+
+	p.producersMutex.Lock()
+	defer p.producersMutex.Unlock()
+
+	if producer, ok := p.producers[class]; ok {
+		return producer, nil
+	}
+
+	p.logger.Info("Creating Kafka SyncProducer For Durability Class", zap.String("DurabilityClass", string(class)))
+	producer, err := p.newProducerFn(p.brokers, Config(class, p.config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer for durability class %s: %w", class, err)
+	}
+	p.producers[class] = producer
+	return producer, nil
+}
+
+// Close closes the producers created by the Pool (but not the default producer).
+func (p *Pool) Close() error {
+	p.producersMutex.Lock()
+	defer p.producersMutex.Unlock()
+
+	var closeErr error
+	for class, producer := range p.producers {
+		if err := producer.Close(); err != nil {
+			p.logger.Error("Failed To Close Kafka SyncProducer For Durability Class", zap.String("DurabilityClass", string(class)), zap.Error(err))
+			closeErr = err
+		}
+		delete(p.producers, class)
+	}
+	return closeErr
+}
+
+// Get The "min.insync.replicas" Of The Specified Topic, Using The Cached Value If Still Valid (Errors Aren't Cached)
+func (p *Pool) topicMinInsyncReplicas(topic string) (int, error) {
+	p.replicasMutex.Lock()
+	cached, ok := p.minInsyncReplicas[topic]
+	p.replicasMutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.minInsyncReplicas, nil
+	}
+
+	// Describe The Topic Outside The Lock, Sharing A Single In-Flight Request Among Concurrent Callers
+	result, err, _ := p.describes.Do(topic, func() (interface{}, error) {
+		minInsyncReplicas, err := p.describeTopicMinInsyncReplicas(topic)
+		if err != nil {
+			return 0, err
+		}
+
+		p.replicasMutex.Lock()
+		p.minInsyncReplicas[topic] = cachedReplicas{minInsyncReplicas: minInsyncReplicas, expires: time.Now().Add(p.cacheDuration)}
+		p.replicasMutex.Unlock()
+		return minInsyncReplicas, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// Describe The "min.insync.replicas" Config Of The Specified Topic (Includes Broker Defaults If Not Overridden)
+func (p *Pool) describeTopicMinInsyncReplicas(topic string) (int, error) {
+	clusterAdmin, err := NewClusterAdminFn(p.brokers, p.config)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = clusterAdmin.Close() }()
+
+	entries, err := clusterAdmin.DescribeConfig(sarama.ConfigResource{
+		Type:        sarama.TopicResource,
+		Name:        topic,
+		ConfigNames: []string{constants.KafkaTopicConfigMinInsyncReplicas},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if entry.Name == constants.KafkaTopicConfigMinInsyncReplicas {
+			return strconv.Atoi(entry.Value)
+		}
+	}
+	return 0, fmt.Errorf("topic %s config does not include %s", topic, constants.KafkaTopicConfigMinInsyncReplicas)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package durability
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test Data
+const testTopic = "test-topic"
+
+// Test The Config() Functionality
+func TestConfig(t *testing.T) {
+	base := sarama.NewConfig()
+	base.Producer.Flush.Frequency = 100
+
+	assert.Same(t, base, Config(v1beta1.DurabilityClassDefault, base))
+
+	fast := Config(v1beta1.DurabilityClassFast, base)
+	assert.Equal(t, sarama.WaitForLocal, fast.Producer.RequiredAcks)
+	assert.Equal(t, sarama.CompressionLZ4, fast.Producer.Compression)
+	assert.Zero(t, fast.Producer.Flush.Frequency)
+	assert.Nil(t, fast.Validate())
+
+	durable := Config(v1beta1.DurabilityClassDurable, base)
+	assert.Equal(t, sarama.WaitForAll, durable.Producer.RequiredAcks)
+	assert.True(t, durable.Producer.Idempotent)
+	assert.Nil(t, durable.Validate())
+
+	// Verify The Base Config Is Unaltered
+	assert.Equal(t, sarama.NewConfig().Producer.RequiredAcks, base.Producer.RequiredAcks)
+	assert.False(t, base.Producer.Idempotent)
+	assert.Equal(t, 100, int(base.Producer.Flush.Frequency))
+}
+
+// Test The Pool.Producer() Functionality
+func TestPoolProducer(t *testing.T) {
+	tests := []struct {
+		name              string
+		class             v1beta1.DurabilityClass
+		minInsyncReplicas int
+		describeErr       error
+		wantDefault       bool
+		wantAcks          sarama.RequiredAcks
+		wantErr           bool
+	}{
+		{name: "Unspecified", class: "", wantDefault: true},
+		{name: "Default", class: v1beta1.DurabilityClassDefault, wantDefault: true},
+		{name: "Fast", class: v1beta1.DurabilityClassFast, wantAcks: sarama.WaitForLocal},
+		{name: "Durable", class: v1beta1.DurabilityClassDurable, minInsyncReplicas: 2, wantAcks: sarama.WaitForAll},
+		{name: "Durable Insufficient Replicas", class: v1beta1.DurabilityClassDurable, minInsyncReplicas: 1, wantErr: true},
+		{name: "Durable Describe Error", class: v1beta1.DurabilityClassDurable, describeErr: errors.New("test describe error"), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stubNewClusterAdminFn(t, test.minInsyncReplicas, test.describeErr)
+			defaultProducer := mocks.NewSyncProducer(t, nil)
+			var createdConfigs []*sarama.Config
+			pool := NewPool(zap.NewNop(), []string{"test-broker"}, sarama.NewConfig(), defaultProducer, func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+				createdConfigs = append(createdConfigs, config)
+				return mocks.NewSyncProducer(t, nil), nil
+			})
+
+			producer, err := pool.Producer(testTopic, test.class)
+			if test.wantErr {
+				assert.Nil(t, producer)
+				var replicasErr *InsufficientReplicasError
+				if test.describeErr != nil {
+					assert.True(t, errors.Is(err, test.describeErr))
+					assert.False(t, errors.As(err, &replicasErr))
+				} else {
+					assert.True(t, errors.As(err, &replicasErr))
+					assert.Equal(t, test.minInsyncReplicas, replicasErr.MinInsyncReplicas)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			if test.wantDefault {
+				assert.Same(t, defaultProducer, producer)
+				assert.Empty(t, createdConfigs)
+			} else {
+				assert.NotSame(t, defaultProducer, producer)
+				assert.Len(t, createdConfigs, 1)
+				assert.Equal(t, test.wantAcks, createdConfigs[0].Producer.RequiredAcks)
+
+				// Verify The Producer Is Reused
+				reused, err := pool.Producer(testTopic, test.class)
+				assert.Nil(t, err)
+				assert.Same(t, producer, reused)
+				assert.Len(t, createdConfigs, 1)
+			}
+			assert.Nil(t, pool.Close())
+		})
+	}
+}
+
+// Test The Pool Caches The Topic's Minimum In-Sync Replicas
+func TestPoolReplicasCache(t *testing.T) {
+	describeCount := stubNewClusterAdminFn(t, 3, nil)
+	pool := NewPool(zap.NewNop(), []string{"test-broker"}, sarama.NewConfig(), nil, func([]string, *sarama.Config) (sarama.SyncProducer, error) {
+		return mocks.NewSyncProducer(t, nil), nil
+	})
+	for i := 0; i < 3; i++ {
+		_, err := pool.Producer(testTopic, v1beta1.DurabilityClassDurable)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, *describeCount)
+	assert.Nil(t, pool.Close())
+}
+
+// Test The Pool Doesn't Cache A Failure To Describe The Topic's Minimum In-Sync Replicas
+func TestPoolReplicasDescribeErrorNotCached(t *testing.T) {
+	describeCount := stubNewClusterAdminFn(t, 3, errors.New("test describe error"))
+	pool := NewPool(zap.NewNop(), []string{"test-broker"}, sarama.NewConfig(), nil, func([]string, *sarama.Config) (sarama.SyncProducer, error) {
+		return mocks.NewSyncProducer(t, nil), nil
+	})
+	for i := 0; i < 2; i++ {
+		_, err := pool.Producer(testTopic, v1beta1.DurabilityClassDurable)
+		assert.NotNil(t, err)
+	}
+	assert.Equal(t, 2, *describeCount)
+	assert.Nil(t, pool.Close())
+}
+
+// Stub The NewClusterAdminFn With A MockClusterAdmin Describing The Specified Minimum In-Sync Replicas
+func stubNewClusterAdminFn(t *testing.T, minInsyncReplicas int, describeErr error) *int {
+	describeCount := 0
+	clusterAdmin := &commontesting.MockClusterAdmin{
+		MockDescribeConfigFunc: func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+			assert.Equal(t, testTopic, resource.Name)
+			assert.Equal(t, []string{constants.KafkaTopicConfigMinInsyncReplicas}, resource.ConfigNames)
+			describeCount++
+			return []sarama.ConfigEntry{{Name: constants.KafkaTopicConfigMinInsyncReplicas, Value: strconv.Itoa(minInsyncReplicas)}}, describeErr
+		},
+	}
+	NewClusterAdminFn = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) { return clusterAdmin, nil }
+	t.Cleanup(func() { NewClusterAdminFn = sarama.NewClusterAdmin })
+	return &describeCount
+}
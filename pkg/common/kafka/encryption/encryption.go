@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// Kafka Headers Marking Encrypted Records (Not CloudEvent Attributes, And Removed Upon Decryption)
+const (
+	KeyIdHeaderKey  = "kafkachannel-encryption-key-id"
+	SecretHeaderKey = "kafkachannel-encryption-secret"
+)
+
+// The Content-Type Prefix Of Structured-Mode CloudEvents (Whose Attributes Are Part Of The Record Value)
+const structuredContentTypePrefix = "application/cloudevents"
+
+// SecretName returns the name of the encryption Secret of the specified KafkaChannel, or "" if the KafkaChannel
+// has not opted in to encryption.
+func SecretName(channel *v1beta1.KafkaChannel) string {
+	if channel == nil || channel.Spec.Encryption == nil {
+		return ""
+	}
+	return channel.Spec.Encryption.SecretName
+}
+
+// ToBinaryMessage converts the Message (applying the Transformers) into a binary-mode Message, so that only
+// the event data (and not the CloudEvent attributes) ends up in the Kafka record value which is encrypted.
+func ToBinaryMessage(ctx context.Context, message binding.Message, transformers ...binding.Transformer) (binding.Message, error) {
+	event, err := binding.ToEvent(ctx, message, transformers...)
+	if err != nil {
+		return nil, err
+	}
+	return binding.ToMessage(event), nil
+}
+
+// Encrypt replaces the value (the CloudEvent data) of the binary-mode ProducerMessage with its AES-GCM encryption
+// using the active key of the specified Secret, adding headers identifying the Secret and key-id.  Nothing is
+// encrypted if the secretName is empty.
+func Encrypt(ctx context.Context, provider KeyProvider, namespace string, secretName string, message *sarama.ProducerMessage) error {
+	if secretName == "" {
+		return nil
+	}
+	if provider == nil {
+		return errors.New("unable to encrypt event: no encryption key provider configured")
+	}
+	for _, header := range message.Headers {
+		if strings.EqualFold(string(header.Key), "content-type") && strings.HasPrefix(string(header.Value), structuredContentTypePrefix) {
+			return errors.New("unable to encrypt structured-mode event: the cloudevent attributes would not remain readable")
+		}
+	}
+
+	keyRing, err := provider.KeyRing(ctx, namespace, secretName)
+	if err != nil {
+		return fmt.Errorf("failed to get encryption keys from secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	var plaintext []byte
+	if message.Value != nil {
+		if plaintext, err = message.Value.Encode(); err != nil {
+			return err
+		}
+	}
+
+	key, _ := keyRing.Key(keyRing.ActiveKeyId)
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+
+	message.Value = sarama.ByteEncoder(aead.Seal(nonce, nonce, plaintext, additionalData(secretName, keyRing.ActiveKeyId)))
+	message.Headers = append(message.Headers,
+		sarama.RecordHeader{Key: []byte(SecretHeaderKey), Value: []byte(secretName)},
+		sarama.RecordHeader{Key: []byte(KeyIdHeaderKey), Value: []byte(keyRing.ActiveKeyId)})
+	return nil
+}
+
+// Decrypt restores the value of an encrypted ConsumerMessage using the key identified by its key-id header from the
+// specified Secret (that of the consuming KafkaChannel, never one named by the record), removing the headers.  The
+// KeyRing is re-read once if the key-id is unknown (e.g. a key was added by a rotation).  Messages without the
+// headers are left untouched.
+func Decrypt(ctx context.Context, provider KeyProvider, namespace string, secretName string, message *sarama.ConsumerMessage) error {
+	var recordSecretName, keyId string
+	headers := make([]*sarama.RecordHeader, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		switch {
+		case header != nil && string(header.Key) == SecretHeaderKey:
+			recordSecretName = string(header.Value)
+		case header != nil && string(header.Key) == KeyIdHeaderKey:
+			keyId = string(header.Value)
+		default:
+			headers = append(headers, header)
+		}
+	}
+	if recordSecretName == "" && keyId == "" {
+		return nil
+	}
+	if secretName == "" {
+		return errors.New("unable to decrypt event: the channel has no encryption secret")
+	}
+	if recordSecretName != secretName {
+		return fmt.Errorf("unable to decrypt event: encrypted with secret %q rather than the channel's secret %q", recordSecretName, secretName)
+	}
+	if provider == nil {
+		return errors.New("unable to decrypt event: no encryption key provider configured")
+	}
+
+	key, err := decryptionKey(ctx, provider, namespace, secretName, keyId)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	if len(message.Value) < aead.NonceSize() {
+		return fmt.Errorf("encrypted event of %d bytes is too short", len(message.Value))
+	}
+
+	nonce, ciphertext := message.Value[:aead.NonceSize()], message.Value[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(secretName, keyId))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt event with key %q of secret %s/%s: %w", keyId, namespace, secretName, err)
+	}
+
+	message.Value = plaintext
+	message.Headers = headers
+	return nil
+}
+
+// Get The Specified Decryption Key, Re-Reading The KeyRing Once If The Key-Id Is Unknown
+func decryptionKey(ctx context.Context, provider KeyProvider, namespace string, secretName string, keyId string) ([]byte, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		keyRing, err := provider.KeyRing(ctx, namespace, secretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get encryption keys from secret %s/%s: %w", namespace, secretName, err)
+		}
+		if key, ok := keyRing.Key(keyId); ok {
+			return key, nil
+		}
+		provider.Forget(namespace, secretName)
+	}
+	return nil, fmt.Errorf("encryption key %q not found in secret %s/%s", keyId, namespace, secretName)
+}
+
+// Create The AES-GCM AEAD For The Specified Key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// The Additional Authenticated Data Binds The Ciphertext To Its Secret & Key-Id Headers
+func additionalData(secretName string, keyId string) []byte {
+	return []byte(secretName + "/" + keyId)
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// Test Data
+const (
+	testNamespace  = "test-namespace"
+	testSecretName = "test-channel-keys"
+	testData       = `{"content":"sensitive"}`
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte("1"), 32)
+	testKey2 = bytes.Repeat([]byte("2"), 16)
+)
+
+// Test The NewKeyRing() / ParseKeyRing() Functionality
+func TestParseKeyRing(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string][]byte
+		wantActiveId string
+		wantErr      bool
+	}{
+		{name: "Single Key", data: map[string][]byte{"key-1": testKey1}, wantActiveId: "key-1"},
+		{name: "Several Keys", data: map[string][]byte{"key-1": testKey1, "key-2": testKey2, ActiveKeyIdSecretKey: []byte("key-2")}, wantActiveId: "key-2"},
+		{name: "Several Keys Without Active Key", data: map[string][]byte{"key-1": testKey1, "key-2": testKey2}, wantErr: true},
+		{name: "Unknown Active Key", data: map[string][]byte{"key-1": testKey1, ActiveKeyIdSecretKey: []byte("key-2")}, wantErr: true},
+		{name: "Invalid Key Size", data: map[string][]byte{"key-1": []byte("too-short")}, wantErr: true},
+		{name: "No Keys", data: map[string][]byte{}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyRing, err := ParseKeyRing(newTestSecret(test.data))
+			assert.Equal(t, test.wantErr, err != nil)
+			if !test.wantErr {
+				assert.Equal(t, test.wantActiveId, keyRing.ActiveKeyId)
+				assert.NotContains(t, keyRing.KeyIds(), ActiveKeyIdSecretKey)
+			}
+		})
+	}
+}
+
+// Test An Encrypted ProducerMessage Is Decrypted As The Original, With Readable CloudEvent Attributes
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.TODO()
+	kubeClient := fake.NewSimpleClientset(newTestSecret(map[string][]byte{"key-1": testKey1}))
+	provider := NewSecretKeyProvider(kubeClient)
+
+	producerMessage := newTestProducerMessage()
+	assert.Nil(t, Encrypt(ctx, provider, testNamespace, testSecretName, producerMessage))
+	encrypted, _ := producerMessage.Value.Encode()
+	assert.NotContains(t, string(encrypted), "sensitive")
+	assert.Contains(t, producerMessage.Headers, sarama.RecordHeader{Key: []byte("ce_id"), Value: []byte("1234")})
+	assert.Contains(t, producerMessage.Headers, sarama.RecordHeader{Key: []byte(KeyIdHeaderKey), Value: []byte("key-1")})
+	assert.Contains(t, producerMessage.Headers, sarama.RecordHeader{Key: []byte(SecretHeaderKey), Value: []byte(testSecretName)})
+
+	consumerMessage := toConsumerMessage(producerMessage)
+	assert.Nil(t, Decrypt(ctx, provider, testNamespace, testSecretName, consumerMessage))
+	assert.Equal(t, testData, string(consumerMessage.Value))
+	assert.Len(t, consumerMessage.Headers, 2)
+
+	// Verify Tampering Is Detected
+	tampered := toConsumerMessage(producerMessage)
+	tampered.Value[len(tampered.Value)-1] ^= 0xFF
+	assert.NotNil(t, Decrypt(ctx, provider, testNamespace, testSecretName, tampered))
+}
+
+// Test Records Encrypted Before A Key Rotation Remain Decryptable, And A New Key-Id Causes A Secret Re-Read
+func TestKeyRotation(t *testing.T) {
+	ctx := context.TODO()
+	kubeClient := fake.NewSimpleClientset(newTestSecret(map[string][]byte{"key-1": testKey1}))
+	encryptProvider := NewSecretKeyProvider(kubeClient)
+	decryptProvider := NewSecretKeyProvider(kubeClient)
+
+	// Encrypt With The Original Key & Cache The Original KeyRing In The Decrypting Provider
+	beforeRotation := newTestProducerMessage()
+	assert.Nil(t, Encrypt(ctx, encryptProvider, testNamespace, testSecretName, beforeRotation))
+	assert.Nil(t, Decrypt(ctx, decryptProvider, testNamespace, testSecretName, toConsumerMessage(beforeRotation)))
+
+	// Rotate To A New Active Key (Retaining The Original)
+	rotated := newTestSecret(map[string][]byte{"key-1": testKey1, "key-2": testKey2, ActiveKeyIdSecretKey: []byte("key-2")})
+	_, err := kubeClient.CoreV1().Secrets(testNamespace).Update(ctx, rotated, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	encryptProvider.Forget(testNamespace, testSecretName)
+
+	afterRotation := newTestProducerMessage()
+	assert.Nil(t, Encrypt(ctx, encryptProvider, testNamespace, testSecretName, afterRotation))
+	assert.Contains(t, afterRotation.Headers, sarama.RecordHeader{Key: []byte(KeyIdHeaderKey), Value: []byte("key-2")})
+
+	// Verify Both Are Decrypted (The Stale Cached KeyRing Is Refreshed For The Unknown Key-Id)
+	for _, producerMessage := range []*sarama.ProducerMessage{afterRotation, beforeRotation} {
+		consumerMessage := toConsumerMessage(producerMessage)
+		assert.Nil(t, Decrypt(ctx, decryptProvider, testNamespace, testSecretName, consumerMessage))
+		assert.Equal(t, testData, string(consumerMessage.Value))
+	}
+}
+
+// Test Decryption Uses The Channel's Secret Rather Than The One Named By The Record
+func TestDecryptChannelSecret(t *testing.T) {
+	ctx := context.TODO()
+	otherSecret := newTestSecret(map[string][]byte{"key-1": testKey2})
+	otherSecret.Name = "other-secret"
+	provider := NewSecretKeyProvider(fake.NewSimpleClientset(newTestSecret(map[string][]byte{"key-1": testKey1}), otherSecret))
+
+	producerMessage := newTestProducerMessage()
+	assert.Nil(t, Encrypt(ctx, provider, testNamespace, testSecretName, producerMessage))
+
+	// A Channel Without (Or With A Different) Encryption Secret Does Not Decrypt The Record
+	assert.NotNil(t, Decrypt(ctx, provider, testNamespace, "", toConsumerMessage(producerMessage)))
+	assert.NotNil(t, Decrypt(ctx, provider, testNamespace, otherSecret.Name, toConsumerMessage(producerMessage)))
+
+	// A Record Naming Another Secret Is Rejected Rather Than Decrypted With That Secret's Keys
+	forged := toConsumerMessage(producerMessage)
+	for _, header := range forged.Headers {
+		if string(header.Key) == SecretHeaderKey {
+			header.Value = []byte(otherSecret.Name)
+		}
+	}
+	err := Decrypt(ctx, provider, testNamespace, testSecretName, forged)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), otherSecret.Name)
+}
+
+// Test Concurrent KeyRing Requests Share A Single Secret Read Without Blocking Cached KeyRings
+func TestKeyRingConcurrentRead(t *testing.T) {
+	var readCount int32
+	started := make(chan struct{}, 5)
+	release := make(chan struct{})
+	kubeClient := fake.NewSimpleClientset(newTestSecret(map[string][]byte{"key-1": testKey1}))
+	kubeClient.PrependReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.(clientgotesting.GetAction).GetName() == testSecretName {
+			atomic.AddInt32(&readCount, 1)
+			started <- struct{}{}
+			<-release
+		}
+		return false, nil, nil
+	})
+	provider := NewSecretKeyProvider(kubeClient)
+	otherName := types.NamespacedName{Namespace: testNamespace, Name: "other-keys"}
+	provider.keyRings[otherName] = cachedKeyRing{keyRing: &KeyRing{}, expires: time.Now().Add(time.Minute)}
+
+	// Start Several Concurrent Requests For The Same Secret & Wait For The Read To Block
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			keyRing, err := provider.KeyRing(context.TODO(), testNamespace, testSecretName)
+			assert.Nil(t, err)
+			assert.NotNil(t, keyRing)
+		}()
+	}
+	<-started
+
+	// Cached KeyRings Of Other Secrets Remain Available While The Read Is In Flight
+	keyRing, err := provider.KeyRing(context.TODO(), testNamespace, otherName.Name)
+	assert.Nil(t, err)
+	assert.NotNil(t, keyRing)
+
+	time.Sleep(50 * time.Millisecond) // Allow The Remaining Requests To Join The In-Flight Read
+	close(release)
+	waitGroup.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&readCount))
+}
+
+// Test Messages Which Are Not Encrypted / Decrypted
+func TestEncryptDecryptSkipped(t *testing.T) {
+	ctx := context.TODO()
+
+	// Not Enabled
+	producerMessage := newTestProducerMessage()
+	assert.Nil(t, Encrypt(ctx, nil, testNamespace, "", producerMessage))
+	assert.Equal(t, sarama.StringEncoder(testData), producerMessage.Value)
+
+	// Not Encrypted
+	consumerMessage := toConsumerMessage(producerMessage)
+	assert.Nil(t, Decrypt(ctx, nil, testNamespace, testSecretName, consumerMessage))
+	assert.Equal(t, testData, string(consumerMessage.Value))
+
+	// No KeyProvider
+	assert.NotNil(t, Encrypt(ctx, nil, testNamespace, testSecretName, producerMessage))
+	consumerMessage.Headers = append(consumerMessage.Headers, &sarama.RecordHeader{Key: []byte(KeyIdHeaderKey), Value: []byte("key-1")})
+	assert.NotNil(t, Decrypt(ctx, nil, testNamespace, testSecretName, consumerMessage))
+
+	// Structured Mode
+	provider := NewSecretKeyProvider(fake.NewSimpleClientset(newTestSecret(map[string][]byte{"key-1": testKey1})))
+	structured := &sarama.ProducerMessage{
+		Value:   sarama.StringEncoder(`{"specversion":"1.0"}`),
+		Headers: []sarama.RecordHeader{{Key: []byte("content-type"), Value: []byte("application/cloudevents+json")}},
+	}
+	assert.NotNil(t, Encrypt(ctx, provider, testNamespace, testSecretName, structured))
+
+	// Missing Secret
+	assert.NotNil(t, Encrypt(ctx, provider, testNamespace, "missing-secret", newTestProducerMessage()))
+}
+
+// Test The ToBinaryMessage() Functionality
+func TestToBinaryMessage(t *testing.T) {
+	testEvent := event.New()
+	testEvent.SetID("1234")
+	testEvent.SetSource("test-source")
+	testEvent.SetType("test-type")
+	assert.Nil(t, testEvent.SetData("application/json", []byte(testData)))
+
+	message, err := ToBinaryMessage(context.TODO(), binding.ToMessage(&testEvent))
+	assert.Nil(t, err)
+	actual, err := binding.ToEvent(context.TODO(), message)
+	assert.Nil(t, err)
+	assert.Equal(t, testEvent.ID(), actual.ID())
+	assert.Equal(t, testData, string(actual.Data()))
+}
+
+// Create A Test Encryption Secret With The Specified Data
+func newTestSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName}, Data: data}
+}
+
+// Create A Binary-Mode Test ProducerMessage
+func newTestProducerMessage() *sarama.ProducerMessage {
+	return &sarama.ProducerMessage{
+		Value: sarama.StringEncoder(testData),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("content-type"), Value: []byte("application/json")},
+			{Key: []byte("ce_id"), Value: []byte("1234")},
+		},
+	}
+}
+
+// Convert The ProducerMessage To The ConsumerMessage Read From Kafka
+func toConsumerMessage(producerMessage *sarama.ProducerMessage) *sarama.ConsumerMessage {
+	value, _ := producerMessage.Value.Encode()
+	consumerMessage := &sarama.ConsumerMessage{Value: append([]byte{}, value...)}
+	for i := range producerMessage.Headers {
+		header := producerMessage.Headers[i]
+		consumerMessage.Headers = append(consumerMessage.Headers, &header)
+	}
+	return consumerMessage
+}
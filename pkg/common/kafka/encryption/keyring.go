@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ActiveKeyIdSecretKey is the (optional) entry of an encryption Secret naming the key-id used for encryption.
+const ActiveKeyIdSecretKey = "activeKeyId"
+
+// KeyRing holds the AES keys of an encryption Secret by key-id, one of which is active (used for encryption).
+type KeyRing struct {
+	ActiveKeyId string
+	keys        map[string][]byte
+}
+
+// NewKeyRing returns a KeyRing with the specified keys and active key-id, validating the key sizes.
+func NewKeyRing(activeKeyId string, keys map[string][]byte) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no encryption keys specified")
+	}
+	for keyId, key := range keys {
+		switch len(key) {
+		case 16, 24, 32: // AES-128, AES-192 & AES-256
+		default:
+			return nil, fmt.Errorf("encryption key %q must be 16, 24 or 32 bytes, but is %d bytes", keyId, len(key))
+		}
+	}
+
+	// Default The Active Key When There Is Only One
+	if activeKeyId == "" {
+		if len(keys) > 1 {
+			return nil, fmt.Errorf("%s must be specified when there are several encryption keys", ActiveKeyIdSecretKey)
+		}
+		for keyId := range keys {
+			activeKeyId = keyId
+		}
+	}
+	if _, ok := keys[activeKeyId]; !ok {
+		return nil, fmt.Errorf("active encryption key %q not found", activeKeyId)
+	}
+
+	return &KeyRing{ActiveKeyId: activeKeyId, keys: keys}, nil
+}
+
+// ParseKeyRing returns the KeyRing described by the specified encryption Secret, in which each entry other
+// than ActiveKeyIdSecretKey is a raw AES key named by its key-id.
+func ParseKeyRing(secret *corev1.Secret) (*KeyRing, error) {
+	keys := make(map[string][]byte, len(secret.Data))
+	for name, value := range secret.Data {
+		if name != ActiveKeyIdSecretKey {
+			keys[name] = value
+		}
+	}
+	keyRing, err := NewKeyRing(string(secret.Data[ActiveKeyIdSecretKey]), keys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return keyRing, nil
+}
+
+// Key returns the key with the specified key-id, if present.
+func (k *KeyRing) Key(keyId string) ([]byte, bool) {
+	key, ok := k.keys[keyId]
+	return key, ok
+}
+
+// KeyIds returns the sorted key-ids of the KeyRing.
+func (k *KeyRing) KeyIds() []string {
+	keyIds := make([]string, 0, len(k.keys))
+	for keyId := range k.keys {
+		keyIds = append(keyIds, keyId)
+	}
+	sort.Strings(keyIds)
+	return keyIds
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultKeyCacheDuration is the length of time a KeyRing is cached before its Secret is re-read.
+const DefaultKeyCacheDuration = time.Minute
+
+// KeyProvider provides the KeyRings of encryption Secrets.
+type KeyProvider interface {
+	// KeyRing returns the KeyRing of the specified encryption Secret.
+	KeyRing(ctx context.Context, namespace string, secretName string) (*KeyRing, error)
+
+	// Forget discards any cached KeyRing of the specified encryption Secret (e.g. after a key rotation).
+	Forget(namespace string, secretName string)
+}
+
+// Verify The SecretKeyProvider Implements The KeyProvider Interface
+var _ KeyProvider = &SecretKeyProvider{}
+
+// The cachedKeyRing struct holds the KeyRing of a single encryption Secret
+type cachedKeyRing struct {
+	keyRing *KeyRing
+	expires time.Time
+}
+
+// SecretKeyProvider is a KeyProvider reading encryption Secrets from the Kubernetes API, caching the resulting
+// KeyRings so that the Secrets are not read for every event.
+type SecretKeyProvider struct {
+	kubeClient    kubernetes.Interface
+	cacheDuration time.Duration
+	keyRingsMutex sync.Mutex
+	keyRings      map[types.NamespacedName]cachedKeyRing
+	reads         singleflight.Group // de-duplicates concurrent reads of the same Secret
+}
+
+// NewSecretKeyProvider returns a new SecretKeyProvider using the specified Kubernetes client.
+func NewSecretKeyProvider(kubeClient kubernetes.Interface) *SecretKeyProvider {
+	return &SecretKeyProvider{
+		kubeClient:    kubeClient,
+		cacheDuration: DefaultKeyCacheDuration,
+		keyRings:      make(map[types.NamespacedName]cachedKeyRing),
+	}
+}
+
+// KeyRing returns the cached KeyRing of the specified Secret, reading the Secret if not cached or expired.
+func (p *SecretKeyProvider) KeyRing(ctx context.Context, namespace string, secretName string) (*KeyRing, error) {
+	name := types.NamespacedName{Namespace: namespace, Name: secretName}
+
+	p.keyRingsMutex.Lock()
+	cached, ok := p.keyRings[name]
+	p.keyRingsMutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.keyRing, nil
+	}
+
+	// Read The Secret Outside The Lock, Sharing A Single In-Flight Request Among Concurrent Callers
+	result, err, _ := p.reads.Do(name.String(), func() (interface{}, error) {
+		secret, err := p.kubeClient.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		keyRing, err := ParseKeyRing(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		p.keyRingsMutex.Lock()
+		p.keyRings[name] = cachedKeyRing{keyRing: keyRing, expires: time.Now().Add(p.cacheDuration)}
+		p.keyRingsMutex.Unlock()
+		return keyRing, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*KeyRing), nil
+}
+
+// Forget discards any cached KeyRing of the specified Secret.
+func (p *SecretKeyProvider) Forget(namespace string, secretName string) {
+	p.keyRingsMutex.Lock()
+	defer p.keyRingsMutex.Unlock()
+	name := types.NamespacedName{Namespace: namespace, Name: secretName}
+	delete(p.keyRings, name)
+	p.reads.Forget(name.String())
+}
@@ -118,6 +118,42 @@ func CheckIfAllOffsetsInitialized(kafkaClient sarama.Client, kafkaAdminClient sa
 	return true, nil
 }
 
+// GetConsumerGroupLag returns the total number of messages in the topics which have not yet been committed by
+// the consumer group (the sum across all partitions of the newest offset minus the committed offset).
+// Partitions whose offsets have not yet been initialized by the consumer group are not included.
+func GetConsumerGroupLag(kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) (int64, error) {
+	_, topicPartitions, err := retrieveAllPartitions(topics, kafkaClient)
+	if err != nil {
+		return -1, err
+	}
+
+	// Fetch topic offsets
+	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	if err != nil {
+		return -1, fmt.Errorf("failed to get the topic offsets: %w", err)
+	}
+
+	// Fetch consumer group offsets
+	offsets, err := kafkaAdminClient.ListConsumerGroupOffsets(consumerGroup, topicPartitions)
+	if err != nil {
+		return -1, err
+	}
+
+	var lag int64
+	for topic, partitions := range offsets.Blocks {
+		for partitionID, block := range partitions {
+			if block.Offset == -1 { // not initialized?
+				continue
+			}
+			if newest, ok := topicOffsets[topic][partitionID]; ok && newest > block.Offset {
+				lag += newest - block.Offset
+			}
+		}
+	}
+
+	return lag, nil
+}
+
 func retrieveAllPartitions(topics []string, kafkaClient sarama.Client) (int, map[string][]int32, error) {
 	totalPartitions := 0
 
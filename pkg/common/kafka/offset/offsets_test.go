@@ -150,6 +150,49 @@ func TestCheckIfAllOffsetsInitialized(t *testing.T) {
 	}
 }
 
+func TestGetConsumerGroupLag(t *testing.T) {
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+
+			group := "my-group"
+
+			configureMockBroker(t, group, tc.topicOffsets, tc.cgOffsets, tc.initialized, broker)
+
+			config := sarama.NewConfig()
+			config.Version = sarama.MaxVersion
+
+			sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			defer sc.Close()
+
+			kac, err := sarama.NewClusterAdminFromClient(sc)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			defer kac.Close()
+
+			// The expected lag excludes uninitialized partitions
+			var expected int64
+			for topic, partitions := range tc.cgOffsets {
+				for partition, offset := range partitions {
+					if offset != -1 {
+						expected += tc.topicOffsets[topic][partition] - offset
+					}
+				}
+			}
+
+			// test GetConsumerGroupLag
+			lag, err := GetConsumerGroupLag(sc, kac, tc.topics, group)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, lag)
+		})
+	}
+}
+
 func configureMockBroker(t *testing.T, group string, topicOffsets map[string]map[int32]int64, cgOffsets map[string]map[int32]int64, initialized bool, broker *sarama.MockBroker) {
 	offsetResponse := sarama.NewMockOffsetResponse(t).SetVersion(1)
 	for topic, partitions := range topicOffsets {
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/network"
+)
+
+// ReceiverPort is the port on which the KafkaChannel receivers listen, which is the port of the HTTPMessageReceiver
+// that the Knative NewMessageReceiver configures.  That receiver offers no option to wrap its handler, so StartReceiver
+// listens on the same port in its place.
+const ReceiverPort = 8080
+
+// Stubbable In Tests
+var drainTimeout = network.DefaultDrainTimeout
+var startListenFn = func(ctx context.Context, handler http.Handler) error {
+	return kncloudevents.NewHTTPMessageReceiver(ReceiverPort).StartListen(ctx, handler)
+}
+
+// The Knative MessageReceiver only distinguishes unknown channels (404) from all other receiver errors (500), so
+// size rejections are recorded in the request context by the Limiter and translated into a 413 response here.
+
+type rejectionKey struct{}
+
+// The rejection struct holds the MessageTooLargeError (if any) recorded while handling a single request
+type rejection struct {
+	mutex sync.Mutex
+	err   *MessageTooLargeError
+}
+
+// Record The MessageTooLargeError In The Request Context (No-Op Outside Of A SizeLimitHandler)
+func reject(ctx context.Context, err *MessageTooLargeError) {
+	if r, ok := ctx.Value(rejectionKey{}).(*rejection); ok {
+		r.mutex.Lock()
+		r.err = err
+		r.mutex.Unlock()
+	}
+}
+
+// NewSizeLimitHandler wraps the specified handler (typically a Knative MessageReceiver) such that events rejected
+// by a Limiter are answered with a 413 (Request Entity Too Large) and a descriptive body.
+func NewSizeLimitHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		r := &rejection{}
+		ctx := context.WithValue(request.Context(), rejectionKey{}, r)
+		handler.ServeHTTP(&sizeLimitResponseWriter{ResponseWriter: writer, rejection: r}, request.WithContext(ctx))
+	})
+}
+
+// The sizeLimitResponseWriter replaces the status code (and body) of rejected requests
+type sizeLimitResponseWriter struct {
+	http.ResponseWriter
+	rejection *rejection
+}
+
+// WriteHeader writes a 413 response in place of the specified status code if the request was rejected.
+func (w *sizeLimitResponseWriter) WriteHeader(statusCode int) {
+	w.rejection.mutex.Lock()
+	err := w.rejection.err
+	w.rejection.mutex.Unlock()
+
+	if err == nil {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	http.Error(w.ResponseWriter, err.Error(), http.StatusRequestEntityTooLarge)
+}
+
+// StartReceiver serves the specified handler (typically a Knative MessageReceiver) behind a SizeLimitHandler until
+// the context is done, with the shutdown semantics of MessageReceiver.Start: a shutdown which doesn't complete within
+// the drain timeout returns an error.
+func StartReceiver(ctx context.Context, handler http.Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- startListenFn(ctx, NewSizeLimitHandler(handler))
+	}()
+
+	// Stop Either If The Receiver Stops Or If The Context Is Done
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Bound The Graceful Shutdown Of The Receiver
+	cancel()
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(drainTimeout):
+		return errors.New("timeout shutting down http bindings receiver")
+	}
+}
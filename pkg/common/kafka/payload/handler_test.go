@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+// Test The StartReceiver() Functionality
+func TestStartReceiver(t *testing.T) {
+	defer restoreReceiverStubs(startListenFn, drainTimeout)
+	drainTimeout = 10 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		listenErr   error
+		hangOnClose bool
+		cancel      bool
+		expectErr   string
+	}{
+		{name: "Listen Failure", listenErr: errors.New("address in use"), expectErr: "address in use"},
+		{name: "Graceful Shutdown", cancel: true},
+		{name: "Shutdown Timeout", cancel: true, hangOnClose: true, expectErr: "timeout shutting down http bindings receiver"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			startListenFn = func(ctx context.Context, handler http.Handler) error {
+				// The Handler Is Wrapped By The SizeLimitHandler
+				_, isReceiver := handler.(*mockHandler)
+				assert.False(t, isReceiver)
+				if test.listenErr != nil {
+					return test.listenErr
+				}
+				<-ctx.Done()
+				if test.hangOnClose {
+					time.Sleep(time.Second)
+				}
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			if test.cancel {
+				cancel()
+			}
+			defer cancel()
+			err := StartReceiver(ctx, &mockHandler{})
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Test The NewSizeLimitHandler() Functionality Via A Knative MessageReceiver
+func TestSizeLimitHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantStatus int
+		wantBody   string
+		wantCount  int64
+	}{
+		{name: "Accepted", data: "small", wantStatus: http.StatusAccepted},
+		{name: "Too Large", data: strings.Repeat("x", 1000), wantStatus: http.StatusRequestEntityTooLarge, wantBody: "exceeds the maximum of 1000 bytes", wantCount: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metricstest.Unregister(OversizedEventCountN)
+			registerViews()
+
+			limiter := newTestLimiter(t, 1000, nil, nil)
+			receiverFunc := func(ctx context.Context, channel eventingchannel.ChannelReference, message binding.Message, _ []binding.Transformer, _ http.Header) error {
+				producerMessage := &sarama.ProducerMessage{Topic: testTopic, Value: sarama.StringEncoder(test.data)}
+				return limiter.Check(ctx, channel, producerMessage)
+			}
+			receiver, err := eventingchannel.NewMessageReceiver(receiverFunc, zap.NewNop(), &mockStatsReporter{},
+				eventingchannel.ResolveMessageChannelFromHostHeader(func(string) (eventingchannel.ChannelReference, error) { return testChannel, nil }))
+			assert.Nil(t, err)
+
+			request := httptest.NewRequest(http.MethodPost, "http://test-channel.test-namespace/", strings.NewReader(test.data))
+			request.Header.Set("ce-specversion", "1.0")
+			request.Header.Set("ce-id", "test-id")
+			request.Header.Set("ce-type", "test-type")
+			request.Header.Set("ce-source", "test-source")
+			request.Header.Set("content-type", "text/plain")
+			response := httptest.NewRecorder()
+
+			NewSizeLimitHandler(receiver).ServeHTTP(response, request)
+
+			assert.Equal(t, test.wantStatus, response.Code)
+			body, _ := io.ReadAll(response.Body)
+			assert.Contains(t, string(body), test.wantBody)
+			if test.wantCount > 0 {
+				metricstest.CheckCountData(t, OversizedEventCountN, map[string]string{"namespace_name": testChannel.Namespace, "name": testChannel.Name}, test.wantCount)
+			} else {
+				metricstest.AssertNoMetric(t, OversizedEventCountN)
+			}
+		})
+	}
+}
+
+// Mock Eventing Channel StatsReporter
+type mockStatsReporter struct{}
+
+func (r *mockStatsReporter) ReportEventCount(*eventingchannel.ReportArgs, int) error { return nil }
+func (r *mockStatsReporter) ReportEventDispatchTime(*eventingchannel.ReportArgs, int, time.Duration) error {
+	return nil
+}
+
+type mockHandler struct{}
+
+func (h *mockHandler) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+func restoreReceiverStubs(startListen func(context.Context, http.Handler) error, timeout time.Duration) {
+	startListenFn = startListen
+	drainTimeout = timeout
+}
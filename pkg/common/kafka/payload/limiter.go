@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+// DefaultLimitCacheDuration is the length of time a channel's topic size limit is cached before being re-read.
+const DefaultLimitCacheDuration = 5 * time.Minute
+
+// NewClusterAdminFn creates the ClusterAdmin used to describe topic configs (may be stubbed for testing)
+var NewClusterAdminFn = sarama.NewClusterAdmin
+
+// The per-record overhead of the Kafka v2 message format (matches Sarama's internal producer size check)
+const maximumRecordOverhead = 5*binary.MaxVarintLen32 + binary.MaxVarintLen64 + 1
+
+// MessageTooLargeError is returned when an event exceeds the maximum message size of the channel's topic.
+type MessageTooLargeError struct {
+	Channel eventingchannel.ChannelReference
+	Topic   string
+	Size    int
+	Limit   int
+}
+
+// Error implements the error interface with a description suitable for returning to the event sender.
+func (e *MessageTooLargeError) Error() string {
+	return fmt.Sprintf("event size of %d bytes exceeds the maximum of %d bytes allowed by topic %s of channel %s",
+		e.Size, e.Limit, e.Topic, e.Channel.String())
+}
+
+// The cachedLimit struct holds the size limit of a single channel's topic
+type cachedLimit struct {
+	topic   string
+	limit   int
+	expires time.Time
+}
+
+// Limiter verifies that events fit within the effective maximum message size of their channel's topic, which
+// is the smaller of the topic's "max.message.bytes" config and the producer's MaxMessageBytes.  The limits are
+// cached per channel to avoid describing the topic config for every event.
+type Limiter struct {
+	logger        *zap.Logger
+	brokers       []string
+	config        *sarama.Config
+	cacheDuration time.Duration
+	limitsMutex   sync.Mutex
+	limits        map[eventingchannel.ChannelReference]cachedLimit
+	describes     singleflight.Group // de-duplicates concurrent describes of the same topic
+}
+
+// NewLimiter returns a new Limiter using the specified brokers and Sarama config to describe topic configs.
+func NewLimiter(logger *zap.Logger, brokers []string, config *sarama.Config) *Limiter {
+	return &Limiter{
+		logger:        logger,
+		brokers:       brokers,
+		config:        config,
+		cacheDuration: DefaultLimitCacheDuration,
+		limits:        make(map[eventingchannel.ChannelReference]cachedLimit),
+	}
+}
+
+// Check verifies the size of the ProducerMessage against the limit of its topic, returning a MessageTooLargeError
+// (and recording the rejection for the enclosing SizeLimitHandler and metrics) if it is too large.  A nil Limiter
+// performs no checks.
+func (l *Limiter) Check(ctx context.Context, channel eventingchannel.ChannelReference, message *sarama.ProducerMessage) error {
+	if l == nil {
+		return nil
+	}
+
+	size := MessageSize(message)
+	limit := l.limit(channel, message.Topic)
+	if size <= limit {
+		return nil
+	}
+
+	err := &MessageTooLargeError{Channel: channel, Topic: message.Topic, Size: size, Limit: limit}
+	l.logger.Warn("Rejecting Event Exceeding Topic Maximum Message Size", zap.Any("Channel", channel), zap.Error(err))
+	reject(ctx, err)
+	reportRejection(ctx, channel)
+	return err
+}
+
+// Forget removes any cached limit for the specified channel (e.g. when it is deleted).
+func (l *Limiter) Forget(channel eventingchannel.ChannelReference) {
+	if l == nil {
+		return
+	}
+	l.limitsMutex.Lock()
+	defer l.limitsMutex.Unlock()
+	delete(l.limits, channel)
+}
+
+// Get The Effective Limit For The Specified Channel/Topic, Using The Cached Value If Still Valid
+func (l *Limiter) limit(channel eventingchannel.ChannelReference, topic string) int {
+	l.limitsMutex.Lock()
+	cached, ok := l.limits[channel]
+	l.limitsMutex.Unlock()
+	if ok && cached.topic == topic && time.Now().Before(cached.expires) {
+		return cached.limit
+	}
+
+	// Describe The Topic Outside The Lock, Sharing A Single In-Flight Request Among Concurrent Callers
+	result, _, _ := l.describes.Do(topic, func() (interface{}, error) {
+		limit := l.config.Producer.MaxMessageBytes
+		topicLimit, err := l.describeTopicLimit(topic)
+		if err != nil {
+			l.logger.Warn("Failed To Describe Topic Maximum Message Size - Using Producer Limit", zap.String("Topic", topic), zap.Int("Limit", limit), zap.Error(err))
+		} else if topicLimit < limit {
+			limit = topicLimit
+		}
+		return limit, nil
+	})
+	limit := result.(int)
+
+	l.limitsMutex.Lock()
+	l.limits[channel] = cachedLimit{topic: topic, limit: limit, expires: time.Now().Add(l.cacheDuration)}
+	l.limitsMutex.Unlock()
+	return limit
+}
+
+// Describe The "max.message.bytes" Config Of The Specified Topic (Includes Broker Defaults If Not Overridden)
+func (l *Limiter) describeTopicLimit(topic string) (int, error) {
+	clusterAdmin, err := NewClusterAdminFn(l.brokers, l.config)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = clusterAdmin.Close() }()
+
+	entries, err := clusterAdmin.DescribeConfig(sarama.ConfigResource{
+		Type:        sarama.TopicResource,
+		Name:        topic,
+		ConfigNames: []string{constants.KafkaTopicConfigMaxMessageBytes},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if entry.Name == constants.KafkaTopicConfigMaxMessageBytes {
+			return strconv.Atoi(entry.Value)
+		}
+	}
+	return 0, fmt.Errorf("topic %s config does not include %s", topic, constants.KafkaTopicConfigMaxMessageBytes)
+}
+
+// MessageSize returns the size of the ProducerMessage as it will be evaluated against the topic's maximum
+// message size (using the Kafka v2 record format).
+func MessageSize(message *sarama.ProducerMessage) int {
+	size := maximumRecordOverhead
+	for _, header := range message.Headers {
+		size += len(header.Key) + len(header.Value) + 2*binary.MaxVarintLen32
+	}
+	if message.Key != nil {
+		size += message.Key.Length()
+	}
+	if message.Value != nil {
+		size += message.Value.Length()
+	}
+	return size
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test Data
+const (
+	testTopic            = "test-topic"
+	testProducerMaxBytes = 2000
+)
+
+var testChannel = eventingchannel.ChannelReference{Namespace: "test-namespace", Name: "test-channel"}
+
+// Test The Limiter.Check() Functionality
+func TestLimiterCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		topicLimit  int
+		describeErr error
+		valueSize   int
+		wantErr     bool
+	}{
+		{name: "Within Topic Limit", topicLimit: 1000, valueSize: 100},
+		{name: "Exceeds Topic Limit", topicLimit: 1000, valueSize: 1000, wantErr: true},
+		{name: "Producer Limit Smaller Than Topic Limit", topicLimit: 1000000, valueSize: testProducerMaxBytes, wantErr: true},
+		{name: "Describe Error Uses Producer Limit", describeErr: errors.New("test describe error"), valueSize: 1000},
+		{name: "Describe Error Exceeds Producer Limit", describeErr: errors.New("test describe error"), valueSize: testProducerMaxBytes, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := newTestLimiter(t, test.topicLimit, test.describeErr, nil)
+			message := &sarama.ProducerMessage{Topic: testTopic, Value: sarama.ByteEncoder(strings.Repeat("x", test.valueSize))}
+			err := limiter.Check(context.TODO(), testChannel, message)
+			assert.Equal(t, test.wantErr, err != nil)
+			if test.wantErr {
+				tooLargeErr := &MessageTooLargeError{}
+				assert.True(t, errors.As(err, &tooLargeErr))
+				assert.Equal(t, MessageSize(message), tooLargeErr.Size)
+				assert.Contains(t, err.Error(), testTopic)
+			}
+		})
+	}
+}
+
+// Test The Limiter Caches Limits Per Channel Until Expiry, Topic Change Or Forget
+func TestLimiterCache(t *testing.T) {
+	describeCount := 0
+	limiter := newTestLimiter(t, 1000, nil, &describeCount)
+	message := &sarama.ProducerMessage{Topic: testTopic, Value: sarama.StringEncoder("test")}
+	otherTopicAdmin := newMockClusterAdmin(t, "other-topic", 1000, nil, &describeCount)
+
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Equal(t, 1, describeCount)
+
+	message.Topic = "other-topic"
+	stubNewClusterAdminFn(t, otherTopicAdmin)
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Equal(t, 2, describeCount)
+
+	limiter.Forget(testChannel)
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Equal(t, 3, describeCount)
+
+	limiter.cacheDuration = -time.Second
+	limiter.Forget(testChannel)
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+	assert.Equal(t, 5, describeCount)
+}
+
+// Test The Limiter Describes Topics Outside Its Lock & Shares Concurrent Describes Of The Same Topic
+func TestLimiterConcurrentDescribe(t *testing.T) {
+	var describeCount int32
+	started := make(chan struct{}, 5)
+	release := make(chan struct{})
+	stubNewClusterAdminFn(t, &commontesting.MockClusterAdmin{
+		MockDescribeConfigFunc: func(sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+			atomic.AddInt32(&describeCount, 1)
+			started <- struct{}{}
+			<-release
+			return []sarama.ConfigEntry{{Name: constants.KafkaTopicConfigMaxMessageBytes, Value: "1000"}}, nil
+		},
+	})
+	limiter := NewLimiter(zap.NewNop(), []string{"test-broker"}, sarama.NewConfig())
+	otherChannel := eventingchannel.ChannelReference{Namespace: "test-namespace", Name: "other-channel"}
+	limiter.limits[otherChannel] = cachedLimit{topic: "other-topic", limit: 1000, expires: time.Now().Add(time.Minute)}
+	message := &sarama.ProducerMessage{Topic: testTopic, Value: sarama.StringEncoder("test")}
+
+	// Start Several Concurrent Checks Of The Same Topic & Wait For The Describe To Block
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			assert.Nil(t, limiter.Check(context.TODO(), testChannel, message))
+		}()
+	}
+	<-started
+
+	// Cached Limits Of Other Channels Remain Available While The Describe Is In Flight
+	assert.Nil(t, limiter.Check(context.TODO(), otherChannel, &sarama.ProducerMessage{Topic: "other-topic", Value: sarama.StringEncoder("test")}))
+
+	time.Sleep(50 * time.Millisecond) // Allow The Remaining Checks To Join The In-Flight Describe
+	close(release)
+	waitGroup.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&describeCount))
+}
+
+// Test A Nil Limiter Performs No Checks
+func TestNilLimiter(t *testing.T) {
+	var limiter *Limiter
+	assert.Nil(t, limiter.Check(context.TODO(), testChannel, &sarama.ProducerMessage{Value: sarama.StringEncoder("test")}))
+	limiter.Forget(testChannel)
+}
+
+// Test The MessageSize() Functionality
+func TestMessageSize(t *testing.T) {
+	message := &sarama.ProducerMessage{
+		Key:     sarama.StringEncoder("key"),
+		Value:   sarama.StringEncoder("value"),
+		Headers: []sarama.RecordHeader{{Key: []byte("ce_id"), Value: []byte("1234")}},
+	}
+	assert.Equal(t, maximumRecordOverhead+3+5+5+4+10, MessageSize(message))
+}
+
+// Create A Limiter Describing The Specified Topic Limit Via A Stubbed Mock ClusterAdmin
+func newTestLimiter(t *testing.T, topicLimit int, describeErr error, describeCount *int) *Limiter {
+	stubNewClusterAdminFn(t, newMockClusterAdmin(t, testTopic, topicLimit, describeErr, describeCount))
+	config := sarama.NewConfig()
+	config.Producer.MaxMessageBytes = testProducerMaxBytes
+	return NewLimiter(zap.NewNop(), []string{"test-broker"}, config)
+}
+
+// Stub The NewClusterAdminFn For The Duration Of The Test
+func stubNewClusterAdminFn(t *testing.T, clusterAdmin sarama.ClusterAdmin) {
+	NewClusterAdminFn = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) { return clusterAdmin, nil }
+	t.Cleanup(func() { NewClusterAdminFn = sarama.NewClusterAdmin })
+}
+
+// Create A MockClusterAdmin Describing The Specified Topic Limit
+func newMockClusterAdmin(t *testing.T, topic string, topicLimit int, describeErr error, describeCount *int) *commontesting.MockClusterAdmin {
+	return &commontesting.MockClusterAdmin{
+		MockDescribeConfigFunc: func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+			assert.Equal(t, sarama.TopicResource, resource.Type)
+			assert.Equal(t, topic, resource.Name)
+			assert.Equal(t, []string{constants.KafkaTopicConfigMaxMessageBytes}, resource.ConfigNames)
+			if describeCount != nil {
+				*describeCount++
+			}
+			return []sarama.ConfigEntry{{Name: constants.KafkaTopicConfigMaxMessageBytes, Value: strconv.Itoa(topicLimit)}}, describeErr
+		},
+	}
+}
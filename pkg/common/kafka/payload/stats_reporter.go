@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/metrics"
+)
+
+// OversizedEventCountN is the number of events rejected for exceeding the maximum message size of their channel's topic.
+const OversizedEventCountN = "kafkachannel_oversized_event_count"
+
+var (
+	oversizedEventCountM = stats.Int64(
+		OversizedEventCountN,
+		"Number of events rejected for exceeding the maximum message size of the channel's topic",
+		stats.UnitDimensionless)
+
+	namespaceTagKey = tag.MustNewKey("namespace_name")
+	nameTagKey      = tag.MustNewKey("name")
+)
+
+func init() {
+	registerViews()
+}
+
+// Register The Views Of The Payload Measures
+func registerViews() {
+	err := view.Register(&view.View{
+		Description: oversizedEventCountM.Description(),
+		Measure:     oversizedEventCountM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTagKey, nameTagKey},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Record A Single Oversized Event Rejection Tagged By Channel
+func reportRejection(ctx context.Context, channel eventingchannel.ChannelReference) {
+	ctx, err := tag.New(ctx, tag.Insert(namespaceTagKey, channel.Namespace), tag.Insert(nameTagKey, channel.Name))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, oversizedEventCountM.M(1))
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/payload"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+//
+// Test Utilities For Stubbing The Payload Limiter's NewClusterAdminFn
+//
+
+// StubNewClusterAdminFn replaces the NewClusterAdminFn with one returning the specified ClusterAdmin for the
+// duration of the test.
+func StubNewClusterAdminFn(t *testing.T, clusterAdmin sarama.ClusterAdmin) {
+	payload.NewClusterAdminFn = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) { return clusterAdmin, nil }
+	t.Cleanup(func() { payload.NewClusterAdminFn = sarama.NewClusterAdmin })
+}
+
+// NewMockClusterAdmin returns a MockClusterAdmin describing the specified "max.message.bytes" topic limit, optionally
+// counting the number of describe requests.
+func NewMockClusterAdmin(t *testing.T, topic string, topicLimit int, describeErr error, describeCount *int) *commontesting.MockClusterAdmin {
+	return &commontesting.MockClusterAdmin{
+		MockDescribeConfigFunc: func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+			assert.Equal(t, sarama.TopicResource, resource.Type)
+			assert.Equal(t, topic, resource.Name)
+			assert.Equal(t, []string{constants.KafkaTopicConfigMaxMessageBytes}, resource.ConfigNames)
+			if describeCount != nil {
+				*describeCount++
+			}
+			return []sarama.ConfigEntry{{Name: constants.KafkaTopicConfigMaxMessageBytes, Value: strconv.Itoa(topicLimit)}}, describeErr
+		},
+	}
+}
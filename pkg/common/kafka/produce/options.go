@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package produce
+
+import (
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/claimcheck"
+	"knative.dev/eventing-kafka/pkg/common/kafka/durability"
+	"knative.dev/eventing-kafka/pkg/common/kafka/encryption"
+)
+
+// Options are the per-KafkaChannel settings with which the receivers produce events to the channel's topic.  The
+// zero Options (e.g. those of KafkaSinks and Brokers) opt in to none of the features and use the default producer.
+type Options struct {
+
+	// ClaimCheckThreshold is the payload size (in bytes) above which events are offloaded to the
+	// claim-check store, or 0 if the channel has not opted in to claim-check.
+	ClaimCheckThreshold int64
+
+	// EncryptionSecret is the name of the Secret holding the keys with which event data is
+	// encrypted, or "" if the channel has not opted in to encryption.
+	EncryptionSecret string
+
+	// DurabilityClass selects the producer with which events are written to the topic.
+	DurabilityClass v1beta1.DurabilityClass
+}
+
+// NewOptions resolves the Options of the specified KafkaChannel, whose own claim-check threshold takes precedence
+// over the specified default (from the config-kafka ConfigMap).
+func NewOptions(channel *v1beta1.KafkaChannel, defaultClaimCheckThreshold int64) Options {
+	return Options{
+		ClaimCheckThreshold: claimcheck.Threshold(channel, defaultClaimCheckThreshold),
+		EncryptionSecret:    encryption.SecretName(channel),
+		DurabilityClass:     durability.Class(channel),
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package produce
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Equal(t, Options{DurabilityClass: v1beta1.DurabilityClassDefault}, NewOptions(nil, 1024))
+	assert.Equal(t, Options{DurabilityClass: v1beta1.DurabilityClassDefault}, NewOptions(&v1beta1.KafkaChannel{}, 1024))
+
+	channel := &v1beta1.KafkaChannel{Spec: v1beta1.KafkaChannelSpec{
+		ClaimCheck:      &v1beta1.KafkaChannelClaimCheckSpec{},
+		Encryption:      &v1beta1.KafkaChannelEncryptionSpec{SecretName: "test-keys"},
+		DurabilityClass: v1beta1.DurabilityClassDurable,
+	}}
+	assert.Equal(t, Options{ClaimCheckThreshold: 1024, EncryptionSecret: "test-keys", DurabilityClass: v1beta1.DurabilityClassDurable}, NewOptions(channel, 1024))
+	channel.Spec.ClaimCheck.ThresholdBytes = 2048
+	assert.Equal(t, int64(2048), NewOptions(channel, 1024).ClaimCheckThreshold)
+}
@@ -53,7 +53,7 @@ func LoadAuthConfig(ctx context.Context, name string, namespace string) *client.
 	kafkaAuthCfg := config.GetAuthConfigFromKubernetes(ctx, name, namespace)
 	if kafkaAuthCfg != nil &&
 		kafkaAuthCfg.SASL != nil &&
-		kafkaAuthCfg.SASL.User == "" {
+		!kafkaAuthCfg.SASL.HasCredentials() {
 		if kafkaAuthCfg.TLS != nil {
 			// backwards-compatibility requires leaving the TLS auth config alone if it exists
 			kafkaAuthCfg.SASL = nil
@@ -80,27 +80,10 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 	ekConfig.Auth = getAuthConfig(ctx, ekConfig.Kafka.AuthSecretName, ekConfig.Kafka.AuthSecretNamespace)
 
 	// Merge The ConfigMap Settings Into The Provided Config
-	saramaShell := &struct {
-		EnableLogging bool   `json:"enableLogging"`
-		Config        string `json:"config"`
-	}{}
 	var saramaConfigString string
-
-	if configMap[constants.VersionConfigKey] != constants.CurrentConfigVersion {
-		// In the old version, the sarama config string was the entire field
-		saramaConfigString = configMap[constants.SaramaSettingsConfigKey]
-	} else {
-		err = yaml.Unmarshal([]byte(configMap[constants.SaramaSettingsConfigKey]), &saramaShell)
-		if err != nil {
-			return nil, err
-		}
-		if saramaShell == nil {
-			// An empty or missing sarama field will create a default sarama.Config
-			ekConfig.Sarama.EnableLogging = false
-		} else {
-			ekConfig.Sarama.EnableLogging = saramaShell.EnableLogging
-			saramaConfigString = saramaShell.Config
-		}
+	ekConfig.Sarama.EnableLogging, saramaConfigString, err = getSaramaSettings(configMap, yaml.Unmarshal)
+	if err != nil {
+		return nil, err
 	}
 
 	// Merge The Sarama Settings In The ConfigMap Into A New Base Sarama Config
@@ -114,6 +97,31 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 	return ekConfig, err
 }
 
+// The saramaShell struct is the format of the "sarama" field of the current version of the configmap
+type saramaShell struct {
+	EnableLogging bool   `json:"enableLogging"`
+	Config        string `json:"config"`
+}
+
+// getSaramaSettings returns the EnableLogging flag and Sarama config YAML string of the configmap's "sarama" field
+func getSaramaSettings(configMap map[string]string, unmarshal func([]byte, interface{}, ...yaml.JSONOpt) error) (bool, string, error) {
+	if configMap[constants.VersionConfigKey] != constants.CurrentConfigVersion {
+		// In the old version, the sarama config string was the entire field
+		return false, configMap[constants.SaramaSettingsConfigKey], nil
+	}
+
+	shell := &saramaShell{}
+	err := unmarshal([]byte(configMap[constants.SaramaSettingsConfigKey]), &shell)
+	if err != nil {
+		return false, "", err
+	}
+	if shell == nil {
+		// An empty or missing sarama field will create a default sarama.Config
+		return false, "", nil
+	}
+	return shell.EnableLogging, shell.Config, nil
+}
+
 // upgradeConfig converts an old configmap into a new EventingKafkaConfig
 // Returns nil if an upgrade is either unnecessary or impossible
 func upgradeConfig(data map[string]string) *commonconfig.EventingKafkaConfig {
@@ -221,8 +229,19 @@ func LoadEventingKafkaSettings(configMap map[string]string) (*commonconfig.Event
 }
 
 // AuthFromSarama creates a KafkaAuthConfig using the SASL settings from
-// a given Sarama config, or nil if there is no SASL user in that config
+// a given Sarama config, or nil if there is no SASL user (or OAuth token provider) in that config
 func AuthFromSarama(config *sarama.Config) *client.KafkaAuthConfig {
+	// Use the OAuth settings of an OAUTHBEARER token provider created by the config builder
+	if tokenProvider, ok := config.Net.SASL.TokenProvider.(*client.ClientCredentialsTokenProvider); ok {
+		oauthConfig := tokenProvider.Config()
+		return &client.KafkaAuthConfig{
+			SASL: &client.KafkaSaslConfig{
+				SaslType: sarama.SASLTypeOAuth,
+				OAuth:    &oauthConfig,
+			},
+		}
+	}
+
 	// Use the SASL settings from the provided Sarama config only if the user is non-empty
 	if config.Net.SASL.User != "" {
 		return &client.KafkaAuthConfig{
@@ -637,6 +637,15 @@ func TestAuthFromSarama(t *testing.T) {
 			}
 		})
 	}
+
+	// Verify The OAuth Settings Of An OAUTHBEARER TokenProvider Are Returned
+	oauthConfig := client.KafkaOAuthConfig{ClientId: "testClientId", ClientSecret: "testClientSecret", TokenUrl: "https://test-idp/token"}
+	existingConfig := sarama.NewConfig()
+	existingConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+	existingConfig.Net.SASL.TokenProvider = client.NewClientCredentialsTokenProvider(oauthConfig)
+	config := AuthFromSarama(existingConfig)
+	assert.Equal(t, sarama.SASLTypeOAuth, config.SASL.SaslType)
+	assert.Equal(t, oauthConfig, *config.SASL.OAuth)
 }
 
 func TestStringifyHeaders(t *testing.T) {
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarama
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"github.com/rickb777/date/period"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/eventing-kafka/pkg/common/client"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+)
+
+// Placeholder SASL Credentials Used To Validate The Shape Of The Sarama SASL Settings
+const placeholderCredential = "placeholder"
+
+// ValidateSettings validates the data of the config-kafka ConfigMap, returning field-level errors (with paths
+// relative to the ConfigMap's data) for settings which would otherwise only fail, or be silently ignored, once
+// loaded by the controllers and data planes.  Unknown fields are rejected as they are most likely misspelled.
+//
+// The Kafka auth Secret is not read, so the Sarama config is validated as if credentials for its SASL mechanism
+// were provided by the Secret.
+func ValidateSettings(ctx context.Context, configMap map[string]string) *apis.FieldError {
+	var errs *apis.FieldError
+
+	// Strictly Parse The Eventing-Kafka Settings Of The Current Version (Without Defaults, Which Would Mask Invalid
+	// Values) And Load Those Of Older Versions (Which Are Upgraded)
+	var err error
+	ekConfig := &commonconfig.EventingKafkaConfig{}
+	if configMap[constants.VersionConfigKey] == constants.CurrentConfigVersion {
+		err = yaml.UnmarshalStrict([]byte(configMap[constants.EventingKafkaSettingsConfigKey]), ekConfig)
+	} else {
+		ekConfig, err = LoadEventingKafkaSettings(configMap)
+	}
+	if err != nil {
+		errs = errs.Also(newInvalidSettingsError("invalid eventing-kafka settings", err, constants.EventingKafkaSettingsConfigKey))
+	} else {
+		errs = errs.Also(validateKubernetesConfig(ekConfig.Channel.Dispatcher.EKKubernetesConfig).ViaField("channel", "dispatcher").ViaField(constants.EventingKafkaSettingsConfigKey))
+		errs = errs.Also(validateKubernetesConfig(ekConfig.Channel.Receiver.EKKubernetesConfig).ViaField("channel", "receiver").ViaField(constants.EventingKafkaSettingsConfigKey))
+		errs = errs.Also(validateBrokerConfig(ekConfig.Broker).ViaField("broker").ViaField(constants.EventingKafkaSettingsConfigKey))
+	}
+
+	// Strictly Parse The Sarama Settings (The Config YAML Is Nested In The "config" Field Of The Current Version)
+	saramaConfigPath := []string{constants.SaramaSettingsConfigKey}
+	if configMap[constants.VersionConfigKey] == constants.CurrentConfigVersion {
+		saramaConfigPath = append(saramaConfigPath, "config")
+	}
+	_, saramaConfigString, err := getSaramaSettings(configMap, yaml.UnmarshalStrict)
+	if err != nil {
+		return errs.Also(newInvalidSettingsError("invalid sarama settings", err, constants.SaramaSettingsConfigKey))
+	}
+
+	// Build The Sarama Config Exactly As When Loading The Settings, But Rejecting Unknown Fields
+	saramaConfig, err := client.NewConfigBuilder().
+		WithDefaults().
+		FromYaml(saramaConfigString).
+		WithStrictYaml().
+		Build(ctx)
+	if err != nil {
+		return errs.Also(newInvalidSettingsError("invalid sarama config", err).ViaField(saramaConfigPath...))
+	}
+	return errs.Also(validateSaramaConfig(saramaConfig).ViaField(saramaConfigPath...))
+}
+
+// Validate The Resource Quantities & Replicas Of The Kubernetes Config Of A Receiver / Dispatcher
+func validateKubernetesConfig(config commonconfig.EKKubernetesConfig) *apis.FieldError {
+	var errs *apis.FieldError
+	errs = errs.Also(validateResources(config.CpuRequest, config.CpuLimit, "cpuRequest", "cpuLimit"))
+	errs = errs.Also(validateResources(config.MemoryRequest, config.MemoryLimit, "memoryRequest", "memoryLimit"))
+	if config.Replicas < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(config.Replicas, "replicas", "must be >= 0"))
+	}
+	return errs
+}
+
+// Validate That The Request & Limit Quantities Are Not Negative And That The Request Does Not Exceed The Limit
+func validateResources(request resource.Quantity, limit resource.Quantity, requestField string, limitField string) *apis.FieldError {
+	var errs *apis.FieldError
+	if request.Sign() < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(request.String(), requestField, "must not be negative"))
+	}
+	if limit.Sign() < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(limit.String(), limitField, "must not be negative"))
+	}
+	if !limit.IsZero() && request.Cmp(limit) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(request.String(), requestField, "must be less than or equal to "+limitField))
+	}
+	return errs
+}
+
+// Validate The Topic Settings Of The Kafka Broker (Unset Values Are Defaulted By The Broker Controller)
+func validateBrokerConfig(config commonconfig.EKBrokerConfig) *apis.FieldError {
+	var errs *apis.FieldError
+	if config.NumPartitions < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(config.NumPartitions, "numPartitions", "must be >= 0"))
+	}
+	if config.ReplicationFactor < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(config.ReplicationFactor, "replicationFactor", "must be >= 0"))
+	}
+	if config.RetentionDuration != "" {
+		if _, err := period.Parse(config.RetentionDuration); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(config.RetentionDuration, "retentionDuration", "must be an ISO-8601 duration"))
+		}
+	}
+	return errs
+}
+
+// Validate The Sarama Config (Including Its Kafka Version And The Shape Of Its SASL Settings)
+func validateSaramaConfig(config *sarama.Config) *apis.FieldError {
+	var errs *apis.FieldError
+
+	// Verify The Kafka Version Is One Supported By Sarama
+	if !config.Version.IsAtLeast(sarama.MinVersion) || !sarama.MaxVersion.IsAtLeast(config.Version) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(config.Version, sarama.MinVersion, sarama.MaxVersion, "Version"))
+	}
+
+	// Provide Placeholder SASL Credentials (As Would The Kafka Secret) So That Only The Shape Of The Settings Is Validated
+	if config.Net.SASL.Enable {
+		switch config.Net.SASL.Mechanism {
+		case sarama.SASLTypeOAuth:
+			if config.Net.SASL.TokenProvider == nil {
+				config.Net.SASL.TokenProvider = client.NewClientCredentialsTokenProvider(client.KafkaOAuthConfig{})
+			}
+		case sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512:
+			if config.Net.SASL.SCRAMClientGeneratorFunc == nil {
+				config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &client.XDGSCRAMClient{HashGeneratorFcn: client.SHA512} }
+			}
+		}
+		if config.Net.SASL.User == "" {
+			config.Net.SASL.User = placeholderCredential
+		}
+		if config.Net.SASL.Password == "" {
+			config.Net.SASL.Password = placeholderCredential
+		}
+	}
+
+	// Let Sarama Validate The Remaining Settings
+	if err := config.Validate(); err != nil {
+		errs = errs.Also(newInvalidSettingsError("invalid sarama config", err))
+	}
+	return errs
+}
+
+// Create A FieldError For Settings Which Could Not Be Parsed Or Validated (Defaulting To The Current Field)
+func newInvalidSettingsError(message string, err error, paths ...string) *apis.FieldError {
+	if len(paths) == 0 {
+		paths = []string{apis.CurrentField}
+	}
+	return &apis.FieldError{
+		Message: message,
+		Paths:   paths,
+		Details: err.Error(),
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarama
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+// Test That The ConfigMaps Shipped In The Config Directory Are Valid
+func TestValidateSettingsOfShippedConfigMaps(t *testing.T) {
+	commontesting.SetTestEnvironment(t)
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	for _, path := range []string{
+		"../../../../config/channel/distributed/300-eventing-kafka-configmap.yaml",
+		"../../../../config/channel/consolidated/configmaps/kafka-config.yaml",
+	} {
+		t.Run(path, func(t *testing.T) {
+			configMapBytes, err := os.ReadFile(path)
+			assert.Nil(t, err)
+			configMap := &corev1.ConfigMap{}
+			assert.Nil(t, yaml.Unmarshal(configMapBytes, configMap))
+			assert.Equal(t, constants.SettingsConfigMapName, configMap.Name)
+			assert.Nil(t, ValidateSettings(ctx, configMap.Data))
+		})
+	}
+}
+
+// Test The ValidateSettings() Functionality
+func TestValidateSettings(t *testing.T) {
+	commontesting.SetTestEnvironment(t)
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	tests := []struct {
+		name          string
+		eventingKafka string
+		sarama        string
+		version       string
+		expectedPaths []string
+	}{
+		{
+			name:          "Valid",
+			eventingKafka: "channel:\n  receiver:\n    cpuRequest: 100m\n    cpuLimit: 200m\n",
+			sarama:        "enableLogging: true\nconfig: |\n  Version: 2.3.0\n  Net:\n    SASL:\n      Enable: true\n      Mechanism: SCRAM-SHA-512\n",
+		},
+		{
+			name:          "Valid Old Version",
+			eventingKafka: "kafka:\n  enableSaramaLogging: true\n",
+			sarama:        "Net:\n  TLS:\n    Enable: true\n",
+			version:       "-",
+		},
+		{
+			name:          "Unknown EventingKafka Field",
+			eventingKafka: "channel:\n  recevier:\n    replicas: 2\n",
+			expectedPaths: []string{"eventing-kafka"},
+		},
+		{
+			name:          "Invalid Resource Quantities",
+			eventingKafka: "channel:\n  receiver:\n    cpuRequest: 500m\n    cpuLimit: 200m\n  dispatcher:\n    memoryRequest: -50Mi\n    replicas: -1\n",
+			expectedPaths: []string{"eventing-kafka.channel.receiver.cpuRequest", "eventing-kafka.channel.dispatcher.memoryRequest", "eventing-kafka.channel.dispatcher.replicas"},
+		},
+		{
+			name:          "Valid Broker Settings",
+			eventingKafka: "broker:\n  numPartitions: 10\n  replicationFactor: 3\n  retentionDuration: PT24H\n",
+		},
+		{
+			name:          "Invalid Broker Settings",
+			eventingKafka: "broker:\n  numPartitions: -1\n  replicationFactor: -3\n  retentionDuration: 1day\n",
+			expectedPaths: []string{"eventing-kafka.broker.numPartitions", "eventing-kafka.broker.replicationFactor", "eventing-kafka.broker.retentionDuration"},
+		},
+		{
+			name:          "Unknown Sarama Settings Field",
+			sarama:        "enableLoging: true\n",
+			expectedPaths: []string{"sarama"},
+		},
+		{
+			name:          "Unknown Sarama Config Field",
+			sarama:        "config: |\n  Net:\n    SASL:\n      Enabled: true\n",
+			expectedPaths: []string{"sarama.config"},
+		},
+		{
+			name:          "Unparseable Kafka Version",
+			sarama:        "config: |\n  Version: latest\n",
+			expectedPaths: []string{"sarama.config"},
+		},
+		{
+			name:          "Unsupported Kafka Version",
+			sarama:        "config: |\n  Version: 9.9.9\n",
+			expectedPaths: []string{"sarama.config.Version"},
+		},
+		{
+			name:          "Invalid SASL Mechanism",
+			sarama:        "config: |\n  Net:\n    SASL:\n      Enable: true\n      Mechanism: BOGUS\n",
+			expectedPaths: []string{"sarama.config"},
+		},
+		{
+			name:          "Invalid Idempotent Producer",
+			sarama:        "config: |\n  Producer:\n    Idempotent: true\n",
+			expectedPaths: []string{"sarama.config"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configMap := map[string]string{
+				constants.VersionConfigKey:               constants.CurrentConfigVersion,
+				constants.EventingKafkaSettingsConfigKey: test.eventingKafka,
+				constants.SaramaSettingsConfigKey:        test.sarama,
+			}
+			if test.version != "" {
+				configMap[constants.VersionConfigKey] = test.version
+			}
+
+			err := ValidateSettings(ctx, configMap)
+			if len(test.expectedPaths) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			assert.NotNil(t, err)
+			var paths []string
+			for _, fieldError := range err.WrappedErrors() {
+				paths = append(paths, fieldError.Paths...)
+			}
+			assert.ElementsMatch(t, test.expectedPaths, paths, err.Error())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topic
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// ValidateExistingTopic verifies that the specified Kafka Topic exists and has the expected number of
+// partitions and replication factor.  It is used for KafkaChannels which reference an existing Topic
+// rather than having one created for them.
+func ValidateExistingTopic(clusterAdmin sarama.ClusterAdmin, topicName string, numPartitions int32, replicationFactor int16) error {
+
+	metadata, err := clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return fmt.Errorf("failed to describe topic %s: %w", topicName, err)
+	}
+	if len(metadata) == 0 || metadata[0] == nil || metadata[0].Err == sarama.ErrUnknownTopicOrPartition {
+		return fmt.Errorf("topic %s does not exist", topicName)
+	}
+	topicMetadata := metadata[0]
+	if topicMetadata.Err != sarama.ErrNoError {
+		return fmt.Errorf("failed to describe topic %s: %w", topicName, topicMetadata.Err)
+	}
+
+	if actualPartitions := int32(len(topicMetadata.Partitions)); actualPartitions != numPartitions {
+		return fmt.Errorf("topic %s has %d partitions, expected %d", topicName, actualPartitions, numPartitions)
+	}
+	for _, partition := range topicMetadata.Partitions {
+		if actualReplicas := int16(len(partition.Replicas)); actualReplicas != replicationFactor {
+			return fmt.Errorf("topic %s partition %d has replication factor %d, expected %d", topicName, partition.ID, actualReplicas, replicationFactor)
+		}
+	}
+
+	return nil
+}
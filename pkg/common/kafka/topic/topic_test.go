@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+func TestValidateExistingTopic(t *testing.T) {
+
+	const topicName = "existing-topic"
+
+	// Create TopicMetadata With The Specified Partitions & Replicas
+	newTopicMetadata := func(partitions int, replicas ...int32) *sarama.TopicMetadata {
+		topicMetadata := &sarama.TopicMetadata{Name: topicName}
+		for i := 0; i < partitions; i++ {
+			topicMetadata.Partitions = append(topicMetadata.Partitions, &sarama.PartitionMetadata{ID: int32(i), Replicas: replicas})
+		}
+		return topicMetadata
+	}
+
+	tests := []struct {
+		name        string
+		metadata    []*sarama.TopicMetadata
+		describeErr error
+		wantErr     bool
+	}{
+		{
+			name:     "Matching Topic",
+			metadata: []*sarama.TopicMetadata{newTopicMetadata(4, 1, 2, 3)},
+		},
+		{
+			name:        "Describe Error",
+			describeErr: errors.New("test describe error"),
+			wantErr:     true,
+		},
+		{
+			name:     "Unknown Topic",
+			metadata: []*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrUnknownTopicOrPartition}},
+			wantErr:  true,
+		},
+		{
+			name:    "No Metadata",
+			wantErr: true,
+		},
+		{
+			name:     "Partitions Mismatch",
+			metadata: []*sarama.TopicMetadata{newTopicMetadata(2, 1, 2, 3)},
+			wantErr:  true,
+		},
+		{
+			name:     "ReplicationFactor Mismatch",
+			metadata: []*sarama.TopicMetadata{newTopicMetadata(4, 1)},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clusterAdmin := &commontesting.MockClusterAdmin{
+				MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					assert.Equal(t, []string{topicName}, topics)
+					return test.metadata, test.describeErr
+				},
+			}
+			err := ValidateExistingTopic(clusterAdmin, topicName, 4, 3)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// IngressTimeHeaderKey is the Kafka header with which the receivers stamp the time (in Unix milliseconds) at which an
+// event was received, so that the dispatchers can measure the event's latency from ingress to consumption.
+const IngressTimeHeaderKey = "kafkachannel-ingress-time"
+
+// Wrapper Function For Stubbing The Current Time In Unit Tests
+var nowFn = time.Now
+
+// StampIngressTime adds the IngressTimeHeaderKey header with the current time to the specified message.
+func StampIngressTime(message *sarama.ProducerMessage) {
+	value := strconv.FormatInt(nowFn().UnixMilli(), 10)
+	message.Headers = append(message.Headers, sarama.RecordHeader{Key: []byte(IngressTimeHeaderKey), Value: []byte(value)})
+}
+
+// FilterIngressTimeHeader returns a new array of RecordHeader pointers excluding the IngressTimeHeaderKey header, which
+// is internal to eventing-kafka and must not be passed through to subscribers as an HTTP header.
+func FilterIngressTimeHeader(recordHeaders []*sarama.RecordHeader) []*sarama.RecordHeader {
+	filteredRecordHeaders := make([]*sarama.RecordHeader, 0, len(recordHeaders))
+	for _, recordHeader := range recordHeaders {
+		if recordHeader != nil && string(recordHeader.Key) != IngressTimeHeaderKey {
+			filteredRecordHeaders = append(filteredRecordHeaders, recordHeader)
+		}
+	}
+	return filteredRecordHeaders
+}
+
+// IngressTime returns the time at which the specified message was received, as stamped by the receiver, falling back
+// to the timestamp of the Kafka record for messages produced elsewhere (e.g. those consumed by a KafkaSource).  The
+// zero time is returned if neither is known.
+func IngressTime(message *sarama.ConsumerMessage) time.Time {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == IngressTimeHeaderKey {
+			if millis, err := strconv.ParseInt(string(header.Value), 10, 64); err == nil {
+				return time.UnixMilli(millis)
+			}
+		}
+	}
+	return message.Timestamp
+}
+
+// Delivery tracks the delivery of a single consumed event to its subscriber, counting the attempts made and noting the
+// time at which the subscriber acknowledged the event.  Requests to other URLs (e.g. a reply or dead letter sink) made
+// while dispatching the event are not counted.
+type Delivery struct {
+	destination *url.URL
+	consumeTime time.Time
+	ackTime     time.Time
+	attempts    int
+}
+
+// NewDelivery returns a Delivery to the specified destination of an event which is being consumed now.
+func NewDelivery(destination *url.URL) *Delivery {
+	return &Delivery{destination: sanitizeURL(destination), consumeTime: nowFn()}
+}
+
+// RetryConfig returns a copy of the specified RetryConfig (or of a RetryConfig without retries if nil) whose CheckRetry
+// function tracks the attempts made to deliver the event.  It is to be passed to the dispatch of the event.
+func (d *Delivery) RetryConfig(config *kncloudevents.RetryConfig) *kncloudevents.RetryConfig {
+	var retryConfig kncloudevents.RetryConfig
+	if config != nil {
+		retryConfig = *config
+	} else {
+		retryConfig = kncloudevents.NoRetries()
+	}
+
+	checkRetry := retryConfig.CheckRetry
+	retryConfig.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		d.track(resp, err)
+		if checkRetry == nil {
+			return false, err
+		}
+		return checkRetry(ctx, resp, err)
+	}
+	return &retryConfig
+}
+
+// Attempts returns the number of attempts made to deliver the event to the destination.
+func (d *Delivery) Attempts() int {
+	return d.attempts
+}
+
+// AckLatency returns the time from the consumption of the event to its acknowledgement by the destination, and whether
+// the destination acknowledged the event at all.
+func (d *Delivery) AckLatency() (time.Duration, bool) {
+	if d.ackTime.IsZero() {
+		return 0, false
+	}
+	return d.ackTime.Sub(d.consumeTime), true
+}
+
+// track counts a single attempt (if it was made to the destination) along with its acknowledgement (if successful)
+func (d *Delivery) track(resp *http.Response, err error) {
+	if d.destination == nil {
+		return
+	}
+
+	var requestURL *url.URL
+	var urlErr *url.Error
+	if resp != nil && resp.Request != nil {
+		requestURL = resp.Request.URL
+	} else if errors.As(err, &urlErr) {
+		requestURL, _ = url.Parse(urlErr.URL)
+	}
+	if requestURL == nil || requestURL.Host != d.destination.Host || requestURL.Path != d.destination.Path {
+		return
+	}
+
+	d.attempts++
+	if err == nil && resp != nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		d.ackTime = nowFn()
+	}
+}
+
+// sanitizeURL mirrors the eventing MessageDispatcher's handling of host-only URLs, so that they match the requests made
+func sanitizeURL(u *url.URL) *url.URL {
+	if u == nil || u.Scheme == "http" || u.Scheme == "https" {
+		return u
+	}
+	return &url.URL{Scheme: "http", Host: u.Host, Path: "/"}
+}
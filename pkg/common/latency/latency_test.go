@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+var (
+	testNow         = time.UnixMilli(1650000000000)
+	testDestination = &url.URL{Scheme: "http", Host: "subscriber.test-namespace.svc.cluster.local", Path: "/"}
+	testDeadLetter  = &url.URL{Scheme: "http", Host: "dead-letter.test-namespace.svc.cluster.local", Path: "/"}
+)
+
+// Test That The Stamped Ingress Time Is Read From The Consumed Message
+func TestStampIngressTime(t *testing.T) {
+	stubNow(t, testNow)
+
+	producerMessage := &sarama.ProducerMessage{}
+	StampIngressTime(producerMessage)
+	assert.Len(t, producerMessage.Headers, 1)
+	assert.Equal(t, IngressTimeHeaderKey, string(producerMessage.Headers[0].Key))
+
+	consumerMessage := &sarama.ConsumerMessage{
+		Headers:   []*sarama.RecordHeader{&producerMessage.Headers[0]},
+		Timestamp: testNow.Add(time.Second),
+	}
+	assert.True(t, testNow.Equal(IngressTime(consumerMessage)))
+}
+
+// Test That The Ingress Time Header Is Filtered From The Headers Passed Through To Subscribers
+func TestFilterIngressTimeHeader(t *testing.T) {
+	traceHeader := &sarama.RecordHeader{Key: []byte("x-b3-traceid"), Value: []byte("trace")}
+	ingressTimeHeader := &sarama.RecordHeader{Key: []byte(IngressTimeHeaderKey), Value: []byte("1650000000000")}
+	assert.Equal(t, []*sarama.RecordHeader{traceHeader}, FilterIngressTimeHeader([]*sarama.RecordHeader{ingressTimeHeader, nil, traceHeader}))
+	assert.Empty(t, FilterIngressTimeHeader(nil))
+}
+
+// Test That The Ingress Time Falls Back To The Timestamp Of The Kafka Record
+func TestIngressTime_Timestamp(t *testing.T) {
+	assert.True(t, testNow.Equal(IngressTime(&sarama.ConsumerMessage{Timestamp: testNow})))
+	assert.True(t, IngressTime(&sarama.ConsumerMessage{}).IsZero())
+	invalid := &sarama.ConsumerMessage{
+		Headers:   []*sarama.RecordHeader{{Key: []byte(IngressTimeHeaderKey), Value: []byte("invalid")}},
+		Timestamp: testNow,
+	}
+	assert.True(t, testNow.Equal(IngressTime(invalid)))
+}
+
+// Test The Delivery's Tracking Of The Attempts Made To (And The Acknowledgement By) The Destination
+func TestDelivery(t *testing.T) {
+	tests := []struct {
+		name         string
+		destination  *url.URL
+		responses    []*http.Response
+		errs         []error
+		wantAttempts int
+		wantAck      bool
+	}{
+		{
+			name:         "Acknowledged On First Attempt",
+			destination:  testDestination,
+			responses:    []*http.Response{newResponse(testDestination, http.StatusAccepted)},
+			wantAttempts: 1,
+			wantAck:      true,
+		},
+		{
+			name:        "Acknowledged After Retries",
+			destination: testDestination,
+			responses: []*http.Response{
+				nil,
+				newResponse(testDestination, http.StatusServiceUnavailable),
+				newResponse(testDestination, http.StatusOK),
+			},
+			errs:         []error{&url.Error{Op: "Post", URL: testDestination.String(), Err: errors.New("connection refused")}},
+			wantAttempts: 3,
+			wantAck:      true,
+		},
+		{
+			name:        "Dead Lettered",
+			destination: testDestination,
+			responses: []*http.Response{
+				newResponse(testDestination, http.StatusInternalServerError),
+				newResponse(testDeadLetter, http.StatusAccepted),
+			},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Host-Only Destination",
+			destination:  &url.URL{Host: testDestination.Host},
+			responses:    []*http.Response{newResponse(testDestination, http.StatusAccepted)},
+			wantAttempts: 1,
+			wantAck:      true,
+		},
+		{
+			name:      "No Destination",
+			responses: []*http.Response{newResponse(testDestination, http.StatusAccepted)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stubNow(t, testNow)
+			delivery := NewDelivery(test.destination)
+			retryConfig := delivery.RetryConfig(nil)
+			assert.Equal(t, 0, retryConfig.RetryMax)
+
+			stubNow(t, testNow.Add(time.Second))
+			for index, response := range test.responses {
+				var err error
+				if index < len(test.errs) {
+					err = test.errs[index]
+				}
+				retry, _ := retryConfig.CheckRetry(context.TODO(), response, err)
+				assert.False(t, retry)
+			}
+
+			assert.Equal(t, test.wantAttempts, delivery.Attempts())
+			ackLatency, acked := delivery.AckLatency()
+			assert.Equal(t, test.wantAck, acked)
+			if test.wantAck {
+				assert.Equal(t, time.Second, ackLatency)
+			}
+		})
+	}
+}
+
+// Test That The Delivery's RetryConfig Preserves The Specified RetryConfig
+func TestDelivery_RetryConfig(t *testing.T) {
+	config := kncloudevents.RetryConfig{
+		RetryMax: 3,
+		CheckRetry: func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+			return resp.StatusCode >= http.StatusInternalServerError, nil
+		},
+	}
+	delivery := NewDelivery(testDestination)
+	retryConfig := delivery.RetryConfig(&config)
+	assert.Equal(t, 3, retryConfig.RetryMax)
+
+	retry, err := retryConfig.CheckRetry(context.TODO(), newResponse(testDestination, http.StatusBadGateway), nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, delivery.Attempts())
+}
+
+func newResponse(requestURL *url.URL, statusCode int) *http.Response {
+	return &http.Response{StatusCode: statusCode, Request: &http.Request{URL: requestURL}}
+}
+
+// stubNow stubs the current time for the duration of the test
+func stubNow(t *testing.T, now time.Time) {
+	nowFnRestore := nowFn
+	t.Cleanup(func() { nowFn = nowFnRestore })
+	nowFn = func() time.Time { return now }
+}
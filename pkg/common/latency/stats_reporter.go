@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// IngressLatencyN is the time from the ingress of an event to its consumption from Kafka.
+	IngressLatencyN = "kafka_event_ingress_latencies"
+
+	// AckLatencyN is the time from the consumption of an event from Kafka to its acknowledgement by the subscriber.
+	AckLatencyN = "kafka_event_ack_latencies"
+
+	// DeliveryAttemptsN is the number of attempts made to deliver an event to the subscriber.
+	DeliveryAttemptsN = "kafka_event_delivery_attempts"
+)
+
+var (
+	ingressLatencyM = stats.Int64(
+		IngressLatencyN,
+		"The time from the ingress of an event to its consumption from Kafka",
+		stats.UnitMilliseconds)
+
+	ackLatencyM = stats.Int64(
+		AckLatencyN,
+		"The time from the consumption of an event from Kafka to its acknowledgement by the subscriber",
+		stats.UnitMilliseconds)
+
+	deliveryAttemptsM = stats.Int64(
+		DeliveryAttemptsN,
+		"Number of attempts made to deliver an event to the subscriber",
+		stats.UnitDimensionless)
+
+	namespaceTagKey     = tag.MustNewKey("namespace_name")
+	nameTagKey          = tag.MustNewKey("name")
+	resourceGroupTagKey = tag.MustNewKey("resource_group")
+	subscriberTagKey    = tag.MustNewKey("subscriber_uid")
+)
+
+func init() {
+	registerViews()
+}
+
+// Register The Views Of The Latency Measures
+func registerViews() {
+	tagKeys := []tag.Key{namespaceTagKey, nameTagKey, resourceGroupTagKey, subscriberTagKey}
+	err := view.Register(
+		&view.View{
+			Description: ingressLatencyM.Description(),
+			Measure:     ingressLatencyM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 1000000)...), // 1ms To ~17 Minutes
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: ackLatencyM.Description(),
+			Measure:     ackLatencyM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 100000)...), // 1ms To 100 Seconds
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: deliveryAttemptsM.Description(),
+			Measure:     deliveryAttemptsM,
+			Aggregation: view.Distribution(1, 2, 3, 4, 5, 10, 20, 50),
+			TagKeys:     tagKeys,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ReportArgs identifies the resource (KafkaChannel, Trigger or KafkaSource) and subscriber of the measured events.
+// (The resource is not an eventing ChannelReference, since the channel package's metrics conflict with the sources'.)
+type ReportArgs struct {
+	Namespace     string
+	Name          string
+	ResourceGroup string
+	Subscriber    types.UID // Optional UID of the Subscription (or Trigger) to which the events are dispatched
+}
+
+// ReportIngressLatency records the time from the ingress of the specified message to now, if its ingress time is known.
+func ReportIngressLatency(ctx context.Context, args ReportArgs, message *sarama.ConsumerMessage) {
+	ingressTime := IngressTime(message)
+	if ingressTime.IsZero() {
+		return
+	}
+	latency := nowFn().Sub(ingressTime)
+	if latency < 0 {
+		latency = 0 // Clock Skew Between The Receiver (Or Kafka) And The Dispatcher
+	}
+	if ctx, err := tagContext(ctx, args); err == nil {
+		metrics.Record(ctx, ingressLatencyM.M(latency.Milliseconds()))
+	}
+}
+
+// ReportDelivery records the attempts made to deliver an event to the subscriber (if any), along with the time from its
+// consumption to its acknowledgement (if acknowledged).
+func ReportDelivery(ctx context.Context, args ReportArgs, delivery *Delivery) {
+	if delivery.Attempts() == 0 {
+		return
+	}
+	ctx, err := tagContext(ctx, args)
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, deliveryAttemptsM.M(int64(delivery.Attempts())))
+	if ackLatency, ok := delivery.AckLatency(); ok {
+		metrics.Record(ctx, ackLatencyM.M(ackLatency.Milliseconds()))
+	}
+}
+
+// tagContext returns a Context tagged with the specified ReportArgs
+func tagContext(ctx context.Context, args ReportArgs) (context.Context, error) {
+	return tag.New(ctx,
+		tag.Insert(namespaceTagKey, args.Namespace),
+		tag.Insert(nameTagKey, args.Name),
+		tag.Insert(resourceGroupTagKey, args.ResourceGroup),
+		tag.Insert(subscriberTagKey, string(args.Subscriber)))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+var testArgs = ReportArgs{Namespace: "test-namespace", Name: "test-channel", ResourceGroup: "kafkachannels.messaging.knative.dev", Subscriber: "test-uid"}
+
+var testTags = map[string]string{
+	"namespace_name": "test-namespace",
+	"name":           "test-channel",
+	"resource_group": "kafkachannels.messaging.knative.dev",
+	"subscriber_uid": "test-uid",
+}
+
+// Test Reporting The Latency From The Ingress Of An Event To Its Consumption
+func TestReportIngressLatency(t *testing.T) {
+	resetViews()
+	stubNow(t, testNow)
+
+	ReportIngressLatency(context.TODO(), testArgs, &sarama.ConsumerMessage{}) // Unknown Ingress Time
+	metricstest.AssertNoMetric(t, IngressLatencyN)
+
+	ReportIngressLatency(context.TODO(), testArgs, &sarama.ConsumerMessage{Timestamp: testNow.Add(-250 * time.Millisecond)})
+	ReportIngressLatency(context.TODO(), testArgs, &sarama.ConsumerMessage{Timestamp: testNow.Add(time.Second)}) // Clock Skew
+	metricstest.CheckDistributionData(t, IngressLatencyN, testTags, 2, 0, 250)
+}
+
+// Test Reporting The Attempts And Acknowledgement Of The Delivery Of An Event
+func TestReportDelivery(t *testing.T) {
+	resetViews()
+	stubNow(t, testNow)
+
+	// Nothing Is Reported Without Any Attempts
+	delivery := NewDelivery(testDestination)
+	ReportDelivery(context.TODO(), testArgs, delivery)
+	metricstest.AssertNoMetric(t, DeliveryAttemptsN, AckLatencyN)
+
+	// An Unacknowledged Delivery Reports Only Its Attempts
+	retryConfig := delivery.RetryConfig(nil)
+	_, _ = retryConfig.CheckRetry(context.TODO(), newResponse(testDestination, http.StatusInternalServerError), nil)
+	ReportDelivery(context.TODO(), testArgs, delivery)
+	metricstest.CheckDistributionData(t, DeliveryAttemptsN, testTags, 1, 1, 1)
+	metricstest.AssertNoMetric(t, AckLatencyN)
+
+	// An Acknowledged Delivery Reports Its Latency
+	delivery = NewDelivery(testDestination)
+	retryConfig = delivery.RetryConfig(nil)
+	stubNow(t, testNow.Add(40*time.Millisecond))
+	_, _ = retryConfig.CheckRetry(context.TODO(), newResponse(testDestination, http.StatusInternalServerError), nil)
+	_, _ = retryConfig.CheckRetry(context.TODO(), newResponse(testDestination, http.StatusAccepted), nil)
+	ReportDelivery(context.TODO(), testArgs, delivery)
+	metricstest.CheckDistributionData(t, DeliveryAttemptsN, testTags, 2, 1, 2)
+	metricstest.CheckDistributionData(t, AckLatencyN, testTags, 1, 40, 40)
+}
+
+// resetViews clears the data recorded by previous tests
+func resetViews() {
+	metricstest.Unregister(IngressLatencyN, AckLatencyN, DeliveryAttemptsN)
+	registerViews()
+}
@@ -56,6 +56,9 @@ var regexDescriptions = []struct {
 	{regexp.MustCompile(`^compression-ratio`), `Distribution of the compression ratio times 100 of record batches for all topics`},
 	{regexp.MustCompile(`^consumer-batch-size`), `Distribution of the number of messages in a batch`},
 
+	// Distributed Receiver Batching Producer Histograms
+	{regexp.MustCompile(`^receiver-produce-latency-in-ms`), `Distribution of the latency in ms from receiving an event to its acknowledgement by the receiver's async producer`},
+
 	// Sarama Meters
 	{regexp.MustCompile(`^incoming-byte-rate`), `Bytes/second read of all brokers`},
 	{regexp.MustCompile(`^request-rate`), `Requests/second sent to all brokers`},
@@ -144,6 +144,7 @@ func TestGetMetricInfo(t *testing.T) {
 		{name: "records-per-request", want: "Distribution of the number of records sent per request for all topics"},
 		{name: "compression-ratio", want: "Distribution of the compression ratio times 100 of record batches for all topics"},
 		{name: "consumer-batch-size", want: "Distribution of the number of messages in a batch"},
+		{name: "receiver-produce-latency-in-ms", want: "Distribution of the latency in ms from receiving an event to its acknowledgement by the receiver's async producer"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
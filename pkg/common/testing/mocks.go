@@ -7,6 +7,8 @@ var _ sarama.ClusterAdmin = (*MockClusterAdmin)(nil)
 type MockClusterAdmin struct {
 	MockCreateTopicFunc        func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
 	MockDeleteTopicFunc        func(topic string) error
+	MockDescribeTopicsFunc     func(topics []string) ([]*sarama.TopicMetadata, error)
+	MockDescribeConfigFunc     func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error)
 	MockListConsumerGroupsFunc func() (map[string]string, error)
 }
 
@@ -65,6 +67,9 @@ func (ca *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (ca *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
+	if ca.MockDescribeTopicsFunc != nil {
+		return ca.MockDescribeTopicsFunc(topics)
+	}
 	return nil, nil
 }
 
@@ -81,6 +86,9 @@ func (ca *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int
 }
 
 func (ca *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	if ca.MockDescribeConfigFunc != nil {
+		return ca.MockDescribeConfigFunc(resource)
+	}
 	return nil, nil
 }
 
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/tracing"
+	tracingconfig "knative.dev/pkg/tracing/config"
+)
+
+const (
+	// OtlpBackend is the config-tracing backend with which spans are exported to an OpenTelemetry collector
+	OtlpBackend tracingconfig.BackendType = "otlp"
+
+	backendKey      = "backend"
+	otlpEndpointKey = "otlp-endpoint"
+	otlpHeadersKey  = "otlp-headers"
+)
+
+// Config extends the Knative tracing Config with the settings of the "otlp" backend, which are read from the
+// "otlp-endpoint" (e.g. "http://otel-collector.observability:4318") and "otlp-headers" (e.g. "api-key=secret,x=y")
+// keys of the config-tracing ConfigMap.
+type Config struct {
+	tracingconfig.Config
+	OtlpEndpoint string            `json:",omitempty"`
+	OtlpHeaders  map[string]string `json:",omitempty"`
+}
+
+// NewConfigFromMap returns the Config of the specified config-tracing data, which the Knative parser would reject
+// if its backend is "otlp" (all the other keys and backends are parsed by the Knative parser as usual).
+func NewConfigFromMap(data map[string]string) (*Config, error) {
+	isOtlp := tracingconfig.BackendType(data[backendKey]) == OtlpBackend
+
+	knativeData := make(map[string]string, len(data))
+	for key, value := range data {
+		knativeData[key] = value
+	}
+	if isOtlp {
+		knativeData[backendKey] = string(tracingconfig.None)
+	}
+	knativeConfig, err := tracingconfig.NewTracingConfigFromMap(knativeData)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{Config: *knativeConfig}
+	if isOtlp {
+		config.Backend = OtlpBackend
+		config.OtlpEndpoint = data[otlpEndpointKey]
+		if config.OtlpEndpoint == "" {
+			return nil, fmt.Errorf("%q backend requires %q to be set", OtlpBackend, otlpEndpointKey)
+		}
+		if _, err = otlpTracesEndpoint(config.OtlpEndpoint); err != nil {
+			return nil, err
+		}
+		config.OtlpHeaders, err = parseOtlpHeaders(data[otlpHeadersKey])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// NewConfigFromConfigMap returns the Config of the specified config-tracing ConfigMap
+func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*Config, error) {
+	return NewConfigFromMap(configMap.Data)
+}
+
+// ConfigToJSON serializes the specified Config (e.g. into an environment variable of a receive adapter)
+func ConfigToJSON(config *Config) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	jsonBytes, err := json.Marshal(config)
+	return string(jsonBytes), err
+}
+
+// JSONToConfig deserializes a Config serialized by ConfigToJSON, defaulting to the Knative no-op Config
+func JSONToConfig(jsonConfig string) (*Config, error) {
+	if jsonConfig == "" {
+		return &Config{Config: *tracingconfig.NoopConfig()}, nil
+	}
+	config := &Config{}
+	if err := json.Unmarshal([]byte(jsonConfig), config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseOtlpHeaders parses the comma-separated "key=value" pairs of the "otlp-headers" key
+func parseOtlpHeaders(headers string) (map[string]string, error) {
+	if strings.TrimSpace(headers) == "" {
+		return nil, nil
+	}
+	parsedHeaders := make(map[string]string)
+	for _, header := range strings.Split(headers, ",") {
+		key, value, ok := strings.Cut(header, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %q entry %q: expected key=value", otlpHeadersKey, header)
+		}
+		parsedHeaders[key] = strings.TrimSpace(value)
+	}
+	return parsedHeaders, nil
+}
+
+// Tracer manages the OpenCensus tracing of a process for a config-tracing Config, relying on the Knative
+// OpenCensusTracer for the sampling and the "zipkin" backend and registering an OtlpExporter for the "otlp" backend.
+type Tracer struct {
+	logger      *zap.SugaredLogger
+	serviceName string
+	oct         *tracing.OpenCensusTracer
+	lock        sync.Mutex
+	config      *Config
+	exporter    *OtlpExporter
+	exporterCfg *Config
+}
+
+// Verify The Tracer Implements The Knative Tracer Interface
+var _ tracing.Tracer = &Tracer{}
+
+// Test Stub For Creating The OtlpExporter
+var newOtlpExporterFn = NewOtlpExporter
+
+// NewTracer returns a Tracer for the specified service, which exports no spans until a Config is applied to it.
+func NewTracer(logger *zap.SugaredLogger, serviceName string) *Tracer {
+	tracer := &Tracer{
+		logger:      logger,
+		serviceName: serviceName,
+	}
+	tracer.oct = tracing.NewOpenCensusTracer(tracing.WithExporter(serviceName, logger), tracer.withOtlpExporter)
+	return tracer
+}
+
+// ApplyConfig applies the specified Config to the OpenCensus tracing of the process.
+func (t *Tracer) ApplyConfig(config *Config) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.config = config
+	return t.oct.ApplyConfig(&config.Config)
+}
+
+// Shutdown flushes and stops the exporter of the Tracer (implements tracing.Tracer)
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.oct.Shutdown(ctx)
+}
+
+// withOtlpExporter is the OpenCensusTracer ConfigOption which (re)creates the OtlpExporter of the "otlp" backend,
+// called with the lock held by ApplyConfig (or Shutdown, with a nil Config).
+func (t *Tracer) withOtlpExporter(knativeConfig *tracingconfig.Config) error {
+	if knativeConfig == nil || knativeConfig.Backend != OtlpBackend || t.config == nil {
+		t.closeExporter()
+		return nil
+	}
+	if t.exporter != nil && t.exporterCfg.OtlpEndpoint == t.config.OtlpEndpoint && reflect.DeepEqual(t.exporterCfg.OtlpHeaders, t.config.OtlpHeaders) {
+		return nil
+	}
+
+	exporter, err := newOtlpExporterFn(t.logger.Desugar(), t.serviceName, t.config.OtlpEndpoint, t.config.OtlpHeaders, DefaultOtlpFlushInterval)
+	if err != nil {
+		t.logger.Errorw("Error creating the OTLP exporter", zap.Error(err))
+		return err
+	}
+	trace.RegisterExporter(exporter)
+	t.closeExporter()
+	t.exporter = exporter
+	t.exporterCfg = t.config
+	return nil
+}
+
+// closeExporter unregisters the OtlpExporter of the Tracer (if any), sending the spans it still has buffered
+func (t *Tracer) closeExporter() {
+	if t.exporter == nil {
+		return
+	}
+	trace.UnregisterExporter(t.exporter)
+	if err := t.exporter.Close(); err != nil {
+		t.logger.Warnw("Error flushing the OTLP exporter", zap.Error(err))
+	}
+	t.exporter = nil
+	t.exporterCfg = nil
+}
+
+// SetupPublishingWithDynamicConfig is the equivalent of the Knative function of the same name which also supports
+// the "otlp" backend, watching the config-tracing ConfigMap and applying its Config to the returned Tracer.
+func SetupPublishingWithDynamicConfig(logger *zap.SugaredLogger, configMapWatcher configmap.Watcher, serviceName string) (*Tracer, error) {
+	tracer := NewTracer(logger, serviceName)
+
+	tracerUpdater := func(name string, value interface{}) {
+		if name == tracingconfig.ConfigName {
+			config := value.(*Config)
+			logger.Debugw("Updating tracing config", zap.Any("cfg", config))
+			if err := tracer.ApplyConfig(config); err != nil {
+				logger.Errorw("Unable to apply open census tracer config", zap.Error(err))
+			}
+		}
+	}
+
+	configStore := configmap.NewUntypedStore(
+		"config-tracing-store",
+		logger,
+		configmap.Constructors{
+			tracingconfig.ConfigName: NewConfigFromConfigMap,
+		},
+		tracerUpdater)
+	configStore.WatchConfigs(configMapWatcher)
+	return tracer, nil
+}
+
+// SetupStaticOtlpPublishing registers an OtlpExporter, and applies the sampling, of the specified Config if its
+// backend is "otlp".  It is meant for processes whose OpenCensusTracer is owned by another framework (e.g. the
+// eventing adapter framework of the KafkaSource receive adapter) and returns nil for the other backends.
+func SetupStaticOtlpPublishing(logger *zap.SugaredLogger, serviceName string, config *Config) (*OtlpExporter, error) {
+	if config == nil || config.Backend != OtlpBackend {
+		return nil, nil
+	}
+	exporter, err := newOtlpExporterFn(logger.Desugar(), serviceName, config.OtlpEndpoint, config.OtlpHeaders, DefaultOtlpFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+	trace.RegisterExporter(exporter)
+
+	sampler := trace.ProbabilitySampler(config.SampleRate)
+	if config.Debug {
+		sampler = trace.AlwaysSample()
+	}
+	trace.ApplyConfig(trace.Config{DefaultSampler: sampler})
+	return exporter, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+	logtesting "knative.dev/pkg/logging/testing"
+	tracingconfig "knative.dev/pkg/tracing/config"
+)
+
+// Test The Parsing Of The config-tracing Data
+func TestNewConfigFromMap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     map[string]string
+		expected *Config
+		err      bool
+	}{
+		{
+			name:     "Empty",
+			data:     map[string]string{},
+			expected: &Config{Config: *tracingconfig.NoopConfig()},
+		},
+		{
+			name: "Zipkin",
+			data: map[string]string{"backend": "zipkin", "zipkin-endpoint": "http://zipkin:9411/api/v2/spans", "sample-rate": "0.5"},
+			expected: &Config{Config: tracingconfig.Config{
+				Backend:        tracingconfig.Zipkin,
+				ZipkinEndpoint: "http://zipkin:9411/api/v2/spans",
+				SampleRate:     0.5,
+			}},
+		},
+		{
+			name: "OTLP",
+			data: map[string]string{"backend": "otlp", "otlp-endpoint": "http://collector:4318", "otlp-headers": "api-key=secret, x-tenant = test", "debug": "true"},
+			expected: &Config{
+				Config:       tracingconfig.Config{Backend: OtlpBackend, Debug: true, SampleRate: 0.1},
+				OtlpEndpoint: "http://collector:4318",
+				OtlpHeaders:  map[string]string{"api-key": "secret", "x-tenant": "test"},
+			},
+		},
+		{
+			name: "OTLP Without Endpoint",
+			data: map[string]string{"backend": "otlp"},
+			err:  true,
+		},
+		{
+			name: "OTLP With Invalid Endpoint",
+			data: map[string]string{"backend": "otlp", "otlp-endpoint": "collector:4318"},
+			err:  true,
+		},
+		{
+			name: "OTLP With Invalid Headers",
+			data: map[string]string{"backend": "otlp", "otlp-endpoint": "http://collector:4318", "otlp-headers": "api-key"},
+			err:  true,
+		},
+		{
+			name: "Invalid Sample Rate",
+			data: map[string]string{"backend": "otlp", "otlp-endpoint": "http://collector:4318", "sample-rate": "2"},
+			err:  true,
+		},
+		{
+			name: "Unknown Backend",
+			data: map[string]string{"backend": "jaeger"},
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config, err := NewConfigFromMap(testCase.data)
+			assert.Equal(t, testCase.err, err != nil)
+			assert.Equal(t, testCase.expected, config)
+		})
+	}
+}
+
+// Test The Serialization Of The Config For The Receive Adapters
+func TestConfigJSON(t *testing.T) {
+	config := &Config{
+		Config:       tracingconfig.Config{Backend: OtlpBackend, SampleRate: 0.2},
+		OtlpEndpoint: "http://collector:4318",
+		OtlpHeaders:  map[string]string{"api-key": "secret"},
+	}
+	jsonConfig, err := ConfigToJSON(config)
+	require.Nil(t, err)
+	deserializedConfig, err := JSONToConfig(jsonConfig)
+	require.Nil(t, err)
+	assert.Equal(t, config, deserializedConfig)
+
+	jsonConfig, err = ConfigToJSON(nil)
+	assert.Nil(t, err)
+	deserializedConfig, err = JSONToConfig(jsonConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, &Config{Config: *tracingconfig.NoopConfig()}, deserializedConfig)
+
+	_, err = JSONToConfig("{")
+	assert.NotNil(t, err)
+}
+
+// Test The Tracer Creates, Keeps And Closes The OTLP Exporter As The Config-Tracing ConfigMap Changes
+func TestSetupPublishingWithDynamicConfig(t *testing.T) {
+	collector := newTestCollector(t, http.StatusOK)
+
+	var exporters []*OtlpExporter
+	newOtlpExporterFn = func(logger *zap.Logger, serviceName string, endpoint string, headers map[string]string, flushInterval time.Duration) (*OtlpExporter, error) {
+		exporter, err := NewOtlpExporter(logger, serviceName, endpoint, headers, flushInterval)
+		exporters = append(exporters, exporter)
+		return exporter, err
+	}
+	defer func() { newOtlpExporterFn = NewOtlpExporter }()
+
+	otlpConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: tracingconfig.ConfigName},
+		Data:       map[string]string{"backend": "otlp", "otlp-endpoint": collector.server.URL, "debug": "true"},
+	}
+	watcher := &configmap.ManualWatcher{Namespace: "test-namespace"}
+	tracer, err := SetupPublishingWithDynamicConfig(logtesting.TestLogger(t), watcher, "test-service")
+	require.Nil(t, err)
+	watcher.OnChange(otlpConfigMap)
+	require.Len(t, exporters, 1)
+
+	// Spans Are Sampled And Exported To The Collector
+	_, span := trace.StartSpan(context.Background(), "test-span")
+	span.End()
+	require.Nil(t, exporters[0].Flush())
+	require.Len(t, collector.Requests(), 1)
+
+	// An Unchanged Endpoint Keeps The Exporter
+	otlpConfigMap.Data["sample-rate"] = "0.5"
+	watcher.OnChange(otlpConfigMap)
+	assert.Len(t, exporters, 1)
+
+	// A Changed Endpoint Replaces The Exporter
+	otlpConfigMap.Data["otlp-headers"] = "api-key=secret"
+	watcher.OnChange(otlpConfigMap)
+	require.Len(t, exporters, 2)
+	assert.Equal(t, exporters[1], tracer.exporter)
+
+	// Another Backend Closes The Exporter
+	watcher.OnChange(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: tracingconfig.ConfigName},
+		Data:       map[string]string{"backend": "none"},
+	})
+	assert.Nil(t, tracer.exporter)
+
+	watcher.OnChange(otlpConfigMap)
+	require.Len(t, exporters, 3)
+	require.Nil(t, tracer.Shutdown(context.Background()))
+	assert.Nil(t, tracer.exporter)
+}
+
+// Test The Static Setup Of The OTLP Exporter (Receive Adapters)
+func TestSetupStaticOtlpPublishing(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+
+	exporter, err := SetupStaticOtlpPublishing(logger, "test-service", nil)
+	assert.Nil(t, exporter)
+	assert.Nil(t, err)
+
+	exporter, err = SetupStaticOtlpPublishing(logger, "test-service", &Config{Config: *tracingconfig.NoopConfig()})
+	assert.Nil(t, exporter)
+	assert.Nil(t, err)
+
+	_, err = SetupStaticOtlpPublishing(logger, "test-service", &Config{Config: tracingconfig.Config{Backend: OtlpBackend}, OtlpEndpoint: "invalid"})
+	assert.NotNil(t, err)
+
+	collector := newTestCollector(t, http.StatusOK)
+	exporter, err = SetupStaticOtlpPublishing(logger, "test-service", &Config{Config: tracingconfig.Config{Backend: OtlpBackend, Debug: true}, OtlpEndpoint: collector.server.URL})
+	require.Nil(t, err)
+	require.NotNil(t, exporter)
+	defer trace.UnregisterExporter(exporter)
+
+	_, span := trace.StartSpan(context.Background(), "test-span")
+	span.End()
+	require.Nil(t, exporter.Close())
+	assert.Len(t, collector.Requests(), 1)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+)
+
+// Attributes Of The OpenTelemetry Messaging Semantic Conventions With Which The Kafka Spans Are Annotated
+const (
+	SpanKindAttribute                 = "span.kind"
+	MessagingSystemAttribute          = "messaging.system"
+	MessagingOperationAttribute       = "messaging.operation"
+	MessagingDestinationAttribute     = "messaging.destination.name"
+	MessagingPartitionAttribute       = "messaging.kafka.destination.partition"
+	MessagingOffsetAttribute          = "messaging.kafka.message.offset"
+	MessagingKeyAttribute             = "messaging.kafka.message.key"
+	MessagingConsumerGroupAttribute   = "messaging.kafka.consumer.group"
+	MessagingMessageBodySizeAttribute = "messaging.message.body.size"
+
+	SpanKindProducer = "producer"
+	SpanKindConsumer = "consumer"
+
+	messagingSystemKafka      = "kafka"
+	messagingOperationSend    = "publish"
+	messagingOperationProcess = "process"
+)
+
+// StartProducerSpan starts a producer span, as a child of the span in the specified context (if any), for the
+// production of the specified ProducerMessage and adds its "traceparent" and "tracestate" headers to the message
+// so that the spans of the consumers of the message are its children.  The partition, offset and size of the
+// message are only known once it has been sent and are added by RecordProducerResult.
+func StartProducerSpan(ctx context.Context, message *sarama.ProducerMessage) (context.Context, *trace.Span) {
+	ctx, span := trace.StartSpan(ctx, message.Topic+" "+messagingOperationSend, trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecordingEvents() {
+		span.AddAttributes(
+			trace.StringAttribute(SpanKindAttribute, SpanKindProducer),
+			trace.StringAttribute(MessagingSystemAttribute, messagingSystemKafka),
+			trace.StringAttribute(MessagingOperationAttribute, messagingOperationSend),
+			trace.StringAttribute(MessagingDestinationAttribute, message.Topic),
+		)
+	}
+	message.Headers = append(message.Headers, SerializeTrace(span.SpanContext())...)
+	return ctx, span
+}
+
+// RecordProducerResult annotates the producer span of the specified ProducerMessage with the partition and
+// offset to which it was sent, and the size and key it was sent with, or with the error which prevented it.
+func RecordProducerResult(span *trace.Span, message *sarama.ProducerMessage, partition int32, offset int64, err error) {
+	if !span.IsRecordingEvents() {
+		return
+	}
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+		return
+	}
+	span.AddAttributes(
+		trace.Int64Attribute(MessagingPartitionAttribute, int64(partition)),
+		trace.Int64Attribute(MessagingOffsetAttribute, offset),
+	)
+	if message.Value != nil {
+		span.AddAttributes(trace.Int64Attribute(MessagingMessageBodySizeAttribute, int64(message.Value.Length())))
+	}
+	if message.Key != nil {
+		if key, keyErr := message.Key.Encode(); keyErr == nil {
+			addKeyAttribute(span, key)
+		}
+	}
+}
+
+// StartConsumerSpan starts a consumer span for the processing of the specified ConsumerMessage by the specified
+// consumer group.  The span is a child of the producer span whose context the message carries in its "traceparent"
+// and "tracestate" headers, or the root of a new trace if the message carries none.
+func StartConsumerSpan(logger *zap.SugaredLogger, ctx context.Context, message *sarama.ConsumerMessage, consumerGroup string, spanName string) (context.Context, *trace.Span) {
+	headers := make(map[string][]byte, len(message.Headers))
+	for _, header := range message.Headers {
+		if header != nil {
+			headers[string(header.Key)] = header.Value
+		}
+	}
+
+	var span *trace.Span
+	if sc, ok := ParseSpanContext(headers); ok {
+		ctx, span = trace.StartSpanWithRemoteParent(ctx, spanName, sc, trace.WithSpanKind(trace.SpanKindServer))
+	} else {
+		logger.Warn("Cannot parse the spancontext, creating a new span")
+		ctx, span = trace.StartSpan(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer))
+	}
+
+	if span.IsRecordingEvents() {
+		span.AddAttributes(
+			trace.StringAttribute(SpanKindAttribute, SpanKindConsumer),
+			trace.StringAttribute(MessagingSystemAttribute, messagingSystemKafka),
+			trace.StringAttribute(MessagingOperationAttribute, messagingOperationProcess),
+			trace.StringAttribute(MessagingDestinationAttribute, message.Topic),
+			trace.Int64Attribute(MessagingPartitionAttribute, int64(message.Partition)),
+			trace.Int64Attribute(MessagingOffsetAttribute, message.Offset),
+			trace.Int64Attribute(MessagingMessageBodySizeAttribute, int64(len(message.Value))),
+		)
+		if consumerGroup != "" {
+			span.AddAttributes(trace.StringAttribute(MessagingConsumerGroupAttribute, consumerGroup))
+		}
+		addKeyAttribute(span, message.Key)
+	}
+	return ctx, span
+}
+
+// addKeyAttribute adds the key of a message to its span, unless it is absent or binary
+func addKeyAttribute(span *trace.Span, key []byte) {
+	if len(key) > 0 && utf8.Valid(key) {
+		span.AddAttributes(trace.StringAttribute(MessagingKeyAttribute, string(key)))
+	}
+}
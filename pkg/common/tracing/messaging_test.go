@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Producer And Consumer Spans Of A Message Form A Single Trace Annotated With The Messaging Attributes
+func TestProducerAndConsumerSpans(t *testing.T) {
+	exporter := sampleAllSpans(t)
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic: "test-topic",
+		Key:   sarama.StringEncoder("test-key"),
+		Value: sarama.ByteEncoder("test-value"),
+	}
+	_, producerSpan := StartProducerSpan(context.Background(), producerMessage)
+	RecordProducerResult(producerSpan, producerMessage, 3, 42, nil)
+	producerSpan.End()
+
+	// The Span Context Is Propagated By The Message's Headers
+	consumerMessage := &sarama.ConsumerMessage{
+		Topic:     producerMessage.Topic,
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("test-key"),
+		Value:     []byte("test-value"),
+	}
+	for i := range producerMessage.Headers {
+		consumerMessage.Headers = append(consumerMessage.Headers, &producerMessage.Headers[i])
+	}
+	_, consumerSpan := StartConsumerSpan(logtesting.TestLogger(t), context.Background(), consumerMessage, "test-group", "kafkachannel-test-topic")
+	consumerSpan.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+	producerData, consumerData := spans[0], spans[1]
+
+	assert.Equal(t, "test-topic publish", producerData.Name)
+	assert.Equal(t, trace.SpanKindClient, producerData.SpanKind)
+	assert.Equal(t, map[string]interface{}{
+		SpanKindAttribute:                 SpanKindProducer,
+		MessagingSystemAttribute:          "kafka",
+		MessagingOperationAttribute:       "publish",
+		MessagingDestinationAttribute:     "test-topic",
+		MessagingPartitionAttribute:       int64(3),
+		MessagingOffsetAttribute:          int64(42),
+		MessagingMessageBodySizeAttribute: int64(10),
+		MessagingKeyAttribute:             "test-key",
+	}, producerData.Attributes)
+
+	assert.Equal(t, "kafkachannel-test-topic", consumerData.Name)
+	assert.Equal(t, trace.SpanKindServer, consumerData.SpanKind)
+	assert.Equal(t, producerData.TraceID, consumerData.TraceID)
+	assert.Equal(t, producerData.SpanID, consumerData.ParentSpanID)
+	assert.True(t, consumerData.HasRemoteParent)
+	assert.Equal(t, map[string]interface{}{
+		SpanKindAttribute:                 SpanKindConsumer,
+		MessagingSystemAttribute:          "kafka",
+		MessagingOperationAttribute:       "process",
+		MessagingDestinationAttribute:     "test-topic",
+		MessagingPartitionAttribute:       int64(3),
+		MessagingOffsetAttribute:          int64(42),
+		MessagingMessageBodySizeAttribute: int64(10),
+		MessagingKeyAttribute:             "test-key",
+		MessagingConsumerGroupAttribute:   "test-group",
+	}, consumerData.Attributes)
+}
+
+// Test The Producer Span Of A Message Which Could Not Be Sent
+func TestRecordProducerResultError(t *testing.T) {
+	exporter := sampleAllSpans(t)
+
+	producerMessage := &sarama.ProducerMessage{Topic: "test-topic", Value: sarama.ByteEncoder("test-value")}
+	_, span := StartProducerSpan(context.Background(), producerMessage)
+	RecordProducerResult(span, producerMessage, -1, -1, errors.New("test-error"))
+	span.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, int32(trace.StatusCodeUnknown), spans[0].Code)
+	assert.Equal(t, "test-error", spans[0].Message)
+	assert.NotContains(t, spans[0].Attributes, MessagingOffsetAttribute)
+}
+
+// Test The Consumer Span Of A Message Without Span Context Or Textual Key Starts A New Trace
+func TestStartConsumerSpanWithoutHeaders(t *testing.T) {
+	exporter := sampleAllSpans(t)
+
+	consumerMessage := &sarama.ConsumerMessage{Topic: "test-topic", Key: []byte{0xff, 0xfe}, Value: []byte("test-value")}
+	_, span := StartConsumerSpan(logtesting.TestLogger(t), context.Background(), consumerMessage, "", "kafka-source-test-topic")
+	span.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanID{}, spans[0].ParentSpanID)
+	assert.NotContains(t, spans[0].Attributes, MessagingKeyAttribute)
+	assert.NotContains(t, spans[0].Attributes, MessagingConsumerGroupAttribute)
+}
+
+// recordingExporter is a trace.Exporter recording the spans it is given
+type recordingExporter struct {
+	lock  sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (e *recordingExporter) ExportSpan(spanData *trace.SpanData) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spanData)
+}
+
+func (e *recordingExporter) Spans() []*trace.SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.spans
+}
+
+// sampleAllSpans registers a recordingExporter and samples all the spans for the duration of the test
+func sampleAllSpans(t *testing.T) *recordingExporter {
+	exporter := &recordingExporter{}
+	trace.RegisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	t.Cleanup(func() {
+		trace.UnregisterExporter(exporter)
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+	})
+	return exporter
+}
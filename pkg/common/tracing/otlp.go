@@ -0,0 +1,267 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	octrace "go.opencensus.io/trace"
+	"go.opencensus.io/trace/tracestate"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// OtlpTracesPath is the path of the OTLP/HTTP traces endpoint, appended to endpoints which specify none
+	OtlpTracesPath = "/v1/traces"
+
+	// DefaultOtlpBatchSize is the number of spans after which the OtlpExporter sends them without waiting
+	DefaultOtlpBatchSize = 512
+
+	// DefaultOtlpFlushInterval is the interval at which the OtlpExporter sends the spans it has buffered
+	DefaultOtlpFlushInterval = 5 * time.Second
+
+	// maxOtlpQueueSize is the number of buffered spans beyond which spans are dropped (e.g. collector down)
+	maxOtlpQueueSize = 4 * DefaultOtlpBatchSize
+
+	otlpScopeName      = "knative.dev/eventing-kafka"
+	otlpRequestTimeout = 10 * time.Second
+)
+
+// OtlpExporter is an OpenCensus trace.Exporter which sends the spans it is given to an OpenTelemetry collector.  The
+// encoding (OTLP/HTTP protobuf), batching, queueing and retries are those of the upstream OpenTelemetry exporter and
+// BatchSpanProcessor; the OpenCensus spans are converted into OpenTelemetry read-only spans as the OpenCensus bridge
+// does.  Producer and consumer spans, which OpenCensus has no kind for, are recognized by their "span.kind" attribute
+// (see StartProducerSpan and StartConsumerSpan).
+type OtlpExporter struct {
+	resource  *resource.Resource
+	processor sdktrace.SpanProcessor
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Verify The OtlpExporter Implements The OpenCensus Exporter Interface
+var _ octrace.Exporter = &OtlpExporter{}
+
+// NewOtlpExporter returns an OtlpExporter, sending the spans of the specified service to the specified OTLP/HTTP
+// endpoint with the specified (e.g. authorization) headers, whose background flushing is stopped by Close.
+func NewOtlpExporter(logger *zap.Logger, serviceName string, endpoint string, headers map[string]string, flushInterval time.Duration) (*OtlpExporter, error) {
+	endpoint, err := otlpTracesEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	endpointUrl, _ := url.Parse(endpoint)
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpointUrl.Host),
+		otlptracehttp.WithURLPath(endpointUrl.Path),
+		otlptracehttp.WithHeaders(headers),
+		otlptracehttp.WithTimeout(otlpRequestTimeout),
+	}
+	if endpointUrl.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the otlp exporter: %w", err)
+	}
+	processor := sdktrace.NewBatchSpanProcessor(&loggingSpanExporter{SpanExporter: exporter, logger: logger.With(zap.String("Endpoint", endpoint))},
+		sdktrace.WithBatchTimeout(flushInterval),
+		sdktrace.WithMaxExportBatchSize(DefaultOtlpBatchSize),
+		sdktrace.WithMaxQueueSize(maxOtlpQueueSize),
+	)
+	return &OtlpExporter{
+		resource:  resource.NewSchemaless(attribute.String("service.name", serviceName)),
+		processor: processor,
+	}, nil
+}
+
+// ExportSpan queues the specified span for the next batch of the OtlpExporter (implements trace.Exporter)
+func (e *OtlpExporter) ExportSpan(spanData *octrace.SpanData) {
+	e.processor.OnEnd(toReadOnlySpan(e.resource, spanData))
+}
+
+// Flush sends the queued spans to the collector (export failures are logged rather than returned).
+func (e *OtlpExporter) Flush() error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpRequestTimeout)
+	defer cancel()
+	return e.processor.ForceFlush(ctx)
+}
+
+// Close sends the spans the OtlpExporter still has queued and stops it (nil-safe).
+func (e *OtlpExporter) Close() error {
+	if e == nil {
+		return nil
+	}
+	e.closeOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), otlpRequestTimeout)
+		defer cancel()
+		e.closeErr = e.processor.Shutdown(ctx)
+	})
+	return e.closeErr
+}
+
+// loggingSpanExporter logs the failures of the batches exported in the background by the BatchSpanProcessor
+type loggingSpanExporter struct {
+	sdktrace.SpanExporter
+	logger *zap.Logger
+}
+
+// ExportSpans exports the specified spans, logging any failure (implements sdktrace.SpanExporter)
+func (e *loggingSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	if err != nil {
+		e.logger.Warn("Failed To Export Spans To OTLP Collector", zap.Int("Spans", len(spans)), zap.Error(err))
+	}
+	return err
+}
+
+// otlpTracesEndpoint validates the specified endpoint and appends the OTLP traces path if it has no path
+func otlpTracesEndpoint(endpoint string) (string, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid otlp endpoint %q: %w", endpoint, err)
+	}
+	if endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https" || endpointUrl.Host == "" {
+		return "", fmt.Errorf("invalid otlp endpoint %q: expected an http(s) url", endpoint)
+	}
+	if endpointUrl.Path == "" || endpointUrl.Path == "/" {
+		endpointUrl.Path = OtlpTracesPath
+	}
+	return endpointUrl.String(), nil
+}
+
+// toReadOnlySpan converts the specified OpenCensus span into an OpenTelemetry span of the specified resource
+func toReadOnlySpan(resource *resource.Resource, spanData *octrace.SpanData) sdktrace.ReadOnlySpan {
+	kindAttribute := spanData.Attributes[SpanKindAttribute]
+	attributes := make(map[string]interface{}, len(spanData.Attributes))
+	for key, value := range spanData.Attributes {
+		if key != SpanKindAttribute {
+			attributes[key] = value
+		}
+	}
+
+	span := tracetest.SpanStub{
+		Name: spanData.Name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID(spanData.TraceID),
+			SpanID:     trace.SpanID(spanData.SpanID),
+			TraceFlags: trace.TraceFlags(spanData.TraceOptions),
+			TraceState: toTraceState(spanData.Tracestate),
+		}),
+		SpanKind:               toSpanKind(spanData.SpanKind, kindAttribute),
+		StartTime:              spanData.StartTime,
+		EndTime:                spanData.EndTime,
+		Attributes:             toAttributes(attributes),
+		DroppedAttributes:      spanData.DroppedAttributeCount,
+		DroppedEvents:          spanData.DroppedAnnotationCount + spanData.DroppedMessageEventCount,
+		DroppedLinks:           spanData.DroppedLinkCount,
+		ChildSpanCount:         spanData.ChildSpanCount,
+		Resource:               resource,
+		InstrumentationLibrary: instrumentation.Library{Name: otlpScopeName},
+	}
+	if spanData.ParentSpanID != (octrace.SpanID{}) {
+		span.Parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID(spanData.TraceID),
+			SpanID:  trace.SpanID(spanData.ParentSpanID),
+			Remote:  spanData.HasRemoteParent,
+		})
+	}
+	for _, annotation := range spanData.Annotations {
+		span.Events = append(span.Events, sdktrace.Event{
+			Name:       annotation.Message,
+			Attributes: toAttributes(annotation.Attributes),
+			Time:       annotation.Time,
+		})
+	}
+	for _, link := range spanData.Links {
+		span.Links = append(span.Links, sdktrace.Link{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID(link.TraceID), SpanID: trace.SpanID(link.SpanID)}),
+			Attributes:  toAttributes(link.Attributes),
+		})
+	}
+	if spanData.Code != octrace.StatusCodeOK {
+		span.Status = sdktrace.Status{Code: codes.Error, Description: spanData.Message}
+	}
+	return span.Snapshot()
+}
+
+// toSpanKind returns the OpenTelemetry kind of a span from its "span.kind" attribute, or else its OpenCensus kind
+func toSpanKind(spanKind int, kindAttribute interface{}) trace.SpanKind {
+	switch kindAttribute {
+	case SpanKindProducer:
+		return trace.SpanKindProducer
+	case SpanKindConsumer:
+		return trace.SpanKindConsumer
+	}
+	switch spanKind {
+	case octrace.SpanKindServer:
+		return trace.SpanKindServer
+	case octrace.SpanKindClient:
+		return trace.SpanKindClient
+	default:
+		return trace.SpanKindInternal
+	}
+}
+
+// toTraceState converts the specified OpenCensus tracestate, dropping it if OpenTelemetry deems it invalid
+func toTraceState(state *tracestate.Tracestate) trace.TraceState {
+	if state == nil {
+		return trace.TraceState{}
+	}
+	entries := make([]string, 0, len(state.Entries()))
+	for _, entry := range state.Entries() {
+		entries = append(entries, entry.Key+"="+entry.Value)
+	}
+	traceState, err := trace.ParseTraceState(strings.Join(entries, ","))
+	if err != nil {
+		return trace.TraceState{}
+	}
+	return traceState
+}
+
+// toAttributes converts the specified OpenCensus attributes into OpenTelemetry attributes
+func toAttributes(attributes map[string]interface{}) []attribute.KeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	keyValues := make([]attribute.KeyValue, 0, len(attributes))
+	for key, value := range attributes {
+		switch typedValue := value.(type) {
+		case bool:
+			keyValues = append(keyValues, attribute.Bool(key, typedValue))
+		case int64:
+			keyValues = append(keyValues, attribute.Int64(key, typedValue))
+		case float64:
+			keyValues = append(keyValues, attribute.Float64(key, typedValue))
+		default:
+			keyValues = append(keyValues, attribute.String(key, fmt.Sprint(typedValue)))
+		}
+	}
+	return keyValues
+}
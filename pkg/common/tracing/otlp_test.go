@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	octrace "go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/proto"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Producer And Consumer Spans Of A Message Are Exported To An OTLP Collector
+func TestOtlpExporter(t *testing.T) {
+	collector := newTestCollector(t, http.StatusOK)
+	sampleAllSpans(t)
+
+	exporter, err := NewOtlpExporter(zap.NewNop(), "test-service", collector.server.URL, map[string]string{"api-key": "secret"}, time.Hour)
+	require.Nil(t, err)
+	octrace.RegisterExporter(exporter)
+	defer octrace.UnregisterExporter(exporter)
+
+	producerMessage := &sarama.ProducerMessage{Topic: "test-topic", Value: sarama.ByteEncoder("test-value")}
+	_, producerSpan := StartProducerSpan(context.Background(), producerMessage)
+	RecordProducerResult(producerSpan, producerMessage, 1, 7, nil)
+	producerSpan.End()
+
+	consumerMessage := &sarama.ConsumerMessage{Topic: "test-topic", Partition: 1, Offset: 7, Value: []byte("test-value")}
+	for i := range producerMessage.Headers {
+		consumerMessage.Headers = append(consumerMessage.Headers, &producerMessage.Headers[i])
+	}
+	_, consumerSpan := StartConsumerSpan(logtesting.TestLogger(t), context.Background(), consumerMessage, "test-group", "kafkachannel-test-topic")
+	consumerSpan.End()
+
+	// Nothing Is Sent Until The Exporter Is Flushed (Or Closed)
+	assert.Empty(t, collector.Requests())
+	require.Nil(t, exporter.Close())
+
+	requests := collector.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, OtlpTracesPath, requests[0].path)
+	assert.Equal(t, "secret", requests[0].header.Get("api-key"))
+	assert.Equal(t, "application/x-protobuf", requests[0].header.Get("Content-Type"))
+
+	request := requests[0].body
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, map[string]string{"service.name": "test-service"}, attributeValues(request.ResourceSpans[0].Resource.Attributes))
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+	assert.Equal(t, otlpScopeName, request.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	producer, consumer := spans[0], spans[1]
+	assert.Equal(t, "test-topic publish", producer.Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_PRODUCER, producer.Kind)
+	assert.Empty(t, producer.ParentSpanId)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, producer.Status.Code)
+	assert.Equal(t, "kafkachannel-test-topic", consumer.Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CONSUMER, consumer.Kind)
+	assert.Equal(t, producer.TraceId, consumer.TraceId)
+	assert.Equal(t, producer.SpanId, consumer.ParentSpanId)
+	assert.Len(t, consumer.TraceId, 16)
+	assert.Len(t, consumer.SpanId, 8)
+	assert.NotZero(t, consumer.StartTimeUnixNano)
+
+	// The "span.kind" Attribute Is Conveyed By The OTLP Kind Rather Than As An Attribute
+	assert.Equal(t, map[string]string{
+		MessagingSystemAttribute:          "kafka",
+		MessagingOperationAttribute:       "process",
+		MessagingDestinationAttribute:     "test-topic",
+		MessagingPartitionAttribute:       "1",
+		MessagingOffsetAttribute:          "7",
+		MessagingMessageBodySizeAttribute: "10",
+		MessagingConsumerGroupAttribute:   "test-group",
+	}, attributeValues(consumer.Attributes))
+}
+
+// Test The Exporter Sends Full Batches Without Waiting For The Flush Interval
+func TestOtlpExporterBatch(t *testing.T) {
+	collector := newTestCollector(t, http.StatusOK)
+
+	exporter, err := NewOtlpExporter(zap.NewNop(), "test-service", collector.server.URL+"/custom/traces", nil, time.Hour)
+	require.Nil(t, err)
+	defer exporter.Close()
+
+	for i := 0; i < DefaultOtlpBatchSize; i++ {
+		exporter.ExportSpan(&octrace.SpanData{SpanContext: sampleSpanContext, Name: "span"})
+	}
+
+	require.Eventually(t, func() bool { return len(collector.Requests()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	request := collector.Requests()[0]
+	assert.Equal(t, "/custom/traces", request.path)
+	assert.Len(t, request.body.ResourceSpans[0].ScopeSpans[0].Spans, DefaultOtlpBatchSize)
+}
+
+// Test The Exporter Logs The Error With Which The Collector Rejected The Spans
+func TestOtlpExporterRejected(t *testing.T) {
+	collector := newTestCollector(t, http.StatusBadRequest)
+	core, logs := observer.New(zap.WarnLevel)
+
+	exporter, err := NewOtlpExporter(zap.New(core), "test-service", collector.server.URL, nil, time.Hour)
+	require.Nil(t, err)
+	exporter.ExportSpan(&octrace.SpanData{SpanContext: sampleSpanContext, Name: "span", Status: octrace.Status{Code: octrace.StatusCodeInternal, Message: "failed"}})
+	assert.Nil(t, exporter.Close())
+	assert.Len(t, collector.Requests(), 1)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Failed To Export Spans To OTLP Collector", logs.All()[0].Message)
+
+	// Closing Again Sends Nothing
+	assert.Nil(t, exporter.Close())
+	assert.Len(t, collector.Requests(), 1)
+}
+
+// Test The Conversion Of The OpenCensus Span Fields Which The Messaging Spans Do Not Use
+func TestToReadOnlySpan(t *testing.T) {
+	now := time.Unix(1, 500)
+	spanResource := resource.NewSchemaless(attribute.String("service.name", "test-service"))
+	span := toReadOnlySpan(spanResource, &octrace.SpanData{
+		SpanContext:     sampleSpanContext,
+		ParentSpanID:    octrace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		HasRemoteParent: true,
+		SpanKind:        octrace.SpanKindClient,
+		Name:            "test-span",
+		StartTime:       now,
+		EndTime:         now.Add(time.Second),
+		Attributes:      map[string]interface{}{"bool": true, "double": 1.5, "string": "value"},
+		Annotations:     []octrace.Annotation{{Time: now, Message: "test-event", Attributes: map[string]interface{}{"int": int64(1)}}},
+		Links:           []octrace.Link{{TraceID: traceID, SpanID: spanID}},
+		Status:          octrace.Status{Code: octrace.StatusCodeInternal, Message: "test-error"},
+	})
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.SpanContext().SpanID().String())
+	assert.True(t, span.SpanContext().IsSampled())
+	assert.Equal(t, "0102030405060708", span.Parent().SpanID().String())
+	assert.True(t, span.Parent().IsRemote())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, now, span.StartTime())
+	assert.Equal(t, now.Add(time.Second), span.EndTime())
+	assert.ElementsMatch(t, []attribute.KeyValue{attribute.Bool("bool", true), attribute.Float64("double", 1.5), attribute.String("string", "value")}, span.Attributes())
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "test-event", span.Events()[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int64("int", 1)}, span.Events()[0].Attributes)
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, span.SpanContext().TraceID(), span.Links()[0].SpanContext.TraceID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "test-error", span.Status().Description)
+	assert.Equal(t, spanResource, span.Resource())
+	assert.Equal(t, otlpScopeName, span.InstrumentationLibrary().Name)
+
+	assert.False(t, toReadOnlySpan(spanResource, &octrace.SpanData{}).Parent().IsValid())
+	assert.Equal(t, trace.SpanKindInternal, toReadOnlySpan(spanResource, &octrace.SpanData{}).SpanKind())
+	assert.Equal(t, trace.SpanKindServer, toReadOnlySpan(spanResource, &octrace.SpanData{SpanKind: octrace.SpanKindServer}).SpanKind())
+}
+
+// Test The Conversion Of The OpenCensus Tracestate
+func TestToTraceState(t *testing.T) {
+	assert.Equal(t, 0, toTraceState(nil).Len())
+	traceState := toTraceState(sampleSpanContext.Tracestate)
+	assert.Equal(t, "bar", traceState.Get("foo"))
+}
+
+// Test The Validation Of The OTLP Endpoint
+func TestOtlpTracesEndpoint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint string
+		expected string
+		err      bool
+	}{
+		{name: "Host Only", endpoint: "http://collector:4318", expected: "http://collector:4318/v1/traces"},
+		{name: "Trailing Slash", endpoint: "https://collector:4318/", expected: "https://collector:4318/v1/traces"},
+		{name: "Custom Path", endpoint: "http://collector:4318/otlp/v1/traces", expected: "http://collector:4318/otlp/v1/traces"},
+		{name: "No Scheme", endpoint: "collector:4318", err: true},
+		{name: "gRPC Scheme", endpoint: "grpc://collector:4317", err: true},
+		{name: "Invalid", endpoint: "http://%zz", err: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoint, err := otlpTracesEndpoint(testCase.endpoint)
+			assert.Equal(t, testCase.err, err != nil)
+			assert.Equal(t, testCase.expected, endpoint)
+		})
+	}
+}
+
+// testCollector is an OTLP/HTTP collector stand-in recording the requests it receives, decoded with the generated
+// OTLP protobuf types of the opentelemetry-proto schema
+type testCollector struct {
+	server   *httptest.Server
+	lock     sync.Mutex
+	requests []testCollectorRequest
+}
+
+type testCollectorRequest struct {
+	path   string
+	header http.Header
+	body   *coltracepb.ExportTraceServiceRequest
+}
+
+func newTestCollector(t *testing.T, statusCode int) *testCollector {
+	collector := &testCollector{}
+	collector.server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		bytes, err := io.ReadAll(request.Body)
+		assert.Nil(t, err)
+		body := &coltracepb.ExportTraceServiceRequest{}
+		assert.Nil(t, proto.Unmarshal(bytes, body))
+		collector.lock.Lock()
+		collector.requests = append(collector.requests, testCollectorRequest{path: request.URL.Path, header: request.Header, body: body})
+		collector.lock.Unlock()
+		writer.WriteHeader(statusCode)
+	}))
+	t.Cleanup(collector.server.Close)
+	return collector
+}
+
+func (c *testCollector) Requests() []testCollectorRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.requests
+}
+
+// attributeValues returns the string representation of the value of each of the specified attributes
+func attributeValues(attributes []*commonpb.KeyValue) map[string]string {
+	values := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		switch value := attribute.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			values[attribute.Key] = value.StringValue
+		case *commonpb.AnyValue_IntValue:
+			values[attribute.Key] = strconv.FormatInt(value.IntValue, 10)
+		}
+	}
+	return values
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// IngressComponent Is The Component Name Of The Shared Ingress (Used For Logging)
+	IngressComponent = "kafka-sink-ingress"
+
+	// IngressServiceName Is The Name Of The Shared Ingress Service (In The System Namespace) Receiving Events For All KafkaSinks
+	IngressServiceName = "kafka-sink-ingress"
+
+	// KafkaSinkServiceNameSuffix Is The Name Suffix Of The Per-KafkaSink ExternalName Services (Parsed From The Host Header By The Ingress)
+	KafkaSinkServiceNameSuffix = "kn-sink"
+
+	// AuthSecretVersionsAnnotation Is The KafkaSink Status Annotation Holding The ResourceVersions Of The Referenced
+	// Secrets, Which Change When Credentials Are Rotated So That The Ingress Recreates The KafkaSink's Producer
+	AuthSecretVersionsAnnotation = "kafka.eventing.knative.dev/auth-secret-versions"
+
+	// KafkaSinkLabel Identifies The Services Created For KafkaSinks
+	KafkaSinkLabel = "kafka.eventing.knative.dev/sink"
+
+	// IngressHttpPort Is The Port On Which The Ingress Receives Events
+	IngressHttpPort = 8080
+
+	// IngressHealthPort Is The Port On Which The Ingress Serves Liveness And Readiness Requests
+	IngressHealthPort = 8082
+)
+
+// AppendKafkaSinkServiceNameSuffix appends the KafkaSink Service name suffix to the specified string.
+func AppendKafkaSinkServiceNameSuffix(sinkName string) string {
+	return fmt.Sprintf("%s-%s", sinkName, KafkaSinkServiceNameSuffix)
+}
+
+// TrimKafkaSinkServiceNameSuffix removes the KafkaSink Service name suffix from the specified string.
+func TrimKafkaSinkServiceNameSuffix(serviceName string) string {
+	return strings.TrimSuffix(serviceName, "-"+KafkaSinkServiceNameSuffix)
+}
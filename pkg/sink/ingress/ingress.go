@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/produce"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/sink/constants"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// NewProducerFn Is A Wrapper For Stubbing The Producer Creation In Unit Tests
+var NewProducerFn = producer.NewProducer
+
+// Ingress produces the events received for KafkaSinks (via their ExternalName Services) to the Kafka Topics of the
+// KafkaSinks, with one Producer per KafkaSink (as each may reference a different Kafka cluster and credentials).
+type Ingress struct {
+	ctx           context.Context // base context of the producer creations, which outlive the requests triggering them
+	logger        *zap.Logger
+	kubeClient    kubernetes.Interface
+	sinkLister    listers.KafkaSinkLister
+	statsReporter metrics.StatsReporter
+
+	producersMutex sync.Mutex
+	producers      map[types.NamespacedName]*sinkProducer
+	creations      singleflight.Group // de-duplicates concurrent creations of the same KafkaSink's producer
+}
+
+// The sinkProducer struct holds the Producer of a KafkaSink along with the fingerprint of the KafkaSink it was created for
+type sinkProducer struct {
+	fingerprint string
+	producer    *producer.Producer
+}
+
+// NewIngress returns a new Ingress which looks up the KafkaSinks with the specified lister, creating their producers
+// with the specified (base) context.
+func NewIngress(ctx context.Context, logger *zap.Logger, kubeClient kubernetes.Interface, sinkLister listers.KafkaSinkLister, statsReporter metrics.StatsReporter) *Ingress {
+	return &Ingress{
+		ctx:           ctx,
+		logger:        logger,
+		kubeClient:    kubeClient,
+		sinkLister:    sinkLister,
+		statsReporter: statsReporter,
+		producers:     make(map[types.NamespacedName]*sinkProducer),
+	}
+}
+
+// HandleMessage is the Knative MessageReceiver function which produces the specified message to the Kafka Topic of the
+// KafkaSink whose Service is referenced (by its Host header), in the content mode of the KafkaSink.
+func (i *Ingress) HandleMessage(ctx context.Context, reference eventingchannel.ChannelReference, message binding.Message, transformers []binding.Transformer, httpHeader http.Header) error {
+
+	// Look Up The KafkaSink Of The Referenced Service (Unknown KafkaSinks Are Answered With A 404)
+	sinkName := constants.TrimKafkaSinkServiceNameSuffix(reference.Name)
+	sink, err := i.sinkLister.KafkaSinks(reference.Namespace).Get(sinkName)
+	if apierrors.IsNotFound(err) {
+		return &eventingchannel.UnknownChannelError{Channel: reference}
+	} else if err != nil {
+		return err
+	}
+	if !sink.Status.IsReady() {
+		return fmt.Errorf("kafkasink %s/%s is not ready", sink.Namespace, sink.Name)
+	}
+
+	// Get The KafkaSink's Producer (Creating It On The First Event Or After A Change Of The KafkaSink)
+	sinkProducer, err := i.getProducer(sink)
+	if err != nil {
+		i.logger.Error("Failed To Get Producer For KafkaSink", zap.String("Namespace", sink.Namespace), zap.String("Name", sink.Name), zap.Error(err))
+		return err
+	}
+
+	// Produce The Message In The KafkaSink's Content Mode
+	if sink.Spec.GetContentMode() == v1alpha1.ModeStructured {
+		ctx = binding.WithForceStructured(ctx)
+	} else {
+		ctx = binding.WithForceBinary(ctx)
+	}
+	reference.Name = sink.Name
+	return sinkProducer.ProduceKafkaMessage(ctx, reference, sink.Spec.Topic, produce.Options{}, message, httpHeader, transformers...)
+}
+
+// Get The Producer Of The Specified KafkaSink, Replacing Any Producer Created For A Previous Version Of The KafkaSink
+// Or Of Its Referenced Secrets
+func (i *Ingress) getProducer(sink *v1alpha1.KafkaSink) (*producer.Producer, error) {
+
+	key := types.NamespacedName{Namespace: sink.Namespace, Name: sink.Name}
+	fingerprint := strconv.FormatInt(sink.Generation, 10) + "/" + sink.Status.Annotations[constants.AuthSecretVersionsAnnotation]
+
+	i.producersMutex.Lock()
+	existing, ok := i.producers[key]
+	i.producersMutex.Unlock()
+	if ok && existing.fingerprint == fingerprint {
+		return existing.producer, nil
+	}
+
+	// Create The Producer Outside The Lock (So That Events Of Other KafkaSinks Are Not Blocked By Resolving Secrets
+	// And Connecting To Kafka), Sharing A Single In-Flight Creation Among Concurrent Events Of The Same KafkaSink (With
+	// The Ingress's Base Context, As The Request Which Starts The Creation May End Before The Others Sharing It)
+	result, err, _ := i.creations.Do(key.String()+"@"+fingerprint, func() (interface{}, error) {
+		return i.createProducer(i.ctx, key, fingerprint, sink)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*producer.Producer), nil
+}
+
+// Create The Producer Of The Specified KafkaSink & Replace (Closing Outside The Lock) Any Previous Producer
+func (i *Ingress) createProducer(ctx context.Context, key types.NamespacedName, fingerprint string, sink *v1alpha1.KafkaSink) (*producer.Producer, error) {
+
+	// Build The Sarama Config From The KafkaSink's Spec (Resolving The Referenced Secrets)
+	envConfig, err := client.NewEnvConfigFromAuthSpec(ctx, i.kubeClient, sink.Namespace, sink.Spec.KafkaAuthSpec)
+	if err != nil {
+		return nil, err
+	}
+	brokers, config, err := client.NewConfigWithEnv(ctx, &envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create The New Producer (Each With Its Own Unstarted Health Server, As Producers Flag Their Own Readiness)
+	logger := i.logger.With(zap.String("KafkaSink", key.String()))
+	newProducer, err := NewProducerFn(logger, config, brokers, i.statsReporter, channelhealth.NewChannelHealthServer("0"), nil, nil, commonconfig.EKReceiverProducerConfig{})
+	if err != nil {
+		return nil, err
+	}
+	newProducer.OmitIngressTime = true // The Records Of A KafkaSink Are Consumed Outside Of Eventing-Kafka
+
+	// Replace Any Previous Producer Of The KafkaSink
+	i.producersMutex.Lock()
+	existing, ok := i.producers[key]
+	i.producers[key] = &sinkProducer{fingerprint: fingerprint, producer: newProducer}
+	i.producersMutex.Unlock()
+	if ok {
+		logger.Info("KafkaSink Changed - Closing Previous Producer")
+		existing.producer.Close()
+	}
+	return newProducer, nil
+}
+
+// KafkaSinkDeleted is the informer delete handler which closes the Producer of a deleted KafkaSink.
+func (i *Ingress) KafkaSinkDeleted(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	sink, ok := obj.(*v1alpha1.KafkaSink)
+	if !ok {
+		return
+	}
+
+	key := types.NamespacedName{Namespace: sink.Namespace, Name: sink.Name}
+	i.producersMutex.Lock()
+	existing, ok := i.producers[key]
+	delete(i.producers, key)
+	i.producersMutex.Unlock()
+	if ok {
+		i.logger.Info("KafkaSink Deleted - Closing Producer", zap.String("KafkaSink", key.String()))
+		existing.producer.Close()
+	}
+}
+
+// Close closes the Producers of all KafkaSinks.
+func (i *Ingress) Close() {
+	i.producersMutex.Lock()
+	defer i.producersMutex.Unlock()
+
+	for key, existing := range i.producers {
+		existing.producer.Close()
+		delete(i.producers, key)
+	}
+}
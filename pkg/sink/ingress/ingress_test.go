@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	producertesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/testing"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	payloadtesting "knative.dev/eventing-kafka/pkg/common/kafka/payload/testing"
+	"knative.dev/eventing-kafka/pkg/common/latency"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/sink/constants"
+)
+
+const (
+	sinkNamespace = "sink-namespace"
+	sinkTopic     = "sink-topic"
+)
+
+// Test The Ingress HandleMessage() Functionality
+func TestHandleMessage(t *testing.T) {
+
+	tests := []struct {
+		name              string
+		sinkName          string
+		contentMode       string
+		expectErr         bool
+		expectUnknown     bool
+		expectContentType string
+	}{
+		{
+			name:        "Binary",
+			sinkName:    "binary-sink",
+			contentMode: v1alpha1.ModeBinary,
+		},
+		{
+			name:              "Structured",
+			sinkName:          "structured-sink",
+			contentMode:       v1alpha1.ModeStructured,
+			expectContentType: cloudevents.ApplicationCloudEventsJSON,
+		},
+		{
+			name:          "Unknown KafkaSink",
+			sinkName:      "unknown-sink",
+			expectErr:     true,
+			expectUnknown: true,
+		},
+		{
+			name:      "KafkaSink Not Ready",
+			sinkName:  "not-ready-sink",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Stub The Kafka SyncProducer & The Payload Limiter's ClusterAdmin
+			mockSyncProducer := producertesting.NewMockSyncProducer()
+			producertesting.StubNewSyncProducerFn(producertesting.NonValidatingNewSyncProducerFn(mockSyncProducer))
+			defer producertesting.RestoreNewSyncProducerFn()
+			payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, sinkTopic, 1000000, nil, nil))
+
+			// Create The Ingress With The Test KafkaSinks
+			ingress := newTestIngress(t,
+				newKafkaSink("binary-sink", v1alpha1.ModeBinary, true),
+				newKafkaSink("structured-sink", v1alpha1.ModeStructured, true),
+				newKafkaSink("not-ready-sink", v1alpha1.ModeBinary, false))
+			defer ingress.Close()
+
+			// Perform The Test
+			reference := eventingchannel.ChannelReference{Namespace: sinkNamespace, Name: constants.AppendKafkaSinkServiceNameSuffix(test.sinkName)}
+			err := ingress.HandleMessage(context.TODO(), reference, receivertesting.CreateBindingMessage(cloudevents.VersionV1), nil, nil)
+
+			// Verify The Results
+			assert.Equal(t, test.expectErr, err != nil, err)
+			var unknownChannelError *eventingchannel.UnknownChannelError
+			assert.Equal(t, test.expectUnknown, errors.As(err, &unknownChannelError))
+			if !test.expectErr {
+				producerMessage := mockSyncProducer.GetMessage()
+				assert.Equal(t, sinkTopic, producerMessage.Topic)
+				for _, header := range producerMessage.Headers {
+					assert.NotEqual(t, latency.IngressTimeHeaderKey, string(header.Key)) // Not Stamped On KafkaSink Records
+				}
+				if test.contentMode == v1alpha1.ModeStructured {
+					receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, "content-type", test.expectContentType)
+				} else {
+					receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, "ce_specversion", cloudevents.VersionV1)
+				}
+			}
+		})
+	}
+}
+
+// Test That The Ingress Reuses The Producer Of A KafkaSink Until It Changes Or Is Deleted
+func TestProducerLifecycle(t *testing.T) {
+
+	// Stub The Kafka SyncProducer (A New Mock Per Producer) & The Payload Limiter's ClusterAdmin
+	var mockSyncProducers []*producertesting.MockSyncProducer
+	producertesting.StubNewSyncProducerFn(func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		assert.Equal(t, []string{"kafka:9092"}, brokers)
+		mockSyncProducer := producertesting.NewMockSyncProducer()
+		mockSyncProducers = append(mockSyncProducers, mockSyncProducer)
+		return mockSyncProducer, nil
+	})
+	defer producertesting.RestoreNewSyncProducerFn()
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, sinkTopic, 1000000, nil, nil))
+
+	sink := newKafkaSink("sink", v1alpha1.ModeBinary, true)
+	ingress := newTestIngress(t)
+	defer ingress.Close()
+
+	// The Producer Is Created Once And Then Reused
+	firstProducer, err := ingress.getProducer(sink)
+	assert.Nil(t, err)
+	secondProducer, err := ingress.getProducer(sink)
+	assert.Nil(t, err)
+	assert.Same(t, firstProducer, secondProducer)
+	assert.Len(t, mockSyncProducers, 1)
+
+	// A New Generation Or Rotated Credentials Replace The Producer
+	sink.Generation++
+	_, err = ingress.getProducer(sink)
+	assert.Nil(t, err)
+	sink.Status.Annotations[constants.AuthSecretVersionsAnnotation] = "rotated"
+	_, err = ingress.getProducer(sink)
+	assert.Nil(t, err)
+	assert.Len(t, mockSyncProducers, 3)
+	assert.Len(t, ingress.producers, 1)
+	assert.True(t, mockSyncProducers[0].Closed())
+	assert.True(t, mockSyncProducers[1].Closed())
+	assert.False(t, mockSyncProducers[2].Closed())
+
+	// Deleting The KafkaSink (Including Via A Tombstone) Closes Its Producer
+	ingress.KafkaSinkDeleted(cache.DeletedFinalStateUnknown{Key: "sink-namespace/sink", Obj: sink})
+	assert.Len(t, ingress.producers, 0)
+	assert.True(t, mockSyncProducers[2].Closed())
+	ingress.KafkaSinkDeleted(sink)
+	assert.Len(t, ingress.producers, 0)
+}
+
+// Test Concurrent Events Share A Single Producer Creation Without Blocking The Producers Of Other KafkaSinks
+func TestProducerConcurrentCreation(t *testing.T) {
+
+	// Stub The Kafka SyncProducer To Block Creation Until Released
+	var createCount int32
+	blockCreation := int32(0)
+	started := make(chan struct{}, 5)
+	release := make(chan struct{})
+	producertesting.StubNewSyncProducerFn(func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		if atomic.LoadInt32(&blockCreation) == 1 {
+			atomic.AddInt32(&createCount, 1)
+			started <- struct{}{}
+			<-release
+		}
+		return producertesting.NewMockSyncProducer(), nil
+	})
+	defer producertesting.RestoreNewSyncProducerFn()
+	payloadtesting.StubNewClusterAdminFn(t, payloadtesting.NewMockClusterAdmin(t, sinkTopic, 1000000, nil, nil))
+
+	ingress := newTestIngress(t)
+	defer ingress.Close()
+	otherSink := newKafkaSink("other-sink", v1alpha1.ModeBinary, true)
+	otherProducer, err := ingress.getProducer(otherSink)
+	assert.Nil(t, err)
+
+	// Start Several Concurrent Events Of The Same KafkaSink & Wait For The Creation To Block
+	atomic.StoreInt32(&blockCreation, 1)
+	sink := newKafkaSink("sink", v1alpha1.ModeBinary, true)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			sinkProducer, err := ingress.getProducer(sink)
+			assert.Nil(t, err)
+			assert.NotNil(t, sinkProducer)
+		}()
+	}
+	<-started
+
+	// The Producers Of Other KafkaSinks Remain Available While The Creation Is In Flight
+	cachedProducer, err := ingress.getProducer(otherSink)
+	assert.Nil(t, err)
+	assert.Same(t, otherProducer, cachedProducer)
+
+	time.Sleep(50 * time.Millisecond) // Allow The Remaining Events To Join The In-Flight Creation
+	close(release)
+	waitGroup.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&createCount))
+	assert.Len(t, ingress.producers, 2)
+}
+
+// Create A New Ingress With A Lister Of The Specified KafkaSinks
+func newTestIngress(t *testing.T, sinks ...*v1alpha1.KafkaSink) *Ingress {
+	logger := logtesting.TestLogger(t).Desugar()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, sink := range sinks {
+		assert.Nil(t, indexer.Add(sink))
+	}
+	return NewIngress(context.TODO(), logger, fake.NewSimpleClientset(), listers.NewKafkaSinkLister(indexer), metrics.NewStatsReporter(logger))
+}
+
+// Create A New KafkaSink With The Specified Content Mode & Readiness
+func newKafkaSink(name string, contentMode string, ready bool) *v1alpha1.KafkaSink {
+	sink := &v1alpha1.KafkaSink{
+		ObjectMeta: metav1.ObjectMeta{Namespace: sinkNamespace, Name: name, Generation: 1},
+		Spec: v1alpha1.KafkaSinkSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"kafka:9092"}},
+			Topic:         sinkTopic,
+			ContentMode:   &contentMode,
+		},
+	}
+	sink.Status.InitializeConditions()
+	if ready {
+		sink.Status.MarkConfigTrue()
+		sink.Status.MarkTopicTrue()
+		sink.Status.SetAddress(apis.HTTP(constants.AppendKafkaSinkServiceNameSuffix(name) + ".sink-namespace.svc.cluster.local"))
+		sink.Status.Annotations = map[string]string{constants.AuthSecretVersionsAnnotation: "checksum"}
+	}
+	return sink
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasink
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	kafkasinkinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkasink"
+	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/kafkasink"
+)
+
+// NewController initializes the KafkaSink controller.
+func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+
+	kafkaSinkInformer := kafkasinkinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+
+	r := &Reconciler{
+		kubeClientSet:   kubeclient.Get(ctx),
+		serviceLister:   serviceInformer.Lister(),
+		secretLister:    secretInformer.Lister(),
+		systemNamespace: system.Namespace(),
+	}
+
+	impl := kafkasink.NewImpl(ctx, r)
+	r.tracker = impl.Tracker
+
+	kafkaSinkInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Reconcile The KafkaSink Of A Service Which Was Changed Or Deleted
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&v1alpha1.KafkaSink{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Reconcile The KafkaSinks Referencing A Secret Whenever It Changes (e.g. Rotated SASL Or TLS Credentials)
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			r.tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("Secret"),
+		),
+	))
+
+	return impl
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/network"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/kafkasink"
+	"knative.dev/eventing-kafka/pkg/common/externalname"
+	"knative.dev/eventing-kafka/pkg/sink/constants"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// NewClusterAdminFn Is A Wrapper For Stubbing The Sarama ClusterAdmin Creation In Unit Tests
+var NewClusterAdminFn = sarama.NewClusterAdmin
+
+// Reconciler reconciles KafkaSinks by verifying their Kafka configuration and Topic, and by exposing them through a
+// Service (an ExternalName reference to the shared ingress) whose address is set in the KafkaSink status.
+type Reconciler struct {
+	kubeClientSet   kubernetes.Interface
+	serviceLister   corev1listers.ServiceLister
+	secretLister    corev1listers.SecretLister
+	tracker         tracker.Interface
+	systemNamespace string
+}
+
+// Check that our Reconciler implements Interface
+var _ kafkasink.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements the KafkaSink Reconciler Interface.
+func (r *Reconciler) ReconcileKind(ctx context.Context, sink *v1alpha1.KafkaSink) pkgreconciler.Event {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	sink.Status.InitializeConditions()
+
+	// Track The Referenced Secrets So That Rotated Credentials Re-Verify The KafkaSink
+	for _, ref := range client.AuthSpecSecretKeyRefs(sink.Spec.KafkaAuthSpec) {
+		err := r.tracker.TrackReference(tracker.Reference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  sink.Namespace,
+			Name:       ref.Name,
+		}, sink)
+		if err != nil {
+			return fmt.Errorf("unable to track secret %s/%s: %w", sink.Namespace, ref.Name, err)
+		}
+	}
+
+	// Resolve The Referenced Secrets And Build The Sarama Config
+	envConfig, err := client.NewEnvConfigFromAuthSpec(ctx, r.kubeClientSet, sink.Namespace, sink.Spec.KafkaAuthSpec)
+	if err != nil {
+		logger.Error("Unable To Resolve Kafka Configuration", zap.Error(err))
+		sink.Status.MarkConfigFailed("InvalidConfiguration", err.Error())
+		return err
+	}
+	brokers, config, err := client.NewConfigWithEnv(ctx, &envConfig)
+	if err != nil {
+		logger.Error("Unable To Build Kafka Configuration", zap.Error(err))
+		sink.Status.MarkConfigFailed("InvalidConfiguration", err.Error())
+		return err
+	}
+	sink.Status.MarkConfigTrue()
+
+	// Record The ResourceVersions Of The Referenced Secrets (The Ingress Recreates The Producer When They Change)
+	secretVersions, err := r.secretVersions(sink)
+	if err != nil {
+		logger.Error("Unable To Get Referenced Secret Versions", zap.Error(err))
+		sink.Status.MarkConfigFailed("InvalidConfiguration", err.Error())
+		return err
+	}
+	if sink.Status.Annotations == nil {
+		sink.Status.Annotations = map[string]string{}
+	}
+	sink.Status.Annotations[constants.AuthSecretVersionsAnnotation] = secretVersions
+
+	// Verify The KafkaSink's Topic Exists (KafkaSinks Produce To Existing Topics Which Are Not Managed By The Controller)
+	err = r.verifyTopic(brokers, config, sink)
+	if err != nil {
+		logger.Error("Unable To Verify Kafka Topic", zap.String("Topic", sink.Spec.Topic), zap.Error(err))
+		return err
+	}
+	sink.Status.MarkTopicTrue()
+
+	// Reconcile The KafkaSink's Service And Expose Its Address
+	service, err := r.reconcileService(ctx, sink)
+	if err != nil {
+		logger.Error("Unable To Reconcile KafkaSink Service", zap.Error(err))
+		sink.Status.MarkAddressableFailed("ServiceFailed", "Failed To Reconcile KafkaSink Service: %v", err)
+		return err
+	}
+	sink.Status.SetAddress(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(service.Name, service.Namespace),
+	})
+
+	return nil
+}
+
+// Get The Sorted "name=resourceVersion" List Of The KafkaSink's Referenced Secrets, Which Changes Whenever Any Of
+// Them Is Updated Without Exposing Anything Derived From Their Values
+func (r *Reconciler) secretVersions(sink *v1alpha1.KafkaSink) (string, error) {
+	names := sets.NewString()
+	for _, ref := range client.AuthSpecSecretKeyRefs(sink.Spec.KafkaAuthSpec) {
+		names.Insert(ref.Name)
+	}
+	versions := make([]string, 0, names.Len())
+	for _, name := range names.List() {
+		secret, err := r.secretLister.Secrets(sink.Namespace).Get(name)
+		if err != nil {
+			return "", fmt.Errorf("unable to get secret %s/%s: %w", sink.Namespace, name, err)
+		}
+		versions = append(versions, name+"="+secret.ResourceVersion)
+	}
+	return strings.Join(versions, ","), nil
+}
+
+// Verify That The KafkaSink's Topic Exists In The Kafka Cluster (Updating The TopicReady Condition On Failure)
+func (r *Reconciler) verifyTopic(brokers []string, config *sarama.Config, sink *v1alpha1.KafkaSink) error {
+
+	clusterAdmin, err := NewClusterAdminFn(brokers, config)
+	if err != nil {
+		sink.Status.MarkTopicFailed("ClusterAdminFailed", "Failed To Create Kafka ClusterAdmin: %v", err)
+		return err
+	}
+	defer func() { _ = clusterAdmin.Close() }()
+
+	topicMetadata, err := clusterAdmin.DescribeTopics([]string{sink.Spec.Topic})
+	if err != nil {
+		sink.Status.MarkTopicFailed("TopicDescribeFailed", "Failed To Describe Kafka Topic %s: %v", sink.Spec.Topic, err)
+		return err
+	}
+	for _, metadata := range topicMetadata {
+		if metadata.Name == sink.Spec.Topic && metadata.Err == sarama.ErrNoError {
+			return nil
+		}
+	}
+	sink.Status.MarkTopicFailed("TopicNotFound", "Kafka Topic %s Not Found", sink.Spec.Topic)
+	return fmt.Errorf("kafka topic %s not found", sink.Spec.Topic)
+}
+
+// Reconcile The KafkaSink's ExternalName Service Referencing The Shared Ingress Service
+func (r *Reconciler) reconcileService(ctx context.Context, sink *v1alpha1.KafkaSink) (*corev1.Service, error) {
+	return externalname.ReconcileService(ctx, r.kubeClientSet, r.serviceLister, sink, r.newService(sink))
+}
+
+// Create The ExternalName Service Model For The Specified KafkaSink
+func (r *Reconciler) newService(sink *v1alpha1.KafkaSink) *corev1.Service {
+	return externalname.NewService(sink,
+		constants.AppendKafkaSinkServiceNameSuffix(sink.Name), // Must Match KafkaSink For HOST Parsing In Ingress!
+		map[string]string{constants.KafkaSinkLabel: sink.Name},
+		constants.IngressServiceName,
+		r.systemNamespace)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/tracker"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+	"knative.dev/eventing-kafka/pkg/sink/constants"
+)
+
+const (
+	sinkNamespace   = "sink-namespace"
+	sinkName        = "sink-name"
+	sinkTopic       = "sink-topic"
+	systemNamespace = "knative-eventing"
+)
+
+// Test The KafkaSink ReconcileKind() Functionality
+func TestReconcileKind(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		secret         *corev1.Secret
+		services       []*corev1.Service
+		topicMetadata  []*sarama.TopicMetadata
+		describeErr    error
+		expectErr      bool
+		expectReady    bool
+		expectedReason string
+	}{
+		{
+			name:          "Ready",
+			topicMetadata: []*sarama.TopicMetadata{{Name: sinkTopic, Err: sarama.ErrNoError}},
+			expectReady:   true,
+		},
+		{
+			name:          "Ready With Existing Service",
+			services:      []*corev1.Service{newReconciler(nil).newService(newKafkaSink(false))},
+			topicMetadata: []*sarama.TopicMetadata{{Name: sinkTopic, Err: sarama.ErrNoError}},
+			expectReady:   true,
+		},
+		{
+			name:           "Missing Secret",
+			secret:         &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: sinkNamespace, Name: "other-secret"}},
+			expectErr:      true,
+			expectedReason: "InvalidConfiguration",
+		},
+		{
+			name:           "Topic Not Found",
+			topicMetadata:  []*sarama.TopicMetadata{{Name: sinkTopic, Err: sarama.ErrUnknownTopicOrPartition}},
+			expectErr:      true,
+			expectedReason: "TopicNotFound",
+		},
+		{
+			name:           "Describe Topic Failed",
+			describeErr:    errors.New("test describe error"),
+			expectErr:      true,
+			expectedReason: "TopicDescribeFailed",
+		},
+		{
+			name: "Service Not Owned",
+			services: []*corev1.Service{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: sinkNamespace, Name: constants.AppendKafkaSinkServiceNameSuffix(sinkName)},
+			}},
+			topicMetadata:  []*sarama.TopicMetadata{{Name: sinkTopic, Err: sarama.ErrNoError}},
+			expectErr:      true,
+			expectedReason: "ServiceFailed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+			// Stub The Sarama ClusterAdmin
+			newClusterAdminFnRestore := NewClusterAdminFn
+			defer func() { NewClusterAdminFn = newClusterAdminFnRestore }()
+			NewClusterAdminFn = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+				assert.Equal(t, []string{"kafka:9092"}, brokers)
+				assert.True(t, config.Net.SASL.Enable)
+				assert.Equal(t, "user", config.Net.SASL.User)
+				return &commontesting.MockClusterAdmin{
+					MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+						assert.Equal(t, []string{sinkTopic}, topics)
+						return test.topicMetadata, test.describeErr
+					},
+				}, nil
+			}
+
+			// Create The Reconciler With The KafkaSink's Secret And Any Existing Services
+			secret := test.secret
+			if secret == nil {
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sinkNamespace, Name: "sink-secret", ResourceVersion: "7"},
+					Data:       map[string][]byte{"user": []byte("user"), "password": []byte("password")},
+				}
+			}
+			kubeClient := fake.NewSimpleClientset(secret)
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, service := range test.services {
+				assert.Nil(t, indexer.Add(service))
+				_, err := kubeClient.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
+				assert.Nil(t, err)
+			}
+			secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.Nil(t, secretIndexer.Add(secret))
+			reconciler := newReconciler(kubeClient)
+			reconciler.serviceLister = corev1listers.NewServiceLister(indexer)
+			reconciler.secretLister = corev1listers.NewSecretLister(secretIndexer)
+
+			// Perform The Test
+			sink := newKafkaSink(true)
+			err := reconciler.ReconcileKind(ctx, sink)
+
+			// Verify The Results
+			assert.Equal(t, test.expectErr, err != nil, err)
+			assert.Equal(t, test.expectReady, sink.Status.IsReady())
+			if test.expectReady {
+				assert.Equal(t, "http://sink-name-kn-sink.sink-namespace.svc.cluster.local", sink.Status.Address.URL.String())
+				assert.Equal(t, "sink-secret=7", sink.Status.Annotations[constants.AuthSecretVersionsAnnotation])
+				service, err := kubeClient.CoreV1().Services(sinkNamespace).Get(ctx, constants.AppendKafkaSinkServiceNameSuffix(sinkName), metav1.GetOptions{})
+				assert.Nil(t, err)
+				assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+				assert.Equal(t, "kafka-sink-ingress.knative-eventing.svc.cluster.local", service.Spec.ExternalName)
+				assert.True(t, metav1.IsControlledBy(service, sink))
+			} else {
+				readyCondition := sink.Status.GetCondition(v1alpha1.KafkaSinkConditionReady)
+				assert.Equal(t, test.expectedReason, readyCondition.Reason)
+			}
+		})
+	}
+}
+
+// Create A New Reconciler For Testing
+func newReconciler(kubeClient *fake.Clientset) *Reconciler {
+	return &Reconciler{
+		kubeClientSet:   kubeClient,
+		tracker:         tracker.New(func(types.NamespacedName) {}, time.Minute),
+		systemNamespace: systemNamespace,
+	}
+}
+
+// Create A New KafkaSink (Optionally Referencing SASL Secrets) For Testing
+func newKafkaSink(withSecrets bool) *v1alpha1.KafkaSink {
+	sink := &v1alpha1.KafkaSink{
+		ObjectMeta: metav1.ObjectMeta{Namespace: sinkNamespace, Name: sinkName, UID: "sink-uid"},
+		Spec: v1alpha1.KafkaSinkSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"kafka:9092"}},
+			Topic:         sinkTopic,
+		},
+	}
+	if withSecrets {
+		sink.Spec.Net.SASL.Enable = true
+		sink.Spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "sink-secret"}, Key: "user"}
+		sink.Spec.Net.SASL.Password.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "sink-secret"}, Key: "password"}
+	}
+	return sink
+}
@@ -18,6 +18,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -25,7 +26,6 @@ import (
 	"strings"
 	"time"
 
-	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "knative.dev/control-protocol/pkg"
@@ -36,6 +36,7 @@ import (
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 
+	"knative.dev/eventing/pkg/eventfilter"
 	"knative.dev/eventing/pkg/metrics/source"
 	"knative.dev/pkg/logging"
 
@@ -43,6 +44,8 @@ import (
 	"knative.dev/eventing/pkg/kncloudevents"
 
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/latency"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 )
@@ -60,6 +63,15 @@ type AdapterConfig struct {
 	Name          string   `envconfig:"NAME" required:"true"`
 	KeyType       string   `envconfig:"KEY_TYPE" required:"false"`
 
+	// Mirror is the Kafka cluster & Topic to which the records are produced instead of being sent to the sink
+	Mirror client.MirrorEnvConfig
+
+	// Filter is the JSON serialized SubscriptionsAPIFilter the events must pass to be sent to the sink
+	Filter string `envconfig:"KAFKA_FILTER" required:"false"`
+
+	// OtlpTracingConfigJson is the json of the tracing config when its backend is "otlp" (rejected in K_TRACING_CONFIG)
+	OtlpTracingConfigJson string `envconfig:"K_KAFKA_TRACING_CONFIG" required:"false"`
+
 	// Turn off the control server.
 	DisableControlServer bool
 }
@@ -74,7 +86,10 @@ type Adapter struct {
 	saramaConfig  *sarama.Config
 
 	httpMessageSender *kncloudevents.HTTPMessageSender
-	reporter          source.StatsReporter
+	mirrorProducer    sarama.SyncProducer
+	mirrorFailures    chan error
+	reporter          StatsReporter
+	filter            eventfilter.Filter
 	logger            *zap.SugaredLogger
 	keyTypeMapper     func([]byte) interface{}
 	rateLimiter       *rate.Limiter
@@ -96,7 +111,7 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMe
 	return &Adapter{
 		config:            config,
 		httpMessageSender: httpMessageSender,
-		reporter:          reporter,
+		reporter:          NewStatsReporter(reporter),
 		logger:            logger,
 		keyTypeMapper:     getKeyTypeMapper(config.KeyType),
 	}
@@ -110,6 +125,7 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		zap.String("Topics", strings.Join(a.config.Topics, ",")),
 		zap.String("ConsumerGroup", a.config.ConsumerGroup),
 		zap.String("SinkURI", a.config.Sink),
+		zap.String("MirrorTopic", a.config.Mirror.Topic),
 		zap.String("Name", a.config.Name),
 		zap.String("Namespace", a.config.Namespace),
 	)
@@ -125,6 +141,20 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		}
 	}
 
+	// Preprocess filter
+	a.filter, err = filter.ParseFilter(a.config.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to parse the filter: %w", err)
+	}
+
+	// Export the spans to an OpenTelemetry collector if the "otlp" tracing backend is configured
+	otlpExporter := a.setupOtlpTracing()
+	defer func() {
+		if err := otlpExporter.Close(); err != nil {
+			a.logger.Errorw("Failed to flush the OTLP exporter", zap.Error(err))
+		}
+	}()
+
 	// Init control service
 	if !a.config.DisableControlServer {
 		a.controlServer, err = ctrlnetwork.StartInsecureControlServer(ctx)
@@ -141,6 +171,21 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 	}
 	a.saramaConfig = config
 
+	// init mirror producer, replacing the http sender
+	if a.config.Mirror.Enabled() {
+		a.mirrorProducer, err = newMirrorProducer(ctx, a.config, addrs, config)
+		if err != nil {
+			return a.terminate(fmt.Errorf("failed to create the mirror producer: %w", err))
+		}
+		a.mirrorFailures = make(chan error, 1)
+		defer func() {
+			err := a.mirrorProducer.Close()
+			if err != nil {
+				a.logger.Errorw("Failed to close mirror producer", zap.Error(err))
+			}
+		}()
+	}
+
 	options := []consumer.SaramaConsumerHandlerOption{consumer.WithSaramaConsumerLifecycleListener(a)}
 	consumerGroupFactory := consumer.NewConsumerGroupFactory(addrs, config, &consumer.NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
 	group, err := consumerGroupFactory.StartConsumerGroup(
@@ -168,9 +213,37 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		}
 	}()
 
-	<-ctx.Done()
-	a.logger.Info("Shutting down...")
-	return nil
+	// Collect the lag of the consumer group
+	lagCollector := consumer.NewLagCollector(a.logger.Desugar(), resourceGroup, addrs, config)
+	lagCollector.Register(a.config.ConsumerGroup, consumer.LagTarget{
+		Ref:    types.NamespacedName{Namespace: a.config.Namespace, Name: a.config.Name},
+		Topics: a.config.Topics,
+	})
+	lagCollector.Start(consumer.DefaultLagInterval)
+	defer lagCollector.Stop()
+
+	select {
+	case <-ctx.Done():
+		a.logger.Info("Shutting down...")
+		return nil
+	case err := <-a.mirrorFailures:
+		a.logger.Errorw("Mirroring failed permanently, shutting down...", zap.Error(err))
+		return a.terminate(err)
+	}
+}
+
+// setupOtlpTracing registers the exporter of the "otlp" tracing backend, if configured (nil otherwise)
+func (a *Adapter) setupOtlpTracing() *tracing.OtlpExporter {
+	otlpTracingConfig, err := tracing.JSONToConfig(a.config.OtlpTracingConfigJson)
+	if err != nil {
+		a.logger.Warnw("OTLP tracing configuration is invalid, ignoring it", zap.Error(err))
+		return nil
+	}
+	exporter, err := tracing.SetupStaticOtlpPublishing(a.logger, a.config.Name+"."+a.config.Namespace, otlpTracingConfig)
+	if err != nil {
+		a.logger.Warnw("Failed to set up the OTLP exporter, ignoring it", zap.Error(err))
+	}
+	return exporter
 }
 
 func (a *Adapter) SetReady(int32, bool) {}
@@ -180,22 +253,48 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 		a.rateLimiter.Wait(ctx)
 	}
 
-	message := protocolkafka.NewMessageFromConsumerMessage(msg)
-	ctx, span := tracing.StartTraceFromMessage(a.logger, ctx, message, "kafka-source-"+msg.Topic)
+	// Measure the latency from the production of the record to its consumption
+	latencyArgs := latency.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+	}
+	latency.ReportIngressLatency(ctx, latencyArgs, msg)
+
+	if a.mirrorProducer != nil {
+		return a.mirror(ctx, msg)
+	}
+
+	ctx, span := tracing.StartConsumerSpan(a.logger, ctx, msg, a.config.ConsumerGroup, "kafka-source-"+msg.Topic)
 	defer span.End()
 
 	req, err := a.httpMessageSender.NewCloudEventRequest(ctx)
 	if err != nil {
 		return false, err
 	}
+	delivery := latency.NewDelivery(req.URL)
+
+	reportArgs := &source.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+	}
 
 	err = a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	if errors.Is(err, errEventFiltered) {
+		_ = a.reporter.ReportFilterEventCount(reportArgs, eventfilter.FailFilter)
+		return true, nil // Event filtered, commit offset
+	}
 	if err != nil {
 		a.logger.Debug("failed to create request", zap.Error(err))
 		return true, err
 	}
+	if a.filter != nil {
+		_ = a.reporter.ReportFilterEventCount(reportArgs, eventfilter.PassFilter)
+	}
 
-	res, err := a.httpMessageSender.SendWithRetries(req, retryConfig)
+	res, err := a.httpMessageSender.SendWithRetries(req, delivery.RetryConfig(retryConfig))
+	latency.ReportDelivery(ctx, latencyArgs, delivery)
 
 	if err != nil {
 		a.logger.Debug("Error while sending the message", zap.Error(err))
@@ -212,12 +311,6 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 		return false, fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
 	}
 
-	reportArgs := &source.ReportArgs{
-		Namespace:     a.config.Namespace,
-		Name:          a.config.Name,
-		ResourceGroup: resourceGroup,
-	}
-
 	_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
 	return true, nil
 }
@@ -85,7 +85,7 @@ func BenchmarkHandle(b *testing.B) {
 		httpMessageSender: &s,
 		logger:            zap.NewNop().Sugar(),
 		keyTypeMapper:     getKeyTypeMapper(""),
-		reporter:          statsReporter,
+		reporter:          NewStatsReporter(statsReporter),
 	}
 	b.SetParallelism(1)
 	b.Run("Baseline", func(b *testing.B) {
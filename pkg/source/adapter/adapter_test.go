@@ -28,12 +28,18 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	tracingconfig "knative.dev/pkg/tracing/config"
 
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/filter"
+	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
 func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
@@ -350,7 +356,7 @@ func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
 				},
 				httpMessageSender: s,
 				logger:            zap.NewNop().Sugar(),
-				reporter:          statsReporter,
+				reporter:          NewStatsReporter(statsReporter),
 				keyTypeMapper:     getKeyTypeMapper(tc.keyTypeMapper),
 			}
 
@@ -400,6 +406,114 @@ func mustJsonMarshal(t *testing.T, val interface{}) []byte {
 	return data
 }
 
+func TestAdapter_Handle_Filter(t *testing.T) {
+	ceMessage := func(eventType string) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Topic: "topic1",
+			Value: mustJsonMarshal(t, map[string]string{"hello": "world"}),
+			Headers: []*sarama.RecordHeader{{
+				Key: []byte("content-type"), Value: []byte("application/json"),
+			}, {
+				Key: []byte("ce_specversion"), Value: []byte("1.0"),
+			}, {
+				Key: []byte("ce_type"), Value: []byte(eventType),
+			}, {
+				Key: []byte("ce_source"), Value: []byte("https://github.com/cloudevents/spec/pull"),
+			}, {
+				Key: []byte("ce_id"), Value: []byte("A234-1234-1234"),
+			}},
+		}
+	}
+	plainMessage := &sarama.ConsumerMessage{
+		Key:   []byte("key"),
+		Topic: "topic1",
+		Value: mustJsonMarshal(t, map[string]string{"hello": "world"}),
+	}
+
+	testCases := map[string]struct {
+		filter       string
+		extensions   map[string]string
+		message      *sarama.ConsumerMessage
+		expectedSent bool
+	}{
+		"no_filter": {
+			message:      ceMessage("com.github.pull.create"),
+			expectedSent: true,
+		},
+		"cloudevent_passes_exact": {
+			filter:       `{"exact":{"type":"com.github.pull.create"}}`,
+			message:      ceMessage("com.github.pull.create"),
+			expectedSent: true,
+		},
+		"cloudevent_fails_exact": {
+			filter:  `{"exact":{"type":"com.github.pull.create"}}`,
+			message: ceMessage("com.github.pull.delete"),
+		},
+		"cloudevent_passes_cesql": {
+			filter:       `{"cesql":"type LIKE 'com.github.pull.%'"}`,
+			message:      ceMessage("com.github.pull.delete"),
+			expectedSent: true,
+		},
+		"cloudevent_passes_extension": {
+			filter:       `{"exact":{"environment":"prod"}}`,
+			extensions:   map[string]string{"environment": "prod"},
+			message:      ceMessage("com.github.pull.create"),
+			expectedSent: true,
+		},
+		"message_passes_key": {
+			filter:       `{"exact":{"key":"key"}}`,
+			message:      plainMessage,
+			expectedSent: true,
+		},
+		"message_fails_not": {
+			filter:  `{"not":{"prefix":{"type":"dev.knative.kafka"}}}`,
+			message: plainMessage,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			h := &fakeHandler{
+				handler: sinkAccepted,
+			}
+			sinkServer := httptest.NewServer(h)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			require.NoError(t, err)
+
+			eventFilter, err := filter.ParseFilter(tc.filter)
+			require.NoError(t, err)
+
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Sink:      sinkServer.URL,
+						Namespace: "test",
+					},
+					Topics:        []string{"topic1"},
+					ConsumerGroup: "group",
+					Name:          "test",
+					Filter:        tc.filter,
+				},
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          NewStatsReporter(statsReporter),
+				filter:            eventFilter,
+				extensions:        tc.extensions,
+				keyTypeMapper:     getKeyTypeMapper(""),
+			}
+
+			commit, err := a.Handle(context.TODO(), tc.message)
+			assert.NoError(t, err)
+			assert.True(t, commit)
+			assert.Equal(t, tc.expectedSent, h.header != nil)
+		})
+	}
+}
+
 type fakeHandler struct {
 	body   []byte
 	header http.Header
@@ -441,3 +555,41 @@ func TestAdapter_Start(t *testing.T) { // just increase code coverage
 	}
 	cancel()
 }
+
+func TestAdapter_SetupOtlpTracing(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer collector.Close()
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+
+	otlpConfig, err := tracing.ConfigToJSON(&tracing.Config{
+		Config:       tracingconfig.Config{Backend: tracing.OtlpBackend, SampleRate: 1},
+		OtlpEndpoint: collector.URL,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		config         string
+		expectExporter bool
+	}{
+		{name: "not configured", config: ""},
+		{name: "invalid", config: "{"},
+		{name: "otlp", config: otlpConfig, expectExporter: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig:             adapter.EnvConfig{Namespace: "test-ns"},
+					Name:                  "test-source",
+					OtlpTracingConfigJson: tc.config,
+				},
+				logger: zap.NewNop().Sugar(),
+			}
+			exporter := a.setupOtlpTracing()
+			defer trace.UnregisterExporter(exporter)
+			assert.Equal(t, tc.expectExporter, exporter != nil)
+			assert.NoError(t, exporter.Close())
+		})
+	}
+}
@@ -18,6 +18,7 @@ package kafka
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"math"
 	nethttp "net/http"
 	"regexp"
@@ -30,9 +31,14 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
 	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/eventfilter"
+
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
+// errEventFiltered is returned when the event of a consumer message doesn't pass the filter of the KafkaSource
+var errEventFiltered = errors.New("event filtered")
+
 func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.ConsumerMessage, req *nethttp.Request) error {
 	msg := protocolkafka.NewMessageFromConsumerMessage(cm)
 
@@ -44,8 +50,19 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 	}()
 
 	if msg.ReadEncoding() != binding.EncodingUnknown {
-		// Message is a CloudEvent -> Encode directly to HTTP
-		return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), msg, req, extensionAsTransformer(a.extensions))
+		if a.filter == nil {
+			// Message is a CloudEvent -> Encode directly to HTTP
+			return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), msg, req, extensionAsTransformer(a.extensions))
+		}
+		// Message is a CloudEvent to be filtered -> Decode it to evaluate the filter, then encode it to HTTP
+		event, err := binding.ToEvent(ctx, msg, extensionAsTransformer(a.extensions))
+		if err != nil {
+			return err
+		}
+		if err := a.filterEvent(ctx, event); err != nil {
+			return err
+		}
+		return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), binding.ToMessage(event), req)
 	}
 
 	a.logger.Debug("Message is not a CloudEvent -> We need to translate it to a valid CloudEvent")
@@ -71,9 +88,27 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 		}
 	}
 
+	if a.filter != nil {
+		for k, v := range a.extensions {
+			event.SetExtension(k, v)
+		}
+		if err := a.filterEvent(ctx, &event); err != nil {
+			return err
+		}
+	}
+
 	return http.WriteRequest(ctx, binding.ToMessage(&event), req, extensionAsTransformer(a.extensions))
 }
 
+// filterEvent evaluates the filter of the KafkaSource, returning errEventFiltered if the event doesn't pass it.
+func (a *Adapter) filterEvent(ctx context.Context, event *cloudevents.Event) error {
+	if a.filter.Filter(ctx, *event) == eventfilter.FailFilter {
+		a.logger.Debugw("Event filtered", zap.String("id", event.ID()), zap.String("type", event.Type()))
+		return errEventFiltered
+	}
+	return nil
+}
+
 func makeEventId(partition int32, offset int64) string {
 	var str strings.Builder
 	str.WriteString("partition:")
@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// NewSyncProducerFn is the constructor of the mirror SyncProducer (stubbed in tests)
+var NewSyncProducerFn = sarama.NewSyncProducer
+
+// mirrorBackoff returns the delay before the specified (zero-based) retry of a failed mirror produce: an exponential
+// backoff with 50ms delay, capped at 5s (stubbed in tests)
+var mirrorBackoff = func(attemptNum int) time.Duration {
+	return time.Duration(math.Min(float64(50*time.Millisecond)*math.Exp2(float64(attemptNum)), float64(5*time.Second)))
+}
+
+// topicPartitionCountsFn returns the number of partitions of each of the topics of a Kafka cluster (stubbed in tests)
+var topicPartitionCountsFn = func(addrs []string, config *sarama.Config, topics []string) (map[string]int, error) {
+	c, err := sarama.NewClient(addrs, config)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	counts := make(map[string]int, len(topics))
+	for _, topic := range topics {
+		partitions, err := c.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+		}
+		counts[topic] = len(partitions)
+	}
+	return counts, nil
+}
+
+// terminationMessagePath is the file from which Kubernetes reads the termination message of the container (stubbed
+// in tests), through which the KafkaSource controller reports why the adapter failed permanently
+var terminationMessagePath = "/dev/termination-log"
+
+// nonRetriableMirrorErrors are the produce errors which retrying cannot fix, as they are caused by the record itself
+// or by the configuration of the mirror Topic
+var nonRetriableMirrorErrors = []error{
+	sarama.ErrInvalidPartition,
+	sarama.ErrInvalidMessage,
+	sarama.ErrInvalidMessageSize,
+	sarama.ErrMessageSizeTooLarge,
+	sarama.ErrMessageSetSizeTooLarge,
+	sarama.ErrInvalidTopic,
+	sarama.ErrInvalidTimestamp,
+	sarama.ErrUnsupportedForMessageFormat,
+	sarama.ErrPolicyViolation,
+	sarama.ErrInvalidRecord,
+}
+
+// newMirrorProducer creates the SyncProducer of the mirror Kafka cluster, which waits for the acknowledgement of all
+// in-sync replicas so that the offset of a record is only committed once it has been durably mirrored.  Preserving
+// the partitions of the records requires the mirror Topic to have at least as many partitions as each source Topic.
+func newMirrorProducer(ctx context.Context, config *AdapterConfig, sourceAddrs []string, sourceConfig *sarama.Config) (sarama.SyncProducer, error) {
+	env := config.Mirror.KafkaEnvConfig(config.KafkaConfigJson)
+	addrs, saramaConfig, err := client.NewConfigWithEnv(ctx, &env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the mirror config: %w", err)
+	}
+
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+	if config.Mirror.PreservePartition {
+		if err := verifyMirrorPartitions(config, sourceAddrs, sourceConfig, addrs, saramaConfig); err != nil {
+			return nil, err
+		}
+		saramaConfig.Producer.Partitioner = sarama.NewManualPartitioner
+	}
+
+	return NewSyncProducerFn(addrs, saramaConfig)
+}
+
+// verifyMirrorPartitions returns an error if the mirror Topic has fewer partitions than any of the source Topics
+func verifyMirrorPartitions(config *AdapterConfig, sourceAddrs []string, sourceConfig *sarama.Config, mirrorAddrs []string, mirrorConfig *sarama.Config) error {
+	sourceCounts, err := topicPartitionCountsFn(sourceAddrs, sourceConfig, config.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to get the partitions of the source topics: %w", err)
+	}
+	mirrorCounts, err := topicPartitionCountsFn(mirrorAddrs, mirrorConfig, []string{config.Mirror.Topic})
+	if err != nil {
+		return fmt.Errorf("failed to get the partitions of the mirror topic: %w", err)
+	}
+	for _, topic := range config.Topics {
+		if sourceCounts[topic] > mirrorCounts[config.Mirror.Topic] {
+			return fmt.Errorf("mirror topic %s has %d partitions, fewer than the %d partitions of topic %s whose partitions are preserved",
+				config.Mirror.Topic, mirrorCounts[config.Mirror.Topic], sourceCounts[topic], topic)
+		}
+	}
+	return nil
+}
+
+// mirror produces the consumed record as-is to the mirror Topic, returning true (committing the offset of the record)
+// only once the produce has been acknowledged.  Failed produces are retried with backoff until they succeed or the
+// context (the consumer session) ends, so that later records of the partition are never committed past a record
+// which was not mirrored.  A produce failing with a non-retriable error is fatal: the adapter is stopped (see Start)
+// and the record is held until the session ends.
+func (a *Adapter) mirror(ctx context.Context, msg *sarama.ConsumerMessage) (bool, error) {
+	var partition int32
+	var offset int64
+	var err error
+	for attemptNum := 0; ; attemptNum++ {
+		// a new ProducerMessage is sent on each attempt, as sarama tracks its own retries in the message
+		partition, offset, err = a.mirrorProducer.SendMessage(newMirrorMessage(msg, a.config.Mirror.Topic, a.config.Mirror.PreservePartition))
+		if err == nil {
+			break
+		}
+		if isNonRetriableMirrorError(err) {
+			a.logger.Errorw("Error while mirroring the message, stopping",
+				zap.String("Topic", msg.Topic),
+				zap.Int32("Partition", msg.Partition),
+				zap.Int64("Offset", msg.Offset),
+				zap.Error(err),
+			)
+			a.failMirror(fmt.Errorf("failed to mirror offset %d of partition %d of topic %s to topic %s: %w",
+				msg.Offset, msg.Partition, msg.Topic, a.config.Mirror.Topic, err))
+			<-ctx.Done()
+			return false, err // Never mirrored, don't commit offset
+		}
+		delay := mirrorBackoff(attemptNum)
+		a.logger.Warnw("Error while mirroring the message, retrying",
+			zap.String("Topic", msg.Topic),
+			zap.Int32("Partition", msg.Partition),
+			zap.Int64("Offset", msg.Offset),
+			zap.Duration("Backoff", delay),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return false, err // Session ended before the record was mirrored, don't commit offset
+		case <-time.After(delay):
+		}
+	}
+	a.logger.Debugw("Mirrored message",
+		zap.String("Topic", msg.Topic),
+		zap.Int32("Partition", msg.Partition),
+		zap.Int64("Offset", msg.Offset),
+		zap.Int32("MirrorPartition", partition),
+		zap.Int64("MirrorOffset", offset),
+	)
+	return true, nil
+}
+
+// isNonRetriableMirrorError returns true if the produce error is one of the nonRetriableMirrorErrors
+func isNonRetriableMirrorError(err error) bool {
+	for _, nonRetriable := range nonRetriableMirrorErrors {
+		if errors.Is(err, nonRetriable) {
+			return true
+		}
+	}
+	return false
+}
+
+// failMirror reports the first permanent mirroring failure to Start, which stops the adapter
+func (a *Adapter) failMirror(err error) {
+	select {
+	case a.mirrorFailures <- err:
+	default:
+	}
+}
+
+// terminate writes the error as the termination message of the container, from which the KafkaSource controller
+// reports it in the Deployed condition of the source, and returns it
+func (a *Adapter) terminate(err error) error {
+	if writeErr := os.WriteFile(terminationMessagePath, []byte(err.Error()), 0644); writeErr != nil {
+		a.logger.Debugw("Failed to write the termination message", zap.Error(writeErr))
+	}
+	return err
+}
+
+// newMirrorMessage returns the ProducerMessage mirroring the key, value, headers and timestamp of the ConsumerMessage
+func newMirrorMessage(msg *sarama.ConsumerMessage, topic string, preservePartition bool) *sarama.ProducerMessage {
+	producerMessage := &sarama.ProducerMessage{
+		Topic:     topic,
+		Timestamp: msg.Timestamp,
+	}
+	if msg.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		producerMessage.Value = sarama.ByteEncoder(msg.Value)
+	}
+	if preservePartition {
+		producerMessage.Partition = msg.Partition
+	}
+	if len(msg.Headers) > 0 {
+		producerMessage.Headers = make([]sarama.RecordHeader, 0, len(msg.Headers))
+		for _, header := range msg.Headers {
+			if header != nil {
+				producerMessage.Headers = append(producerMessage.Headers, *header)
+			}
+		}
+	}
+	return producerMessage
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+func TestHandleMirror(t *testing.T) {
+	timestamp := time.Now().Truncate(time.Millisecond)
+	message := &sarama.ConsumerMessage{
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Topic:     "topic1",
+		Partition: 3,
+		Offset:    7,
+		Timestamp: timestamp,
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("header1"), Value: []byte("value1")},
+			nil,
+			{Key: []byte("header2"), Value: []byte("value2")},
+		},
+	}
+
+	testCases := map[string]struct {
+		preservePartition bool
+		produceErr        error
+		expectPartition   int32
+	}{
+		"mirrored":                   {expectPartition: 0},
+		"mirrored to same partition": {preservePartition: true, expectPartition: 3},
+		"produce failure at session end not committed": {produceErr: errors.New("produce failure")},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// The Manual Partitioner Of The Mock Preserves The Partition Of The Mirrored Message
+			mockConfig := mocks.NewTestConfig()
+			mockConfig.Producer.Partitioner = sarama.NewManualPartitioner
+			producer := mocks.NewSyncProducer(t, mockConfig)
+			checker := func(msg *sarama.ProducerMessage) error {
+				assert.Equal(t, "mirror-topic", msg.Topic)
+				assert.Equal(t, sarama.ByteEncoder("key"), msg.Key)
+				assert.Equal(t, sarama.ByteEncoder("value"), msg.Value)
+				assert.Equal(t, timestamp, msg.Timestamp)
+				assert.Equal(t, []sarama.RecordHeader{
+					{Key: []byte("header1"), Value: []byte("value1")},
+					{Key: []byte("header2"), Value: []byte("value2")},
+				}, msg.Headers)
+				assert.Equal(t, tc.expectPartition, msg.Partition)
+				return nil
+			}
+			if tc.produceErr != nil {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndFail(checker, tc.produceErr)
+			} else {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+			}
+
+			a := &Adapter{
+				config: &AdapterConfig{
+					Mirror: client.MirrorEnvConfig{
+						BootstrapServers:  []string{"mirror:9092"},
+						Topic:             "mirror-topic",
+						PreservePartition: tc.preservePartition,
+					},
+				},
+				mirrorProducer: producer,
+				logger:         zap.NewNop().Sugar(),
+			}
+
+			// A failed produce is retried until the session (the context of Handle) ends
+			ctx, cancel := context.WithCancel(context.TODO())
+			if tc.produceErr != nil {
+				cancel()
+			}
+			defer cancel()
+			commit, err := a.Handle(ctx, message)
+			assert.Equal(t, tc.produceErr == nil, commit)
+			assert.Equal(t, tc.produceErr, err)
+			require.NoError(t, producer.Close())
+		})
+	}
+}
+
+func TestHandleMirrorRetry(t *testing.T) {
+	defer restoreMirrorBackoff(mirrorBackoff)
+	mirrorBackoff = func(int) time.Duration { return time.Millisecond }
+
+	// the 2nd of 3 sends fails, and is retried before the 3rd record is mirrored
+	var mirrored []string
+	checker := func(msg *sarama.ProducerMessage) error {
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		mirrored = append(mirrored, string(value))
+		return nil
+	}
+	producer := mocks.NewSyncProducer(t, mocks.NewTestConfig())
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndFail(checker, errors.New("produce failure"))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			Mirror: client.MirrorEnvConfig{
+				BootstrapServers: []string{"mirror:9092"},
+				Topic:            "mirror-topic",
+			},
+		},
+		mirrorProducer: producer,
+		logger:         zap.NewNop().Sugar(),
+	}
+
+	for offset, value := range []string{"value1", "value2", "value3"} {
+		commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{Value: []byte(value), Topic: "topic1", Offset: int64(offset)})
+		assert.True(t, commit)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"value1", "value2", "value2", "value3"}, mirrored)
+	require.NoError(t, producer.Close())
+}
+
+func TestHandleMirrorNonRetriable(t *testing.T) {
+	defer restoreMirrorBackoff(mirrorBackoff)
+	mirrorBackoff = func(int) time.Duration { return time.Hour }
+
+	producer := mocks.NewSyncProducer(t, mocks.NewTestConfig())
+	producer.ExpectSendMessageAndFail(sarama.ErrMessageSizeTooLarge)
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			Mirror: client.MirrorEnvConfig{
+				BootstrapServers: []string{"mirror:9092"},
+				Topic:            "mirror-topic",
+			},
+		},
+		mirrorProducer: producer,
+		mirrorFailures: make(chan error, 1),
+		logger:         zap.NewNop().Sugar(),
+	}
+
+	// the record is not retried, and is held (not committed) until the session ends
+	ctx, cancel := context.WithCancel(context.TODO())
+	result := make(chan error)
+	go func() {
+		commit, err := a.Handle(ctx, &sarama.ConsumerMessage{Value: []byte("value"), Topic: "topic1", Partition: 2, Offset: 4})
+		assert.False(t, commit)
+		result <- err
+	}()
+
+	failure := <-a.mirrorFailures
+	assert.ErrorIs(t, failure, sarama.ErrMessageSizeTooLarge)
+	assert.Contains(t, failure.Error(), "offset 4 of partition 2 of topic topic1")
+	cancel()
+	assert.Equal(t, sarama.ErrMessageSizeTooLarge, <-result)
+	require.NoError(t, producer.Close())
+}
+
+func TestIsNonRetriableMirrorError(t *testing.T) {
+	assert.True(t, isNonRetriableMirrorError(sarama.ErrInvalidPartition))
+	assert.True(t, isNonRetriableMirrorError(sarama.ErrMessageSizeTooLarge))
+	assert.False(t, isNonRetriableMirrorError(sarama.ErrNotLeaderForPartition))
+	assert.False(t, isNonRetriableMirrorError(sarama.ErrOutOfBrokers))
+	assert.False(t, isNonRetriableMirrorError(errors.New("produce failure")))
+}
+
+func TestTerminate(t *testing.T) {
+	defer restoreTerminationMessagePath(terminationMessagePath)
+	terminationMessagePath = filepath.Join(t.TempDir(), "termination-log")
+
+	a := &Adapter{logger: zap.NewNop().Sugar()}
+	err := errors.New("mirror topic has too few partitions")
+	assert.Equal(t, err, a.terminate(err))
+	message, readErr := os.ReadFile(terminationMessagePath)
+	require.NoError(t, readErr)
+	assert.Equal(t, "mirror topic has too few partitions", string(message))
+}
+
+func TestMirrorBackoff(t *testing.T) {
+	assert.Equal(t, 50*time.Millisecond, mirrorBackoff(0))
+	assert.Equal(t, 200*time.Millisecond, mirrorBackoff(2))
+	assert.Equal(t, 5*time.Second, mirrorBackoff(10))
+}
+
+func TestNewMirrorMessageNilKey(t *testing.T) {
+	producerMessage := newMirrorMessage(&sarama.ConsumerMessage{Value: []byte("value"), Partition: 2}, "mirror-topic", false)
+	assert.Nil(t, producerMessage.Key)
+	assert.Nil(t, producerMessage.Headers)
+	assert.Equal(t, int32(0), producerMessage.Partition)
+}
+
+func TestNewMirrorProducer(t *testing.T) {
+	defer restoreNewSyncProducerFn(NewSyncProducerFn)
+	defer restoreTopicPartitionCountsFn(topicPartitionCountsFn)
+
+	testCases := map[string]struct {
+		mirrorPartitions int
+		partitionsErr    error
+		expectErr        string
+	}{
+		"as many partitions as the source topics": {mirrorPartitions: 8},
+		"more partitions than the source topics":  {mirrorPartitions: 16},
+		"fewer partitions than a source topic": {
+			mirrorPartitions: 4,
+			expectErr:        "mirror topic mirror-topic has 4 partitions, fewer than the 8 partitions of topic topic2 whose partitions are preserved",
+		},
+		"partitions lookup failure": {
+			partitionsErr: errors.New("lookup failure"),
+			expectErr:     "failed to get the partitions of the source topics: lookup failure",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var producerAddrs []string
+			var producerConfig *sarama.Config
+			NewSyncProducerFn = func(addrs []string, config *sarama.Config) (sarama.SyncProducer, error) {
+				producerAddrs = addrs
+				producerConfig = config
+				return mocks.NewSyncProducer(t, config), nil
+			}
+			topicPartitionCountsFn = func(addrs []string, _ *sarama.Config, topics []string) (map[string]int, error) {
+				if tc.partitionsErr != nil {
+					return nil, tc.partitionsErr
+				}
+				if addrs[0] == "mirror:9092" {
+					assert.Equal(t, []string{"mirror-topic"}, topics)
+					return map[string]int{"mirror-topic": tc.mirrorPartitions}, nil
+				}
+				assert.Equal(t, []string{"source:9092"}, addrs)
+				assert.Equal(t, []string{"topic1", "topic2"}, topics)
+				return map[string]int{"topic1": 2, "topic2": 8}, nil
+			}
+
+			config := &AdapterConfig{
+				Topics: []string{"topic1", "topic2"},
+				Mirror: client.MirrorEnvConfig{
+					BootstrapServers:  []string{"mirror:9092"},
+					Topic:             "mirror-topic",
+					PreservePartition: true,
+				},
+			}
+			producer, err := newMirrorProducer(context.TODO(), config, []string{"source:9092"}, sarama.NewConfig())
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, producerConfig)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, producer)
+			assert.Equal(t, []string{"mirror:9092"}, producerAddrs)
+			assert.Equal(t, sarama.WaitForAll, producerConfig.Producer.RequiredAcks)
+			assert.True(t, producerConfig.Producer.Return.Successes)
+			partitioner := producerConfig.Producer.Partitioner("mirror-topic")
+			partition, err := partitioner.Partition(&sarama.ProducerMessage{Partition: 5}, 8)
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), partition)
+			require.NoError(t, producer.Close())
+		})
+	}
+}
+
+func TestAdapterConfigMirror(t *testing.T) {
+	t.Setenv("KAFKA_BOOTSTRAP_SERVERS", "source:9092")
+	t.Setenv("KAFKA_TOPICS", "topic1")
+	t.Setenv("KAFKA_CONSUMER_GROUP", "group")
+	t.Setenv("NAME", "source")
+	t.Setenv("KAFKA_MIRROR_BOOTSTRAP_SERVERS", "mirror1:9092,mirror2:9092")
+	t.Setenv("KAFKA_MIRROR_TOPIC", "mirror-topic")
+	t.Setenv("KAFKA_MIRROR_PRESERVE_PARTITION", "true")
+	t.Setenv("KAFKA_MIRROR_NET_SASL_ENABLE", "true")
+	t.Setenv("KAFKA_MIRROR_NET_SASL_USER", "user")
+
+	config := &AdapterConfig{}
+	require.NoError(t, envconfig.Process("", config))
+	assert.Equal(t, []string{"source:9092"}, config.BootstrapServers)
+	assert.False(t, config.Net.SASL.Enable)
+	assert.Equal(t, client.MirrorEnvConfig{
+		BootstrapServers:  []string{"mirror1:9092", "mirror2:9092"},
+		Topic:             "mirror-topic",
+		PreservePartition: true,
+		SASLEnable:        true,
+		SASLUser:          "user",
+	}, config.Mirror)
+}
+
+func restoreMirrorBackoff(fn func(int) time.Duration) {
+	mirrorBackoff = fn
+}
+
+func restoreNewSyncProducerFn(fn func([]string, *sarama.Config) (sarama.SyncProducer, error)) {
+	NewSyncProducerFn = fn
+}
+
+func restoreTopicPartitionCountsFn(fn func([]string, *sarama.Config, []string) (map[string]int, error)) {
+	topicPartitionCountsFn = fn
+}
+
+func restoreTerminationMessagePath(path string) {
+	terminationMessagePath = path
+}
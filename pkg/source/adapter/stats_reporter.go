@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/eventing/pkg/eventfilter"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+	"knative.dev/eventing/pkg/metrics/source"
+	"knative.dev/pkg/metrics"
+)
+
+// FilterEventCountN is the number of events evaluated by the filter of a KafkaSource, tagged by the filter result.
+const FilterEventCountN = "kafkasource_filter_event_count"
+
+var (
+	filterEventCountM = stats.Int64(
+		FilterEventCountN,
+		"Number of events evaluated by the filter of the KafkaSource",
+		stats.UnitDimensionless)
+
+	namespaceTagKey     = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+	nameTagKey          = tag.MustNewKey(eventingmetrics.LabelName)
+	resourceGroupTagKey = tag.MustNewKey(eventingmetrics.LabelResourceGroup)
+	filterResultTagKey  = tag.MustNewKey("filter_result")
+)
+
+func init() {
+	registerViews()
+}
+
+// StatsReporter extends the source StatsReporter with the counts of the events passed and filtered by the KafkaSource.
+type StatsReporter interface {
+	source.StatsReporter
+
+	// ReportFilterEventCount captures the result of filtering an event. It records one per call.
+	ReportFilterEventCount(args *source.ReportArgs, result eventfilter.FilterResult) error
+}
+
+// statsReporter wraps the source StatsReporter, recording the filter counts to the views of this package
+type statsReporter struct {
+	source.StatsReporter
+}
+
+var _ StatsReporter = (*statsReporter)(nil)
+
+// NewStatsReporter returns a StatsReporter extending the specified source StatsReporter.
+func NewStatsReporter(reporter source.StatsReporter) StatsReporter {
+	if statsReporter, ok := reporter.(StatsReporter); ok {
+		return statsReporter
+	}
+	return &statsReporter{StatsReporter: reporter}
+}
+
+// ReportFilterEventCount records a single filter result tagged by KafkaSource.
+func (r *statsReporter) ReportFilterEventCount(args *source.ReportArgs, result eventfilter.FilterResult) error {
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(namespaceTagKey, args.Namespace),
+		tag.Insert(nameTagKey, args.Name),
+		tag.Insert(resourceGroupTagKey, args.ResourceGroup),
+		tag.Insert(filterResultTagKey, string(result)))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, filterEventCountM.M(1))
+	return nil
+}
+
+// Register The Views Of The Filter Measures
+func registerViews() {
+	err := view.Register(&view.View{
+		Description: filterEventCountM.Description(),
+		Measure:     filterEventCountM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTagKey, nameTagKey, resourceGroupTagKey, filterResultTagKey},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
@@ -20,14 +20,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubernetes "k8s.io/client-go/kubernetes"
 
 	"github.com/Shopify/sarama"
 	"github.com/kelseyhightower/envconfig"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 )
@@ -37,6 +40,12 @@ type AdapterSASL struct {
 	User     string `envconfig:"KAFKA_NET_SASL_USER" required:"false"`
 	Password string `envconfig:"KAFKA_NET_SASL_PASSWORD" required:"false"`
 	Type     string `envconfig:"KAFKA_NET_SASL_TYPE" required:"false"`
+
+	// OAUTHBEARER Client Credentials
+	ClientId     string `envconfig:"KAFKA_NET_SASL_CLIENT_ID" required:"false"`
+	ClientSecret string `envconfig:"KAFKA_NET_SASL_CLIENT_SECRET" required:"false"`
+	TokenUrl     string `envconfig:"KAFKA_NET_SASL_TOKEN_URL" required:"false"`
+	Scopes       string `envconfig:"KAFKA_NET_SASL_SCOPES" required:"false"`
 }
 
 type AdapterTLS struct {
@@ -64,6 +73,60 @@ type KafkaEnvConfig struct {
 	Net              AdapterNet
 }
 
+// MirrorEnvConfig is the target Kafka cluster & Topic of a KafkaSource mirroring its records, whose settings
+// are passed to the adapter in separate environment variables from those of the source cluster.
+type MirrorEnvConfig struct {
+	BootstrapServers  []string `envconfig:"KAFKA_MIRROR_BOOTSTRAP_SERVERS" required:"false"`
+	Topic             string   `envconfig:"KAFKA_MIRROR_TOPIC" required:"false"`
+	PreservePartition bool     `envconfig:"KAFKA_MIRROR_PRESERVE_PARTITION" required:"false"`
+
+	SASLEnable       bool   `envconfig:"KAFKA_MIRROR_NET_SASL_ENABLE" required:"false"`
+	SASLUser         string `envconfig:"KAFKA_MIRROR_NET_SASL_USER" required:"false"`
+	SASLPassword     string `envconfig:"KAFKA_MIRROR_NET_SASL_PASSWORD" required:"false"`
+	SASLType         string `envconfig:"KAFKA_MIRROR_NET_SASL_TYPE" required:"false"`
+	SASLClientId     string `envconfig:"KAFKA_MIRROR_NET_SASL_CLIENT_ID" required:"false"`
+	SASLClientSecret string `envconfig:"KAFKA_MIRROR_NET_SASL_CLIENT_SECRET" required:"false"`
+	SASLTokenUrl     string `envconfig:"KAFKA_MIRROR_NET_SASL_TOKEN_URL" required:"false"`
+	SASLScopes       string `envconfig:"KAFKA_MIRROR_NET_SASL_SCOPES" required:"false"`
+
+	TLSEnable bool   `envconfig:"KAFKA_MIRROR_NET_TLS_ENABLE" required:"false"`
+	TLSCert   string `envconfig:"KAFKA_MIRROR_NET_TLS_CERT" required:"false"`
+	TLSKey    string `envconfig:"KAFKA_MIRROR_NET_TLS_KEY" required:"false"`
+	TLSCACert string `envconfig:"KAFKA_MIRROR_NET_TLS_CA_CERT" required:"false"`
+}
+
+// Enabled returns true if a mirror Kafka cluster & Topic have been configured.
+func (m MirrorEnvConfig) Enabled() bool {
+	return len(m.BootstrapServers) > 0 && m.Topic != ""
+}
+
+// KafkaEnvConfig returns the KafkaEnvConfig of the mirror Kafka cluster, sharing the Sarama configuration JSON
+// of the source cluster.
+func (m MirrorEnvConfig) KafkaEnvConfig(kafkaConfigJson string) KafkaEnvConfig {
+	return KafkaEnvConfig{
+		KafkaConfigJson:  kafkaConfigJson,
+		BootstrapServers: m.BootstrapServers,
+		Net: AdapterNet{
+			SASL: AdapterSASL{
+				Enable:       m.SASLEnable,
+				User:         m.SASLUser,
+				Password:     m.SASLPassword,
+				Type:         m.SASLType,
+				ClientId:     m.SASLClientId,
+				ClientSecret: m.SASLClientSecret,
+				TokenUrl:     m.SASLTokenUrl,
+				Scopes:       m.SASLScopes,
+			},
+			TLS: AdapterTLS{
+				Enable: m.TLSEnable,
+				Cert:   m.TLSCert,
+				Key:    m.TLSKey,
+				CACert: m.TLSCACert,
+			},
+		},
+	}
+}
+
 // NewConfig extracts the Kafka configuration from the environment.
 func NewConfigFromEnv(ctx context.Context) ([]string, *sarama.Config, error) {
 	var env KafkaEnvConfig
@@ -92,6 +155,14 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 			Password: env.Net.SASL.Password,
 			SaslType: env.Net.SASL.Type,
 		}
+		if env.Net.SASL.Type == sarama.SASLTypeOAuth {
+			kafkaAuthConfig.SASL.OAuth = &client.KafkaOAuthConfig{
+				ClientId:     env.Net.SASL.ClientId,
+				ClientSecret: env.Net.SASL.ClientSecret,
+				TokenUrl:     env.Net.SASL.TokenUrl,
+				Scopes:       client.ParseScopes(env.Net.SASL.Scopes),
+			}
+		}
 	}
 
 	configBuilder := client.NewConfigBuilder().
@@ -128,48 +199,83 @@ func NewConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *source
 
 // NewEnvConfigFromSpec validates and creates a KafkaEnvConfig from a KafkaSource
 func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sourcesv1beta1.KafkaSource) (KafkaEnvConfig, error) {
-	saslUser, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.User.SecretKeyRef)
+	config, err := NewEnvConfigFromAuthSpec(ctx, kc, obj.Namespace, obj.Spec.KafkaAuthSpec)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
+	config.InitialOffset = obj.Spec.InitialOffset
+	return config, nil
+}
 
-	saslPassword, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Password.SecretKeyRef)
+// NewEnvConfigFromAuthSpec validates and creates a KafkaEnvConfig from the KafkaAuthSpec of a resource (KafkaSource,
+// KafkaSink, etc.) in the specified namespace, resolving the referenced Secret values.
+func NewEnvConfigFromAuthSpec(ctx context.Context, kc kubernetes.Interface, namespace string, spec bindingsv1beta1.KafkaAuthSpec) (KafkaEnvConfig, error) {
+	saslUser, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.User.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
-	saslType, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Type.SecretKeyRef)
+	saslPassword, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.Password.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
-	tlsCert, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.Cert.SecretKeyRef)
+	saslType, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.Type.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
-	tlsKey, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.Key.SecretKeyRef)
+	saslClientId, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.ClientId.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
-	tlsCACert, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.CACert.SecretKeyRef)
+	saslClientSecret, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.ClientSecret.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslTokenUrl, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.TokenUrl.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslScopes, err := resolveSecret(ctx, kc, namespace, spec.Net.SASL.Scopes.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	tlsCert, err := resolveSecret(ctx, kc, namespace, spec.Net.TLS.Cert.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	tlsKey, err := resolveSecret(ctx, kc, namespace, spec.Net.TLS.Key.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	tlsCACert, err := resolveSecret(ctx, kc, namespace, spec.Net.TLS.CACert.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
 	config := KafkaEnvConfig{
-		BootstrapServers: obj.Spec.BootstrapServers,
-		InitialOffset:    obj.Spec.InitialOffset,
+		BootstrapServers: spec.BootstrapServers,
 		Net: AdapterNet{
 			SASL: AdapterSASL{
-				Enable:   obj.Spec.Net.SASL.Enable,
+				Enable:   spec.Net.SASL.Enable,
 				User:     saslUser,
 				Password: saslPassword,
 				Type:     saslType,
+
+				ClientId:     saslClientId,
+				ClientSecret: saslClientSecret,
+				TokenUrl:     saslTokenUrl,
+				Scopes:       saslScopes,
 			},
 			TLS: AdapterTLS{
-				Enable: obj.Spec.Net.TLS.Enable,
+				Enable: spec.Net.TLS.Enable,
 				Cert:   tlsCert,
 				Key:    tlsKey,
 				CACert: tlsCACert,
@@ -180,6 +286,58 @@ func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sou
 	return config, nil
 }
 
+// SecretKeyRefs returns the (non-nil) Secret key references of a KafkaSource's SASL and TLS settings, including
+// those of its mirror Kafka cluster (if any).
+func SecretKeyRefs(obj *sourcesv1beta1.KafkaSource) []*corev1.SecretKeySelector {
+	refs := AuthSpecSecretKeyRefs(obj.Spec.KafkaAuthSpec)
+	if obj.Spec.Mirror != nil {
+		refs = append(refs, AuthSpecSecretKeyRefs(obj.Spec.Mirror.KafkaAuthSpec)...)
+	}
+	return refs
+}
+
+// AuthSpecSecretKeyRefs returns the (non-nil) Secret key references of the SASL and TLS settings of a KafkaAuthSpec.
+func AuthSpecSecretKeyRefs(spec bindingsv1beta1.KafkaAuthSpec) []*corev1.SecretKeySelector {
+	sasl := spec.Net.SASL
+	tls := spec.Net.TLS
+	var refs []*corev1.SecretKeySelector
+	for _, ref := range []*corev1.SecretKeySelector{
+		sasl.User.SecretKeyRef,
+		sasl.Password.SecretKeyRef,
+		sasl.Type.SecretKeyRef,
+		sasl.ClientId.SecretKeyRef,
+		sasl.ClientSecret.SecretKeyRef,
+		sasl.TokenUrl.SecretKeyRef,
+		sasl.Scopes.SecretKeyRef,
+		tls.Cert.SecretKeyRef,
+		tls.Key.SecretKeyRef,
+		tls.CACert.SecretKeyRef,
+	} {
+		if ref != nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// SecretVersions returns the sorted "name=resourceVersion" list of the distinct Secrets referenced by refs, which
+// changes whenever any of them is updated without exposing anything derived from their values.
+func SecretVersions(ctx context.Context, kc kubernetes.Interface, namespace string, refs []*corev1.SecretKeySelector) (string, error) {
+	names := sets.NewString()
+	for _, ref := range refs {
+		names.Insert(ref.Name)
+	}
+	versions := make([]string, 0, names.Len())
+	for _, name := range names.List() {
+		secret, err := kc.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret (%v)", err)
+		}
+		versions = append(versions, name+"="+secret.ResourceVersion)
+	}
+	return strings.Join(versions, ","), nil
+}
+
 // NewProducer is a helper method for constructing a client for producing kafka methods.
 func NewProducer(ctx context.Context) (sarama.Client, error) {
 	bs, cfg, err := NewConfigFromEnv(ctx)
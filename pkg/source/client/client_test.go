@@ -169,6 +169,20 @@ func TestNewConfig(t *testing.T) {
 			saslMechanism:   sarama.SASLTypeSCRAMSHA512,
 			bootstrapServer: defaultBootstrapServer,
 		},
+		"Only SASL-OAUTHBEARER Auth": {
+			env: map[string]string{
+				"KAFKA_BOOTSTRAP_SERVERS":      defaultBootstrapServer,
+				"KAFKA_NET_SASL_ENABLE":        "true",
+				"KAFKA_NET_SASL_TYPE":          sarama.SASLTypeOAuth,
+				"KAFKA_NET_SASL_CLIENT_ID":     "client-id",
+				"KAFKA_NET_SASL_CLIENT_SECRET": "client-secret",
+				"KAFKA_NET_SASL_TOKEN_URL":     "https://idp.example.com/token",
+				"KAFKA_NET_SASL_SCOPES":        "kafka",
+			},
+			enabledSASL:     true,
+			saslMechanism:   sarama.SASLTypeOAuth,
+			bootstrapServer: defaultBootstrapServer,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -197,6 +211,9 @@ func TestNewConfig(t *testing.T) {
 				if config.Net.SASL.Password != tc.saslPassword && !tc.wantErr {
 					t.Fatalf("Incorrect SASL Password, got: %s vs want: %s", config.Net.SASL.Password, tc.saslPassword)
 				}
+				if (config.Net.SASL.TokenProvider != nil) != (tc.saslMechanism == sarama.SASLTypeOAuth) {
+					t.Fatalf("Incorrect SASL TokenProvider, got: %v for mechanism %s", config.Net.SASL.TokenProvider, tc.saslMechanism)
+				}
 			}
 			require.NotNil(t, config)
 			for k := range tc.env {
@@ -430,6 +447,73 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 	}
 }
 
+func TestSecretKeyRefs(t *testing.T) {
+	src := &v1beta1.KafkaSource{}
+	require.Empty(t, SecretKeyRefs(src))
+
+	src.Spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "sasl"}, Key: "user"}
+	src.Spec.Net.SASL.ClientSecret.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "clientsecret"}
+	src.Spec.Net.TLS.CACert.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "ca.crt"}
+	refs := SecretKeyRefs(src)
+	require.Len(t, refs, 3)
+	require.Equal(t, "sasl", refs[0].Name)
+	require.Equal(t, "oauth", refs[1].Name)
+	require.Equal(t, "tls", refs[2].Name)
+
+	src.Spec.Mirror = &v1beta1.KafkaMirrorSpec{Topic: "mirror-topic"}
+	src.Spec.Mirror.Net.SASL.Password.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mirror"}, Key: "password"}
+	refs = SecretKeyRefs(src)
+	require.Len(t, refs, 4)
+	require.Equal(t, "mirror", refs[3].Name)
+}
+
+func TestMirrorEnvConfig(t *testing.T) {
+	mirror := MirrorEnvConfig{}
+	require.False(t, mirror.Enabled())
+
+	mirror = MirrorEnvConfig{
+		BootstrapServers: []string{"mirror:9092"},
+		Topic:            "mirror-topic",
+		SASLEnable:       true,
+		SASLUser:         "user",
+		SASLPassword:     "password",
+		TLSEnable:        true,
+		TLSCACert:        "ca",
+	}
+	require.True(t, mirror.Enabled())
+
+	env := mirror.KafkaEnvConfig("{}")
+	require.Equal(t, "{}", env.KafkaConfigJson)
+	require.Equal(t, []string{"mirror:9092"}, env.BootstrapServers)
+	require.Equal(t, AdapterSASL{Enable: true, User: "user", Password: "password"}, env.Net.SASL)
+	require.Equal(t, AdapterTLS{Enable: true, CACert: "ca"}, env.Net.TLS)
+}
+
+func TestSecretVersions(t *testing.T) {
+	ctx := context.Background()
+	user := constructSecret("sasl", "user", "user")
+	user.ResourceVersion = "3"
+	ca := constructSecret("tls", "ca", "ca")
+	ca.ResourceVersion = "5"
+	kc := fake.NewSimpleClientset(user, ca)
+	refs := []*corev1.SecretKeySelector{
+		{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "ca"},
+		{LocalObjectReference: corev1.LocalObjectReference{Name: "sasl"}, Key: "user"},
+		{LocalObjectReference: corev1.LocalObjectReference{Name: "sasl"}, Key: "password"},
+	}
+
+	versions, err := SecretVersions(ctx, kc, "source-namespace", refs)
+	require.NoError(t, err)
+	require.Equal(t, "sasl=3,tls=5", versions)
+
+	versions, err = SecretVersions(ctx, kc, "source-namespace", nil)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	_, err = SecretVersions(ctx, kc, "other-namespace", refs)
+	require.Error(t, err)
+}
+
 func constructSecret(name, key, secret string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
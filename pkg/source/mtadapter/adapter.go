@@ -56,6 +56,10 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 type cancelContext struct {
 	fn      context.CancelFunc
 	stopped chan bool
+
+	// The source resource version and secret versions the adapter was started with
+	resourceVersion string
+	secretVersions  string
 }
 
 type Adapter struct {
@@ -113,6 +117,12 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 	cancel, ok := a.sources[key]
 
 	if ok {
+		// Re-enqueued sources (e.g. on resync, or on changes to unrelated keys of a tracked secret) keep running
+		if a.unchanged(ctx, obj, cancel) {
+			logger.Info("source and secrets unchanged. skipping")
+			return nil
+		}
+
 		// TODO: do not stop if the only thing that changes is the number of vreplicas
 		logger.Info("stopping adapter")
 		cancel.fn()
@@ -136,6 +146,11 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		return err
 	}
 
+	secretVersions, err := client.SecretVersions(ctx, a.kubeClient, obj.Namespace, client.AuthSpecSecretKeyRefs(obj.Spec.KafkaAuthSpec))
+	if err != nil {
+		return err
+	}
+
 	// Enforce memory limits
 	if a.memLimit > 0 {
 		// TODO: periodically enforce limits as the number of partitions can dynamically change
@@ -182,6 +197,12 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		config.CEOverrides = string(ceJson)
 	}
 
+	if obj.Spec.Filter != nil {
+		// Cannot fail here.
+		filterJson, _ := json.Marshal(obj.Spec.Filter)
+		config.Filter = string(filterJson)
+	}
+
 	reporter, err := source.NewStatsReporter()
 	if err != nil {
 		a.logger.Error("error building statsreporter", zap.Error(err))
@@ -204,8 +225,10 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 	ctx, cancelFn := context.WithCancel(ctx)
 
 	cancel = cancelContext{
-		fn:      cancelFn,
-		stopped: make(chan bool),
+		fn:              cancelFn,
+		stopped:         make(chan bool),
+		resourceVersion: obj.ResourceVersion,
+		secretVersions:  secretVersions,
 	}
 
 	a.sources[key] = cancel
@@ -222,6 +245,19 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 	return nil
 }
 
+// unchanged returns true if the running adapter was started with the current version of the source and the current
+// versions of the secrets it references.
+func (a *Adapter) unchanged(ctx context.Context, obj *v1beta1.KafkaSource, cancel cancelContext) bool {
+	if obj.ResourceVersion == "" || obj.ResourceVersion != cancel.resourceVersion {
+		return false
+	}
+	secretVersions, err := client.SecretVersions(ctx, a.kubeClient, obj.Namespace, client.AuthSpecSecretKeyRefs(obj.Spec.KafkaAuthSpec))
+	if err != nil {
+		return false
+	}
+	return secretVersions == cancel.secretVersions
+}
+
 func (a *Adapter) Remove(name, namespace string) {
 	a.sourcesMu.Lock()
 	defer a.sourcesMu.Unlock()
@@ -177,6 +177,90 @@ func TestUpdateRemoveSources(t *testing.T) {
 	}
 }
 
+func TestUpdateSecretRotation(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "test-ns"},
+		Data:       map[string][]byte{"user": []byte("user1")},
+	}
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, kubeClient := fakekubeclient.With(ctx, secret)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0"}
+	mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), newSampleAdapter).(*Adapter)
+
+	src := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-name",
+			Namespace:       "test-ns",
+			ResourceVersion: "1",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				Net: bindingsv1beta1.KafkaNetSpec{
+					SASL: bindingsv1beta1.KafkaSASLSpec{
+						User: bindingsv1beta1.SecretValueFromSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "mysecret"},
+								Key:                  "user",
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+		},
+	}
+
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	select {
+	case <-runningAdapterChan:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("sub-adapter failed to start after 100 ms")
+	}
+
+	// The same source version with the same secret values keeps running
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	select {
+	case <-stoppingAdapterChan:
+		t.Fatal("Expected unchanged sub-adapter to keep running")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A rotated secret value (i.e. a new secret resourceVersion) restarts the source
+	secret.Data["user"] = []byte("user2")
+	secret.ResourceVersion = "2"
+	if _, err := kubeClient.CoreV1().Secrets("test-ns").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	select {
+	case <-stoppingAdapterChan:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("sub-adapter failed to stop after 100 ms")
+	}
+	select {
+	case <-runningAdapterChan:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("sub-adapter failed to restart after 100 ms")
+	}
+
+	mtadapter.Remove("test-name", "test-ns")
+	<-stoppingAdapterChan
+}
+
 func TestSourceMTAdapter(t *testing.T) {
 	testCases := map[string]struct {
 		objects []runtime.Object
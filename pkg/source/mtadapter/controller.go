@@ -20,7 +20,9 @@ import (
 	"context"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
@@ -88,5 +90,15 @@ func NewController(ctx context.Context, adapter adapter.Adapter) *controller.Imp
 			},
 			DeleteFunc: r.deleteFunc,
 		})
+
+	// Restart the sources referencing a secret whenever it changes (e.g. rotated SASL or TLS credentials)
+	r.tracker = impl.Tracker
+	secretinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			r.tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("Secret"),
+		),
+	))
+
 	return impl
 }
@@ -22,14 +22,17 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	kafkasourcereconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 )
 
 // Reconciler updates the internal Adapter cache kafkaSources
 type Reconciler struct {
 	mtadapter MTAdapter
+	tracker   tracker.Interface
 	logger    *zap.SugaredLogger
 }
 
@@ -37,6 +40,11 @@ type Reconciler struct {
 var _ kafkasourcereconciler.Interface = (*Reconciler)(nil)
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1beta1.KafkaSource) reconciler.Event {
+	// Track the referenced secrets so that rotated credentials restart the source
+	if err := common.TrackSecrets(r.tracker, source); err != nil {
+		return err
+	}
+
 	if !source.Status.IsReady() {
 		r.logger.Warn("warning: KafkaSource is not ready")
 		return nil
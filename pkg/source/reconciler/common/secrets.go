@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// TrackSecrets tracks the secrets referenced by the SASL and TLS settings of the source, so that
+// rotated credentials (or a secret created after the source) trigger a reconciliation of it.
+func TrackSecrets(t tracker.Interface, src *v1beta1.KafkaSource) error {
+	for _, ref := range client.SecretKeyRefs(src) {
+		err := t.TrackReference(tracker.Reference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  src.Namespace,
+			Name:       ref.Name,
+		}, src)
+		if err != nil {
+			return fmt.Errorf("unable to track secret %s/%s: %w", src.Namespace, ref.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestTrackSecrets(t *testing.T) {
+	var enqueued []types.NamespacedName
+	secretTracker := tracker.New(func(key types.NamespacedName) { enqueued = append(enqueued, key) }, time.Minute)
+
+	src := &v1beta1.KafkaSource{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "sources.knative.dev/v1beta1", Kind: "KafkaSource"},
+		ObjectMeta: metav1.ObjectMeta{Name: "source-name", Namespace: "source-namespace"},
+	}
+	src.Spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "sasl-secret"}, Key: "user"}
+	src.Spec.Net.TLS.Cert.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls-secret"}, Key: "tls.crt"}
+	assert.Nil(t, TrackSecrets(secretTracker, src))
+
+	// Newly Tracked References Immediately "Catch Up" By Enqueuing The Source
+	assert.Len(t, enqueued, 2)
+	enqueued = nil
+
+	// Verify Only Changes To The Referenced Secrets (In The Source's Namespace) Enqueue The Source
+	secretTracker.OnChanged(newSecret("source-namespace", "unrelated-secret"))
+	secretTracker.OnChanged(newSecret("other-namespace", "sasl-secret"))
+	assert.Empty(t, enqueued)
+
+	secretTracker.OnChanged(newSecret("source-namespace", "sasl-secret"))
+	secretTracker.OnChanged(newSecret("source-namespace", "tls-secret"))
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "source-namespace", Name: "source-name"},
+		{Namespace: "source-namespace", Name: "source-name"},
+	}, enqueued)
+}
+
+func newSecret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+}
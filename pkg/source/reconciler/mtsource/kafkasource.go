@@ -33,6 +33,7 @@ import (
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 	pkgreconciler "knative.dev/pkg/reconciler"
@@ -75,6 +76,12 @@ var _ reconcilerkafkasource.Interface = (*Reconciler)(nil)
 func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
 	src.Status.InitializeConditions()
 
+	// Mirroring requires the dedicated receive adapter of the KafkaSource
+	if src.Spec.Mirror != nil {
+		src.Status.MarkNoSink("MirrorNotSupported", "The multi-tenant receive adapter does not support spec.mirror")
+		return controller.NewPermanentError(errors.New("spec.mirror is not supported by the multi-tenant receive adapter"))
+	}
+
 	if (src.Spec.Sink == duckv1.Destination{}) {
 		src.Status.MarkNoSink("SinkMissing", "")
 		return fmt.Errorf("spec.sink missing")
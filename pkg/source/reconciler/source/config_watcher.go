@@ -26,13 +26,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/tracing"
 	"knative.dev/eventing/pkg/reconciler/source"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
+	tracingconfig "knative.dev/pkg/tracing/config"
 )
 
 const (
 	EnvKafkaCfg           = "K_KAFKA_CONFIG"
+	EnvKafkaTracingCfg    = "K_KAFKA_TRACING_CONFIG"
 	kafkaConfigMapNameEnv = "CONFIG_KAFKA_NAME"
 )
 
@@ -43,6 +46,7 @@ type KafkaConfig struct {
 type KafkaSourceConfigAccessor interface {
 	source.ConfigAccessor
 	KafkaConfig() *KafkaConfig
+	OtlpTracingConfig() *tracing.Config
 }
 
 // ToEnvVars serializes the contents of the ConfigWatcher to individual
@@ -54,6 +58,11 @@ func (cw *KafkaSourceConfigWatcher) ToEnvVars() []corev1.EnvVar {
 		envs = append(envs, cw.kafkaConfigEnvVar())
 	}
 
+	// The "otlp" tracing backend, which the Knative tracing config rejects, is passed separately to the adapters
+	if otlpCfg := cw.OtlpTracingConfig(); otlpCfg != nil && otlpCfg.Backend == tracing.OtlpBackend {
+		envs = append(envs, cw.otlpTracingConfigEnvVar())
+	}
+
 	return envs
 }
 
@@ -65,13 +74,22 @@ func (cw *KafkaSourceConfigWatcher) KafkaConfig() *KafkaConfig {
 	return cw.kafkaCfg
 }
 
+// OtlpTracingConfig returns the tracing configuration, including the "otlp" backend, from the ConfigWatcher.
+func (cw *KafkaSourceConfigWatcher) OtlpTracingConfig() *tracing.Config {
+	if cw == nil {
+		return nil
+	}
+	return cw.otlpTracingCfg
+}
+
 var _ KafkaSourceConfigAccessor = (*KafkaSourceConfigWatcher)(nil)
 
 type KafkaSourceConfigWatcher struct {
 	*source.ConfigWatcher
 	logger *zap.SugaredLogger
 
-	kafkaCfg *KafkaConfig
+	kafkaCfg       *KafkaConfig
+	otlpTracingCfg *tracing.Config
 }
 
 // WatchConfigurations returns a ConfigWatcher initialized with the given
@@ -89,6 +107,7 @@ func WatchConfigurations(loggingCtx context.Context, component string,
 	}
 
 	WatchConfigMapWithKafka(cw, cmw)
+	WatchConfigMapWithOtlpTracing(cw, cmw)
 
 	return cw
 }
@@ -99,6 +118,11 @@ func WatchConfigMapWithKafka(cw *KafkaSourceConfigWatcher, cmw configmap.Watcher
 	watchConfigMap(cmw, KafkaConfigMapName(), cw.updateFromKafkaConfigMap)
 }
 
+// WatchConfigMapWithOtlpTracing observes the tracing ConfigMap for the "otlp" backend.
+func WatchConfigMapWithOtlpTracing(cw *KafkaSourceConfigWatcher, cmw configmap.Watcher) {
+	watchConfigMap(cmw, tracingconfig.ConfigName, cw.updateFromOtlpTracingConfigMap)
+}
+
 func watchConfigMap(cmw configmap.Watcher, cmName string, obs configmap.Observer) {
 	if dcmw, ok := cmw.(configmap.DefaultingWatcher); ok {
 		dcmw.WatchWithDefault(corev1.ConfigMap{
@@ -120,8 +144,19 @@ func (cw *KafkaSourceConfigWatcher) updateFromKafkaConfigMap(cfg *corev1.ConfigM
 
 	cw.kafkaCfg = kafkaCfg
 
-	cw.logger.Debugw("Updated Kafka config from ConfigMap", zap.Any("ConfigMap", cfg))
-	cw.logger.Debugf("Updated Kafka config from ConfigMap 2 %+v", cw.kafkaCfg)
+	cw.logger.Debugw("Updated Kafka config from ConfigMap", zap.String("cfg.Name", cfg.Name))
+}
+
+func (cw *KafkaSourceConfigWatcher) updateFromOtlpTracingConfigMap(cfg *corev1.ConfigMap) {
+	otlpTracingCfg, err := tracing.NewConfigFromMap(cfg.Data)
+	if err != nil {
+		cw.logger.Warnw("ignoring configuration in tracing configmap", zap.String("cfg.Name", cfg.Name), zap.Error(err))
+		return
+	}
+
+	cw.otlpTracingCfg = otlpTracingCfg
+
+	cw.logger.Debugw("Updated OTLP tracing config from ConfigMap", zap.String("cfg.Name", cfg.Name))
 }
 
 func NewKafkaConfigFromConfigMap(cfg *corev1.ConfigMap) (*KafkaConfig, error) {
@@ -151,6 +186,24 @@ func (cw *KafkaSourceConfigWatcher) kafkaConfigEnvVar() corev1.EnvVar {
 	}
 }
 
+// otlpTracingConfigEnvVar returns an EnvVar containing the serialized tracing
+// configuration, including the "otlp" backend, from the ConfigWatcher. The
+// OTLP headers may hold credentials and are left out, as environment variables
+// are readable by anyone allowed to get the adapter deployment.
+func (cw *KafkaSourceConfigWatcher) otlpTracingConfigEnvVar() corev1.EnvVar {
+	otlpTracingCfg := *cw.OtlpTracingConfig()
+	otlpTracingCfg.OtlpHeaders = nil
+	cfg, err := tracing.ConfigToJSON(&otlpTracingCfg)
+	if err != nil {
+		cw.logger.Warnw("Error while serializing OTLP tracing config", zap.Error(err))
+	}
+
+	return corev1.EnvVar{
+		Name:  EnvKafkaTracingCfg,
+		Value: cfg,
+	}
+}
+
 func kafkaConfigToJSON(cfg *KafkaConfig) (string, error) { //nolint // for backcompat.
 	if cfg == nil {
 		return "", nil
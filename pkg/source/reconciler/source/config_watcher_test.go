@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-kafka/pkg/common/tracing"
 	"knative.dev/eventing/pkg/reconciler/source"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
@@ -99,6 +100,41 @@ func TestNewConfigWatcher_defaults(t *testing.T) {
 	}
 }
 
+func TestNewConfigWatcher_otlpTracing(t *testing.T) {
+	ctx := loggingtesting.TestContextWithLogger(t)
+	cw := WatchConfigurations(ctx, testComponent, configmap.NewStaticWatcher(
+		newTestConfigMap(logging.ConfigMapName(), loggingConfigMapData()),
+		newTestConfigMap(metrics.ConfigMapName(), metricsConfigMapData()),
+		newTestConfigMap(tracingconfig.ConfigName, map[string]string{
+			"backend":       "otlp",
+			"otlp-endpoint": "http://otel-collector.observability:4318",
+			"otlp-headers":  "api-key=secret",
+		}),
+		newTestConfigMap(KafkaConfigMapName(), kafkaConfigMapData()),
+	))
+
+	require.NotNil(t, cw.OtlpTracingConfig(), "otlp tracing config should be enabled")
+	assert.Equal(t, tracing.OtlpBackend, cw.OtlpTracingConfig().Backend)
+
+	envs := cw.ToEnvVars()
+
+	const expectEnvs = 5
+	require.Lenf(t, envs, expectEnvs, "there should be %d env var(s)", expectEnvs)
+
+	// The Knative tracing config ignores the otlp backend it doesn't support
+	assert.Equal(t, source.EnvTracingCfg, envs[2].Name, "third env var is tracing config")
+	assert.NotContains(t, envs[2].Value, "otlp")
+
+	assert.Equal(t, EnvKafkaTracingCfg, envs[4].Name, "fifth env var is otlp tracing config")
+	assert.Contains(t, envs[4].Value, `"Backend":"otlp"`)
+	assert.Contains(t, envs[4].Value, `"OtlpEndpoint":"http://otel-collector.observability:4318"`)
+
+	// The otlp headers may hold credentials and are kept out of the env vars
+	assert.Equal(t, map[string]string{"api-key": "secret"}, cw.OtlpTracingConfig().OtlpHeaders)
+	assert.NotContains(t, envs[4].Value, "OtlpHeaders")
+	assert.NotContains(t, envs[4].Value, "secret")
+}
+
 // configMapWatcherWithSampleData constructs a Watcher for static sample data.
 func configMapWatcherWithSampleData() configmap.Watcher {
 	return configmap.NewStaticWatcher(
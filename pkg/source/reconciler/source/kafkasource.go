@@ -44,6 +44,7 @@ import (
 	ctrlservice "knative.dev/control-protocol/pkg/service"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/source/resources"
@@ -53,6 +54,7 @@ import (
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
 
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	reconcilerkafkasource "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
@@ -107,6 +109,7 @@ type Reconciler struct {
 	loggingContext context.Context
 
 	sinkResolver *resolver.URIResolver
+	tracker      tracker.Interface
 
 	configs KafkaSourceConfigAccessor
 
@@ -121,30 +124,16 @@ var _ reconcilerkafkasource.Interface = (*Reconciler)(nil)
 func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
 	src.Status.InitializeConditions()
 
-	if (src.Spec.Sink == duckv1.Destination{}) {
-		src.Status.MarkNoSink("SinkMissing", "")
-		return fmt.Errorf("spec.sink missing")
-	}
-
-	dest := src.Spec.Sink.DeepCopy()
-	if dest.Ref != nil {
-		// To call URIFromDestination(), dest.Ref must have a Namespace. If there is
-		// no Namespace defined in dest.Ref, we will use the Namespace of the source
-		// as the Namespace of dest.Ref.
-		if dest.Ref.Namespace == "" {
-			dest.Ref.Namespace = src.GetNamespace()
-		}
-	}
-	sinkURI, err := r.sinkResolver.URIFromDestinationV1(ctx, *dest, src)
-	if err != nil {
-		src.Status.MarkNoSink("NotFound", "")
-		//delete adapter deployment if sink not found
-		if err := r.deleteReceiveAdapter(ctx, src); err != nil && !apierrors.IsNotFound(err) {
-			logging.FromContext(ctx).Error("Unable to delete receiver adapter when sink is missing", zap.Error(err))
+	var sinkURI *apis.URL
+	if src.Spec.Mirror != nil && (src.Spec.Sink == duckv1.Destination{}) {
+		// The receive adapter produces the records to the mirror Topic rather than sending them to a sink
+		src.Status.MarkMirror(src.Spec.Mirror.Topic)
+	} else {
+		var err error
+		if sinkURI, err = r.resolveSink(ctx, src); err != nil {
+			return err
 		}
-		return fmt.Errorf("getting sink URI: %v", err)
 	}
-	src.Status.MarkSink(sinkURI)
 
 	selector, err := resources.GetLabelsAsSelector(src.Name)
 	if err != nil {
@@ -168,8 +157,19 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 
+	// Track the referenced secrets so that rotated credentials roll the receive adapter
+	if err := common.TrackSecrets(r.tracker, src); err != nil {
+		return err
+	}
+
 	// Validate configuration and offsets
-	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, src)
+	envConfig, err := client.NewEnvConfigFromSpec(ctx, r.KubeClientSet, src)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve Kafka configuration", zap.Error(err))
+		src.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
+		return err
+	}
+	bs, config, err := client.NewConfigWithEnv(ctx, &envConfig)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to build Kafka configuration", zap.Error(err))
 		src.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
@@ -205,7 +205,24 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 
 	// TODO(mattmoor): create KafkaBinding for the receive adapter.
 
-	ra, err := r.createReceiveAdapter(ctx, src, sinkURI)
+	// Validate the configuration of the mirror Kafka cluster (if any)
+	if src.Spec.Mirror != nil {
+		if _, err = client.NewEnvConfigFromAuthSpec(ctx, r.KubeClientSet, src.Namespace, src.Spec.Mirror.KafkaAuthSpec); err != nil {
+			logging.FromContext(ctx).Errorw("unable to resolve mirror Kafka configuration", zap.Error(err))
+			src.Status.MarkConnectionNotEstablished("InvalidMirrorConfiguration", err.Error())
+			return err
+		}
+	}
+
+	// Roll the receive adapter whenever any of the referenced secrets is updated
+	secretVersions, err := client.SecretVersions(ctx, r.KubeClientSet, src.Namespace, client.SecretKeyRefs(src))
+	if err != nil {
+		logging.FromContext(ctx).Errorw("unable to get referenced secret versions", zap.Error(err))
+		src.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
+		return err
+	}
+
+	ra, err := r.createReceiveAdapter(ctx, src, sinkURI, secretVersions)
 	if err != nil {
 		var event *pkgreconciler.ReconcilerEvent
 		isReconcilerEvent := pkgreconciler.EventAs(err, &event)
@@ -227,6 +244,12 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 
 	if !ready {
+		// A receive adapter which failed permanently (e.g. a record which cannot be mirrored) reports why
+		if failure := r.receiveAdapterFailure(src); failure != "" {
+			logging.FromContext(ctx).Errorw("receive adapter failed", zap.String("failure", failure))
+			src.Status.MarkNotDeployed("ReceiveAdapterFailed", "%s", failure)
+			return nil
+		}
 		logging.FromContext(ctx).Errorw(msg)
 		src.Status.MarkNotDeployed("DeploymentNotReady", msg)
 		return nil
@@ -287,6 +310,35 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	return nil
 }
 
+// resolveSink resolves the URI of the sink of the KafkaSource, deleting the receive adapter if it cannot be resolved
+func (r *Reconciler) resolveSink(ctx context.Context, src *v1beta1.KafkaSource) (*apis.URL, error) {
+	if (src.Spec.Sink == duckv1.Destination{}) {
+		src.Status.MarkNoSink("SinkMissing", "")
+		return nil, fmt.Errorf("spec.sink missing")
+	}
+
+	dest := src.Spec.Sink.DeepCopy()
+	if dest.Ref != nil {
+		// To call URIFromDestination(), dest.Ref must have a Namespace. If there is
+		// no Namespace defined in dest.Ref, we will use the Namespace of the source
+		// as the Namespace of dest.Ref.
+		if dest.Ref.Namespace == "" {
+			dest.Ref.Namespace = src.GetNamespace()
+		}
+	}
+	sinkURI, err := r.sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	if err != nil {
+		src.Status.MarkNoSink("NotFound", "")
+		//delete adapter deployment if sink not found
+		if err := r.deleteReceiveAdapter(ctx, src); err != nil && !apierrors.IsNotFound(err) {
+			logging.FromContext(ctx).Error("Unable to delete receiver adapter when sink is missing", zap.Error(err))
+		}
+		return nil, fmt.Errorf("getting sink URI: %v", err)
+	}
+	src.Status.MarkSink(sinkURI)
+	return sinkURI, nil
+}
+
 func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
 	// Cleanup all the connections in the connection pool associated to src
 	r.connectionPool.RemoveAllConnections(ctx, string(src.UID))
@@ -303,13 +355,14 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource)
 	return common.FinalizeKind(ctx, r.KubeClientSet, src)
 }
 
-func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource, sinkURI *apis.URL) (*appsv1.Deployment, error) {
+func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource, sinkURI *apis.URL, secretVersions string) (*appsv1.Deployment, error) {
 	raArgs := resources.ReceiveAdapterArgs{
 		Image:          r.receiveAdapterImage,
 		Source:         src,
 		Labels:         resources.GetLabels(src.Name),
 		SinkURI:        sinkURI.String(),
 		AdditionalEnvs: r.configs.ToEnvVars(),
+		SecretVersions: secretVersions,
 	}
 	expected := resources.MakeReceiveAdapter(&raArgs)
 
@@ -333,8 +386,9 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.Kafk
 		return nil, err
 	} else if !metav1.IsControlledBy(ra, src) {
 		return nil, fmt.Errorf("deployment %q is not owned by KafkaSource %q", ra.Name, src.Name)
-	} else if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) {
+	} else if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) || secretVersionsChanged(ra.Spec.Template, expected.Spec.Template) {
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
+		setSecretVersions(&ra.Spec.Template, expected.Spec.Template.Annotations[constants.SecretVersionsAnnotationKey])
 		if ra, err = r.KubeClientSet.AppsV1().Deployments(src.Namespace).Update(ctx, ra, metav1.UpdateOptions{}); err != nil {
 			return ra, err
 		}
@@ -358,6 +412,33 @@ func (r *Reconciler) deleteReceiveAdapter(ctx context.Context, src *v1beta1.Kafk
 	return r.KubeClientSet.AppsV1().Deployments(src.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// receiveAdapterFailure returns the termination message of a failing receive adapter pod, if any
+func (r *Reconciler) receiveAdapterFailure(src *v1beta1.KafkaSource) string {
+	pods, err := r.podIpGetter.Lister.Pods(src.Namespace).List(labels.Set(resources.GetLabels(src.Name)).AsSelector())
+	if err != nil {
+		return ""
+	}
+	return terminationMessage(pods)
+}
+
+// terminationMessage returns the first termination message of the containers of the pods which are not ready
+func terminationMessage(pods []*corev1.Pod) string {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				continue
+			}
+			if terminated := status.State.Terminated; terminated != nil && terminated.Message != "" {
+				return terminated.Message
+			}
+			if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Message != "" {
+				return terminated.Message
+			}
+		}
+	}
+	return ""
+}
+
 // receiveAdapterStatus returns a message describing deployment status, and a bool value indicating if the status is considered done.
 func (r *Reconciler) receiveAdapterStatus(deployment *appsv1.Deployment) (string, bool, error) {
 	// Copied from https://github.com/kubernetes/kubectl/blob/release-1.22/pkg/polymorphichelpers/rollout_status.go#L75-L91
@@ -395,6 +476,23 @@ func podSpecChanged(oldPodSpec corev1.PodSpec, newPodSpec corev1.PodSpec) bool {
 	return false
 }
 
+func secretVersionsChanged(oldTemplate corev1.PodTemplateSpec, newTemplate corev1.PodTemplateSpec) bool {
+	return oldTemplate.Annotations[constants.SecretVersionsAnnotationKey] != newTemplate.Annotations[constants.SecretVersionsAnnotationKey]
+}
+
+// setSecretVersions updates the secret versions annotation of the pod template, preserving any other annotations
+// (e.g. those of a "kubectl rollout restart")
+func setSecretVersions(template *corev1.PodTemplateSpec, secretVersions string) {
+	if secretVersions == "" {
+		delete(template.Annotations, constants.SecretVersionsAnnotationKey)
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string, 1)
+	}
+	template.Annotations[constants.SecretVersionsAnnotationKey] = secretVersions
+}
+
 func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource) []duckv1.CloudEventAttributes {
 	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(src.Spec.Topics))
 	for i := range src.Spec.Topics {
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTerminationMessage(t *testing.T) {
+	terminated := func(message string) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: message}}
+	}
+	pod := func(statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: statuses}}
+	}
+
+	testCases := map[string]struct {
+		pods   []*corev1.Pod
+		expect string
+	}{
+		"no pods":       {},
+		"running pod":   {pods: []*corev1.Pod{pod(corev1.ContainerStatus{Ready: true})}},
+		"recovered pod": {pods: []*corev1.Pod{pod(corev1.ContainerStatus{Ready: true, LastTerminationState: terminated("mirror failure")})}},
+		"terminated pod": {
+			pods:   []*corev1.Pod{pod(corev1.ContainerStatus{State: terminated("mirror failure")})},
+			expect: "mirror failure",
+		},
+		"crashlooping pod": {
+			pods: []*corev1.Pod{pod(corev1.ContainerStatus{
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: terminated("mirror failure"),
+			})},
+			expect: "mirror failure",
+		},
+		"crashed without message": {pods: []*corev1.Pod{pod(corev1.ContainerStatus{LastTerminationState: terminated("")})}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, terminationMessage(tc.pods))
+		})
+	}
+}
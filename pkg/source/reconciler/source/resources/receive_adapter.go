@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/kmeta"
 )
@@ -37,6 +38,8 @@ type ReceiveAdapterArgs struct {
 	Labels         map[string]string
 	SinkURI        string
 	AdditionalEnvs []corev1.EnvVar
+	// SecretVersions of the referenced SASL & TLS secrets, rolling the deployment when they are rotated
+	SecretVersions string
 }
 
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
@@ -86,13 +89,57 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		env = append(env, corev1.EnvVar{Name: adapter.EnvConfigCEOverrides, Value: string(ceJson)})
 	}
 
+	if args.Source.Spec.Filter != nil {
+		// Cannot fail.
+		filterJson, _ := json.Marshal(args.Source.Spec.Filter)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_FILTER", Value: string(filterJson)})
+	}
+
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_USER", args.Source.Spec.Net.SASL.User.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_PASSWORD", args.Source.Spec.Net.SASL.Password.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_TYPE", args.Source.Spec.Net.SASL.Type.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_CLIENT_ID", args.Source.Spec.Net.SASL.ClientId.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_CLIENT_SECRET", args.Source.Spec.Net.SASL.ClientSecret.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_TOKEN_URL", args.Source.Spec.Net.SASL.TokenUrl.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_SCOPES", args.Source.Spec.Net.SASL.Scopes.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CERT", args.Source.Spec.Net.TLS.Cert.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_KEY", args.Source.Spec.Net.TLS.Key.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CA_CERT", args.Source.Spec.Net.TLS.CACert.SecretKeyRef)
 
+	if mirror := args.Source.Spec.Mirror; mirror != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_MIRROR_BOOTSTRAP_SERVERS",
+			Value: strings.Join(mirror.BootstrapServers, ","),
+		}, corev1.EnvVar{
+			Name:  "KAFKA_MIRROR_TOPIC",
+			Value: mirror.Topic,
+		}, corev1.EnvVar{
+			Name:  "KAFKA_MIRROR_PRESERVE_PARTITION",
+			Value: strconv.FormatBool(mirror.PreservePartition),
+		}, corev1.EnvVar{
+			Name:  "KAFKA_MIRROR_NET_SASL_ENABLE",
+			Value: strconv.FormatBool(mirror.Net.SASL.Enable),
+		}, corev1.EnvVar{
+			Name:  "KAFKA_MIRROR_NET_TLS_ENABLE",
+			Value: strconv.FormatBool(mirror.Net.TLS.Enable),
+		})
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_USER", mirror.Net.SASL.User.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_PASSWORD", mirror.Net.SASL.Password.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_TYPE", mirror.Net.SASL.Type.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_CLIENT_ID", mirror.Net.SASL.ClientId.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_CLIENT_SECRET", mirror.Net.SASL.ClientSecret.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_TOKEN_URL", mirror.Net.SASL.TokenUrl.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_SASL_SCOPES", mirror.Net.SASL.Scopes.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_TLS_CERT", mirror.Net.TLS.Cert.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_TLS_KEY", mirror.Net.TLS.Key.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "KAFKA_MIRROR_NET_TLS_CA_CERT", mirror.Net.TLS.CACert.SecretKeyRef)
+	}
+
+	var podAnnotations map[string]string
+	if args.SecretVersions != "" {
+		podAnnotations = map[string]string{constants.SecretVersionsAnnotationKey: args.SecretVersions}
+	}
+
 	return &v1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      kmeta.ChildName(fmt.Sprintf("kafkasource-%s-", args.Source.Name), string(args.Source.GetUID())),
@@ -109,7 +156,8 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 			Replicas: args.Source.Spec.Consumers,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: args.Labels,
+					Labels:      args.Labels,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
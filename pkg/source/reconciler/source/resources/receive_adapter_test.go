@@ -26,6 +26,8 @@ import (
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/constants"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/ptr"
 )
@@ -109,7 +111,8 @@ func TestMakeReceiveAdapter(t *testing.T) {
 			"test-key1": "test-value1",
 			"test-key2": "test-value2",
 		},
-		SinkURI: "sink-uri",
+		SinkURI:        "sink-uri",
+		SecretVersions: "test-secret=1",
 	})
 
 	want := &appsv1.Deployment{
@@ -135,6 +138,9 @@ func TestMakeReceiveAdapter(t *testing.T) {
 						"test-key1": "test-value1",
 						"test-key2": "test-value2",
 					},
+					Annotations: map[string]string{
+						constants.SecretVersionsAnnotationKey: "test-secret=1",
+					},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: "source-svc-acct",
@@ -266,6 +272,10 @@ func TestMakeReceiveAdapter(t *testing.T) {
 	if diff, err := kmp.SafeDiff(want, got); err != nil {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
+
+	if versions := got.Spec.Template.Annotations[constants.SecretVersionsAnnotationKey]; versions != "test-secret=1" {
+		t.Errorf("unexpected secret versions annotation = %q", versions)
+	}
 }
 
 func TestMakeReceiveAdapterNoNet(t *testing.T) {
@@ -489,6 +499,10 @@ func TestMakeReceiveAdapterNoNet(t *testing.T) {
 	if diff, err := kmp.SafeDiff(want, got); err != nil {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
+
+	if _, ok := got.Spec.Template.Annotations[constants.SecretVersionsAnnotationKey]; ok {
+		t.Errorf("unexpected secret versions annotation without secrets")
+	}
 }
 
 func TestMakeReceiveAdapterKeyType(t *testing.T) {
@@ -613,3 +627,93 @@ func TestMakeReceiveAdapterKeyType(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterMirror(t *testing.T) {
+	passwordRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "mirror-secret"},
+		Key:                  "password",
+	}
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Mirror: &v1beta1.KafkaMirrorSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					BootstrapServers: []string{"mirror1", "mirror2"},
+					Net: bindingsv1beta1.KafkaNetSpec{
+						SASL: bindingsv1beta1.KafkaSASLSpec{
+							Enable:   true,
+							Password: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: passwordRef},
+						},
+					},
+				},
+				Topic:             "mirror-topic",
+				PreservePartition: true,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{Image: "test-image", Source: src})
+
+	env := make(map[string]corev1.EnvVar)
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar
+	}
+	for name, value := range map[string]string{
+		"KAFKA_MIRROR_BOOTSTRAP_SERVERS":  "mirror1,mirror2",
+		"KAFKA_MIRROR_TOPIC":              "mirror-topic",
+		"KAFKA_MIRROR_PRESERVE_PARTITION": "true",
+		"KAFKA_MIRROR_NET_SASL_ENABLE":    "true",
+		"KAFKA_MIRROR_NET_TLS_ENABLE":     "false",
+	} {
+		if env[name].Value != value {
+			t.Errorf("unexpected value of %s: want %q, got %q", name, value, env[name].Value)
+		}
+	}
+	if password := env["KAFKA_MIRROR_NET_SASL_PASSWORD"]; password.ValueFrom == nil || password.ValueFrom.SecretKeyRef != passwordRef {
+		t.Errorf("expected KAFKA_MIRROR_NET_SASL_PASSWORD from the mirror secret, got %v", password)
+	}
+	if _, ok := env["KAFKA_MIRROR_NET_SASL_USER"]; ok {
+		t.Error("unexpected KAFKA_MIRROR_NET_SASL_USER without a secret reference")
+	}
+}
+
+func TestMakeReceiveAdapterFilter(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Filter: &eventingv1.SubscriptionsAPIFilter{
+				CESQL: "type = 'com.example.order.created'",
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{Image: "test-image", Source: src})
+
+	want := `{"cesql":"type = 'com.example.order.created'"}`
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == "KAFKA_FILTER" {
+			if envVar.Value != want {
+				t.Errorf("unexpected value of KAFKA_FILTER: want %q, got %q", want, envVar.Value)
+			}
+			return
+		}
+	}
+	t.Error("expected KAFKA_FILTER env var")
+}